@@ -26,6 +26,8 @@ type Config interface {
 	GetEthereumContextWaitTimeout() time.Duration
 	GetEthereumGasLimit(op config.ContractOp) uint64
 	GetCentChainAnchorLifespan() time.Duration
+	GetCentChainAnchorBatchWindow() time.Duration
+	GetCentChainAnchorBatchSize() int
 }
 
 // ToAnchorID convert the bytes into AnchorID type
@@ -0,0 +1,181 @@
+package anchors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+)
+
+// utilityBatch is centrifuge chain module function name for dispatching a batch of calls.
+const utilityBatch = "Utility.batch"
+
+// pendingCommit is a commit waiting for the batch of its account to be submitted.
+type pendingCommit struct {
+	anchorID     AnchorID
+	documentRoot DocumentRoot
+	proof        [32]byte
+	done         chan error
+}
+
+// anchorBatch holds the pending commits of an account.
+type anchorBatch struct {
+	acc     config.Account
+	commits []*pendingCommit
+}
+
+// batchService is an anchor service which commits the anchors of an account, requested within the batch window,
+// in one Utility.batch extrinsic. Pre-commits and reads are passed through to the underlying service.
+type batchService struct {
+	*service
+
+	mu      sync.Mutex
+	batches map[string]*anchorBatch // batches of the accounts, keyed by DID
+}
+
+func newBatchService(srv *service) Service {
+	return &batchService{service: srv, batches: make(map[string]*anchorBatch)}
+}
+
+// CommitAnchor adds the commit to the batch of the account in context, and waits until the batch is submitted
+// and the anchor is found on chain. Anchors are committed one by one if the batch window is not set.
+func (s *batchService) CommitAnchor(ctx context.Context, anchorID AnchorID, documentRoot DocumentRoot, proof [32]byte) error {
+	window := s.config.GetCentChainAnchorBatchWindow()
+	if window <= 0 {
+		return s.service.CommitAnchor(ctx, anchorID, documentRoot, proof)
+	}
+
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return err
+	}
+
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return err
+	}
+
+	pc := &pendingCommit{anchorID: anchorID, documentRoot: documentRoot, proof: proof, done: make(chan error, 1)}
+	key := did.String()
+	s.mu.Lock()
+	b, ok := s.batches[key]
+	if !ok {
+		b = &anchorBatch{acc: acc}
+		s.batches[key] = b
+		time.AfterFunc(window, func() {
+			if s.take(key, b) {
+				s.submit(b)
+			}
+		})
+	}
+
+	b.commits = append(b.commits, pc)
+	size := s.config.GetCentChainAnchorBatchSize()
+	full := size > 0 && len(b.commits) >= size
+	s.mu.Unlock()
+	if full && s.take(key, b) {
+		go s.submit(b)
+	}
+
+	select {
+	case err := <-pc.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// take removes the batch of the account so that no more commits are added to it.
+// Returns false if the batch was taken already.
+func (s *batchService) take(key string, b *anchorBatch) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.batches[key] != b {
+		return false
+	}
+
+	delete(s.batches, key)
+	return true
+}
+
+// submit commits the anchors of the batch, and reports the result of each anchor to its commit.
+func (s *batchService) submit(b *anchorBatch) {
+	// the batch outlives the contexts of the commits
+	ctx, err := contextutil.New(context.Background(), b.acc)
+	if err != nil {
+		for _, pc := range b.commits {
+			pc.done <- err
+		}
+
+		return
+	}
+
+	if len(b.commits) == 1 {
+		pc := b.commits[0]
+		pc.done <- s.service.CommitAnchor(ctx, pc.anchorID, pc.documentRoot, pc.proof)
+		return
+	}
+
+	log.Infof("committing %d anchors of account %x", len(b.commits), b.acc.GetIdentityID())
+	err = s.submitBatch(ctx, b)
+	for _, pc := range b.commits {
+		if err != nil {
+			pc.done <- err
+			continue
+		}
+
+		pc.done <- s.checkAnchor(pc)
+	}
+}
+
+func (s *batchService) submitBatch(ctx context.Context, b *anchorBatch) error {
+	krp, err := b.acc.GetCentChainAccount().KeyRingPair()
+	if err != nil {
+		return err
+	}
+
+	meta, err := s.api.GetMetadataLatest()
+	if err != nil {
+		return err
+	}
+
+	calls := make([]types.Call, len(b.commits))
+	for i, pc := range b.commits {
+		calls[i], err = s.commitCall(meta, pc.anchorID, pc.documentRoot, pc.proof)
+		if err != nil {
+			return err
+		}
+	}
+
+	c, err := types.NewCall(meta, utilityBatch, calls)
+	if err != nil {
+		return err
+	}
+
+	err = s.api.SubmitAndWatch(ctx, meta, c, krp)
+	if err != nil {
+		return fmt.Errorf("failed to commit documents: %w", err)
+	}
+
+	return nil
+}
+
+// checkAnchor checks that the anchor of the commit is on chain.
+// Utility.batch stops at the first failed call, while the extrinsic itself succeeds.
+func (s *batchService) checkAnchor(pc *pendingCommit) error {
+	root, _, err := s.GetAnchorData(pc.anchorID)
+	if err != nil {
+		return fmt.Errorf("failed to commit document: %w", err)
+	}
+
+	if root != pc.documentRoot {
+		return errors.New("anchor %s committed with a different document root", pc.anchorID.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// +build unit
+
+package anchors
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type batchConfig struct {
+	Config
+	window time.Duration
+	size   int
+}
+
+func (c batchConfig) GetCentChainAnchorLifespan() time.Duration    { return time.Hour }
+func (c batchConfig) GetCentChainAnchorBatchWindow() time.Duration { return c.window }
+func (c batchConfig) GetCentChainAnchorBatchSize() int             { return c.size }
+
+func batchMetadata() *types.Metadata {
+	meta := centchain.MetaDataWithCall(commit)
+	meta.AsMetadataV8.Modules = append(meta.AsMetadataV8.Modules, types.ModuleMetadataV8{
+		Name:     "Utility",
+		HasCalls: true,
+		Calls:    []types.FunctionMetadataV4{{Name: "batch"}},
+	})
+	return meta
+}
+
+func accountContext(t *testing.T) context.Context {
+	acc := new(config.MockAccount)
+	acc.On("GetIdentityID").Return(utils.RandomSlice(20))
+	acc.On("GetCentChainAccount").Return(config.CentChainAccount{ID: hexutil.Encode(utils.RandomSlice(32))})
+	ctx, err := contextutil.New(context.Background(), acc)
+	assert.NoError(t, err)
+	return ctx
+}
+
+// isCall returns a matcher of the call of the function.
+func isCall(meta *types.Metadata, fn string) interface{} {
+	idx, err := meta.FindCallIndex(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	return mock.MatchedBy(func(c types.Call) bool {
+		return c.CallIndex == idx
+	})
+}
+
+// onchain mocks the anchors found on chain.
+func onchain(api *centchain.MockAPI, roots map[types.Hash]DocumentRoot) {
+	api.On("Call", mock.Anything, getByID, mock.Anything).Run(func(args mock.Arguments) {
+		ad := args.Get(0).(*AnchorData)
+		id := args.Get(2).([]interface{})[0].(types.Hash)
+		ad.DocumentRoot = types.Hash(roots[id])
+	}).Return(nil)
+	api.On("GetBlockInfo", mock.Anything).Return(centchain.BlockInfo{Timestamp: time.Now()}, nil)
+}
+
+// commitAll commits the anchors concurrently, and returns the error of each commit.
+func commitAll(srv Service, ctx context.Context, ids []AnchorID, roots []DocumentRoot) []error {
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = srv.CommitAnchor(ctx, ids[i], roots[i], utils.RandomByte32())
+		}(i)
+	}
+
+	wg.Wait()
+	return errs
+}
+
+func randomAnchors(n int) (ids []AnchorID, roots []DocumentRoot) {
+	for i := 0; i < n; i++ {
+		id, _ := ToAnchorID(utils.RandomSlice(AnchorIDLength))
+		ids = append(ids, id)
+		roots = append(roots, RandomDocumentRoot())
+	}
+
+	return ids, roots
+}
+
+func TestBatchService_CommitAnchor(t *testing.T) {
+	meta := batchMetadata()
+	ctx := accountContext(t)
+
+	// batching disabled
+	api := new(centchain.MockAPI)
+	api.On("GetMetadataLatest").Return(meta, nil)
+	api.On("SubmitAndWatch", isCall(meta, commit), mock.Anything).Return(nil).Once()
+	srv := newBatchService(newService(batchConfig{}, api))
+	ids, roots := randomAnchors(1)
+	assert.NoError(t, srv.CommitAnchor(ctx, ids[0], roots[0], utils.RandomByte32()))
+	api.AssertExpectations(t)
+
+	// single anchor in the window is committed on its own
+	api = new(centchain.MockAPI)
+	api.On("GetMetadataLatest").Return(meta, nil)
+	api.On("SubmitAndWatch", isCall(meta, commit), mock.Anything).Return(nil).Once()
+	srv = newBatchService(newService(batchConfig{window: 10 * time.Millisecond, size: 10}, api))
+	assert.NoError(t, srv.CommitAnchor(ctx, ids[0], roots[0], utils.RandomByte32()))
+	api.AssertExpectations(t)
+
+	// anchors in the window are committed in one batch, the anchor missing on chain fails
+	api = new(centchain.MockAPI)
+	api.On("GetMetadataLatest").Return(meta, nil)
+	api.On("SubmitAndWatch", isCall(meta, utilityBatch), mock.Anything).Return(nil).Once()
+	srv = newBatchService(newService(batchConfig{window: 100 * time.Millisecond, size: 10}, api))
+	ids, roots = randomAnchors(3)
+	onchain(api, map[types.Hash]DocumentRoot{
+		types.NewHash(ids[0][:]): roots[0],
+		types.NewHash(ids[1][:]): roots[1],
+	})
+	errs := commitAll(srv, ctx, ids, roots)
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.Error(t, errs[2])
+	assert.Contains(t, errs[2].Error(), "anchor data empty")
+	api.AssertExpectations(t)
+
+	// full batch is committed before the window ends
+	api = new(centchain.MockAPI)
+	api.On("GetMetadataLatest").Return(meta, nil)
+	api.On("SubmitAndWatch", isCall(meta, utilityBatch), mock.Anything).Return(nil).Once()
+	srv = newBatchService(newService(batchConfig{window: time.Hour, size: 2}, api))
+	ids, roots = randomAnchors(2)
+	onchain(api, map[types.Hash]DocumentRoot{
+		types.NewHash(ids[0][:]): roots[0],
+		types.NewHash(ids[1][:]): roots[1],
+	})
+	for _, err := range commitAll(srv, ctx, ids, roots) {
+		assert.NoError(t, err)
+	}
+	api.AssertExpectations(t)
+
+	// failed batch fails all the anchors
+	api = new(centchain.MockAPI)
+	api.On("GetMetadataLatest").Return(meta, nil)
+	api.On("SubmitAndWatch", isCall(meta, utilityBatch), mock.Anything).Return(errors.New("extrinsic failed")).Once()
+	srv = newBatchService(newService(batchConfig{window: time.Hour, size: 2}, api))
+	for _, err := range commitAll(srv, ctx, ids, roots) {
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "extrinsic failed")
+	}
+	api.AssertExpectations(t)
+}
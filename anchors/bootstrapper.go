@@ -26,7 +26,7 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	}
 
 	client := ctx[centchain.BootstrappedCentChainClient].(centchain.API)
-	srv := newService(cfg, client)
+	srv := newBatchService(newService(cfg, client))
 	ctx[BootstrappedAnchorService] = srv
 	return nil
 }
@@ -41,6 +41,12 @@ func (m *MockAnchorService) GetAnchorData(anchorID AnchorID) (docRoot DocumentRo
 	return docRoot, anchoredTime, args.Error(1)
 }
 
+func (m *MockAnchorService) GetAnchor(anchorID AnchorID) (Anchor, error) {
+	args := m.Called(anchorID)
+	a, _ := args.Get(0).(Anchor)
+	return a, args.Error(1)
+}
+
 func (m *MockAnchorService) PreCommitAnchor(ctx context.Context, anchorID AnchorID, signingRoot DocumentRoot) (err error) {
 	args := m.Called(anchorID, signingRoot)
 	return args.Error(0)
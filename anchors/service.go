@@ -10,8 +10,11 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-substrate-rpc-client/types"
+	logging "github.com/ipfs/go-log"
 )
 
+var log = logging.Logger("anchors")
+
 const (
 	// preCommit is centrifuge chain module function name for pre-commit call.
 	preCommit = "Anchor.pre_commit"
@@ -36,6 +39,18 @@ type Service interface {
 
 	// GetAnchorData takes an anchorID and returns the corresponding documentRoot from the chain.
 	GetAnchorData(anchorID AnchorID) (docRoot DocumentRoot, anchoredTime time.Time, err error)
+
+	// GetAnchor takes an anchorID and returns the anchor along with the block it was committed in.
+	GetAnchor(anchorID AnchorID) (Anchor, error)
+}
+
+// Anchor is an anchor committed on chain.
+type Anchor struct {
+	ID           AnchorID
+	DocumentRoot DocumentRoot
+	BlockNumber  uint32
+	BlockHash    [32]byte
+	AnchoredAt   time.Time
 }
 
 type service struct {
@@ -43,7 +58,7 @@ type service struct {
 	api    centchain.API
 }
 
-func newService(config Config, api centchain.API) Service {
+func newService(config Config, api centchain.API) *service {
 	return &service{config: config, api: api}
 }
 
@@ -57,19 +72,40 @@ type AnchorData struct {
 // GetAnchorData takes an anchorID and returns the corresponding documentRoot from the chain.
 // Returns a nil error when the anchor data is found else returns a non nil error
 func (s *service) GetAnchorData(anchorID AnchorID) (docRoot DocumentRoot, anchoredTime time.Time, err error) {
+	a, err := s.GetAnchor(anchorID)
+	if err != nil {
+		return docRoot, anchoredTime, err
+	}
+
+	return a.DocumentRoot, a.AnchoredAt, nil
+}
+
+// GetAnchor takes an anchorID and returns the anchor along with the block it was committed in.
+// The anchored time is the timestamp of the block.
+func (s *service) GetAnchor(anchorID AnchorID) (Anchor, error) {
 	var ad AnchorData
 	h := types.NewHash(anchorID[:])
-	err = s.api.Call(&ad, getByID, h)
+	err := s.api.Call(&ad, getByID, h)
 	if err != nil {
-		return docRoot, anchoredTime, fmt.Errorf("failed to get anchor: %w", err)
+		return Anchor{}, fmt.Errorf("failed to get anchor: %w", err)
 	}
 
 	if utils.IsEmptyByte32(ad.DocumentRoot) {
-		return docRoot, anchoredTime, errors.New("anchor data empty for id: %v", anchorID.String())
+		return Anchor{}, errors.New("anchor data empty for id: %v", anchorID.String())
+	}
+
+	block, err := s.api.GetBlockInfo(ad.BlockNumber)
+	if err != nil {
+		return Anchor{}, fmt.Errorf("failed to get anchored block: %w", err)
 	}
 
-	// TODO(ved): return the anchored time
-	return DocumentRoot(ad.DocumentRoot), time.Unix(0, 0), nil
+	return Anchor{
+		ID:           anchorID,
+		DocumentRoot: DocumentRoot(ad.DocumentRoot),
+		BlockNumber:  ad.BlockNumber,
+		BlockHash:    block.Hash,
+		AnchoredAt:   block.Timestamp,
+	}, nil
 }
 
 // PreCommitAnchor will call the transaction PreCommit substrate module
@@ -119,13 +155,7 @@ func (s *service) CommitAnchor(ctx context.Context, anchorID AnchorID, documentR
 		return err
 	}
 
-	c, err := types.NewCall(
-		meta,
-		commit,
-		types.NewHash(anchorID[:]),
-		types.NewHash(documentRoot[:]),
-		types.NewHash(proof[:]),
-		types.NewMoment(time.Now().UTC().Add(s.config.GetCentChainAnchorLifespan())))
+	c, err := s.commitCall(meta, anchorID, documentRoot, proof)
 	if err != nil {
 		return err
 	}
@@ -137,3 +167,14 @@ func (s *service) CommitAnchor(ctx context.Context, anchorID AnchorID, documentR
 
 	return nil
 }
+
+// commitCall returns the commit call of the anchor.
+func (s *service) commitCall(meta *types.Metadata, anchorID AnchorID, documentRoot DocumentRoot, proof [32]byte) (types.Call, error) {
+	return types.NewCall(
+		meta,
+		commit,
+		types.NewHash(anchorID[:]),
+		types.NewHash(documentRoot[:]),
+		types.NewHash(proof[:]),
+		types.NewMoment(time.Now().UTC().Add(s.config.GetCentChainAnchorLifespan())))
+}
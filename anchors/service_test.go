@@ -0,0 +1,59 @@
+// +build unit
+
+package anchors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_GetAnchor(t *testing.T) {
+	api := new(centchain.MockAPI)
+	srv := newService(batchConfig{}, api)
+	ids, roots := randomAnchors(1)
+
+	// missing anchor
+	api.On("Call", mock.Anything, getByID, mock.Anything).Return(nil).Once()
+	_, err := srv.GetAnchor(ids[0])
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "anchor data empty")
+
+	// failed to get block
+	found := func(args mock.Arguments) {
+		ad := args.Get(0).(*AnchorData)
+		ad.DocumentRoot = types.Hash(roots[0])
+		ad.BlockNumber = 42
+	}
+	api.On("Call", mock.Anything, getByID, mock.Anything).Run(found).Return(nil).Once()
+	api.On("GetBlockInfo", uint32(42)).Return(nil, errors.New("block not found")).Once()
+	_, err = srv.GetAnchor(ids[0])
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block not found")
+
+	// success
+	block := centchain.BlockInfo{Hash: types.Hash(utils.RandomByte32()), Number: 42, Timestamp: time.Now().UTC()}
+	api.On("Call", mock.Anything, getByID, mock.Anything).Run(found).Return(nil).Twice()
+	api.On("GetBlockInfo", uint32(42)).Return(block, nil).Twice()
+	a, err := srv.GetAnchor(ids[0])
+	assert.NoError(t, err)
+	assert.Equal(t, Anchor{
+		ID:           ids[0],
+		DocumentRoot: roots[0],
+		BlockNumber:  42,
+		BlockHash:    block.Hash,
+		AnchoredAt:   block.Timestamp,
+	}, a)
+
+	root, anchoredAt, err := srv.GetAnchorData(ids[0])
+	assert.NoError(t, err)
+	assert.Equal(t, roots[0], root)
+	assert.Equal(t, block.Timestamp, anchoredAt)
+	api.AssertExpectations(t)
+}
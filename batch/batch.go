@@ -0,0 +1,183 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/http/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("batch")
+
+const (
+	// ErrInvalidBatch is returned when the operations of the batch are invalid.
+	ErrInvalidBatch = errors.Error("invalid batch")
+
+	// ErrBatchNotFound is returned when the batch doesn't exist.
+	ErrBatchNotFound = errors.Error("batch not found")
+
+	// MaxOperations is the maximum number of the operations of a batch.
+	MaxOperations = 500
+)
+
+// OperationType is the type of a batch operation.
+type OperationType string
+
+// Operation types of the batch.
+const (
+	// OperationCreate creates a pending document.
+	OperationCreate OperationType = "create"
+
+	// OperationUpdate updates the pending document, or creates a pending version of the committed document.
+	OperationUpdate OperationType = "update"
+
+	// OperationAddAttributes adds the attributes to the pending document.
+	OperationAddAttributes OperationType = "add_attributes"
+
+	// OperationCommit commits the pending document. The commits are run once the other operations of the batch
+	// are run, and each document is committed, and anchored, once with its latest pending version.
+	OperationCommit OperationType = "commit"
+)
+
+// Operation is an operation on a document of the batch.
+// The document is either the document with DocumentID, or the document of the earlier operation Ref.
+type Operation struct {
+	Type       OperationType      `json:"type" enums:"create,update,add_attributes,commit"`
+	DocumentID byteutils.HexBytes `json:"document_id,omitempty" swaggertype:"primitive,string"`
+
+	// Ref is the index of the earlier operation of the batch whose document is used.
+	Ref *int `json:"ref,omitempty"`
+
+	// Document is the payload of the create and update operations.
+	Document *coreapi.CreateDocumentRequest `json:"document,omitempty"`
+
+	// Attributes is the payload of the add_attributes operation.
+	Attributes coreapi.AttributeMapRequest `json:"attributes,omitempty"`
+}
+
+// Status is the status of a batch operation.
+type Status string
+
+// Statuses of a batch operation.
+const (
+	StatusPending Status = "pending"
+	StatusSuccess Status = "success"
+	StatusFailed  Status = "failed"
+)
+
+// Result is the result of a batch operation.
+type Result struct {
+	Status     Status             `json:"status" enums:"pending,success,failed"`
+	DocumentID byteutils.HexBytes `json:"document_id,omitempty" swaggertype:"primitive,string"`
+	VersionID  byteutils.HexBytes `json:"version_id,omitempty" swaggertype:"primitive,string"`
+
+	// JobID is the anchor job of the commit operation.
+	JobID byteutils.HexBytes `json:"job_id,omitempty" swaggertype:"primitive,string"`
+	Error string             `json:"error,omitempty"`
+}
+
+// Batch is an ordered list of operations run under the job with the ID of the batch.
+// Failed operations don't stop the batch, but fail the operations on their documents which come after them.
+type Batch struct {
+	ID         byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`
+	AccountID  byteutils.HexBytes `json:"account_id" swaggertype:"primitive,string"`
+	Operations []Operation        `json:"operations"`
+
+	// Results holds the result of each operation, in the order of the operations.
+	Results    []Result   `json:"results"`
+	CreatedAt  time.Time  `json:"created_at" swaggertype:"primitive,string"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" swaggertype:"primitive,string"`
+}
+
+// JSON marshals Batch to json bytes.
+func (b *Batch) JSON() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+// FromJSON loads json bytes to Batch.
+func (b *Batch) FromJSON(data []byte) error {
+	return json.Unmarshal(data, b)
+}
+
+// Type returns the type of Batch.
+func (b *Batch) Type() reflect.Type {
+	return reflect.TypeOf(b)
+}
+
+// Failed returns the number of the failed operations of the batch.
+func (b Batch) Failed() (failed int) {
+	for _, r := range b.Results {
+		if r.Status == StatusFailed {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// Validate checks the operations of the batch.
+func Validate(ops []Operation) error {
+	if len(ops) == 0 || len(ops) > MaxOperations {
+		return errors.NewTypedError(ErrInvalidBatch, errors.New("batch must have 1 to %d operations", MaxOperations))
+	}
+
+	for i, op := range ops {
+		if err := validateOperation(i, op); err != nil {
+			return errors.NewTypedError(ErrInvalidBatch, errors.New("operation %d: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+func validateOperation(i int, op Operation) error {
+	if op.Ref != nil && (*op.Ref < 0 || *op.Ref >= i) {
+		return errors.New("ref must be an earlier operation")
+	}
+
+	hasDoc := len(op.DocumentID) > 0 || op.Ref != nil
+	if len(op.DocumentID) > 0 && op.Ref != nil {
+		return errors.New("either document_id or ref must be set")
+	}
+
+	switch op.Type {
+	case OperationCreate:
+		if hasDoc {
+			return errors.New("create doesn't take a document")
+		}
+
+		if op.Document == nil {
+			return errors.New("document is missing")
+		}
+	case OperationUpdate:
+		if !hasDoc || op.Document == nil {
+			return errors.New("document and either document_id or ref are required")
+		}
+	case OperationAddAttributes:
+		if !hasDoc || len(op.Attributes) == 0 {
+			return errors.New("attributes and either document_id or ref are required")
+		}
+	case OperationCommit:
+		if !hasDoc {
+			return errors.New("either document_id or ref is required")
+		}
+	default:
+		return errors.New("unknown operation type %q", op.Type)
+	}
+
+	return nil
+}
+
+// Service submits the batches of the accounts.
+type Service interface {
+	// Submit validates and stores the batch of the account in context, and dispatches the job running it.
+	Submit(ctx context.Context, ops []Operation) (Batch, error)
+
+	// Get returns the batch of the account in context, along with the results of its operations.
+	Get(ctx context.Context, batchID []byte) (Batch, error)
+}
@@ -0,0 +1,102 @@
+// +build unit
+
+package batch
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/http/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func ref(i int) *int {
+	return &i
+}
+
+func TestValidate(t *testing.T) {
+	docID := utils.RandomSlice(32)
+	doc := &coreapi.CreateDocumentRequest{Scheme: "generic"}
+	attrs := coreapi.AttributeMapRequest{"label": {Type: "string", Value: "value"}}
+	tests := []struct {
+		ops []Operation
+		err string
+	}{
+		{
+			err: "batch must have 1 to 500 operations",
+		},
+
+		{
+			ops: make([]Operation, MaxOperations+1),
+			err: "batch must have 1 to 500 operations",
+		},
+
+		{
+			ops: []Operation{{Type: "delete", DocumentID: docID}},
+			err: "operation 0: unknown operation type",
+		},
+
+		{
+			ops: []Operation{{Type: OperationCreate, Document: doc, DocumentID: docID}},
+			err: "operation 0: create doesn't take a document",
+		},
+
+		{
+			ops: []Operation{{Type: OperationCreate}},
+			err: "operation 0: document is missing",
+		},
+
+		{
+			ops: []Operation{{Type: OperationCreate, Document: doc}, {Type: OperationUpdate, Ref: ref(0)}},
+			err: "operation 1: document and either document_id or ref are required",
+		},
+
+		{
+			ops: []Operation{{Type: OperationAddAttributes, DocumentID: docID}},
+			err: "operation 0: attributes and either document_id or ref are required",
+		},
+
+		{
+			ops: []Operation{{Type: OperationCommit}},
+			err: "operation 0: either document_id or ref is required",
+		},
+
+		{
+			ops: []Operation{{Type: OperationCommit, Ref: ref(0)}},
+			err: "operation 0: ref must be an earlier operation",
+		},
+
+		{
+			ops: []Operation{{Type: OperationCreate, Document: doc}, {Type: OperationCommit, Ref: ref(0), DocumentID: docID}},
+			err: "operation 1: either document_id or ref must be set",
+		},
+
+		{
+			ops: []Operation{
+				{Type: OperationCreate, Document: doc},
+				{Type: OperationUpdate, Ref: ref(0), Document: doc},
+				{Type: OperationAddAttributes, DocumentID: docID, Attributes: attrs},
+				{Type: OperationCommit, Ref: ref(0)},
+				{Type: OperationCommit, DocumentID: docID},
+			},
+		},
+	}
+
+	for _, c := range tests {
+		err := Validate(c.ops)
+		if c.err == "" {
+			assert.NoError(t, err)
+			continue
+		}
+
+		assert.Error(t, err)
+		assert.True(t, errors.IsOfType(ErrInvalidBatch, err))
+		assert.Contains(t, err.Error(), c.err)
+	}
+}
+
+func TestBatch_Failed(t *testing.T) {
+	b := Batch{Results: []Result{{Status: StatusSuccess}, {Status: StatusFailed}, {Status: StatusPending}}}
+	assert.Equal(t, 1, b.Failed())
+}
@@ -0,0 +1,47 @@
+package batch
+
+import (
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// BootstrappedBatchService is the key to the batch service in bootstrap context.
+const BootstrappedBatchService = "BootstrappedBatchService"
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap initialises the batch service and registers the batch job.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	pendingSrv, ok := ctx[pending.BootstrappedPendingDocumentService].(pending.Service)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", pending.BootstrappedPendingDocumentService)
+	}
+
+	repo, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", storage.BootstrappedDB)
+	}
+
+	configSrv, ok := ctx[config.BootstrappedConfigStorage].(config.Service)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", config.BootstrappedConfigStorage)
+	}
+
+	dispatcher, ok := ctx[jobs.BootstrappedDispatcher].(jobs.Dispatcher)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", jobs.BootstrappedDispatcher)
+	}
+
+	ctx[BootstrappedBatchService] = newService(repo, dispatcher)
+	go dispatcher.RegisterRunner(batchJob, &OperationsJob{
+		repo:       repo,
+		configSrv:  configSrv,
+		pendingSrv: pendingSrv,
+		dispatcher: dispatcher,
+	})
+	return nil
+}
@@ -0,0 +1,303 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/http/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const batchJob = "Batch operations"
+
+// OperationsJob runs the operations of a batch, then commits the documents of the commit operations,
+// and waits for their anchor jobs.
+type OperationsJob struct {
+	jobs.Base
+
+	repo       storage.Repository
+	configSrv  config.Service
+	pendingSrv pending.Service
+	dispatcher jobs.Dispatcher
+}
+
+// New returns a new instance of OperationsJob
+func (j *OperationsJob) New() gocelery.Runner {
+	nj := &OperationsJob{
+		repo:       j.repo,
+		configSrv:  j.configSrv,
+		pendingSrv: j.pendingSrv,
+		dispatcher: j.dispatcher,
+	}
+
+	nj.Base = jobs.NewBase(nj.getTasks())
+	return nj
+}
+
+// batchRun is the batch of the job being run.
+type batchRun struct {
+	Batch
+	ctx  context.Context
+	did  identity.DID
+	repo storage.Repository
+	log  jobs.Logger
+}
+
+func (j *OperationsJob) batchRun(args []interface{}, overrides map[string]interface{}) (*batchRun, error) {
+	did := args[0].(identity.DID)
+	b, err := getBatch(j.repo, did[:], jobs.JobIDFromOverrides(overrides))
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := j.configSrv.GetAccount(did[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	ctx, err := contextutil.New(context.Background(), acc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create context: %w", err)
+	}
+
+	return &batchRun{Batch: b, ctx: ctx, did: did, repo: j.repo, log: j.Logger(overrides)}, nil
+}
+
+// save stores the results of the batch, so that the operations run already are skipped if the task is run again.
+func (r *batchRun) save() error {
+	return r.repo.Update(batchKey(r.AccountID, r.ID), &r.Batch)
+}
+
+func (r *batchRun) fail(i int, err error) {
+	r.log.Warnf("operation %d failed: %v", i, err)
+	r.Results[i].Status = StatusFailed
+	r.Results[i].Error = err.Error()
+}
+
+// documentID returns the document of the operation.
+// Fails if an earlier operation on the document failed.
+func (r *batchRun) documentID(i int) ([]byte, error) {
+	op := r.Operations[i]
+	docID := op.DocumentID.Bytes()
+	if op.Ref != nil {
+		res := r.Results[*op.Ref]
+		if res.Status == StatusFailed || len(res.DocumentID) == 0 {
+			return nil, errors.New("operation %d failed", *op.Ref)
+		}
+
+		docID = res.DocumentID
+	}
+
+	for j := 0; j < i; j++ {
+		res := r.Results[j]
+		if res.Status == StatusFailed && res.DocumentID.String() == hexutil.Encode(docID) {
+			return nil, errors.New("operation %d on the document failed", j)
+		}
+	}
+
+	return docID, nil
+}
+
+func (j *OperationsJob) getTasks() map[string]jobs.Task {
+	return map[string]jobs.Task{
+		"run_operations": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				r, err := j.batchRun(args, overrides)
+				if err != nil {
+					return nil, err
+				}
+
+				for i, op := range r.Operations {
+					if r.Results[i].Status != StatusPending {
+						continue
+					}
+
+					doc, err := j.runOperation(r, i)
+					if err != nil {
+						r.fail(i, err)
+					} else if op.Type != OperationCommit {
+						r.Results[i] = Result{
+							Status:     StatusSuccess,
+							DocumentID: doc.ID(),
+							VersionID:  doc.CurrentVersion(),
+						}
+					}
+
+					if err := r.save(); err != nil {
+						return nil, err
+					}
+				}
+
+				r.log.Infof("ran %d operations, %d failed", len(r.Operations), r.Failed())
+				return nil, nil
+			},
+			Next: "commit_documents",
+		},
+		"commit_documents": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				r, err := j.batchRun(args, overrides)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, docID := range r.pendingCommits() {
+					commits := r.commitsOf(docID)
+					doc, jobID, err := j.pendingSrv.Commit(r.ctx, docID)
+					for _, i := range commits {
+						if err != nil {
+							r.fail(i, err)
+							continue
+						}
+
+						r.Results[i].VersionID = doc.CurrentVersion()
+						r.Results[i].JobID = byteutils.HexBytes(jobID)
+					}
+
+					if err == nil {
+						r.log.Infof("committing document %s in job %s for %d operations",
+							hexutil.Encode(docID), jobID.Hex(), len(commits))
+					}
+
+					if err := r.save(); err != nil {
+						return nil, err
+					}
+				}
+
+				return nil, nil
+			},
+			Next: "wait_for_commits",
+		},
+		"wait_for_commits": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				r, err := j.batchRun(args, overrides)
+				if err != nil {
+					return nil, err
+				}
+
+				var waiting int
+				for i, res := range r.Results {
+					if res.Status != StatusPending {
+						continue
+					}
+
+					info, err := j.dispatcher.Info(r.did, gocelery.JobID(res.JobID))
+					if err != nil {
+						return nil, fmt.Errorf("failed to fetch anchor job: %w", err)
+					}
+
+					switch info.Status {
+					case jobs.StatusSuccess:
+						r.Results[i].Status = StatusSuccess
+					case jobs.StatusPending:
+						waiting++
+					default:
+						r.fail(i, errors.New("anchor job %s", info.Status))
+					}
+				}
+
+				if waiting == 0 {
+					now := time.Now().UTC()
+					r.FinishedAt = &now
+				}
+
+				if err := r.save(); err != nil {
+					return nil, err
+				}
+
+				// polled until the documents are committed
+				if waiting > 0 {
+					return nil, errors.New("%d documents not committed yet", waiting)
+				}
+
+				r.log.Infof("batch finished, %d of %d operations failed", r.Failed(), len(r.Operations))
+				return nil, nil
+			},
+		},
+	}
+}
+
+// runOperation runs the operation, other than commit, on the pending document.
+// Commit operations are only checked for their document, and committed once all the operations are run.
+func (j *OperationsJob) runOperation(r *batchRun, i int) (documents.Document, error) {
+	op := r.Operations[i]
+	if op.Type == OperationCreate {
+		cp, err := coreapi.ToDocumentsCreatePayload(*op.Document)
+		if err != nil {
+			return nil, err
+		}
+
+		return j.pendingSrv.Create(r.ctx, documents.UpdatePayload{CreatePayload: cp})
+	}
+
+	docID, err := r.documentID(i)
+	if err != nil {
+		return nil, err
+	}
+
+	// the failures of the operations are matched to their document
+	r.Results[i].DocumentID = docID
+	switch op.Type {
+	case OperationUpdate:
+		cp, err := coreapi.ToDocumentsCreatePayload(*op.Document)
+		if err != nil {
+			return nil, err
+		}
+
+		return j.pendingSrv.Update(r.ctx, documents.UpdatePayload{CreatePayload: cp, DocumentID: docID})
+	case OperationAddAttributes:
+		cattrs, err := coreapi.ToDocumentAttributes(op.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		var attrs []documents.Attribute
+		for _, attr := range cattrs {
+			attrs = append(attrs, attr)
+		}
+
+		return j.pendingSrv.AddAttributes(r.ctx, docID, attrs)
+	default:
+		return nil, nil
+	}
+}
+
+// pendingCommits returns the documents of the commit operations yet to be committed, each once.
+func (r *batchRun) pendingCommits() (docIDs [][]byte) {
+	seen := make(map[string]bool)
+	for i, op := range r.Operations {
+		res := r.Results[i]
+		if op.Type != OperationCommit || res.Status != StatusPending || len(res.JobID) > 0 {
+			continue
+		}
+
+		if key := res.DocumentID.String(); !seen[key] {
+			seen[key] = true
+			docIDs = append(docIDs, res.DocumentID)
+		}
+	}
+
+	return docIDs
+}
+
+// commitsOf returns the pending commit operations of the document.
+func (r *batchRun) commitsOf(docID []byte) (ops []int) {
+	for i, op := range r.Operations {
+		res := r.Results[i]
+		if op.Type == OperationCommit && res.Status == StatusPending && res.DocumentID.String() == hexutil.Encode(docID) {
+			ops = append(ops, i)
+		}
+	}
+
+	return ops
+}
@@ -0,0 +1,131 @@
+// +build unit
+
+package batch
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/http/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestRepo(t *testing.T) storage.Repository {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	repo := leveldb.NewLevelDBRepository(db)
+	repo.Register(new(Batch))
+	return repo
+}
+
+func mockDocument(docID, versionID []byte) *documents.MockModel {
+	doc := new(documents.MockModel)
+	doc.On("ID").Return(docID)
+	doc.On("CurrentVersion").Return(versionID)
+	return doc
+}
+
+func TestOperationsJob(t *testing.T) {
+	repo := newTestRepo(t)
+	did := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+	acc := new(config.MockAccount)
+	acc.On("GetIdentityID").Return(did[:])
+	cfgSrv := new(config.MockService)
+	cfgSrv.On("GetAccount", did[:]).Return(acc, nil)
+	pendingSrv := new(pending.MockService)
+	dispatcher := new(jobs.MockDispatcher)
+
+	docID, otherDocID := utils.RandomSlice(32), utils.RandomSlice(32)
+	ops := []Operation{
+		{Type: OperationCreate, Document: &coreapi.CreateDocumentRequest{Scheme: "generic"}},
+		{Type: OperationAddAttributes, Ref: ref(0), Attributes: coreapi.AttributeMapRequest{
+			"label": {Type: "string", Value: "value"},
+		}},
+		{Type: OperationCommit, Ref: ref(0)},
+		{Type: OperationUpdate, DocumentID: otherDocID, Document: &coreapi.CreateDocumentRequest{Scheme: "generic"}},
+		{Type: OperationCommit, DocumentID: otherDocID},
+		{Type: OperationCommit, DocumentID: docID},
+	}
+
+	batchID := utils.RandomSlice(32)
+	b := Batch{ID: batchID, AccountID: did[:], Operations: ops, Results: make([]Result, len(ops))}
+	for i := range b.Results {
+		b.Results[i].Status = StatusPending
+	}
+	assert.NoError(t, repo.Create(batchKey(did[:], batchID), &b))
+
+	j := (&OperationsJob{
+		repo:       repo,
+		configSrv:  cfgSrv,
+		pendingSrv: pendingSrv,
+		dispatcher: dispatcher,
+	}).New().(*OperationsJob)
+	args := []interface{}{did}
+	overrides := map[string]interface{}{"job_id": hexutil.Encode(batchID)}
+
+	// run operations
+	pendingSrv.On("Create", mock.Anything, mock.Anything).Return(mockDocument(docID, utils.RandomSlice(32)), nil).Once()
+	pendingSrv.On("AddAttributes", mock.Anything, docID, mock.Anything).Return(
+		mockDocument(docID, utils.RandomSlice(32)), nil).Once()
+	pendingSrv.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("document not found")).Once()
+	_, err := j.RunnerFunc("run_operations")(args, overrides)
+	assert.NoError(t, err)
+	b, err = getBatch(repo, did[:], batchID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusSuccess, b.Results[0].Status)
+	assert.Equal(t, StatusSuccess, b.Results[1].Status)
+	assert.Equal(t, StatusPending, b.Results[2].Status)
+	assert.Equal(t, StatusFailed, b.Results[3].Status)
+	assert.Contains(t, b.Results[3].Error, "document not found")
+	assert.Equal(t, StatusFailed, b.Results[4].Status)
+	assert.Contains(t, b.Results[4].Error, "operation 3 on the document failed")
+	assert.Equal(t, StatusPending, b.Results[5].Status)
+
+	// operations already run are skipped
+	_, err = j.RunnerFunc("run_operations")(args, overrides)
+	assert.NoError(t, err)
+
+	// the document is committed once
+	versionID, anchorJob := utils.RandomSlice(32), gocelery.JobID(utils.RandomSlice(32))
+	pendingSrv.On("Commit", mock.Anything, docID).Return(mockDocument(docID, versionID), anchorJob, nil).Once()
+	_, err = j.RunnerFunc("commit_documents")(args, overrides)
+	assert.NoError(t, err)
+	b, err = getBatch(repo, did[:], batchID)
+	assert.NoError(t, err)
+	for _, i := range []int{2, 5} {
+		assert.Equal(t, StatusPending, b.Results[i].Status)
+		assert.Equal(t, versionID, b.Results[i].VersionID.Bytes())
+		assert.Equal(t, anchorJob.Hex(), b.Results[i].JobID.String())
+	}
+
+	// anchor job pending
+	dispatcher.On("Info", did, anchorJob).Return(jobs.Info{Status: jobs.StatusPending}, nil).Twice()
+	_, err = j.RunnerFunc("wait_for_commits")(args, overrides)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "2 documents not committed yet")
+
+	// anchor job done
+	dispatcher.On("Info", did, anchorJob).Return(jobs.Info{Status: jobs.StatusSuccess}, nil).Twice()
+	_, err = j.RunnerFunc("wait_for_commits")(args, overrides)
+	assert.NoError(t, err)
+	b, err = getBatch(repo, did[:], batchID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusSuccess, b.Results[2].Status)
+	assert.Equal(t, StatusSuccess, b.Results[5].Status)
+	assert.Equal(t, 2, b.Failed())
+	assert.NotNil(t, b.FinishedAt)
+	pendingSrv.AssertExpectations(t)
+	dispatcher.AssertExpectations(t)
+}
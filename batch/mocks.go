@@ -0,0 +1,34 @@
+// +build integration unit
+
+package batch
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
+	return b.Bootstrap(context)
+}
+
+func (Bootstrapper) TestTearDown() error {
+	return nil
+}
+
+type MockService struct {
+	mock.Mock
+	Service
+}
+
+func (m *MockService) Submit(ctx context.Context, ops []Operation) (Batch, error) {
+	args := m.Called(ctx, ops)
+	b, _ := args.Get(0).(Batch)
+	return b, args.Error(1)
+}
+
+func (m *MockService) Get(ctx context.Context, batchID []byte) (Batch, error) {
+	args := m.Called(ctx, batchID)
+	b, _ := args.Get(0).(Batch)
+	return b, args.Error(1)
+}
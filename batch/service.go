@@ -0,0 +1,97 @@
+package batch
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const batchPrefix = "batch_"
+
+func batchKey(accountID, batchID []byte) []byte {
+	return []byte(batchPrefix + hexutil.Encode(accountID) + "_" + hexutil.Encode(batchID))
+}
+
+type service struct {
+	repo       storage.Repository
+	dispatcher jobs.Dispatcher
+}
+
+// newService returns the batch service storing the batches in repo.
+func newService(repo storage.Repository, dispatcher jobs.Dispatcher) Service {
+	repo.Register(new(Batch))
+	return service{repo: repo, dispatcher: dispatcher}
+}
+
+func (s service) Submit(ctx context.Context, ops []Operation) (Batch, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return Batch{}, err
+	}
+
+	if err := Validate(ops); err != nil {
+		return Batch{}, err
+	}
+
+	// the batch is stored under the ID of its job
+	job := gocelery.NewRunnerJob(
+		"Batch operations", batchJob, "run_operations",
+		[]interface{}{did}, make(map[string]interface{}), time.Time{})
+	b := Batch{
+		ID:         byteutils.HexBytes(job.ID),
+		AccountID:  did[:],
+		Operations: ops,
+		Results:    make([]Result, len(ops)),
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	for i := range b.Results {
+		b.Results[i].Status = StatusPending
+	}
+
+	key := batchKey(did[:], b.ID)
+	err = s.repo.Create(key, &b)
+	if err != nil {
+		return Batch{}, err
+	}
+
+	_, err = s.dispatcher.Dispatch(did, job)
+	if err != nil {
+		if derr := s.repo.Delete(key); derr != nil {
+			log.Errorf("failed to delete the batch[%s]: %v", b.ID.String(), derr)
+		}
+
+		return Batch{}, err
+	}
+
+	return b, nil
+}
+
+func (s service) Get(ctx context.Context, batchID []byte) (Batch, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return Batch{}, err
+	}
+
+	return getBatch(s.repo, did[:], batchID)
+}
+
+func getBatch(repo storage.Repository, accountID, batchID []byte) (Batch, error) {
+	m, err := repo.Get(batchKey(accountID, batchID))
+	if err != nil {
+		return Batch{}, ErrBatchNotFound
+	}
+
+	b, ok := m.(*Batch)
+	if !ok {
+		return Batch{}, ErrBatchNotFound
+	}
+
+	return *b, nil
+}
@@ -0,0 +1,61 @@
+// +build unit
+
+package batch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_Submit(t *testing.T) {
+	repo := newTestRepo(t)
+	dispatcher := new(jobs.MockDispatcher)
+	srv := newService(repo, dispatcher)
+	ops := []Operation{{Type: OperationCommit, DocumentID: utils.RandomSlice(32)}}
+
+	// missing account
+	_, err := srv.Submit(context.Background(), ops)
+	assert.Error(t, err)
+
+	did := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+	acc := new(config.MockAccount)
+	acc.On("GetIdentityID").Return(did[:])
+	ctx, err := contextutil.New(context.Background(), acc)
+	assert.NoError(t, err)
+
+	// invalid batch
+	_, err = srv.Submit(ctx, nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBatch, err))
+
+	// failed to dispatch
+	dispatcher.On("Dispatch", did, mock.Anything).Return(nil, errors.New("failed to dispatch")).Once()
+	_, err = srv.Submit(ctx, ops)
+	assert.Error(t, err)
+
+	// success
+	dispatcher.On("Dispatch", did, mock.Anything).Return(nil, nil).Once()
+	b, err := srv.Submit(ctx, ops)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPending, b.Results[0].Status)
+
+	gb, err := srv.Get(ctx, b.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, b.ID, gb.ID)
+	assert.Equal(t, ops[0].DocumentID, gb.Operations[0].DocumentID)
+
+	// other accounts can't see the batch
+	_, err = getBatch(repo, utils.RandomSlice(20), b.ID)
+	assert.Equal(t, ErrBatchNotFound, err)
+	dispatcher.AssertExpectations(t)
+}
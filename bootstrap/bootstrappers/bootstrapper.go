@@ -2,6 +2,7 @@ package bootstrappers
 
 import (
 	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/batch"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/centchain"
 	"github.com/centrifuge/go-centrifuge/config"
@@ -11,16 +12,19 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/ethereum/devchain"
+	"github.com/centrifuge/go-centrifuge/fees"
 	"github.com/centrifuge/go-centrifuge/http"
 	v2 "github.com/centrifuge/go-centrifuge/http/v2"
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
 	"github.com/centrifuge/go-centrifuge/node"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/oracle"
 	"github.com/centrifuge/go-centrifuge/p2p"
 	"github.com/centrifuge/go-centrifuge/pending"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/centrifuge/go-centrifuge/version"
 	log2 "github.com/ipfs/go-log"
 )
@@ -37,10 +41,13 @@ func (m *MainBootstrapper) PopulateBaseBootstrappers() {
 	m.Bootstrappers = []bootstrap.Bootstrapper{
 		&version.Bootstrapper{},
 		&config.Bootstrapper{},
-		&leveldb.Bootstrapper{},
+		&backend.Bootstrapper{},
+		notification.Bootstrapper{},
 		jobs.Bootstrapper{},
 		centchain.Bootstrapper{},
 		ethereum.Bootstrapper{},
+		devchain.Bootstrapper{},
+		fees.Bootstrapper{},
 		&ideth.Bootstrapper{},
 		&configstore.Bootstrapper{},
 		&anchors.Bootstrapper{},
@@ -54,6 +61,7 @@ func (m *MainBootstrapper) PopulateBaseBootstrappers() {
 		pending.Bootstrapper{},
 		&entity.Bootstrapper{},
 		oracle.Bootstrapper{},
+		batch.Bootstrapper{},
 		v2.Bootstrapper{},
 	}
 }
@@ -63,10 +71,11 @@ func (m *MainBootstrapper) PopulateCommandBootstrappers() {
 	m.Bootstrappers = []bootstrap.Bootstrapper{
 		&version.Bootstrapper{},
 		&config.Bootstrapper{},
-		&leveldb.Bootstrapper{},
+		&backend.Bootstrapper{},
 		jobs.Bootstrapper{},
 		centchain.Bootstrapper{},
 		ethereum.Bootstrapper{},
+		fees.Bootstrapper{},
 		&ideth.Bootstrapper{},
 		&anchors.Bootstrapper{},
 	}
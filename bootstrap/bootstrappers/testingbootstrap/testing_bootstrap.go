@@ -4,6 +4,7 @@ package testingbootstrap
 
 import (
 	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/batch"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
 	"github.com/centrifuge/go-centrifuge/centchain"
@@ -18,10 +19,11 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/oracle"
 	"github.com/centrifuge/go-centrifuge/p2p"
 	"github.com/centrifuge/go-centrifuge/pending"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	logging "github.com/ipfs/go-log"
 )
@@ -31,7 +33,8 @@ var log = logging.Logger("context")
 var bootstrappers = []bootstrap.TestBootstrapper{
 	&testlogging.TestLoggingBootstrapper{},
 	&config.Bootstrapper{},
-	&leveldb.Bootstrapper{},
+	&backend.Bootstrapper{},
+	notification.Bootstrapper{},
 	jobs.Bootstrapper{},
 	centchain.Bootstrapper{},
 	ethereum.Bootstrapper{},
@@ -47,6 +50,7 @@ var bootstrappers = []bootstrap.TestBootstrapper{
 	pending.Bootstrapper{},
 	&entity.Bootstrapper{},
 	oracle.Bootstrapper{},
+	batch.Bootstrapper{},
 	v2.Bootstrapper{},
 }
 
@@ -2,22 +2,23 @@ package centchain
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client"
 	"github.com/centrifuge/go-substrate-rpc-client/client"
 	"github.com/centrifuge/go-substrate-rpc-client/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/types"
 	"github.com/centrifuge/gocelery/v2"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	logging "github.com/ipfs/go-log"
 )
 
@@ -31,6 +32,16 @@ const (
 	// ErrInvalidTransaction wrapper for a general error
 	// Used sometimes as stale extrinsic (nonce too low)
 	ErrInvalidTransaction = errors.Error("Invalid Transaction")
+
+	// ErrExtrinsicNotSubmitted is returned by SubmitAndWatch when the extrinsic failed before reaching the
+	// transaction pool, so no fee was paid for it.
+	ErrExtrinsicNotSubmitted = errors.Error("extrinsic not submitted")
+
+	// ErrNodeUnavailable is returned when the node fails to answer the requests preparing the extrinsic. It is transient.
+	ErrNodeUnavailable = errors.Error("centchain node unavailable")
+
+	// timestampSet is centrifuge chain module function name for the timestamp inherent of a block.
+	timestampSet = "Timestamp.set"
 )
 
 var log = logging.Logger("centchain-client")
@@ -50,6 +61,26 @@ type API interface {
 
 	// SubmitAndWatch returns function that submits and watches an extrinsic, implements transaction.Submitter
 	SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) error
+
+	// GetBlockInfo returns the hash and the timestamp of the block with the given number.
+	GetBlockInfo(number uint32) (BlockInfo, error)
+
+	// QueryFeeInfo returns the weight and the fee of the call when submitted by the account.
+	QueryFeeInfo(meta *types.Metadata, c types.Call, accountID []byte) (FeeInfo, error)
+}
+
+// FeeInfo holds the weight of a call and the fee paid for it, in the smallest unit of the chain currency.
+// The fee excludes the tip.
+type FeeInfo struct {
+	Weight uint64
+	Fee    *big.Int
+}
+
+// BlockInfo holds the hash, the number and the timestamp of a block.
+type BlockInfo struct {
+	Hash      types.Hash
+	Number    uint32
+	Timestamp time.Time
 }
 
 // substrateAPI exposes Substrate API functions
@@ -63,6 +94,8 @@ type substrateAPI interface {
 	GetStorageLatest(key types.StorageKey, target interface{}) error
 	GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) error
 	GetBlock(blockHash types.Hash) (*types.SignedBlock, error)
+	GetFinalizedHead() (types.Hash, error)
+	GetHeader(blockHash types.Hash) (*types.Header, error)
 }
 
 // Config defines functions to get centchain details
@@ -92,6 +125,14 @@ func (dsa *defaultSubstrateAPI) GetBlock(blockHash types.Hash) (*types.SignedBlo
 	return dsa.sapi.RPC.Chain.GetBlock(blockHash)
 }
 
+func (dsa *defaultSubstrateAPI) GetFinalizedHead() (types.Hash, error) {
+	return dsa.sapi.RPC.Chain.GetFinalizedHead()
+}
+
+func (dsa *defaultSubstrateAPI) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	return dsa.sapi.RPC.Chain.GetHeader(blockHash)
+}
+
 func (dsa *defaultSubstrateAPI) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) error {
 	_, err := dsa.sapi.RPC.State.GetStorage(key, target, blockHash)
 	return err
@@ -118,18 +159,16 @@ type api struct {
 	sapi       substrateAPI
 	config     Config
 	dispatcher jobs.Dispatcher
-	accounts   map[string]uint32
-	accMu      sync.Mutex // accMu to protect accounts
+	nonces     *nonceManager
 }
 
-// NewAPI returns a new centrifuge chain api.
-func NewAPI(sapi substrateAPI, config Config, dispatcher jobs.Dispatcher) API {
+// NewAPI returns a new centrifuge chain api. The nonces of the accounts are persisted in repo.
+func NewAPI(sapi substrateAPI, config Config, dispatcher jobs.Dispatcher, repo storage.Repository) API {
 	return &api{
 		sapi:       sapi,
 		config:     config,
 		dispatcher: dispatcher,
-		accounts:   make(map[string]uint32),
-		accMu:      sync.Mutex{},
+		nonces:     newNonceManager(sapi, repo),
 	}
 }
 
@@ -141,19 +180,24 @@ func (a *api) GetMetadataLatest() (*types.Metadata, error) {
 	return a.sapi.GetMetadataLatest()
 }
 
-func (a *api) submitExtrinsic(c types.Call, nonce uint64, krp signature.KeyringPair) (txHash types.Hash,
+// unavailable returns err typed as ErrNodeUnavailable.
+func unavailable(err error) error {
+	return jobs.Transient(errors.NewTypedError(ErrNodeUnavailable, err))
+}
+
+func (a *api) submitExtrinsic(c types.Call, nonce, tip uint64, krp signature.KeyringPair) (txHash types.Hash,
 	bn types.BlockNumber, sig types.MultiSignature, err error) {
 	ext := types.NewExtrinsic(c)
 	era := types.ExtrinsicEra{IsMortalEra: false}
 
 	genesisHash, err := a.sapi.GetBlockHash(0)
 	if err != nil {
-		return txHash, bn, sig, err
+		return txHash, bn, sig, unavailable(err)
 	}
 
 	rv, err := a.sapi.GetRuntimeVersionLatest()
 	if err != nil {
-		return txHash, bn, sig, err
+		return txHash, bn, sig, unavailable(err)
 	}
 
 	o := types.SignatureOptions{
@@ -162,7 +206,7 @@ func (a *api) submitExtrinsic(c types.Call, nonce uint64, krp signature.KeyringP
 		GenesisHash:        genesisHash,
 		Nonce:              types.NewUCompactFromUInt(nonce),
 		SpecVersion:        rv.SpecVersion,
-		Tip:                types.NewUCompactFromUInt(0),
+		Tip:                types.NewUCompactFromUInt(tip),
 		TransactionVersion: rv.TransactionVersion,
 	}
 
@@ -174,7 +218,7 @@ func (a *api) submitExtrinsic(c types.Call, nonce uint64, krp signature.KeyringP
 	auth := author.NewAuthor(a.sapi.GetClient())
 	startBlock, err := a.sapi.GetBlockLatest()
 	if err != nil {
-		return txHash, bn, sig, err
+		return txHash, bn, sig, unavailable(err)
 	}
 
 	startBlockNumber := startBlock.Block.Header.Number
@@ -189,47 +233,57 @@ func (a *api) SubmitExtrinsic(ctx context.Context, meta *types.Metadata, c types
 	var bn types.BlockNumber
 	var sig types.MultiSignature
 	var nonce uint32
+	var tip uint64
 
 	maxTries := a.config.GetCentChainMaxRetries()
 	for {
 		if current >= maxTries {
+			// the nonces are taken by the concurrent transactions, which may be done on retry
 			err = errors.Error("max concurrent transaction tries reached")
-			return types.Hash{}, types.BlockNumber(0), types.MultiSignature{}, errors.NewTypedError(ErrCentChainTransaction, err)
+			return types.Hash{}, types.BlockNumber(0), types.MultiSignature{},
+				jobs.Transient(errors.NewTypedError(ErrCentChainTransaction, err))
 		}
 
 		current++
-		var ok bool
-		nonce, ok = a.getNonceInAccount(krp.PublicKey)
-		if !ok { // First time using account in session
-			nonce, err = a.getNonceFromChain(meta, krp.PublicKey)
-			if err != nil {
-				return txHash, bn, sig, err
-			}
-			a.setNonceInAccount(krp.PublicKey, nonce)
+		nonce, tip, err = a.nonces.reserve(meta, krp.PublicKey)
+		if err != nil {
+			return txHash, bn, sig, err
 		}
 
-		txHash, bn, sig, err = a.submitExtrinsic(c, uint64(nonce), krp)
+		txHash, bn, sig, err = a.submitExtrinsic(c, uint64(nonce), tip, krp)
 		if err == nil {
 			break
 		}
 
-		if strings.Contains(err.Error(), ErrNonceTooLow.Error()) || strings.Contains(err.Error(), ErrInvalidTransaction.Error()) {
+		switch {
+		case strings.Contains(err.Error(), ErrNonceTooLow.Error()):
+			log.Warnf("Used Nonce %v. Failed with error: %v\n", nonce, err)
+			log.Warnf("Nonce used by a pending transaction, trying again with a higher tip [%d/%d]\n", current, maxTries)
+			err = a.nonces.conflict(krp.PublicKey, nonce)
+		case strings.Contains(err.Error(), ErrInvalidTransaction.Error()):
 			log.Warnf("Used Nonce %v. Failed with error: %v\n", nonce, err)
 			log.Warnf("Concurrent transaction identified, trying again [%d/%d]\n", current, maxTries)
-			chainNonce, err := a.getNonceFromChain(meta, krp.PublicKey)
-			if err != nil {
-				return txHash, bn, sig, err
+			err = a.nonces.stale(krp.PublicKey, nonce)
+		default:
+			if rerr := a.nonces.release(krp.PublicKey, nonce); rerr != nil {
+				log.Errorf("failed to release nonce %d: %v", nonce, rerr)
 			}
-			a.setNonceInAccount(krp.PublicKey, chainNonce)
-			time.Sleep(a.config.GetCentChainIntervalRetry())
-			continue
+
+			return txHash, bn, sig, err
 		}
 
-		return txHash, bn, sig, err
+		if err != nil {
+			return txHash, bn, sig, err
+		}
+
+		time.Sleep(a.config.GetCentChainIntervalRetry())
+	}
+
+	log.Infof("Successfully submitted ext %s with nonce %d and tip %d from blockNumber %d", txHash.Hex(), nonce, tip, bn)
+	if err := a.nonces.submitted(krp.PublicKey, nonce, txHash); err != nil {
+		log.Errorf("failed to track extrinsic %s: %v", txHash.Hex(), err)
 	}
 
-	log.Infof("Successfully submitted ext %s with nonce %d and from blockNumber %d", txHash.Hex(), nonce, bn)
-	a.incrementNonce(krp.PublicKey)
 	return txHash, bn, sig, nil
 }
 
@@ -242,7 +296,7 @@ func (a *api) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.
 
 	txHash, bn, sig, err := a.SubmitExtrinsic(ctx, meta, c, krp)
 	if err != nil {
-		return fmt.Errorf("failed to submit extrinsic: %w", err)
+		return errors.NewTypedError(ErrExtrinsicNotSubmitted, err)
 	}
 
 	s, err := getSignature(sig)
@@ -278,47 +332,103 @@ func (a *api) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.
 		return fmt.Errorf("failed to dispatch job: %w", err)
 	}
 
-	_, err = res.Await(context.Background())
+	_, err = res.Await(ctx)
 	return err
 }
 
-func (a *api) incrementNonce(accountID []byte) {
-	a.accMu.Lock()
-	defer a.accMu.Unlock()
-	acc := hexutil.Encode(accountID)
-	if _, ok := a.accounts[acc]; !ok { // Should not be reached
-		return
+// GetBlockInfo returns the hash and the timestamp of the block with the given number.
+// The timestamp is read from the timestamp inherent of the block.
+func (a *api) GetBlockInfo(number uint32) (BlockInfo, error) {
+	bh, err := a.sapi.GetBlockHash(uint64(number))
+	if err != nil {
+		return BlockInfo{}, fmt.Errorf("failed to get block hash: %w", err)
+	}
+
+	block, err := a.sapi.GetBlock(bh)
+	if err != nil {
+		return BlockInfo{}, fmt.Errorf("failed to get block: %w", err)
 	}
-	a.accounts[acc]++
-}
 
-func (a *api) getNonceFromChain(meta *types.Metadata, krp []byte) (uint32, error) {
-	key, err := types.CreateStorageKey(meta, "System", "Account", krp, nil)
+	meta, err := a.sapi.GetMetadataLatest()
 	if err != nil {
-		return 0, err
+		return BlockInfo{}, err
 	}
 
-	var accountInfo types.AccountInfo
-	err = a.sapi.GetStorageLatest(key, &accountInfo)
+	ts, err := blockTimestamp(meta, block.Block)
 	if err != nil {
-		return 0, err
+		return BlockInfo{}, err
 	}
-	return uint32(accountInfo.Nonce), nil
+
+	return BlockInfo{Hash: bh, Number: number, Timestamp: ts}, nil
+}
+
+// runtimeDispatchInfo is the result of the payment_queryInfo RPC.
+type runtimeDispatchInfo struct {
+	Weight     uint64          `json:"weight"`
+	PartialFee json.RawMessage `json:"partialFee"`
 }
 
-func (a *api) setNonceInAccount(accountID []byte, nonce uint32) {
-	a.accMu.Lock()
-	defer a.accMu.Unlock()
+// QueryFeeInfo returns the weight and the fee of the call when submitted by the account, as computed by
+// payment_queryInfo. The extrinsic is not signed since neither the weight nor the fee depend on the signature.
+func (a *api) QueryFeeInfo(meta *types.Metadata, c types.Call, accountID []byte) (FeeInfo, error) {
+	ext := signedExtrinsic(c, accountID, 0, types.MultiSignature{IsSr25519: true})
+	enc, err := types.EncodeToHexString(ext)
+	if err != nil {
+		return FeeInfo{}, err
+	}
+
+	var info runtimeDispatchInfo
+	err = a.sapi.Call(&info, "payment_queryInfo", enc)
+	if err != nil {
+		return FeeInfo{}, fmt.Errorf("failed to query fee info: %w", err)
+	}
+
+	// the fee is a decimal string or a number depending on the node version
+	fee, ok := new(big.Int).SetString(strings.Trim(string(info.PartialFee), `"`), 10)
+	if !ok {
+		return FeeInfo{}, errors.New("invalid partial fee %s", info.PartialFee)
+	}
 
-	a.accounts[hexutil.Encode(accountID)] = nonce
+	return FeeInfo{Weight: info.Weight, Fee: fee}, nil
 }
 
-func (a *api) getNonceInAccount(accountID []byte) (uint32, bool) {
-	a.accMu.Lock()
-	defer a.accMu.Unlock()
+// signedExtrinsic returns the immortal extrinsic of the call with the signature, no tip and the nonce of the account.
+func signedExtrinsic(c types.Call, accountID []byte, nonce uint64, sig types.MultiSignature) types.Extrinsic {
+	ext := types.NewExtrinsic(c)
+	ext.Signature = types.ExtrinsicSignatureV4{
+		Signer:    types.NewAddressFromAccountID(accountID),
+		Signature: sig,
+		Era:       types.ExtrinsicEra{IsImmortalEra: true},
+		Nonce:     types.NewUCompactFromUInt(nonce),
+		Tip:       types.NewUCompactFromUInt(0),
+	}
+	ext.Version |= types.ExtrinsicBitSigned
+	return ext
+}
+
+// blockTimestamp returns the time set by the timestamp inherent of the block.
+func blockTimestamp(meta *types.Metadata, block types.Block) (time.Time, error) {
+	idx, err := meta.FindCallIndex(timestampSet)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, ext := range block.Extrinsics {
+		if ext.Method.CallIndex != idx {
+			continue
+		}
+
+		// Timestamp.set takes the milliseconds since epoch, compact encoded
+		var ms types.UCompact
+		err = types.DecodeFromBytes(ext.Method.Args, &ms)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to decode block timestamp: %w", err)
+		}
+
+		return time.Unix(0, (*big.Int)(&ms).Int64()*int64(time.Millisecond)).UTC(), nil
+	}
 
-	n, ok := a.accounts[hexutil.Encode(accountID)]
-	return n, ok
+	return time.Time{}, errors.New("timestamp of block %d not found", block.Header.Number)
 }
 
 func getSignature(msig types.MultiSignature) (types.Signature, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-substrate-rpc-client/types"
@@ -38,7 +39,7 @@ func TestMain(m *testing.M) {
 func TestApi_GetMetadataLatest(t *testing.T) {
 	mockSAPI := new(MockSubstrateAPI)
 	mockSAPI.On("GetMetadataLatest").Return(types.NewMetadataV8(), nil).Once()
-	api := NewAPI(mockSAPI, nil, nil)
+	api := NewAPI(mockSAPI, nil, nil, newTestRepo(t))
 	meta, err := api.GetMetadataLatest()
 	assert.NoError(t, err)
 	assert.Equal(t, types.NewMetadataV8(), meta)
@@ -47,7 +48,7 @@ func TestApi_GetMetadataLatest(t *testing.T) {
 func TestApi_Call(t *testing.T) {
 	mockSAPI := new(MockSubstrateAPI)
 	mockSAPI.On("Call", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
-	api := NewAPI(mockSAPI, nil, nil)
+	api := NewAPI(mockSAPI, nil, nil, newTestRepo(t))
 	err := api.Call(nil, "", nil)
 	assert.NoError(t, err)
 }
@@ -71,7 +72,7 @@ func TestApi_SubmitWithRetries(t *testing.T) {
 	defer mockRetries()
 
 	mockSAPI := new(MockSubstrateAPI)
-	iapi := NewAPI(mockSAPI, cfg, nil)
+	iapi := NewAPI(mockSAPI, cfg, nil, newTestRepo(t))
 	tapi := iapi.(*api)
 
 	// Failed to get nonce from chain
@@ -87,13 +88,17 @@ func TestApi_SubmitWithRetries(t *testing.T) {
 	_, _, _, err = tapi.SubmitExtrinsic(ctx, meta, c, krp)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get block hash")
+	assert.True(t, errors.IsOfType(ErrNodeUnavailable, err))
+	assert.True(t, jobs.IsTransient(err))
 
 	// Recoverable failure to submit extrinsic, max retrials reached
-	mockSAPI.On("GetStorageLatest", mock.Anything, mock.Anything).Return(nil).Times(3)
+	// the nonce released above is handed out again without reading the chain
 	mockSAPI.On("GetBlockHash", mock.Anything).Return(types.Hash{}, ErrNonceTooLow).Times(3)
 	_, _, _, err = tapi.SubmitExtrinsic(ctx, meta, c, krp)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "max concurrent transaction tries reached")
+	assert.True(t, errors.IsOfType(ErrCentChainTransaction, err))
+	assert.True(t, jobs.IsTransient(err))
 
 	// Success
 	mockSAPI.On("GetBlockHash", mock.Anything).Return(types.Hash(utils.RandomByte32()), nil).Once()
@@ -104,6 +109,88 @@ func TestApi_SubmitWithRetries(t *testing.T) {
 	mockClient.On("Call", mock.Anything, mock.Anything, mock.Anything).Return(hexutil.Encode(utils.RandomSlice(32)), nil)
 	_, _, _, err = tapi.SubmitExtrinsic(ctx, meta, c, krp)
 	assert.NoError(t, err)
-	assert.Equal(t, uint32(1), tapi.accounts[cacc.ID]) // Incremented nonce
+	acc := tapi.nonces.accounts[hexutil.Encode(krp.PublicKey)]
+	assert.Equal(t, uint32(1), acc.Next)
+	assert.Contains(t, acc.Inflight, uint32(0))
+	mockSAPI.AssertExpectations(t)
+}
+
+func TestApi_GetBlockInfo(t *testing.T) {
+	meta := MetaDataWithCall(timestampSet)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	c, err := types.NewCall(meta, timestampSet, types.NewUCompactFromUInt(uint64(now.UnixNano()/int64(time.Millisecond))))
+	assert.NoError(t, err)
+	bh := types.Hash(utils.RandomByte32())
+
+	// failed to get block
+	mockSAPI := new(MockSubstrateAPI)
+	api := NewAPI(mockSAPI, nil, nil, newTestRepo(t))
+	mockSAPI.On("GetBlockHash", uint64(10)).Return(bh, nil)
+	mockSAPI.On("GetMetadataLatest").Return(meta, nil)
+	mockSAPI.On("GetBlock", bh).Return(nil, errors.New("block not found")).Once()
+	_, err = api.GetBlockInfo(10)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block not found")
+
+	// timestamp missing
+	block := new(types.SignedBlock)
+	mockSAPI.On("GetBlock", bh).Return(block, nil).Once()
+	_, err = api.GetBlockInfo(10)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timestamp of block 0 not found")
+
+	// success
+	block.Block.Extrinsics = []types.Extrinsic{types.NewExtrinsic(c)}
+	mockSAPI.On("GetBlock", bh).Return(block, nil).Once()
+	info, err := api.GetBlockInfo(10)
+	assert.NoError(t, err)
+	assert.Equal(t, bh, info.Hash)
+	assert.Equal(t, uint32(10), info.Number)
+	assert.True(t, now.Equal(info.Timestamp))
+	mockSAPI.AssertExpectations(t)
+}
+
+func TestApi_QueryFeeInfo(t *testing.T) {
+	meta := MetaDataWithCall(anchorCommit)
+	c, err := types.NewCall(
+		meta, anchorCommit, types.NewHash(utils.RandomSlice(32)), types.NewHash(utils.RandomSlice(32)),
+		types.NewHash(utils.RandomSlice(32)), types.NewMoment(time.Now()))
+	assert.NoError(t, err)
+	accountID := utils.RandomSlice(32)
+	mockSAPI := new(MockSubstrateAPI)
+	api := NewAPI(mockSAPI, nil, nil, newTestRepo(t))
+
+	// failed rpc
+	mockSAPI.On("Call", mock.Anything, "payment_queryInfo", mock.Anything).Return(errors.New("rpc failed")).Once()
+	_, err = api.QueryFeeInfo(meta, c, accountID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rpc failed")
+
+	// fee as a string and as a number
+	for _, fee := range []string{`"125000000000000000"`, `125000000000000000`} {
+		mockSAPI.On("Call", mock.Anything, "payment_queryInfo", mock.Anything).Run(func(args mock.Arguments) {
+			info := args.Get(0).(*runtimeDispatchInfo)
+			info.Weight = 1000
+			info.PartialFee = []byte(fee)
+
+			// the extrinsic is signed by the account
+			var ext types.Extrinsic
+			assert.NoError(t, types.DecodeFromHexString(args.Get(2).([]interface{})[0].(string), &ext))
+			assert.True(t, ext.IsSigned())
+			assert.Equal(t, accountID, ext.Signature.Signer.AsAccountID[:])
+			assert.Equal(t, c, ext.Method)
+		}).Return(nil).Once()
+		info, err := api.QueryFeeInfo(meta, c, accountID)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1000), info.Weight)
+		assert.Equal(t, "125000000000000000", info.Fee.String())
+	}
+
+	// invalid fee
+	mockSAPI.On("Call", mock.Anything, "payment_queryInfo", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*runtimeDispatchInfo).PartialFee = []byte(`"0x10"`)
+	}).Return(nil).Once()
+	_, err = api.QueryFeeInfo(meta, c, accountID)
+	assert.Error(t, err)
 	mockSAPI.AssertExpectations(t)
 }
@@ -3,11 +3,17 @@ package centchain
 import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client"
 )
 
-// BootstrappedCentChainClient is a key to mapped client in bootstrap context.
-const BootstrappedCentChainClient string = "BootstrappedCentChainClient"
+const (
+	// BootstrappedCentChainClient is a key to mapped client in bootstrap context.
+	BootstrappedCentChainClient string = "BootstrappedCentChainClient"
+
+	// BootstrappedWatcher is a key to mapped chain watcher in bootstrap context.
+	BootstrappedWatcher string = "BootstrappedCentChainWatcher"
+)
 
 // Bootstrapper implements bootstrap.Bootstrapper.
 type Bootstrapper struct{}
@@ -19,13 +25,44 @@ func (Bootstrapper) Bootstrap(context map[string]interface{}) error {
 		return err
 	}
 
+	repo := context[storage.BootstrappedDB].(storage.Repository)
+	if cfg.IsCentChainSimulatorEnabled() {
+		return bootstrapSimulator(context, cfg, repo)
+	}
+
 	dispatcher := context[jobs.BootstrappedDispatcher].(jobs.Dispatcher)
 	sapi, err := gsrpc.NewSubstrateAPI(cfg.GetCentChainNodeURL())
 	if err != nil {
 		return err
 	}
 	centSAPI := &defaultSubstrateAPI{sapi}
-	client := NewAPI(centSAPI, cfg, dispatcher)
+	client := NewAPI(centSAPI, cfg, dispatcher, repo)
 	context[BootstrappedCentChainClient] = client
+	context[BootstrappedWatcher] = NewWatcher(centSAPI, repo)
+	return nil
+}
+
+// bootstrapSimulator maps the simulated chain as the centchain client.
+// The watcher cursor is reset since the blocks of the simulated chain don't outlive the node.
+func bootstrapSimulator(context map[string]interface{}, cfg config.Configuration, repo storage.Repository) error {
+	log.Warnf("Centrifuge chain simulator enabled, anchors are lost when the node stops")
+	sim, err := NewSimulator(SimulatorConfig{
+		BlockTime:   cfg.GetCentChainSimulatorBlockTime(),
+		FailureRate: cfg.GetCentChainSimulatorFailureRate(),
+	})
+	if err != nil {
+		return err
+	}
+
+	key := []byte(watcherCursorKey)
+	if repo.Exists(key) {
+		err = repo.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	context[BootstrappedCentChainClient] = sim
+	context[BootstrappedWatcher] = NewWatcher(sim, repo)
 	return nil
 }
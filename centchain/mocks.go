@@ -37,27 +37,39 @@ func (ms *MockSubstrateAPI) GetMetadataLatest() (*types.Metadata, error) {
 }
 
 func (ms *MockSubstrateAPI) Call(result interface{}, method string, args ...interface{}) error {
-	argss := ms.Called()
+	argss := ms.Called(result, method, args)
 	return argss.Error(0)
 }
 
 func (ms *MockSubstrateAPI) GetBlockHash(blockNumber uint64) (types.Hash, error) {
-	args := ms.Called()
+	args := ms.Called(blockNumber)
 	md, _ := args.Get(0).(types.Hash)
 	return md, args.Error(1)
 }
 
 func (ms *MockSubstrateAPI) GetBlock(blockHash types.Hash) (*types.SignedBlock, error) {
-	args := ms.Called()
+	args := ms.Called(blockHash)
 	md, _ := args.Get(0).(*types.SignedBlock)
 	return md, args.Error(1)
 }
 
 func (ms *MockSubstrateAPI) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) error {
-	args := ms.Called()
+	args := ms.Called(key, target, blockHash)
 	return args.Error(0)
 }
 
+func (ms *MockSubstrateAPI) GetFinalizedHead() (types.Hash, error) {
+	args := ms.Called()
+	h, _ := args.Get(0).(types.Hash)
+	return h, args.Error(1)
+}
+
+func (ms *MockSubstrateAPI) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	args := ms.Called(blockHash)
+	h, _ := args.Get(0).(*types.Header)
+	return h, args.Error(1)
+}
+
 func (ms *MockSubstrateAPI) GetBlockLatest() (*types.SignedBlock, error) {
 	args := ms.Called()
 	md, _ := args.Get(0).(*types.SignedBlock)
@@ -81,6 +93,11 @@ func (ms *MockSubstrateAPI) GetStorageLatest(key types.StorageKey, target interf
 	return args.Error(0)
 }
 
+func (m *MockAPI) Call(result interface{}, method string, args ...interface{}) error {
+	margs := m.Called(result, method, args)
+	return margs.Error(0)
+}
+
 func (m *MockAPI) GetMetadataLatest() (*types.Metadata, error) {
 	args := m.Called()
 	md, _ := args.Get(0).(*types.Metadata)
@@ -101,6 +118,18 @@ func (m *MockAPI) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c ty
 	return args.Error(0)
 }
 
+func (m *MockAPI) GetBlockInfo(number uint32) (BlockInfo, error) {
+	args := m.Called(number)
+	info, _ := args.Get(0).(BlockInfo)
+	return info, args.Error(1)
+}
+
+func (m *MockAPI) QueryFeeInfo(meta *types.Metadata, c types.Call, accountID []byte) (FeeInfo, error) {
+	args := m.Called(meta, c, accountID)
+	info, _ := args.Get(0).(FeeInfo)
+	return info, args.Error(1)
+}
+
 func MetaDataWithCall(call string) *types.Metadata {
 	data := strings.Split(call, ".")
 	meta := types.NewMetadataV8()
@@ -0,0 +1,347 @@
+package centchain
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	nonceKeyPrefix = "centchain_nonce_"
+
+	// nonceDropTimeout is the time after which an extrinsic neither included in a block nor in the
+	// transaction pool is considered dropped.
+	nonceDropTimeout = 2 * time.Minute
+
+	// tipBump is the tip added to an extrinsic replacing another extrinsic with the same nonce.
+	tipBump uint64 = 1e9
+)
+
+// inflight is an extrinsic submitted to the transaction pool and not yet included in a block.
+type inflight struct {
+	TxHash      types.Hash `json:"tx_hash"`
+	Tip         uint64     `json:"tip"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+}
+
+// accountNonces is the nonce state of an account.
+// The values of Reserved and Gaps are the tips to submit the extrinsic with.
+type accountNonces struct {
+	// Next is the nonce after the highest nonce handed out.
+	Next uint32 `json:"next"`
+
+	// Reserved are the nonces handed out, and not yet submitted.
+	Reserved map[uint32]uint64 `json:"reserved"`
+
+	// Inflight are the extrinsics submitted with the nonces.
+	Inflight map[uint32]inflight `json:"inflight"`
+
+	// Gaps are the nonces below Next to be handed out again, since their extrinsic was never submitted or dropped.
+	Gaps map[uint32]uint64 `json:"gaps"`
+
+	// Resync is set when the nonces may be behind the chain, like after a stale nonce.
+	Resync bool `json:"resync,omitempty"`
+}
+
+func newAccountNonces(next uint32) *accountNonces {
+	return &accountNonces{
+		Next:     next,
+		Reserved: make(map[uint32]uint64),
+		Inflight: make(map[uint32]inflight),
+		Gaps:     make(map[uint32]uint64),
+	}
+}
+
+// JSON marshals accountNonces to json bytes.
+func (a *accountNonces) JSON() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// FromJSON loads json bytes to accountNonces.
+func (a *accountNonces) FromJSON(data []byte) error {
+	return json.Unmarshal(data, a)
+}
+
+// Type returns the type of accountNonces.
+func (a *accountNonces) Type() reflect.Type {
+	return reflect.TypeOf(a)
+}
+
+// nonceManager hands out the nonces of the extrinsics of the accounts, and tracks the submitted extrinsics until
+// they are included in a block or dropped. Nonces of dropped extrinsics are handed out again before new ones.
+// The state of the accounts is persisted, so the nonces in flight are kept across restarts.
+// The state of each account is guarded by the lock of the account.
+type nonceManager struct {
+	sapi substrateAPI
+	repo storage.Repository
+
+	// mu guards the maps.
+	mu       sync.Mutex
+	locks    map[string]*sync.Mutex
+	accounts map[string]*accountNonces
+}
+
+func newNonceManager(sapi substrateAPI, repo storage.Repository) *nonceManager {
+	repo.Register(newAccountNonces(0))
+	return &nonceManager{
+		sapi:     sapi,
+		repo:     repo,
+		locks:    make(map[string]*sync.Mutex),
+		accounts: make(map[string]*accountNonces),
+	}
+}
+
+// lock locks the state of the account, and returns the func unlocking it.
+func (m *nonceManager) lock(accountID []byte) (unlock func()) {
+	id := hexutil.Encode(accountID)
+	m.mu.Lock()
+	l, ok := m.locks[id]
+	if !ok {
+		l = new(sync.Mutex)
+		m.locks[id] = l
+	}
+	m.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// reserve hands out the nonce and the tip for the next extrinsic of the account.
+// Gaps left by dropped extrinsics are filled first.
+// The chain is only read when the state of the account may be behind it, like with the extrinsics in flight.
+func (m *nonceManager) reserve(meta *types.Metadata, accountID []byte) (nonce uint32, tip uint64, err error) {
+	defer m.lock(accountID)()
+	acc, err := m.load(accountID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if acc == nil || acc.Resync || len(acc.Inflight) > 0 {
+		chainNonce, err := m.getNonceFromChain(meta, accountID)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if acc == nil {
+			acc = newAccountNonces(chainNonce)
+			m.mu.Lock()
+			m.accounts[hexutil.Encode(accountID)] = acc
+			m.mu.Unlock()
+		}
+
+		m.sync(acc, chainNonce)
+	}
+
+	gaps := make([]uint32, 0, len(acc.Gaps))
+	for n := range acc.Gaps {
+		gaps = append(gaps, n)
+	}
+
+	if len(gaps) > 0 {
+		sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+		nonce = gaps[0]
+		tip = acc.Gaps[nonce]
+		delete(acc.Gaps, nonce)
+	} else {
+		nonce = acc.Next
+		acc.Next++
+	}
+
+	acc.Reserved[nonce] = tip
+	return nonce, tip, m.save(accountID, acc)
+}
+
+// submitted marks the reserved nonce as in flight with the extrinsic.
+func (m *nonceManager) submitted(accountID []byte, nonce uint32, txHash types.Hash) error {
+	return m.update(accountID, func(acc *accountNonces) {
+		acc.Inflight[nonce] = inflight{TxHash: txHash, Tip: acc.Reserved[nonce], SubmittedAt: time.Now().UTC()}
+		delete(acc.Reserved, nonce)
+	})
+}
+
+// release hands out the reserved nonce again, since no extrinsic was submitted with it.
+func (m *nonceManager) release(accountID []byte, nonce uint32) error {
+	return m.update(accountID, func(acc *accountNonces) {
+		acc.Gaps[nonce] = acc.Reserved[nonce]
+		delete(acc.Reserved, nonce)
+	})
+}
+
+// conflict hands out the reserved nonce again with a bumped tip, since an extrinsic with the same nonce
+// is in the transaction pool.
+func (m *nonceManager) conflict(accountID []byte, nonce uint32) error {
+	return m.update(accountID, func(acc *accountNonces) {
+		acc.Gaps[nonce] = acc.Reserved[nonce] + tipBump
+		delete(acc.Reserved, nonce)
+	})
+}
+
+// stale drops the reserved nonce, since it was already used on chain.
+// The nonces are synced with the chain on the next reservation.
+func (m *nonceManager) stale(accountID []byte, nonce uint32) error {
+	return m.update(accountID, func(acc *accountNonces) {
+		delete(acc.Reserved, nonce)
+		acc.Resync = true
+	})
+}
+
+func (m *nonceManager) update(accountID []byte, f func(acc *accountNonces)) error {
+	defer m.lock(accountID)()
+	acc, err := m.load(accountID)
+	if err != nil {
+		return err
+	}
+
+	if acc == nil {
+		return nil
+	}
+
+	f(acc)
+	return m.save(accountID, acc)
+}
+
+// sync drops the nonces used on chain, and moves the dropped extrinsics to the gaps with a bumped tip.
+// The transaction pool is only read when an extrinsic is in flight for longer than nonceDropTimeout.
+// Caller must hold the lock of the account.
+func (m *nonceManager) sync(acc *accountNonces, chainNonce uint32) {
+	acc.Resync = false
+	if acc.Next < chainNonce {
+		acc.Next = chainNonce
+	}
+
+	for n := range acc.Gaps {
+		if n < chainNonce {
+			delete(acc.Gaps, n)
+		}
+	}
+
+	var pool map[types.Hash]bool
+	for n, ext := range acc.Inflight {
+		if n < chainNonce {
+			delete(acc.Inflight, n)
+			continue
+		}
+
+		if time.Since(ext.SubmittedAt) < nonceDropTimeout {
+			continue
+		}
+
+		if pool == nil {
+			var err error
+			pool, err = m.pendingExtrinsics()
+			if err != nil {
+				log.Warnf("failed to get pending extrinsics: %v", err)
+				return
+			}
+		}
+
+		if pool[ext.TxHash] {
+			continue
+		}
+
+		log.Warnf("extrinsic %s with nonce %d was dropped, resubmitting with a higher tip", ext.TxHash.Hex(), n)
+		delete(acc.Inflight, n)
+		acc.Gaps[n] = ext.Tip + tipBump
+	}
+}
+
+// pendingExtrinsics returns the hashes of the extrinsics in the transaction pool.
+func (m *nonceManager) pendingExtrinsics() (map[types.Hash]bool, error) {
+	var exts []types.Extrinsic
+	err := m.sapi.Call(&exts, "author_pendingExtrinsics")
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[types.Hash]bool)
+	for _, ext := range exts {
+		b, err := types.EncodeToBytes(ext)
+		if err != nil {
+			return nil, err
+		}
+
+		h, err := crypto.Blake2bHash(b)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes[types.NewHash(h)] = true
+	}
+
+	return hashes, nil
+}
+
+// load returns the state of the account, or nil if the account has no state yet.
+// Nonces reserved before a restart are handed out again once synced with the chain, since they may have been
+// submitted. Caller must hold the lock of the account.
+func (m *nonceManager) load(accountID []byte) (*accountNonces, error) {
+	id := hexutil.Encode(accountID)
+	m.mu.Lock()
+	acc, ok := m.accounts[id]
+	m.mu.Unlock()
+	if ok {
+		return acc, nil
+	}
+
+	key := []byte(nonceKeyPrefix + id)
+	if !m.repo.Exists(key) {
+		return nil, nil
+	}
+
+	model, err := m.repo.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	acc = model.(*accountNonces)
+	loaded := newAccountNonces(acc.Next)
+	loaded.Resync = acc.Resync || len(acc.Reserved) > 0
+	for n, ext := range acc.Inflight {
+		loaded.Inflight[n] = ext
+	}
+
+	for n, tip := range acc.Gaps {
+		loaded.Gaps[n] = tip
+	}
+
+	for n, tip := range acc.Reserved {
+		loaded.Gaps[n] = tip
+	}
+
+	m.mu.Lock()
+	m.accounts[id] = loaded
+	m.mu.Unlock()
+	return loaded, nil
+}
+
+// save persists the state of the account. Caller must hold the lock of the account.
+func (m *nonceManager) save(accountID []byte, acc *accountNonces) error {
+	key := []byte(nonceKeyPrefix + hexutil.Encode(accountID))
+	if m.repo.Exists(key) {
+		return m.repo.Update(key, acc)
+	}
+
+	return m.repo.Create(key, acc)
+}
+
+func (m *nonceManager) getNonceFromChain(meta *types.Metadata, accountID []byte) (uint32, error) {
+	key, err := types.CreateStorageKey(meta, "System", "Account", accountID, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var accountInfo types.AccountInfo
+	err = m.sapi.GetStorageLatest(key, &accountInfo)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(accountInfo.Nonce), nil
+}
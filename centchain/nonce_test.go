@@ -0,0 +1,216 @@
+// +build unit
+
+package centchain
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockChainNonce(sapi *MockSubstrateAPI, nonce uint32) *mock.Call {
+	return sapi.On("GetStorageLatest", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*types.AccountInfo).Nonce = types.U32(nonce)
+	}).Return(nil)
+}
+
+func TestNonceManager_Reserve(t *testing.T) {
+	meta := MetaDataWithCall(anchorCommit)
+	sapi := new(MockSubstrateAPI)
+	m := newNonceManager(sapi, newTestRepo(t))
+	accountID := utils.RandomSlice(32)
+
+	// failed to get nonce from chain
+	sapi.On("GetStorageLatest", mock.Anything, mock.Anything).Return(errors.New("failed to get nonce")).Once()
+	_, _, err := m.reserve(meta, accountID)
+	assert.Error(t, err)
+
+	// concurrent reservations get distinct nonces, the chain is read once since nothing is in flight
+	mockChainNonce(sapi, 5).Once()
+	var mu sync.Mutex
+	nonces := make(map[uint32]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, tip, err := m.reserve(meta, accountID)
+			assert.NoError(t, err)
+			assert.Zero(t, tip)
+			mu.Lock()
+			nonces[n] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, nonces, 10)
+	for n := uint32(5); n < 15; n++ {
+		assert.True(t, nonces[n])
+		assert.NoError(t, m.submitted(accountID, n, types.Hash(utils.RandomByte32())))
+	}
+
+	// gaps are filled before new nonces
+	mockChainNonce(sapi, 5).Times(3)
+	n, _, err := m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(15), n)
+	assert.NoError(t, m.release(accountID, n))
+	n, tip, err := m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(15), n)
+	assert.Zero(t, tip)
+
+	// conflicting nonce is handed out again with a higher tip
+	assert.NoError(t, m.conflict(accountID, n))
+	n, tip, err = m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(15), n)
+	assert.Equal(t, tipBump, tip)
+	assert.NoError(t, m.submitted(accountID, n, types.Hash(utils.RandomByte32())))
+	assert.Equal(t, tipBump, m.accounts[hexutil.Encode(accountID)].Inflight[15].Tip)
+
+	// included extrinsics are dropped
+	mockChainNonce(sapi, 10).Once()
+	n, _, err = m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(16), n)
+	acc := m.accounts[hexutil.Encode(accountID)]
+	assert.Len(t, acc.Inflight, 6)
+	assert.NotContains(t, acc.Inflight, uint32(9))
+
+	// stale nonces are forgotten
+	assert.NoError(t, m.stale(accountID, n))
+	assert.Empty(t, acc.Reserved)
+	assert.Empty(t, acc.Gaps)
+	assert.True(t, acc.Resync)
+	sapi.AssertExpectations(t)
+}
+
+func TestNonceManager_Resync(t *testing.T) {
+	meta := MetaDataWithCall(anchorCommit)
+	sapi := new(MockSubstrateAPI)
+	m := newNonceManager(sapi, newTestRepo(t))
+	accountID := utils.RandomSlice(32)
+	mockChainNonce(sapi, 3).Once()
+	n, _, err := m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), n)
+
+	// nothing in flight, the chain isn't read
+	assert.NoError(t, m.release(accountID, n))
+	n, _, err = m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), n)
+
+	// nonce used on chain by another extrinsic of the account
+	assert.NoError(t, m.stale(accountID, n))
+	mockChainNonce(sapi, 7).Once()
+	n, _, err = m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(7), n)
+	acc := m.accounts[hexutil.Encode(accountID)]
+	assert.False(t, acc.Resync)
+
+	// nonces reserved before a restart are synced with the chain
+	m = newNonceManager(sapi, m.repo)
+	mockChainNonce(sapi, 8).Once()
+	n, _, err = m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(8), n)
+	sapi.AssertExpectations(t)
+}
+
+func TestNonceManager_Lock(t *testing.T) {
+	m := newNonceManager(new(MockSubstrateAPI), newTestRepo(t))
+	accountID := utils.RandomSlice(32)
+	unlock := m.lock(accountID)
+
+	// other accounts aren't blocked
+	done := make(chan struct{})
+	go func() {
+		m.lock(utils.RandomSlice(32))()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("account blocked by the lock of another account")
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		m.lock(accountID)()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("account lock acquired twice")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	unlock()
+	<-locked
+}
+
+func TestNonceManager_Dropped(t *testing.T) {
+	meta := MetaDataWithCall(anchorCommit)
+	sapi := new(MockSubstrateAPI)
+	m := newNonceManager(sapi, newTestRepo(t))
+	accountID := utils.RandomSlice(32)
+	mockChainNonce(sapi, 0).Times(2)
+	for i := 0; i < 2; i++ {
+		n, _, err := m.reserve(meta, accountID)
+		assert.NoError(t, err)
+		assert.NoError(t, m.submitted(accountID, n, types.Hash(utils.RandomByte32())))
+	}
+
+	// extrinsics submitted long ago, the first one is still in the pool
+	acc := m.accounts[hexutil.Encode(accountID)]
+	pending := types.NewExtrinsic(types.Call{})
+	b, err := types.EncodeToBytes(pending)
+	assert.NoError(t, err)
+	h, err := crypto.Blake2bHash(b)
+	assert.NoError(t, err)
+	acc.Inflight[0] = inflight{TxHash: types.NewHash(h), SubmittedAt: time.Now().Add(-nonceDropTimeout)}
+	acc.Inflight[1] = inflight{TxHash: acc.Inflight[1].TxHash, Tip: 10, SubmittedAt: time.Now().Add(-nonceDropTimeout)}
+
+	// failed to get the pool
+	mockChainNonce(sapi, 0).Times(3)
+	sapi.On("Call", mock.Anything, "author_pendingExtrinsics", mock.Anything).Return(errors.New("pool unavailable")).Once()
+	n, _, err := m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), n)
+	assert.NoError(t, m.release(accountID, n))
+
+	// dropped extrinsic is resubmitted with a higher tip
+	sapi.On("Call", mock.Anything, "author_pendingExtrinsics", mock.Anything).Run(func(args mock.Arguments) {
+		*(args.Get(0).(*[]types.Extrinsic)) = []types.Extrinsic{pending}
+	}).Return(nil).Twice()
+	n, tip, err := m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), n)
+	assert.Equal(t, 10+tipBump, tip)
+	assert.Contains(t, acc.Inflight, uint32(0))
+
+	// reserved nonces are handed out again after a restart
+	m = newNonceManager(sapi, m.repo)
+	n, tip, err = m.reserve(meta, accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), n)
+	assert.Equal(t, 10+tipBump, tip)
+	acc = m.accounts[hexutil.Encode(accountID)]
+	assert.Equal(t, uint32(3), acc.Next)
+	assert.Contains(t, acc.Inflight, uint32(0))
+	assert.Contains(t, acc.Gaps, uint32(2))
+	sapi.AssertExpectations(t)
+}
@@ -0,0 +1,1024 @@
+package centchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-substrate-rpc-client/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// anchorPreCommit is centrifuge chain module function name for pre-commit call.
+	anchorPreCommit = "Anchor.pre_commit"
+
+	// anchorGetByID is centrifuge chain RPC returning the anchor with the given ID.
+	anchorGetByID = "anchor_getAnchorById"
+
+	// simPreCommitExpiry is the number of blocks a pre-commit is valid for, as on centrifuge chain.
+	simPreCommitExpiry = 800
+
+	// simWeight is the weight of every extrinsic on the simulated chain.
+	simWeight = 100000000
+
+	// simBaseFee and simByteFee make up the fee of an extrinsic on the simulated chain,
+	// the base fee plus the byte fee for every byte of the extrinsic.
+	simBaseFee = 1000000000000
+	simByteFee = 1000000000
+
+	// simPollInterval is the interval extrinsics waiting on a nonce gap are checked at when blocks are sealed
+	// on submission.
+	simPollInterval = 100 * time.Millisecond
+)
+
+// event IDs of the simulated chain, the index of the module among the modules with events and the index of the event.
+var (
+	simExtrinsicSuccess = types.EventID{0, 0}
+	simExtrinsicFailed  = types.EventID{0, 1}
+	simBatchInterrupted = types.EventID{1, 0}
+	simBatchCompleted   = types.EventID{1, 1}
+)
+
+// simError is a dispatch error of the simulated chain, reported as an error of the module of the call.
+type simError uint8
+
+// Dispatch errors of the simulated chain.
+const (
+	simErrUnsupportedCall simError = iota
+	simErrInvalidArgs
+	simErrInjected
+	simErrAnchorExists
+	simErrPreCommitExists
+	simErrPreCommitOwner
+	simErrInvalidProof
+	simErrStoredUntil
+)
+
+var simErrors = map[simError]string{
+	simErrUnsupportedCall: "call not supported by the simulator",
+	simErrInvalidArgs:     "invalid call args",
+	simErrInjected:        "injected failure",
+	simErrAnchorExists:    "anchor already exists",
+	simErrPreCommitExists: "a valid pre-commit already exists",
+	simErrPreCommitOwner:  "pre-commit owned by another account",
+	simErrInvalidProof:    "pre-commit proof doesn't match the document root",
+	simErrStoredUntil:     "stored until date is in the past",
+}
+
+func (e simError) Error() string {
+	return simErrors[e]
+}
+
+// Failure is a failure injected in the simulated chain.
+type Failure int
+
+// Failures injected in the simulated chain.
+const (
+	// FailureSubmit rejects the submission of the extrinsic.
+	FailureSubmit Failure = iota
+
+	// FailureStale rejects the submission of the extrinsic as if its nonce was used already.
+	FailureStale
+
+	// FailureDrop accepts the extrinsic but drops it from the pool before it is included in a block.
+	FailureDrop
+
+	// FailureDispatch includes the extrinsic in a block but fails its dispatch.
+	FailureDispatch
+)
+
+type injectedFailure struct {
+	call    string
+	failure Failure
+}
+
+// SimulatorConfig holds the parameters of the simulated chain.
+type SimulatorConfig struct {
+	// BlockTime is the time between two blocks. Extrinsics are sealed in a block of their own as soon as they are
+	// submitted if zero.
+	BlockTime time.Duration
+
+	// FailureRate is the rate of the extrinsics failed on dispatch, between 0 and 1.
+	FailureRate float64
+}
+
+type simBlock struct {
+	hash      types.Hash
+	block     types.SignedBlock
+	events    []byte
+	timestamp time.Time
+}
+
+type simExtrinsic struct {
+	ext          types.Extrinsic
+	hash         types.Hash
+	signer       string
+	nonce        uint32
+	tip          uint64
+	failDispatch bool
+}
+
+type simAnchor struct {
+	documentRoot types.Hash
+	block        uint32
+}
+
+type simPreCommit struct {
+	signingRoot types.Hash
+	owner       string
+	expiresAt   uint32
+}
+
+// preCommitArgs are the args of the Anchor.pre_commit call.
+type preCommitArgs struct {
+	AnchorID    types.Hash
+	SigningRoot types.Hash
+}
+
+// simAnchorData is the anchor returned by anchor_getAnchorById.
+type simAnchorData struct {
+	AnchorID     types.Hash `json:"id"`
+	DocumentRoot types.Hash `json:"doc_root"`
+	BlockNumber  uint32     `json:"anchored_block"`
+}
+
+// Simulator is an in-process centrifuge chain implementing API, to run a node or tests without a chain.
+// It supports the pre-commits and the commits of anchors, Utility.batch of anchor calls, and the RPCs used by
+// the node. Extrinsics are not verified against their signature, every block is final, and the state is lost
+// when the process stops.
+type Simulator struct {
+	config      SimulatorConfig
+	meta        *types.Metadata
+	calls       map[types.CallIndex]string
+	eventsKey   types.StorageKey
+	accountKeys map[string]string
+
+	mu         sync.Mutex
+	blocks     []*simBlock
+	byHash     map[types.Hash]*simBlock
+	pool       []*simExtrinsic
+	results    map[types.Hash]error
+	nonces     map[string]uint32
+	anchors    map[types.Hash]simAnchor
+	preCommits map[types.Hash]simPreCommit
+	failures   []injectedFailure
+}
+
+// NewSimulator returns a simulated chain with its genesis block sealed.
+func NewSimulator(config SimulatorConfig) (*Simulator, error) {
+	s := &Simulator{
+		config:      config,
+		meta:        simMetadata(),
+		calls:       make(map[types.CallIndex]string),
+		accountKeys: make(map[string]string),
+		byHash:      make(map[types.Hash]*simBlock),
+		results:     make(map[types.Hash]error),
+		nonces:      make(map[string]uint32),
+		anchors:     make(map[types.Hash]simAnchor),
+		preCommits:  make(map[types.Hash]simPreCommit),
+	}
+
+	for _, call := range []string{timestampSet, anchorPreCommit, anchorCommit, utilityBatch} {
+		idx, err := s.meta.FindCallIndex(call)
+		if err != nil {
+			return nil, err
+		}
+
+		s.calls[idx] = call
+	}
+
+	var err error
+	s.eventsKey, err = types.CreateStorageKey(s.meta, "System", "Events", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, s.seal(time.Now())
+}
+
+// simMetadata returns the metadata of the simulated chain.
+func simMetadata() *types.Metadata {
+	meta := types.NewMetadataV8()
+	meta.AsMetadataV8.Modules = []types.ModuleMetadataV8{
+		{
+			Name:       "System",
+			HasStorage: true,
+			Storage: types.StorageMetadata{
+				Prefix: "System",
+				Items: []types.StorageFunctionMetadataV5{
+					{
+						Name: "Account",
+						Type: types.StorageFunctionTypeV5{
+							IsMap: true,
+							AsMap: types.MapTypeV4{
+								Hasher: types.StorageHasher{IsBlake2_256: true},
+								Key:    "T::AccountId",
+								Value:  "AccountInfo",
+							},
+						},
+					},
+					{
+						Name: "Events",
+						Type: types.StorageFunctionTypeV5{IsType: true, AsType: "Vec<EventRecord>"},
+					},
+				},
+			},
+			HasEvents: true,
+			Events:    []types.EventMetadataV4{{Name: "ExtrinsicSuccess"}, {Name: "ExtrinsicFailed"}},
+		},
+		{
+			Name:     "Timestamp",
+			HasCalls: true,
+			Calls:    []types.FunctionMetadataV4{{Name: "set"}},
+		},
+		{
+			Name:     "Anchor",
+			HasCalls: true,
+			Calls:    []types.FunctionMetadataV4{{Name: "pre_commit"}, {Name: "commit"}},
+		},
+		{
+			Name:      "Utility",
+			HasCalls:  true,
+			Calls:     []types.FunctionMetadataV4{{Name: "batch"}},
+			HasEvents: true,
+			Events:    []types.EventMetadataV4{{Name: "BatchInterrupted"}, {Name: "BatchCompleted"}},
+		},
+	}
+	return meta
+}
+
+// InjectFailure makes the next extrinsic of the call fail, or the next extrinsic if call is empty.
+// Failures are applied in the order they were injected.
+func (s *Simulator) InjectFailure(call string, failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, injectedFailure{call: call, failure: failure})
+}
+
+// ProduceBlocks seals n blocks right away, with the ready extrinsics of the pool in the first one.
+func (s *Simulator) ProduceBlocks(n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		err := s.seal(s.nextTimestamp())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// head returns the latest block. Caller must hold the lock.
+func (s *Simulator) head() *simBlock {
+	return s.blocks[len(s.blocks)-1]
+}
+
+// nextTimestamp returns the timestamp of the next block sealed out of schedule. Caller must hold the lock.
+func (s *Simulator) nextTimestamp() time.Time {
+	ts := s.head().timestamp.Add(time.Millisecond)
+	if s.config.BlockTime > 0 {
+		return s.head().timestamp.Add(s.config.BlockTime)
+	}
+
+	if now := time.Now(); now.After(ts) {
+		return now
+	}
+
+	return ts
+}
+
+// advance seals the blocks due since the latest block. Caller must hold the lock.
+func (s *Simulator) advance() error {
+	if s.config.BlockTime <= 0 {
+		return nil
+	}
+
+	for next := s.head().timestamp.Add(s.config.BlockTime); !time.Now().Before(next); next = next.Add(s.config.BlockTime) {
+		err := s.seal(next)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seal dispatches the ready extrinsics of the pool in a new block with the timestamp. Extrinsics of an account
+// are ready once the extrinsics with the lower nonces are dispatched. Caller must hold the lock.
+func (s *Simulator) seal(ts time.Time) error {
+	ts = ts.Truncate(time.Millisecond).UTC()
+	var number uint32
+	var parent types.Hash
+	if len(s.blocks) > 0 {
+		number = uint32(s.head().block.Block.Header.Number) + 1
+		parent = s.head().hash
+	}
+
+	set, err := types.NewCall(s.meta, timestampSet, types.NewUCompactFromUInt(uint64(ts.UnixNano()/int64(time.Millisecond))))
+	if err != nil {
+		return err
+	}
+
+	exts := []types.Extrinsic{types.NewExtrinsic(set)}
+	events := new(simEvents)
+	events.add(0, simExtrinsicSuccess, simDispatchInfo())
+	sort.SliceStable(s.pool, func(i, j int) bool {
+		return s.pool[i].nonce < s.pool[j].nonce
+	})
+
+	var pool []*simExtrinsic
+	for _, x := range s.pool {
+		next := s.nonces[x.signer]
+		if x.nonce > next {
+			pool = append(pool, x)
+			continue
+		}
+
+		// stale extrinsics are dropped
+		if x.nonce < next {
+			continue
+		}
+
+		idx := uint32(len(exts))
+		exts = append(exts, x.ext)
+		s.nonces[x.signer]++
+		err := s.dispatch(x, idx, number, ts, events)
+		if err != nil {
+			events.add(idx, simExtrinsicFailed, simDispatchError(x.ext.Method.CallIndex, err), simDispatchInfo())
+		} else {
+			events.add(idx, simExtrinsicSuccess, simDispatchInfo())
+		}
+
+		s.results[x.hash] = err
+	}
+
+	s.pool = pool
+	er, err := events.bytes()
+	if err != nil {
+		return err
+	}
+
+	extsRoot, err := encodedHash(exts)
+	if err != nil {
+		return err
+	}
+
+	b := &simBlock{events: er, timestamp: ts}
+	b.block.Block.Header = types.Header{
+		ParentHash:     parent,
+		Number:         types.BlockNumber(number),
+		ExtrinsicsRoot: extsRoot,
+	}
+	b.block.Block.Extrinsics = exts
+	b.hash, err = encodedHash(b.block.Block.Header)
+	if err != nil {
+		return err
+	}
+
+	s.blocks = append(s.blocks, b)
+	s.byHash[b.hash] = b
+	return nil
+}
+
+// dispatch applies the call of the extrinsic, and records the events of a batch. Caller must hold the lock.
+func (s *Simulator) dispatch(x *simExtrinsic, idx, number uint32, ts time.Time, events *simEvents) error {
+	if x.failDispatch {
+		return simErrInjected
+	}
+
+	c := x.ext.Method
+	if s.calls[c.CallIndex] != utilityBatch {
+		return s.apply(x.signer, c.CallIndex, scale.NewDecoder(bytes.NewReader(c.Args)), number, ts)
+	}
+
+	// calls of a batch are dispatched until the first failing call, the calls before it aren't reverted
+	dec := scale.NewDecoder(bytes.NewReader(c.Args))
+	n, err := dec.DecodeUintCompact()
+	if err != nil {
+		return simErrInvalidArgs
+	}
+
+	for i := 0; i < int(n.Int64()); i++ {
+		var ci types.CallIndex
+		err = dec.Decode(&ci)
+		if err == nil {
+			err = s.apply(x.signer, ci, dec, number, ts)
+		}
+
+		if err != nil {
+			events.add(idx, simBatchInterrupted, types.U32(i), simDispatchError(ci, err))
+			return nil
+		}
+	}
+
+	events.add(idx, simBatchCompleted)
+	return nil
+}
+
+// apply decodes the args of the anchor call and applies it. Caller must hold the lock.
+func (s *Simulator) apply(signer string, ci types.CallIndex, dec *scale.Decoder, number uint32, ts time.Time) error {
+	switch s.calls[ci] {
+	case anchorPreCommit:
+		var args preCommitArgs
+		if err := dec.Decode(&args); err != nil {
+			return simErrInvalidArgs
+		}
+
+		return s.preCommit(signer, args, number)
+	case anchorCommit:
+		var args commitArgs
+		if err := dec.Decode(&args); err != nil {
+			return simErrInvalidArgs
+		}
+
+		return s.commit(signer, args, number, ts)
+	default:
+		return simErrUnsupportedCall
+	}
+}
+
+// preCommit locks the anchor ID to the signer until the pre-commit expires. Caller must hold the lock.
+func (s *Simulator) preCommit(signer string, args preCommitArgs, number uint32) error {
+	if _, ok := s.anchors[args.AnchorID]; ok {
+		return simErrAnchorExists
+	}
+
+	if pc, ok := s.preCommits[args.AnchorID]; ok && pc.expiresAt >= number {
+		return simErrPreCommitExists
+	}
+
+	s.preCommits[args.AnchorID] = simPreCommit{
+		signingRoot: args.SigningRoot,
+		owner:       signer,
+		expiresAt:   number + simPreCommitExpiry,
+	}
+	return nil
+}
+
+// commit stores the anchor. If the anchor ID is pre-committed, the anchor must be committed by the owner of the
+// pre-commit, and the document root must be the hash of the signing root and the proof. Caller must hold the lock.
+func (s *Simulator) commit(signer string, args commitArgs, number uint32, ts time.Time) error {
+	h, err := crypto.Blake2bHash(args.AnchorIDPreimage[:])
+	if err != nil {
+		return simErrInvalidArgs
+	}
+
+	id := types.NewHash(h)
+	if _, ok := s.anchors[id]; ok {
+		return simErrAnchorExists
+	}
+
+	if int64(args.StoredUntil) <= ts.UnixNano()/int64(time.Millisecond) {
+		return simErrStoredUntil
+	}
+
+	if pc, ok := s.preCommits[id]; ok && pc.expiresAt >= number {
+		if pc.owner != signer {
+			return simErrPreCommitOwner
+		}
+
+		root, err := crypto.Blake2bHash(append(pc.signingRoot[:], args.Proof[:]...))
+		if err != nil || types.NewHash(root) != args.DocumentRoot {
+			return simErrInvalidProof
+		}
+	}
+
+	delete(s.preCommits, id)
+	s.anchors[id] = simAnchor{documentRoot: args.DocumentRoot, block: number}
+	return nil
+}
+
+// takeFailure returns the first failure injected for the call. Caller must hold the lock.
+func (s *Simulator) takeFailure(call string) (Failure, bool) {
+	for i, f := range s.failures {
+		if f.call == "" || f.call == call {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			return f.failure, true
+		}
+	}
+
+	return 0, false
+}
+
+// submit validates the extrinsic and adds it to the pool. An extrinsic replaces the extrinsic of the pool with the
+// same nonce if its tip is higher. Caller must hold the lock.
+func (s *Simulator) submit(ext types.Extrinsic) (types.Hash, error) {
+	if !ext.IsSigned() || !ext.Signature.Signer.IsAccountID {
+		return types.Hash{}, errors.New("1010: %s: unsigned extrinsics are not supported", ErrInvalidTransaction)
+	}
+
+	hash, err := encodedHash(ext)
+	if err != nil {
+		return hash, err
+	}
+
+	x := &simExtrinsic{
+		ext:    ext,
+		hash:   hash,
+		signer: hexutil.Encode(ext.Signature.Signer.AsAccountID[:]),
+		nonce:  uint32((*big.Int)(&ext.Signature.Nonce).Uint64()),
+		tip:    (*big.Int)(&ext.Signature.Tip).Uint64(),
+	}
+
+	failure, injected := s.takeFailure(s.calls[ext.Method.CallIndex])
+	switch {
+	case injected && failure == FailureSubmit:
+		return hash, errors.New("simulated submission failure")
+	case injected && failure == FailureStale, x.nonce < s.nonces[x.signer]:
+		return hash, errors.New("1010: %s: Transaction is outdated", ErrInvalidTransaction)
+	}
+
+	for i, px := range s.pool {
+		if px.hash == hash {
+			return hash, errors.New("1013: Transaction Already Imported")
+		}
+
+		if px.signer != x.signer || px.nonce != x.nonce {
+			continue
+		}
+
+		if x.tip <= px.tip {
+			return hash, errors.New("1014: %s: (%d vs %d)", ErrNonceTooLow, px.tip, x.tip)
+		}
+
+		s.pool = append(s.pool[:i], s.pool[i+1:]...)
+		break
+	}
+
+	key, err := types.CreateStorageKey(s.meta, "System", "Account", ext.Signature.Signer.AsAccountID[:], nil)
+	if err != nil {
+		return hash, err
+	}
+
+	s.accountKeys[hexutil.Encode(key)] = x.signer
+
+	if injected && failure == FailureDrop {
+		return hash, nil
+	}
+
+	x.failDispatch = (injected && failure == FailureDispatch) || rand.Float64() < s.config.FailureRate
+	s.pool = append(s.pool, x)
+	if s.config.BlockTime > 0 {
+		return hash, nil
+	}
+
+	return hash, s.seal(s.nextTimestamp())
+}
+
+// nextNonce returns the nonce following the nonces of the extrinsics of the account in the pool.
+// Caller must hold the lock.
+func (s *Simulator) nextNonce(signer string) uint32 {
+	nonce := s.nonces[signer]
+	for _, x := range s.pool {
+		if x.signer == signer && x.nonce >= nonce {
+			nonce = x.nonce + 1
+		}
+	}
+
+	return nonce
+}
+
+// inPool returns true if the extrinsic is in the pool. Caller must hold the lock.
+func (s *Simulator) inPool(hash types.Hash) bool {
+	for _, x := range s.pool {
+		if x.hash == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Call serves the RPCs used by the node.
+func (s *Simulator) Call(result interface{}, method string, args ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.advance()
+	if err != nil {
+		return err
+	}
+
+	switch method {
+	case "author_submitExtrinsic":
+		var ext types.Extrinsic
+		err = decodeHexArg(args, &ext)
+		if err != nil {
+			return err
+		}
+
+		hash, err := s.submit(ext)
+		if err != nil {
+			return err
+		}
+
+		return respond(result, hash.Hex())
+	case "author_pendingExtrinsics":
+		exts := make([]types.Extrinsic, 0, len(s.pool))
+		for _, x := range s.pool {
+			exts = append(exts, x.ext)
+		}
+
+		return respond(result, exts)
+	case anchorGetByID:
+		if len(args) != 1 {
+			return errors.New("%s takes the anchor ID", method)
+		}
+
+		id, ok := args[0].(types.Hash)
+		if !ok {
+			return errors.New("invalid anchor ID %v", args[0])
+		}
+
+		a, ok := s.anchors[id]
+		if !ok {
+			return respond(result, nil)
+		}
+
+		return respond(result, simAnchorData{AnchorID: id, DocumentRoot: a.documentRoot, BlockNumber: a.block})
+	case "payment_queryInfo":
+		var ext types.Extrinsic
+		err = decodeHexArg(args, &ext)
+		if err != nil {
+			return err
+		}
+
+		info, err := simFeeInfo(ext)
+		if err != nil {
+			return err
+		}
+
+		return respond(result, runtimeDispatchInfo{
+			Weight:     info.Weight,
+			PartialFee: json.RawMessage(`"` + info.Fee.String() + `"`),
+		})
+	default:
+		return errors.New("method %s not supported by the simulator", method)
+	}
+}
+
+// GetMetadataLatest returns the metadata of the simulated chain.
+func (s *Simulator) GetMetadataLatest() (*types.Metadata, error) {
+	return s.meta, nil
+}
+
+// SubmitExtrinsic submits an extrinsic of the call with the next nonce of the account. The extrinsic isn't signed.
+func (s *Simulator) SubmitExtrinsic(ctx context.Context, meta *types.Metadata, c types.Call,
+	krp signature.KeyringPair) (txHash types.Hash, bn types.BlockNumber, sig types.MultiSignature, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = s.advance()
+	if err != nil {
+		return txHash, bn, sig, err
+	}
+
+	nonce := s.nextNonce(hexutil.Encode(krp.PublicKey))
+	ext := signedExtrinsic(c, krp.PublicKey, uint64(nonce), types.MultiSignature{})
+	h, err := encodedHash(ext)
+	if err != nil {
+		return txHash, bn, sig, err
+	}
+
+	// the signature only needs to be unique for the extrinsic to be found in its block
+	copy(ext.Signature.Signature.AsSr25519[:], h[:])
+	copy(ext.Signature.Signature.AsSr25519[32:], krp.PublicKey)
+	ext.Signature.Signature.IsSr25519 = true
+	bn = s.head().block.Block.Header.Number
+	txHash, err = s.submit(ext)
+	return txHash, bn, ext.Signature.Signature, err
+}
+
+// SubmitAndWatch submits an extrinsic of the call and waits until its block is sealed.
+// Returns an error if the extrinsic failed or was dropped from the pool.
+func (s *Simulator) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) error {
+	txHash, _, _, err := s.SubmitExtrinsic(ctx, meta, c, krp)
+	if err != nil {
+		return errors.NewTypedError(ErrExtrinsicNotSubmitted, err)
+	}
+
+	for {
+		s.mu.Lock()
+		err = s.advance()
+		res, included := s.results[txHash]
+		pending := s.inPool(txHash)
+		wait := simPollInterval
+		if s.config.BlockTime > 0 {
+			wait = time.Until(s.head().timestamp.Add(s.config.BlockTime))
+		}
+		s.mu.Unlock()
+
+		switch {
+		case err != nil:
+			return err
+		case included && res != nil:
+			return errors.New("extrinsic %s failed: %v", txHash.Hex(), res)
+		case included:
+			return nil
+		case !pending:
+			return errors.NewTypedError(ErrExtrinsicNotSubmitted,
+				errors.New("extrinsic %s was dropped from the pool", txHash.Hex()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// GetBlockInfo returns the hash and the timestamp of the block with the given number.
+func (s *Simulator) GetBlockInfo(number uint32) (BlockInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.block(uint64(number))
+	if err != nil {
+		return BlockInfo{}, err
+	}
+
+	return BlockInfo{Hash: b.hash, Number: number, Timestamp: b.timestamp}, nil
+}
+
+// QueryFeeInfo returns the weight and the fee of the call on the simulated chain.
+func (s *Simulator) QueryFeeInfo(meta *types.Metadata, c types.Call, accountID []byte) (FeeInfo, error) {
+	return simFeeInfo(signedExtrinsic(c, accountID, 0, types.MultiSignature{IsSr25519: true}))
+}
+
+// block returns the block with the given number. Caller must hold the lock.
+func (s *Simulator) block(number uint64) (*simBlock, error) {
+	err := s.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	if number >= uint64(len(s.blocks)) {
+		return nil, errors.New("block %d not found", number)
+	}
+
+	return s.blocks[number], nil
+}
+
+// blockByHash returns the block with the given hash. Caller must hold the lock.
+func (s *Simulator) blockByHash(hash types.Hash) (*simBlock, error) {
+	err := s.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	b, ok := s.byHash[hash]
+	if !ok {
+		return nil, errors.New("block %s not found", hash.Hex())
+	}
+
+	return b, nil
+}
+
+// GetBlockHash returns the hash of the block with the given number.
+func (s *Simulator) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.block(blockNumber)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return b.hash, nil
+}
+
+// GetBlockLatest returns the latest block.
+func (s *Simulator) GetBlockLatest() (*types.SignedBlock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.advance()
+	if err != nil {
+		return nil, err
+	}
+
+	b := s.head().block
+	return &b, nil
+}
+
+// GetBlock returns the block with the given hash.
+func (s *Simulator) GetBlock(blockHash types.Hash) (*types.SignedBlock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.blockByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	sb := b.block
+	return &sb, nil
+}
+
+// GetFinalizedHead returns the hash of the latest block, every block of the simulated chain is final.
+func (s *Simulator) GetFinalizedHead() (types.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.advance()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return s.head().hash, nil
+}
+
+// GetHeader returns the header of the block with the given hash.
+func (s *Simulator) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.blockByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	h := b.block.Block.Header
+	return &h, nil
+}
+
+// GetRuntimeVersionLatest returns the runtime version of the simulated chain.
+func (s *Simulator) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	return &types.RuntimeVersion{
+		ImplName:           "centrifuge-simulator",
+		SpecName:           "centrifuge-simulator",
+		SpecVersion:        1,
+		TransactionVersion: 1,
+	}, nil
+}
+
+// GetClient returns the simulator, only Call is supported.
+func (s *Simulator) GetClient() client.Client {
+	return s
+}
+
+// Subscribe isn't supported by the simulator.
+func (s *Simulator) Subscribe(ctx context.Context, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string, channel interface{}, args ...interface{}) (*gethrpc.ClientSubscription, error) {
+	return nil, errors.New("subscriptions are not supported by the simulator")
+}
+
+// URL returns the URL of the simulator.
+func (s *Simulator) URL() string {
+	return "simulator://"
+}
+
+// GetStorageLatest reads the storage at the latest block.
+func (s *Simulator) GetStorageLatest(key types.StorageKey, target interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.advance()
+	if err != nil {
+		return err
+	}
+
+	return s.storage(key, target, s.head())
+}
+
+// GetStorage reads the storage at the block with the given hash.
+func (s *Simulator) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.blockByHash(blockHash)
+	if err != nil {
+		return err
+	}
+
+	return s.storage(key, target, b)
+}
+
+// storage decodes the events of the block, or the latest info of an account, to target. Target is left untouched
+// for the other keys, as for missing storage on chain. Caller must hold the lock.
+func (s *Simulator) storage(key types.StorageKey, target interface{}, b *simBlock) error {
+	if bytes.Equal(key, s.eventsKey) {
+		return types.DecodeFromBytes(b.events, target)
+	}
+
+	signer, ok := s.accountKeys[hexutil.Encode(key)]
+	if !ok {
+		return nil
+	}
+
+	// balances aren't simulated
+	info := types.AccountInfo{Nonce: types.U32(s.nonces[signer])}
+	zero := types.NewU128(*big.NewInt(0))
+	info.Data.Free, info.Data.Reserved, info.Data.MiscFrozen, info.Data.FreeFrozen = zero, zero, zero, zero
+	enc, err := types.EncodeToBytes(info)
+	if err != nil {
+		return err
+	}
+
+	return types.DecodeFromBytes(enc, target)
+}
+
+// simEvents encodes the event records of a block.
+type simEvents struct {
+	buf   bytes.Buffer
+	count int64
+	err   error
+}
+
+// add encodes the event emitted by the extrinsic at idx, with the fields of the event.
+func (e *simEvents) add(idx uint32, id types.EventID, fields ...interface{}) {
+	enc := scale.NewEncoder(&e.buf)
+	vals := append([]interface{}{types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: idx}, id}, fields...)
+
+	// topics
+	vals = append(vals, []types.Hash{})
+	for _, v := range vals {
+		if e.err == nil {
+			e.err = enc.Encode(v)
+		}
+	}
+
+	e.count++
+}
+
+func (e *simEvents) bytes() ([]byte, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	var buf bytes.Buffer
+	err := scale.NewEncoder(&buf).EncodeUintCompact(*big.NewInt(e.count))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf.Bytes(), e.buf.Bytes()...), nil
+}
+
+func simDispatchInfo() types.DispatchInfo {
+	return types.DispatchInfo{Weight: simWeight, Class: types.DispatchClass{IsNormal: true}, PaysFee: true}
+}
+
+// simDispatchError returns the error of the module of the call.
+func simDispatchError(ci types.CallIndex, err error) types.DispatchError {
+	code := simErrUnsupportedCall
+	if se, ok := err.(simError); ok {
+		code = se
+	}
+
+	return types.DispatchError{HasModule: true, Module: ci.SectionIndex, Error: uint8(code)}
+}
+
+// simFeeInfo returns the weight and the fee of the extrinsic on the simulated chain.
+func simFeeInfo(ext types.Extrinsic) (FeeInfo, error) {
+	b, err := types.EncodeToBytes(ext)
+	if err != nil {
+		return FeeInfo{}, err
+	}
+
+	fee := new(big.Int).Mul(big.NewInt(simByteFee), big.NewInt(int64(len(b))))
+	return FeeInfo{Weight: simWeight, Fee: fee.Add(fee, big.NewInt(simBaseFee))}, nil
+}
+
+// encodedHash returns the blake2b hash of the encoded value.
+func encodedHash(v interface{}) (types.Hash, error) {
+	b, err := types.EncodeToBytes(v)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	h, err := crypto.Blake2bHash(b)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return types.NewHash(h), nil
+}
+
+// decodeHexArg decodes the hex encoded only arg to target.
+func decodeHexArg(args []interface{}, target interface{}) error {
+	if len(args) != 1 {
+		return errors.New("expected one arg, got %d", len(args))
+	}
+
+	h, ok := args[0].(string)
+	if !ok {
+		return errors.New("expected a hex encoded arg, got %v", args[0])
+	}
+
+	return types.DecodeFromHexString(h, target)
+}
+
+// respond sets the result as the JSON RPC client would.
+func respond(result interface{}, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, result)
+}
@@ -0,0 +1,200 @@
+// +build unit
+
+package centchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAnchor struct {
+	id, signingRoot, proof, docRoot types.Hash
+	preimage                        types.Hash
+}
+
+func newTestSimulator(t *testing.T, blockTime time.Duration) *Simulator {
+	s, err := NewSimulator(SimulatorConfig{BlockTime: blockTime})
+	assert.NoError(t, err)
+	return s
+}
+
+func randomKeyringPair() signature.KeyringPair {
+	return signature.KeyringPair{PublicKey: utils.RandomSlice(32)}
+}
+
+// randomAnchor returns an anchor whose document root is the hash of its signing root and proof.
+func randomAnchor(t *testing.T) testAnchor {
+	a := testAnchor{
+		preimage:    types.NewHash(utils.RandomSlice(32)),
+		signingRoot: types.NewHash(utils.RandomSlice(32)),
+		proof:       types.NewHash(utils.RandomSlice(32)),
+	}
+
+	id, err := crypto.Blake2bHash(a.preimage[:])
+	assert.NoError(t, err)
+	root, err := crypto.Blake2bHash(append(a.signingRoot[:], a.proof[:]...))
+	assert.NoError(t, err)
+	a.id, a.docRoot = types.NewHash(id), types.NewHash(root)
+	return a
+}
+
+func (a testAnchor) preCommit(t *testing.T, s *Simulator) types.Call {
+	c, err := types.NewCall(s.meta, anchorPreCommit, a.id, a.signingRoot)
+	assert.NoError(t, err)
+	return c
+}
+
+func (a testAnchor) commit(t *testing.T, s *Simulator) types.Call {
+	c, err := types.NewCall(s.meta, anchorCommit, a.preimage, a.docRoot, a.proof, types.NewMoment(time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+	return c
+}
+
+func getAnchor(t *testing.T, s *Simulator, id types.Hash) *simAnchorData {
+	var ad *simAnchorData
+	assert.NoError(t, s.GetClient().Call(&ad, anchorGetByID, id))
+	return ad
+}
+
+func TestSimulator_Anchor(t *testing.T) {
+	s := newTestSimulator(t, 0)
+	ctx := context.Background()
+	krp := randomKeyringPair()
+
+	// commit without a pre-commit
+	a := randomAnchor(t)
+	assert.Nil(t, getAnchor(t, s, a.id))
+	assert.NoError(t, s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), krp))
+	ad := getAnchor(t, s, a.id)
+	assert.NotNil(t, ad)
+	assert.Equal(t, a.docRoot, ad.DocumentRoot)
+	bi, err := s.GetBlockInfo(ad.BlockNumber)
+	assert.NoError(t, err)
+	assert.False(t, bi.Timestamp.IsZero())
+
+	// duplicate anchors are rejected
+	assert.Error(t, s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), krp))
+	assert.Error(t, s.SubmitAndWatch(ctx, s.meta, a.preCommit(t, s), krp))
+
+	// pre-committed anchor must be committed by its owner with a valid proof
+	a = randomAnchor(t)
+	assert.NoError(t, s.SubmitAndWatch(ctx, s.meta, a.preCommit(t, s), krp))
+	assert.Error(t, s.SubmitAndWatch(ctx, s.meta, a.preCommit(t, s), randomKeyringPair()))
+	assert.Error(t, s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), randomKeyringPair()))
+	invalid := a
+	invalid.proof = types.NewHash(utils.RandomSlice(32))
+	assert.Error(t, s.SubmitAndWatch(ctx, s.meta, invalid.commit(t, s), krp))
+	assert.Nil(t, getAnchor(t, s, a.id))
+	assert.NoError(t, s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), krp))
+	assert.NotNil(t, getAnchor(t, s, a.id))
+
+	// expired pre-commits can be replaced
+	a = randomAnchor(t)
+	assert.NoError(t, s.SubmitAndWatch(ctx, s.meta, a.preCommit(t, s), krp))
+	assert.NoError(t, s.ProduceBlocks(simPreCommitExpiry+1))
+	other := randomKeyringPair()
+	assert.NoError(t, s.SubmitAndWatch(ctx, s.meta, a.preCommit(t, s), other))
+	assert.NoError(t, s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), other))
+	assert.NotNil(t, getAnchor(t, s, a.id))
+}
+
+func TestSimulator_Batch(t *testing.T) {
+	s := newTestSimulator(t, 0)
+	krp := randomKeyringPair()
+	a1, a2 := randomAnchor(t), randomAnchor(t)
+	batch, err := types.NewCall(s.meta, utilityBatch, []types.Call{a1.commit(t, s), a1.commit(t, s), a2.commit(t, s)})
+	assert.NoError(t, err)
+
+	// batch is interrupted at the duplicate commit, the calls before it persist
+	assert.NoError(t, s.SubmitAndWatch(context.Background(), s.meta, batch, krp))
+	assert.NotNil(t, getAnchor(t, s, a1.id))
+	assert.Nil(t, getAnchor(t, s, a2.id))
+
+	hash, err := s.GetFinalizedHead()
+	assert.NoError(t, err)
+	var er types.EventRecordsRaw
+	assert.NoError(t, s.GetStorage(s.eventsKey, &er, hash))
+	events := new(Events)
+	assert.NoError(t, er.DecodeEventRecords(s.meta, events))
+	assert.Len(t, events.Utility_BatchInterrupted, 1)
+	assert.Equal(t, types.U32(1), events.Utility_BatchInterrupted[0].Index)
+	assert.Len(t, events.System_ExtrinsicSuccess, 2)
+}
+
+func TestSimulator_Failures(t *testing.T) {
+	s := newTestSimulator(t, 0)
+	ctx := context.Background()
+	krp := randomKeyringPair()
+
+	s.InjectFailure(anchorCommit, FailureDispatch)
+	s.InjectFailure("", FailureDrop)
+	s.InjectFailure(anchorPreCommit, FailureSubmit)
+	s.InjectFailure("", FailureStale)
+
+	// failures are matched against the call
+	a := randomAnchor(t)
+	err := s.SubmitAndWatch(ctx, s.meta, a.preCommit(t, s), krp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "dropped")
+	assert.Error(t, s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), krp))
+	assert.Nil(t, getAnchor(t, s, a.id))
+	assert.Error(t, s.SubmitAndWatch(ctx, s.meta, a.preCommit(t, s), krp))
+	err = s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), krp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidTransaction)
+
+	// no failures left
+	assert.NoError(t, s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), krp))
+	assert.NotNil(t, getAnchor(t, s, a.id))
+
+	// nonces of the failed extrinsics are used, the dropped one is reused
+	key, err := types.CreateStorageKey(s.meta, "System", "Account", krp.PublicKey, nil)
+	assert.NoError(t, err)
+	var info types.AccountInfo
+	assert.NoError(t, s.GetStorageLatest(key, &info))
+	assert.Equal(t, types.U32(2), info.Nonce)
+}
+
+func TestSimulator_BlockTime(t *testing.T) {
+	s := newTestSimulator(t, 50*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	a := randomAnchor(t)
+	assert.NoError(t, s.SubmitAndWatch(ctx, s.meta, a.commit(t, s), randomKeyringPair()))
+	ad := getAnchor(t, s, a.id)
+	assert.NotNil(t, ad)
+	assert.True(t, ad.BlockNumber > 0)
+
+	// blocks are sealed at the block time
+	b1, err := s.GetBlockInfo(ad.BlockNumber - 1)
+	assert.NoError(t, err)
+	b2, err := s.GetBlockInfo(ad.BlockNumber)
+	assert.NoError(t, err)
+	assert.Equal(t, 50*time.Millisecond, b2.Timestamp.Sub(b1.Timestamp))
+	time.Sleep(120 * time.Millisecond)
+	latest, err := s.GetBlockLatest()
+	assert.NoError(t, err)
+	assert.True(t, uint32(latest.Block.Header.Number) >= ad.BlockNumber+2)
+}
+
+func TestSimulator_Watcher(t *testing.T) {
+	s := newTestSimulator(t, 0)
+	w := NewWatcher(s, newTestRepo(t)).(*watcher)
+	assert.NoError(t, w.sync())
+	events, unsubscribe := w.Subscribe(EventTypeAnchorCommitted)
+	defer unsubscribe()
+
+	a := randomAnchor(t)
+	assert.NoError(t, s.SubmitAndWatch(context.Background(), s.meta, a.commit(t, s), randomKeyringPair()))
+	assert.NoError(t, w.sync())
+	assert.Len(t, events, 1)
+	e := <-events
+	assert.Equal(t, AnchorCommitted{AnchorID: a.id, DocumentRoot: a.docRoot}, *e.AnchorCommitted)
+}
@@ -0,0 +1,450 @@
+package centchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-substrate-rpc-client/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+)
+
+const (
+	// anchorCommit is centrifuge chain module function name for commit call.
+	anchorCommit = "Anchor.commit"
+
+	// utilityBatch is centrifuge chain module function name for dispatching a batch of calls.
+	utilityBatch = "Utility.batch"
+
+	watcherCursorKey = "centchain_watcher_cursor"
+
+	// watchInterval is the interval between the checks of the finalized head.
+	watchInterval = 6 * time.Second
+
+	// subscriberBuffer is the number of events buffered for a subscriber.
+	// Slow subscribers exceeding the buffer are disconnected.
+	subscriberBuffer = 100
+)
+
+// EventType is the type of a chain event published by the watcher.
+type EventType string
+
+// Event types published by the watcher.
+const (
+	EventTypeAnchorCommitted EventType = "anchor_committed"
+	EventTypeNFTDeposited    EventType = "nft_deposited"
+	EventTypeFeeChanged      EventType = "fee_changed"
+)
+
+// AnchorCommitted is an anchor committed in a finalized block.
+type AnchorCommitted struct {
+	AnchorID     types.Hash
+	DocumentRoot types.Hash
+}
+
+// ChainEvent is an event of the anchor, NFT or fee modules in a finalized block.
+// Only the field of the event type is set.
+type ChainEvent struct {
+	Type        EventType
+	BlockNumber uint32
+	BlockHash   types.Hash
+
+	AnchorCommitted *AnchorCommitted
+	NFTDeposited    *EventNFTDeposited
+	FeeChanged      *EventFeeChanged
+}
+
+// Watcher follows the finalized blocks of the centrifuge chain, and publishes the events of the anchor, NFT and
+// fee modules to the subscribers. The last indexed block is persisted, so the watcher resumes from it after a restart.
+type Watcher interface {
+	// Name returns the name of the watcher.
+	Name() string
+
+	// Start indexes the finalized blocks until the context is done.
+	Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error)
+
+	// Subscribe returns a channel of the events of the given types, or of all the events if none are given.
+	// The channel is closed if the subscriber falls behind. unsubscribe must be called once done.
+	Subscribe(eventTypes ...EventType) (events <-chan ChainEvent, unsubscribe func())
+
+	// Cursor returns the number of the last indexed block.
+	// Returns false if the watcher didn't index any block yet.
+	Cursor() (number uint32, ok bool)
+}
+
+// cursor is the last indexed block of the watcher.
+type cursor struct {
+	BlockNumber uint32 `json:"block_number"`
+}
+
+// JSON marshals cursor to json bytes.
+func (c *cursor) JSON() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// FromJSON loads json bytes to cursor.
+func (c *cursor) FromJSON(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+// Type returns the type of cursor.
+func (c *cursor) Type() reflect.Type {
+	return reflect.TypeOf(c)
+}
+
+type watcher struct {
+	sapi substrateAPI
+	repo storage.Repository
+
+	mu          sync.Mutex
+	cursor      *cursor
+	subscribers map[chan ChainEvent]map[EventType]bool
+}
+
+// NewWatcher returns a watcher of the chain, persisting its cursor in repo.
+func NewWatcher(sapi substrateAPI, repo storage.Repository) Watcher {
+	repo.Register(new(cursor))
+	return &watcher{sapi: sapi, repo: repo, subscribers: make(map[chan ChainEvent]map[EventType]bool)}
+}
+
+func (w *watcher) Name() string {
+	return "CentChainWatcher"
+}
+
+func (w *watcher) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		if err := w.sync(); err != nil {
+			log.Warnf("failed to index centchain blocks: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("Shutting down centchain watcher")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *watcher) Subscribe(eventTypes ...EventType) (<-chan ChainEvent, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	filter := make(map[EventType]bool)
+	for _, t := range eventTypes {
+		filter[t] = true
+	}
+
+	ch := make(chan ChainEvent, subscriberBuffer)
+	w.subscribers[ch] = filter
+	unsubscribe := func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if _, ok := w.subscribers[ch]; ok {
+			delete(w.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+func (w *watcher) Cursor() (uint32, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	c, err := w.loadCursor()
+	if err != nil || c == nil {
+		return 0, false
+	}
+
+	return c.BlockNumber, true
+}
+
+// loadCursor returns the cursor, or nil if the watcher didn't index any block yet. Caller must hold the lock.
+func (w *watcher) loadCursor() (*cursor, error) {
+	if w.cursor != nil {
+		return w.cursor, nil
+	}
+
+	key := []byte(watcherCursorKey)
+	if !w.repo.Exists(key) {
+		return nil, nil
+	}
+
+	m, err := w.repo.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	c, ok := m.(*cursor)
+	if !ok {
+		return nil, errors.New("invalid watcher cursor")
+	}
+
+	w.cursor = c
+	return c, nil
+}
+
+// saveCursor persists the block as the last indexed block. Caller must hold the lock.
+func (w *watcher) saveCursor(number uint32) error {
+	key := []byte(watcherCursorKey)
+	c := &cursor{BlockNumber: number}
+	var err error
+	if w.repo.Exists(key) {
+		err = w.repo.Update(key, c)
+	} else {
+		err = w.repo.Create(key, c)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.cursor = c
+	return nil
+}
+
+// sync indexes the finalized blocks after the cursor. The first sync starts from the finalized head.
+func (w *watcher) sync() error {
+	head, err := w.sapi.GetFinalizedHead()
+	if err != nil {
+		return err
+	}
+
+	header, err := w.sapi.GetHeader(head)
+	if err != nil {
+		return err
+	}
+
+	finalized := uint32(header.Number)
+	w.mu.Lock()
+	c, err := w.loadCursor()
+	if err == nil && c == nil {
+		log.Infof("indexing centchain from finalized block %d", finalized)
+		err = w.saveCursor(finalized)
+	}
+	w.mu.Unlock()
+	if err != nil || c == nil {
+		return err
+	}
+
+	if c.BlockNumber >= finalized {
+		return nil
+	}
+
+	meta, err := w.sapi.GetMetadataLatest()
+	if err != nil {
+		return err
+	}
+
+	for n := c.BlockNumber + 1; n <= finalized; n++ {
+		events, err := blockEvents(w.sapi, meta, n)
+		if err != nil {
+			return errors.New("failed to index block %d: %v", n, err)
+		}
+
+		w.mu.Lock()
+		w.publish(events)
+		err = w.saveCursor(n)
+		w.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publish sends the events to the subscribers. Caller must hold the lock.
+func (w *watcher) publish(events []ChainEvent) {
+	for _, e := range events {
+		for ch, filter := range w.subscribers {
+			if len(filter) > 0 && !filter[e.Type] {
+				continue
+			}
+
+			select {
+			case ch <- e:
+			default:
+				log.Warnf("disconnecting slow centchain event subscriber")
+				delete(w.subscribers, ch)
+				close(ch)
+			}
+		}
+	}
+}
+
+// blockEvents returns the events of the anchor, NFT and fee modules in the block.
+func blockEvents(sapi substrateAPI, meta *types.Metadata, number uint32) ([]ChainEvent, error) {
+	bh, err := sapi.GetBlockHash(uint64(number))
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := sapi.GetBlock(bh)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := types.CreateStorageKey(meta, "System", "Events", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var er types.EventRecordsRaw
+	err = sapi.GetStorage(key, &er, bh)
+	if err != nil {
+		return nil, err
+	}
+
+	e := Events{}
+	err = er.DecodeEventRecords(meta, &e)
+	if err != nil {
+		return nil, err
+	}
+
+	succeeded := make(map[uint32]bool)
+	for _, es := range e.System_ExtrinsicSuccess {
+		if es.Phase.IsApplyExtrinsic {
+			succeeded[es.Phase.AsApplyExtrinsic] = true
+		}
+	}
+
+	// calls of an interrupted batch from the index onwards are not dispatched
+	interrupted := make(map[uint32]int)
+	for _, ei := range e.Utility_BatchInterrupted {
+		if ei.Phase.IsApplyExtrinsic {
+			interrupted[ei.Phase.AsApplyExtrinsic] = int(ei.Index)
+		}
+	}
+
+	var events []ChainEvent
+	newEvent := func(t EventType) ChainEvent {
+		return ChainEvent{Type: t, BlockNumber: number, BlockHash: bh}
+	}
+
+	for idx, ext := range block.Block.Extrinsics {
+		if !succeeded[uint32(idx)] {
+			continue
+		}
+
+		limit, ok := interrupted[uint32(idx)]
+		if !ok {
+			limit = -1
+		}
+
+		commits, err := anchorCommits(meta, ext.Method, limit)
+		if err != nil {
+			log.Warnf("failed to decode anchors of extrinsic %d in block %d: %v", idx, number, err)
+		}
+
+		for _, ac := range commits {
+			ce := newEvent(EventTypeAnchorCommitted)
+			ce.AnchorCommitted = &AnchorCommitted{AnchorID: ac.AnchorID, DocumentRoot: ac.DocumentRoot}
+			events = append(events, ce)
+		}
+	}
+
+	for i := range e.Nfts_DepositAsset {
+		ce := newEvent(EventTypeNFTDeposited)
+		ce.NFTDeposited = &e.Nfts_DepositAsset[i]
+		events = append(events, ce)
+	}
+
+	for i := range e.Fees_FeeChanged {
+		ce := newEvent(EventTypeFeeChanged)
+		ce.FeeChanged = &e.Fees_FeeChanged[i]
+		events = append(events, ce)
+	}
+
+	return events, nil
+}
+
+// commitArgs are the args of the Anchor.commit call.
+type commitArgs struct {
+	AnchorIDPreimage types.Hash
+	DocumentRoot     types.Hash
+	Proof            types.Hash
+	StoredUntil      types.U64
+}
+
+// anchorCommits returns the anchors committed by the call, either an Anchor.commit call or a Utility.batch of
+// Anchor.commit calls. Only the first limit calls of the batch are dispatched if limit is not negative.
+func anchorCommits(meta *types.Metadata, c types.Call, limit int) ([]AnchorCommitted, error) {
+	commitIdx, err := meta.FindCallIndex(anchorCommit)
+	if err != nil {
+		// chain without the anchor module
+		return nil, nil
+	}
+
+	if c.CallIndex == commitIdx {
+		ac, err := decodeCommit(scale.NewDecoder(bytes.NewReader(c.Args)))
+		if err != nil {
+			return nil, err
+		}
+
+		return []AnchorCommitted{ac}, nil
+	}
+
+	batchIdx, err := meta.FindCallIndex(utilityBatch)
+	if err != nil || c.CallIndex != batchIdx {
+		return nil, nil
+	}
+
+	dec := scale.NewDecoder(bytes.NewReader(c.Args))
+	n, err := dec.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []AnchorCommitted
+	for i := 0; i < int(n.Int64()); i++ {
+		if limit >= 0 && i >= limit {
+			break
+		}
+
+		var ci types.CallIndex
+		err = dec.Decode(&ci)
+		if err != nil {
+			return commits, err
+		}
+
+		// args of the other calls can't be skipped without their types
+		if ci != commitIdx {
+			return commits, errors.New("unknown call %d.%d in batch", ci.SectionIndex, ci.MethodIndex)
+		}
+
+		ac, err := decodeCommit(dec)
+		if err != nil {
+			return commits, err
+		}
+
+		commits = append(commits, ac)
+	}
+
+	return commits, nil
+}
+
+// decodeCommit decodes the args of the Anchor.commit call. The anchor ID is the hash of the preimage.
+func decodeCommit(dec *scale.Decoder) (AnchorCommitted, error) {
+	var args commitArgs
+	err := dec.Decode(&args)
+	if err != nil {
+		return AnchorCommitted{}, err
+	}
+
+	id, err := crypto.Blake2bHash(args.AnchorIDPreimage[:])
+	if err != nil {
+		return AnchorCommitted{}, err
+	}
+
+	return AnchorCommitted{AnchorID: types.NewHash(id), DocumentRoot: args.DocumentRoot}, nil
+}
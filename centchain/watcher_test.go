@@ -0,0 +1,236 @@
+// +build unit
+
+package centchain
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// watcherMetadata returns the metadata with the calls and the events indexed by the watcher.
+// Event modules are indexed System=0, Utility=1, Nfts=2, Fees=3.
+func watcherMetadata() *types.Metadata {
+	meta := types.NewMetadataV8()
+	meta.AsMetadataV8.Modules = []types.ModuleMetadataV8{
+		{
+			Name:       "System",
+			HasStorage: true,
+			Storage: types.StorageMetadata{
+				Prefix: "System",
+				Items: []types.StorageFunctionMetadataV5{{
+					Name: "Events",
+					Type: types.StorageFunctionTypeV5{IsType: true, AsType: "Vec<EventRecord>"},
+				}},
+			},
+			HasEvents: true,
+			Events:    []types.EventMetadataV4{{Name: "ExtrinsicSuccess"}, {Name: "ExtrinsicFailed"}},
+		},
+		{
+			Name:     "Anchor",
+			HasCalls: true,
+			Calls:    []types.FunctionMetadataV4{{Name: "commit"}},
+		},
+		{
+			Name:      "Utility",
+			HasCalls:  true,
+			Calls:     []types.FunctionMetadataV4{{Name: "batch"}},
+			HasEvents: true,
+			Events:    []types.EventMetadataV4{{Name: "BatchInterrupted"}, {Name: "BatchCompleted"}},
+		},
+		{
+			Name:      "Nfts",
+			HasEvents: true,
+			Events:    []types.EventMetadataV4{{Name: "DepositAsset"}},
+		},
+		{
+			Name:      "Fees",
+			HasEvents: true,
+			Events:    []types.EventMetadataV4{{Name: "FeeChanged"}},
+		},
+	}
+	return meta
+}
+
+// eventRecords encodes the event records.
+func eventRecords(t *testing.T, records ...[]interface{}) types.EventRecordsRaw {
+	var buf bytes.Buffer
+	enc := scale.NewEncoder(&buf)
+	assert.NoError(t, enc.EncodeUintCompact(*big.NewInt(int64(len(records)))))
+	for _, r := range records {
+		for _, v := range r {
+			assert.NoError(t, enc.Encode(v))
+		}
+
+		// topics
+		assert.NoError(t, enc.Encode([]types.Hash{}))
+	}
+
+	return buf.Bytes()
+}
+
+func applyExtrinsic(idx uint32) types.Phase {
+	return types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: idx}
+}
+
+func commitCall(t *testing.T, meta *types.Metadata) (types.Call, AnchorCommitted) {
+	preimage, root := utils.RandomByte32(), utils.RandomByte32()
+	c, err := types.NewCall(
+		meta, anchorCommit, types.NewHash(preimage[:]), types.NewHash(root[:]),
+		types.NewHash(utils.RandomSlice(32)), types.NewMoment(time.Now()))
+	assert.NoError(t, err)
+	id, err := crypto.Blake2bHash(preimage[:])
+	assert.NoError(t, err)
+	return c, AnchorCommitted{AnchorID: types.NewHash(id), DocumentRoot: types.NewHash(root[:])}
+}
+
+// mockBlock mocks the block with the number, and returns the anchors committed in it.
+func mockBlock(t *testing.T, sapi *MockSubstrateAPI, meta *types.Metadata, number uint32) []AnchorCommitted {
+	bh := types.Hash(utils.RandomByte32())
+	commit, ac := commitCall(t, meta)
+	failed, _ := commitCall(t, meta)
+	var calls []types.Call
+	var batched []AnchorCommitted
+	for i := 0; i < 3; i++ {
+		c, bac := commitCall(t, meta)
+		calls = append(calls, c)
+		batched = append(batched, bac)
+	}
+
+	batch, err := types.NewCall(meta, utilityBatch, calls)
+	assert.NoError(t, err)
+	block := new(types.SignedBlock)
+	block.Block.Header.Number = types.BlockNumber(number)
+	block.Block.Extrinsics = []types.Extrinsic{types.NewExtrinsic(commit), types.NewExtrinsic(batch), types.NewExtrinsic(failed)}
+	info := types.DispatchInfo{Class: types.DispatchClass{IsNormal: true}}
+	er := eventRecords(t,
+		[]interface{}{applyExtrinsic(0), types.EventID{0, 0}, info},
+		// batch interrupted at its third call
+		[]interface{}{applyExtrinsic(1), types.EventID{1, 0}, types.U32(2), types.DispatchError{}},
+		[]interface{}{applyExtrinsic(1), types.EventID{0, 0}, info},
+		[]interface{}{applyExtrinsic(2), types.EventID{0, 1}, types.DispatchError{}, info},
+		[]interface{}{applyExtrinsic(1), types.EventID{2, 0}, types.NewHash(utils.RandomSlice(32))},
+		[]interface{}{applyExtrinsic(1), types.EventID{3, 0}, types.NewHash(utils.RandomSlice(32)), types.NewU128(*utils.ByteSliceToBigInt([]byte{1}))},
+	)
+
+	sapi.On("GetBlockHash", uint64(number)).Return(bh, nil).Once()
+	sapi.On("GetBlock", bh).Return(block, nil).Once()
+	sapi.On("GetStorage", mock.Anything, mock.Anything, bh).Run(func(args mock.Arguments) {
+		*(args.Get(1).(*types.EventRecordsRaw)) = er
+	}).Return(nil).Once()
+	return append([]AnchorCommitted{ac}, batched[:2]...)
+}
+
+func newTestRepo(t *testing.T) storage.Repository {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	return leveldb.NewLevelDBRepository(db)
+}
+
+func mockFinalizedHead(sapi *MockSubstrateAPI, number uint32) {
+	head := types.Hash(utils.RandomByte32())
+	sapi.On("GetFinalizedHead").Return(head, nil).Once()
+	sapi.On("GetHeader", head).Return(&types.Header{Number: types.BlockNumber(number)}, nil).Once()
+}
+
+func TestWatcher_Sync(t *testing.T) {
+	meta := watcherMetadata()
+	repo := newTestRepo(t)
+	sapi := new(MockSubstrateAPI)
+	w := NewWatcher(sapi, repo).(*watcher)
+	_, ok := w.Cursor()
+	assert.False(t, ok)
+
+	// first sync starts from the finalized head
+	mockFinalizedHead(sapi, 10)
+	assert.NoError(t, w.sync())
+	n, ok := w.Cursor()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(10), n)
+
+	all, unsubscribeAll := w.Subscribe()
+	fees, unsubscribeFees := w.Subscribe(EventTypeFeeChanged)
+	defer unsubscribeFees()
+
+	// index the new finalized blocks
+	mockFinalizedHead(sapi, 12)
+	sapi.On("GetMetadataLatest").Return(meta, nil)
+	commits := mockBlock(t, sapi, meta, 11)
+	commits = append(commits, mockBlock(t, sapi, meta, 12)...)
+	assert.NoError(t, w.sync())
+	n, _ = w.Cursor()
+	assert.Equal(t, uint32(12), n)
+
+	var gotCommits []AnchorCommitted
+	counts := make(map[EventType]int)
+	assert.Len(t, all, 10)
+	for len(all) > 0 {
+		e := <-all
+		counts[e.Type]++
+		if e.Type == EventTypeAnchorCommitted {
+			gotCommits = append(gotCommits, *e.AnchorCommitted)
+		}
+	}
+
+	assert.Equal(t, commits, gotCommits)
+	assert.Equal(t, map[EventType]int{
+		EventTypeAnchorCommitted: 6,
+		EventTypeNFTDeposited:    2,
+		EventTypeFeeChanged:      2,
+	}, counts)
+	assert.Len(t, fees, 2)
+	for i := uint32(11); len(fees) > 0; i++ {
+		e := <-fees
+		assert.Equal(t, EventTypeFeeChanged, e.Type)
+		assert.Equal(t, i, e.BlockNumber)
+		assert.NotNil(t, e.FeeChanged)
+	}
+
+	unsubscribeAll()
+	_, open := <-all
+	assert.False(t, open)
+
+	// failed block is retried from the cursor
+	mockFinalizedHead(sapi, 13)
+	sapi.On("GetBlockHash", uint64(13)).Return(nil, errors.New("connection lost")).Once()
+	assert.Error(t, w.sync())
+	n, _ = w.Cursor()
+	assert.Equal(t, uint32(12), n)
+	sapi.AssertExpectations(t)
+
+	// cursor survives restarts
+	w = NewWatcher(new(MockSubstrateAPI), repo).(*watcher)
+	n, ok = w.Cursor()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(12), n)
+}
+
+func TestWatcher_Start(t *testing.T) {
+	sapi := new(MockSubstrateAPI)
+	w := NewWatcher(sapi, newTestRepo(t))
+	mockFinalizedHead(sapi, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go w.Start(ctx, wg, nil)
+	assert.Eventually(t, func() bool {
+		_, ok := w.Cursor()
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	wg.Wait()
+	sapi.AssertExpectations(t)
+}
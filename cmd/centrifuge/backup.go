@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/centrifuge/go-centrifuge/storage/backup"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	var archivePath string
+
+	var backupCmd = &cobra.Command{
+		Use:   "backup",
+		Short: "backs up the node databases",
+		Long:  `Backs up the main and the config databases of a stopped node. Use GET /v2/admin/backup to back up a running node.`,
+		Run: func(c *cobra.Command, args []string) {
+			err := doBackup(archivePath)
+			if err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	var restoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "restores the node databases from a backup",
+		Long:  `Restores the main and the config databases of a stopped node from a backup. The current databases are kept next to the restored ones.`,
+		Run: func(c *cobra.Command, args []string) {
+			err := doRestore(archivePath)
+			if err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	backupCmd.Flags().StringVarP(&archivePath, "output", "o", "centrifuge-backup.tar.gz", "Path of the backup archive")
+	restoreCmd.Flags().StringVarP(&archivePath, "input", "i", "", "Path of the backup archive")
+	rootCmd.AddCommand(backupCmd, restoreCmd)
+}
+
+// dbPaths returns the paths of the node databases mapped by name.
+func dbPaths(cfg config.Configuration) map[string]string {
+	return map[string]string{
+		backend.MainDB:   cfg.GetStoragePath(),
+		backend.ConfigDB: cfg.GetConfigStoragePath(),
+	}
+}
+
+func doBackup(archivePath string) (err error) {
+	cfg := config.LoadConfiguration(cfgFile)
+	dbs := make(map[string]storage.KV)
+	for name, path := range dbPaths(cfg) {
+		db, err := backend.Open(cfg.GetStorageBackend(), path)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		dbs[name] = db.KV
+	}
+
+	f, err := os.OpenFile(archivePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	m, err := backup.Write(f, dbs)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		if rerr := os.Remove(archivePath); rerr != nil {
+			log.Warnf("failed to remove %s: %v", archivePath, rerr)
+		}
+
+		return err
+	}
+
+	log.Infof("Backed up %d dbs to %s", len(m.DBs), archivePath)
+	return nil
+}
+
+func doRestore(archivePath string) error {
+	cfg := config.LoadConfiguration(cfgFile)
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	m, err := backup.Restore(f, cfg.GetStorageBackend(), dbPaths(cfg))
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Restored %d dbs from the backup created at %s", len(m.DBs), m.CreatedAt)
+	return nil
+}
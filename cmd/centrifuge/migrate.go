@@ -1,29 +1,102 @@
 package main
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/migration"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
+// dbPassphraseEnv is the environment variable holding the passphrase the databases are encrypted with.
+const dbPassphraseEnv = "CENT_DB_PASSPHRASE"
+
+var dbKeyFile string
+var dbPassphraseStdin bool
+
 func init() {
 	var migrateCmd = &cobra.Command{
 		Use:   "migrate",
 		Short: "Runs node migrations",
-		Long:  ``,
+		Long:  `Runs node migrations. If a passphrase or a key file is supplied, plaintext databases are encrypted.`,
 		Run: func(c *cobra.Command, args []string) {
-			err := doMigrate()
+			secret, err := loadSecret()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			err = doMigrate(secret)
 			if err != nil {
 				log.Fatal(err)
 			}
 		},
 	}
 
+	addEncryptionFlags(migrateCmd)
 	rootCmd.AddCommand(migrateCmd)
 }
 
-func doMigrate() error {
+// addEncryptionFlags adds the flags supplying the secret the databases are encrypted with.
+// The passphrase is never passed as a flag value so that it doesn't show up in the process list or the shell history.
+func addEncryptionFlags(c *cobra.Command) {
+	c.Flags().BoolVar(&dbPassphraseStdin, "dbpassphrase-stdin", false, "Read the passphrase the databases are encrypted with from stdin, "+dbPassphraseEnv+" is used otherwise")
+	c.Flags().StringVar(&dbKeyFile, "dbkeyfile", "", "Path of the file holding the 32 byte key, raw or hex encoded, the databases are encrypted with")
+}
+
+// loadSecret returns the secret the databases are encrypted with, nil if none is supplied.
+func loadSecret() (*encryption.Secret, error) {
+	passphrase := os.Getenv(dbPassphraseEnv)
+	if dbPassphraseStdin {
+		var err error
+		passphrase, err = readPassphrase()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return encryption.LoadSecret(passphrase, dbKeyFile)
+}
+
+// readPassphrase reads the passphrase from stdin, prompting for it if stdin is a terminal.
+func readPassphrase() (string, error) {
+	if terminal.IsTerminal(syscall.Stdin) {
+		_, err := fmt.Fprintln(os.Stderr, "Enter the database passphrase:")
+		if err != nil {
+			return "", err
+		}
+
+		pwd, err := terminal.ReadPassword(syscall.Stdin)
+		return string(pwd), err
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read the database passphrase from stdin: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func doMigrate(secret *encryption.Secret) error {
 	cfg := config.LoadConfiguration(cfgFile)
 	runner := migration.NewMigrationRunner()
-	return runner.RunMigrations(cfg.GetStoragePath())
+	err := runner.RunMigrations(cfg.GetStorageBackend(), cfg.GetStoragePath(), secret)
+	if err != nil || secret == nil {
+		return err
+	}
+
+	for _, path := range []string{cfg.GetStoragePath(), cfg.GetConfigStoragePath()} {
+		err = runner.EncryptDB(cfg.GetStorageBackend(), path, secret)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
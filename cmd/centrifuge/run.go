@@ -6,6 +6,7 @@ import (
 )
 
 var runMigrations bool
+var devMode bool
 
 func init() {
 	// runCmd represents the run command
@@ -17,19 +18,26 @@ func init() {
 			// cm requires a config file
 			cfgFile := ensureConfigFile()
 
+			secret, err := loadSecret()
+			if err != nil {
+				log.Fatal(err)
+			}
+
 			// Check if migrations should run
 			if runMigrations {
-				err := doMigrate()
+				err = doMigrate(secret)
 				if err != nil {
 					log.Fatal(err)
 				}
 			}
 
 			// the following call will block
-			cmd.RunBootstrap(cfgFile)
+			cmd.RunBootstrap(cfgFile, secret, devMode)
 		},
 	}
 
 	runCmd.Flags().BoolVarP(&runMigrations, "runmigrations", "m", true, "Run Migrations at startup (-m=false)")
+	runCmd.Flags().BoolVar(&devMode, "dev", false, "Run against in-process simulated Centrifuge and Ethereum chains with the contracts deployed at startup")
+	addEncryptionFlags(runCmd)
 	rootCmd.AddCommand(runCmd)
 }
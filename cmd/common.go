@@ -16,6 +16,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/node"
 	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
 	"github.com/centrifuge/gocelery/v2"
 	logging "github.com/ipfs/go-log"
 )
@@ -144,11 +145,16 @@ func CreateConfig(
 }
 
 // RunBootstrap bootstraps the node for running
-func RunBootstrap(cfgFile string) {
+// dev runs the node against the in-process simulated chains.
+func RunBootstrap(cfgFile string, secret *encryption.Secret, dev bool) {
 	mb := bootstrappers.MainBootstrapper{}
 	mb.PopulateRunBootstrappers()
 	ctx := map[string]interface{}{}
 	ctx[config.BootstrappedConfigFile] = cfgFile
+	ctx[config.BootstrappedDevMode] = dev
+	if secret != nil {
+		ctx[encryption.BootstrappedSecret] = secret
+	}
 	err := mb.Bootstrap(ctx)
 	if err != nil {
 		// application must not continue to run
@@ -12,6 +12,9 @@ const (
 
 	// BootstrappedConfigStorage indicates that config storage has been bootstrapped and its the key for config storage service in the bootstrap context
 	BootstrappedConfigStorage string = "BootstrappedConfigStorage"
+
+	// BootstrappedDevMode indicates that the node runs against the simulated chains
+	BootstrappedDevMode string = "BootstrappedDevMode"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper to initialise config package.
@@ -24,6 +27,10 @@ func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
 	}
 	cfgFile := context[BootstrappedConfigFile].(string)
 	c := LoadConfiguration(cfgFile)
+	if dev, ok := context[BootstrappedDevMode].(bool); ok && dev {
+		c.Set("centChain.simulator.enabled", true)
+		c.Set("ethereum.simulator.enabled", true)
+	}
 	context[bootstrap.BootstrappedConfig] = c
 	if c.IsDebugLogEnabled() {
 		logging.SetAllLoggers(logging.LevelDebug)
@@ -113,6 +113,11 @@ func (nc *NodeConfig) GetDuration(key string) time.Duration {
 	panic("irrelevant, NodeConfig#GetDuration must not be used")
 }
 
+// GetStorageBackend refer the interface
+func (nc *NodeConfig) GetStorageBackend() string {
+	panic("irrelevant, NodeConfig#GetStorageBackend must not be used")
+}
+
 // GetStoragePath refer the interface
 func (nc *NodeConfig) GetStoragePath() string {
 	return nc.StoragePath
@@ -213,6 +218,16 @@ func (nc *NodeConfig) GetEthereumGasMultiplier() float64 {
 	return nc.EthereumGasMultiplier
 }
 
+// IsEthereumSimulatorEnabled refer the interface
+func (nc *NodeConfig) IsEthereumSimulatorEnabled() bool {
+	panic("irrelevant, NodeConfig#IsEthereumSimulatorEnabled must not be used")
+}
+
+// GetEthereumSimulatorContractsDir refer the interface
+func (nc *NodeConfig) GetEthereumSimulatorContractsDir() string {
+	panic("irrelevant, NodeConfig#GetEthereumSimulatorContractsDir must not be used")
+}
+
 // GetNetworkString refer the interface
 func (nc *NodeConfig) GetNetworkString() string {
 	return nc.NetworkString
@@ -273,6 +288,31 @@ func (nc *NodeConfig) GetCentChainAnchorLifespan() time.Duration {
 	return nc.CentChainAnchorLifespan
 }
 
+// GetCentChainAnchorBatchWindow refer the interface
+func (nc *NodeConfig) GetCentChainAnchorBatchWindow() time.Duration {
+	panic("irrelevant, NodeConfig#GetCentChainAnchorBatchWindow must not be used")
+}
+
+// GetCentChainAnchorBatchSize refer the interface
+func (nc *NodeConfig) GetCentChainAnchorBatchSize() int {
+	panic("irrelevant, NodeConfig#GetCentChainAnchorBatchSize must not be used")
+}
+
+// IsCentChainSimulatorEnabled refer the interface
+func (nc *NodeConfig) IsCentChainSimulatorEnabled() bool {
+	panic("irrelevant, NodeConfig#IsCentChainSimulatorEnabled must not be used")
+}
+
+// GetCentChainSimulatorBlockTime refer the interface
+func (nc *NodeConfig) GetCentChainSimulatorBlockTime() time.Duration {
+	panic("irrelevant, NodeConfig#GetCentChainSimulatorBlockTime must not be used")
+}
+
+// GetCentChainSimulatorFailureRate refer the interface
+func (nc *NodeConfig) GetCentChainSimulatorFailureRate() float64 {
+	panic("irrelevant, NodeConfig#GetCentChainSimulatorFailureRate must not be used")
+}
+
 // GetEthereumDefaultAccountName refer the interface
 func (nc *NodeConfig) GetEthereumDefaultAccountName() string {
 	return nc.MainIdentity.EthereumDefaultAccountName
@@ -283,6 +323,21 @@ func (nc *NodeConfig) GetReceiveEventNotificationEndpoint() string {
 	return nc.MainIdentity.ReceiveEventNotificationEndpoint
 }
 
+// GetNotificationSecret refer the interface
+func (nc *NodeConfig) GetNotificationSecret() string {
+	panic("irrelevant, NodeConfig#GetNotificationSecret must not be used")
+}
+
+// GetNotificationMaxAttempts refer the interface
+func (nc *NodeConfig) GetNotificationMaxAttempts() int {
+	panic("irrelevant, NodeConfig#GetNotificationMaxAttempts must not be used")
+}
+
+// GetNotificationRetryInterval refer the interface
+func (nc *NodeConfig) GetNotificationRetryInterval() time.Duration {
+	panic("irrelevant, NodeConfig#GetNotificationRetryInterval must not be used")
+}
+
 // GetIdentityID refer the interface
 func (nc *NodeConfig) GetIdentityID() ([]byte, error) {
 	return nc.MainIdentity.IdentityID, nil
@@ -22,6 +22,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/resources"
 	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-substrate-rpc-client/signature"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -57,6 +58,12 @@ const (
 	// InvoiceUnpaidNFT is the contract name for InvoiceUnpaidNFT
 	InvoiceUnpaidNFT ContractName = "invoiceUnpaid"
 
+	// NFTRegistry is the contract name for the generic AssetNFT registry
+	NFTRegistry ContractName = "nftRegistry"
+
+	// NFTOracle is the contract name for NFTOracle
+	NFTOracle ContractName = "nftOracle"
+
 	// IDCreate identity creation operation
 	IDCreate ContractOp = "idCreate"
 
@@ -86,8 +93,8 @@ const (
 )
 
 // ContractNames returns the list of smart contract names currently used in the system, please update this when adding new contracts
-func ContractNames() [5]ContractName {
-	return [5]ContractName{AnchorRepo, IdentityFactory, Identity, IdentityRegistry, InvoiceUnpaidNFT}
+func ContractNames() [7]ContractName {
+	return [7]ContractName{AnchorRepo, IdentityFactory, Identity, IdentityRegistry, InvoiceUnpaidNFT, NFTRegistry, NFTOracle}
 }
 
 // ContractOps returns the list of smart contract ops currently used in the system, please update this when adding new ops
@@ -111,6 +118,7 @@ type Configuration interface {
 	GetFloat(key string) float64
 	GetDuration(key string) time.Duration
 
+	GetStorageBackend() string
 	GetStoragePath() string
 	GetConfigStoragePath() string
 	GetAccountsKeystore() string
@@ -131,6 +139,8 @@ type Configuration interface {
 	GetEthereumMaxGasPrice() *big.Int
 	GetEthereumGasLimit(op ContractOp) uint64
 	GetEthereumGasMultiplier() float64
+	IsEthereumSimulatorEnabled() bool
+	GetEthereumSimulatorContractsDir() string
 	GetNetworkString() string
 	GetNetworkKey(k string) string
 	GetContractAddressString(address string) string
@@ -142,6 +152,9 @@ type Configuration interface {
 	GetEthereumAccount(accountName string) (account *AccountConfig, err error)
 	GetEthereumDefaultAccountName() string
 	GetReceiveEventNotificationEndpoint() string
+	GetNotificationSecret() string
+	GetNotificationMaxAttempts() int
+	GetNotificationRetryInterval() time.Duration
 	GetIdentityID() ([]byte, error)
 	GetP2PKeyPair() (pub, priv string)
 	GetSigningKeyPair() (pub, priv string)
@@ -157,6 +170,11 @@ type Configuration interface {
 	GetCentChainMaxRetries() int
 	GetCentChainNodeURL() string
 	GetCentChainAnchorLifespan() time.Duration
+	GetCentChainAnchorBatchWindow() time.Duration
+	GetCentChainAnchorBatchSize() int
+	IsCentChainSimulatorEnabled() bool
+	GetCentChainSimulatorBlockTime() time.Duration
+	GetCentChainSimulatorFailureRate() float64
 }
 
 // Account exposes account options
@@ -294,6 +312,11 @@ func (c *configuration) get(key string) interface{} {
 	return c.v.Get(key)
 }
 
+// GetStorageBackend returns the storage backend of the data and config storages.
+func (c *configuration) GetStorageBackend() string {
+	return c.GetString("storage.backend")
+}
+
 // GetStoragePath returns the data storage backend.
 func (c *configuration) GetStoragePath() string {
 	return c.GetString("storage.path")
@@ -334,6 +357,21 @@ func (c *configuration) GetReceiveEventNotificationEndpoint() string {
 	return c.GetString("notifications.endpoint")
 }
 
+// GetNotificationSecret returns the secret used to sign the webhook payloads.
+func (c *configuration) GetNotificationSecret() string {
+	return c.GetString("notifications.secret")
+}
+
+// GetNotificationMaxAttempts returns the number of delivery attempts of a webhook notification.
+func (c *configuration) GetNotificationMaxAttempts() int {
+	return c.GetInt("notifications.maxAttempts")
+}
+
+// GetNotificationRetryInterval returns the delay before the first retry of a webhook notification.
+func (c *configuration) GetNotificationRetryInterval() time.Duration {
+	return c.GetDuration("notifications.retryInterval")
+}
+
 // GetServerPort returns the defined server port in the config.
 func (c *configuration) GetServerPort() int {
 	return c.GetInt("nodePort")
@@ -404,6 +442,16 @@ func (c *configuration) GetEthereumGasMultiplier() float64 {
 	return c.GetFloat("ethereum.gasMultiplier")
 }
 
+// IsEthereumSimulatorEnabled returns true if the node runs against an in-process simulated Ethereum chain.
+func (c *configuration) IsEthereumSimulatorEnabled() bool {
+	return c.GetBool("ethereum.simulator.enabled")
+}
+
+// GetEthereumSimulatorContractsDir returns the directory with the compiled contracts deployed on the simulated Ethereum chain.
+func (c *configuration) GetEthereumSimulatorContractsDir() string {
+	return c.GetString("ethereum.simulator.contractsDir")
+}
+
 // GetEthereumDefaultAccountName returns the default account to use for the transaction.
 func (c *configuration) GetEthereumDefaultAccountName() string {
 	return c.GetString("ethereum.defaultAccountName")
@@ -470,6 +518,31 @@ func (c *configuration) GetCentChainAnchorLifespan() time.Duration {
 	return c.GetDuration("centChain.anchorLifespan")
 }
 
+// GetCentChainAnchorBatchWindow returns the window in which the anchors of an account are committed together.
+func (c *configuration) GetCentChainAnchorBatchWindow() time.Duration {
+	return c.GetDuration("centChain.anchorBatchWindow")
+}
+
+// GetCentChainAnchorBatchSize returns the max number of the anchors committed together.
+func (c *configuration) GetCentChainAnchorBatchSize() int {
+	return c.GetInt("centChain.anchorBatchSize")
+}
+
+// IsCentChainSimulatorEnabled returns true if the node runs against an in-process simulated CentChain.
+func (c *configuration) IsCentChainSimulatorEnabled() bool {
+	return c.GetBool("centChain.simulator.enabled")
+}
+
+// GetCentChainSimulatorBlockTime returns the block time of the simulated CentChain.
+func (c *configuration) GetCentChainSimulatorBlockTime() time.Duration {
+	return c.GetDuration("centChain.simulator.blockTime")
+}
+
+// GetCentChainSimulatorFailureRate returns the rate of the extrinsics failed by the simulated CentChain.
+func (c *configuration) GetCentChainSimulatorFailureRate() float64 {
+	return c.GetFloat("centChain.simulator.failureRate")
+}
+
 // GetNetworkString returns defined network the node is connected to.
 func (c *configuration) GetNetworkString() string {
 	return c.GetString("centrifugeNetwork")
@@ -660,6 +733,8 @@ func CreateConfigFile(args map[string]interface{}) (*viper.Viper, error) {
 	v.Set("nodePort", apiPort)
 	v.Set("p2p.port", p2pPort)
 	v.Set("notifications.endpoint", webhookURL)
+	// the webhook payloads are signed with a secret of the node
+	v.Set("notifications.secret", hexutil.Encode(utils.RandomSlice(32)))
 	if p2pConnectTimeout != "" {
 		v.Set("p2p.connectTimeout", p2pConnectTimeout)
 	}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,6 +57,9 @@ func TestConfiguration_CreateConfigFile(t *testing.T) {
 
 	cfg := c.(*configuration)
 	assert.NotNil(t, cfg.GetP2PResponseDelay())
+	secret, err := hexutil.Decode(cfg.GetNotificationSecret())
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
 
 	assert.NoError(t, os.RemoveAll(targetDir))
 }
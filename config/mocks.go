@@ -220,6 +220,16 @@ func (m *MockAccount) GetReceiveEventNotificationEndpoint() string {
 	return args.String(0)
 }
 
+func (m *MockAccount) GetIdentityID() []byte {
+	args := m.Called()
+	return args.Get(0).([]byte)
+}
+
+func (m *MockAccount) GetCentChainAccount() CentChainAccount {
+	args := m.Called()
+	return args.Get(0).(CentChainAccount)
+}
+
 func (m *MockAccount) SignMsg(msg []byte) (*coredocumentpb.Signature, error) {
 	args := m.Called(msg)
 	sig, _ := args.Get(0).(*coredocumentpb.Signature)
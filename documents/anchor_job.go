@@ -1,6 +1,7 @@
 package documents
 
 import (
+	"bytes"
 	"context"
 	"encoding/gob"
 	"fmt"
@@ -10,30 +11,29 @@ import (
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func init() {
 	gob.Register(identity.DID{})
 }
 
-type task struct {
-	runnerFunc gocelery.RunnerFunc
-	next       string
-}
-
 const anchorJob = "Commit document anchor"
 
 // AnchorJob is a async anchoring task
 // args should be as follows
 // DID, versionID, preCommit(true|false)
 // ignores overrides
+// the progress of the anchoring is sent to notifier after every task.
 type AnchorJob struct {
+	jobs.Base
 	configSrv config.Service
 	repo      Repository
 	processor AnchorProcessor
-
-	tasks map[string]task
+	notifier  notification.Sender
 }
 
 // New returns a new instance of anchor Job
@@ -42,23 +42,15 @@ func (a *AnchorJob) New() gocelery.Runner {
 		configSrv: a.configSrv,
 		repo:      a.repo,
 		processor: a.processor,
+		notifier:  a.notifier,
 	}
-	aj.loadTasks()
+	aj.Base = jobs.NewBase(aj.getTasks())
 	return aj
 }
 
-// RunnerFunc will return a RunnerFunc for a given task
-func (a *AnchorJob) RunnerFunc(task string) gocelery.RunnerFunc {
-	return a.tasks[task].runnerFunc
-}
-
-// Next returns the next task in line after task provided
-func (a *AnchorJob) Next(task string) (next string, ok bool) {
-	t := a.tasks[task]
-	return t.next, t.next != ""
-}
-
-func (a *AnchorJob) runnerFunc(run func(ctx context.Context, doc Document) error) gocelery.RunnerFunc {
+// runnerFunc returns a runnerFunc running the step on the document. done, if set, logs the outcome of the step.
+func (a *AnchorJob) runnerFunc(run func(ctx context.Context, doc Document) error,
+	done func(l jobs.Logger, doc Document)) gocelery.RunnerFunc {
 	return func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 		did := args[0].(identity.DID)
 		versionID := args[1].([]byte)
@@ -72,7 +64,7 @@ func (a *AnchorJob) runnerFunc(run func(ctx context.Context, doc Document) error
 			return nil, fmt.Errorf("failed to get account from config service: %w", err)
 		}
 
-		ctx, err := contextutil.New(context.Background(), acc)
+		ctx, err := contextutil.New(jobs.ContextFromOverrides(overrides), acc)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create context: %w", err)
 		}
@@ -82,50 +74,202 @@ func (a *AnchorJob) runnerFunc(run func(ctx context.Context, doc Document) error
 			return nil, err
 		}
 
+		if done != nil {
+			done(a.Logger(overrides), doc)
+		}
+
 		return nil, a.repo.Update(did[:], versionID, doc)
 	}
 }
 
-func (a *AnchorJob) loadTasks() {
-	a.tasks = map[string]task{
+func (a *AnchorJob) getTasks() map[string]jobs.Task {
+	tasks := map[string]jobs.Task{
 		"prepare_request_signatures": {
-			runnerFunc: a.runnerFunc(a.processor.PrepareForSignatureRequests),
-			next:       "pre_commit",
+			RunnerFunc: a.runnerFunc(a.processor.PrepareForSignatureRequests, nil),
+			Next:       "pre_commit",
 		},
 		"pre_commit": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{},
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{},
 				error) {
 				preCommit := args[2].(bool)
 				if !preCommit {
+					a.Logger(overrides).Infof("pre-commit not requested, skipping")
 					return nil, nil
 				}
 
-				return a.runnerFunc(a.processor.PreAnchorDocument)(args, overrides)
+				return a.runnerFunc(a.processor.PreAnchorDocument, func(l jobs.Logger, doc Document) {
+					l.Infof("pre-committed version %s", hexutil.Encode(doc.CurrentVersion()))
+				})(args, overrides)
 			},
-			next: "request_signatures",
+			Next:  "request_signatures",
+			Retry: jobs.DefaultRetryPolicy(),
 		},
 		"request_signatures": {
-			runnerFunc: a.runnerFunc(a.processor.RequestSignatures),
-			next:       "prepare_anchor",
+			RunnerFunc: a.runnerFunc(a.processor.RequestSignatures, func(l jobs.Logger, doc Document) {
+				l.Infof("collected %d signatures", len(doc.Signatures()))
+			}),
+			Next:  "prepare_anchor",
+			Retry: jobs.DefaultRetryPolicy(),
 		},
 		"prepare_anchor": {
-			runnerFunc: a.runnerFunc(a.processor.PrepareForAnchoring),
-			next:       "anchor_document",
+			RunnerFunc: a.runnerFunc(a.processor.PrepareForAnchoring, nil),
+			Next:       "anchor_document",
 		},
 		"anchor_document": {
-			runnerFunc: a.runnerFunc(a.processor.AnchorDocument),
-			next:       "set_document_committed",
+			RunnerFunc: a.runnerFunc(a.processor.AnchorDocument, func(l jobs.Logger, doc Document) {
+				l.Infof("anchored version %s", hexutil.Encode(doc.CurrentVersion()))
+			}),
+			Next:  "set_document_committed",
+			Retry: jobs.DefaultRetryPolicy(),
 		},
 		"set_document_committed": {
-			runnerFunc: a.runnerFunc(func(ctx context.Context, doc Document) error {
+			RunnerFunc: a.runnerFunc(func(ctx context.Context, doc Document) error {
 				return doc.SetStatus(Committed)
-			}),
-			next: "send_document",
+			}, nil),
+			Next: "send_document",
 		},
 		"send_document": {
-			runnerFunc: a.runnerFunc(a.processor.SendDocument),
+			RunnerFunc: a.runnerFunc(a.processor.SendDocument, func(l jobs.Logger, doc Document) {
+				l.Infof("sent the document to the collaborators")
+			}),
+			Timeout: 5 * time.Minute,
+			Retry:   jobs.DefaultRetryPolicy(),
 		},
 	}
+
+	for name, t := range tasks {
+		t.RunnerFunc = a.notifyProgress(name, t.RunnerFunc)
+		tasks[name] = t
+	}
+
+	return tasks
+}
+
+// notifyProgress returns a runnerFunc which sends the anchoring progress, and the lifecycle events of the step,
+// once the step succeeds.
+func (a *AnchorJob) notifyProgress(step string, runnerFunc gocelery.RunnerFunc) gocelery.RunnerFunc {
+	return func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+		result, err := runnerFunc(args, overrides)
+		if err != nil || a.notifier == nil {
+			return result, err
+		}
+
+		if err := a.sendProgress(step, args, overrides); err != nil {
+			log.Errorf("failed to send anchor progress: %v", err)
+		}
+
+		return result, nil
+	}
+}
+
+func (a *AnchorJob) sendProgress(step string, args []interface{}, overrides map[string]interface{}) error {
+	did := args[0].(identity.DID)
+	versionID := args[1].([]byte)
+	doc, err := a.repo.Get(did[:], versionID)
+	if err != nil {
+		return fmt.Errorf("failed to get document from ID and Version: %w", err)
+	}
+
+	acc, err := a.configSrv.GetAccount(did[:])
+	if err != nil {
+		return fmt.Errorf("failed to get account from config service: %w", err)
+	}
+
+	jobID := jobs.JobIDFromOverrides(overrides)
+	msgs, err := a.lifecycleMessages(step, did, jobID, doc)
+	if err != nil {
+		return err
+	}
+
+	msgs = append([]notification.Message{{
+		EventType: notification.EventTypeAnchor,
+		Anchor: &notification.AnchorMessage{
+			JobID:      jobID,
+			DocumentID: doc.ID(),
+			VersionID:  versionID,
+			Step:       step,
+		},
+	}}, msgs...)
+
+	ctx := contextutil.WithAccount(context.Background(), acc)
+	for _, msg := range msgs {
+		msg.RecordedAt = time.Now().UTC()
+		if err := a.notifier.Send(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lifecycleMessages returns the document lifecycle events of the step.
+func (a *AnchorJob) lifecycleMessages(step string, did identity.DID, jobID []byte, doc Document) ([]notification.Message, error) {
+	var msgs []notification.Message
+	switch step {
+	case "request_signatures":
+		for _, sig := range doc.Signatures() {
+			if bytes.Equal(sig.SignerId, did[:]) {
+				continue
+			}
+
+			msgs = append(msgs, notification.Message{
+				EventType: notification.EventTypeSignatureGiven,
+				SignatureGiven: &notification.SignatureGivenMessage{
+					JobID:       jobID,
+					DocumentID:  doc.ID(),
+					VersionID:   doc.CurrentVersion(),
+					Signer:      sig.SignerId,
+					SignatureID: sig.SignatureId,
+				},
+			})
+		}
+	case "anchor_document":
+		dr, err := doc.CalculateDocumentRoot()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get document root: %w", err)
+		}
+
+		msgs = append(msgs, notification.Message{
+			EventType: notification.EventTypeDocumentAnchored,
+			DocumentAnchored: &notification.DocumentAnchoredMessage{
+				JobID:        jobID,
+				DocumentID:   doc.ID(),
+				VersionID:    doc.CurrentVersion(),
+				AnchorID:     doc.CurrentVersion(),
+				DocumentRoot: dr,
+			},
+		})
+	case "set_document_committed":
+		msgs = append(msgs, notification.Message{
+			EventType: notification.EventTypeDocumentCommitted,
+			DocumentCommitted: &notification.DocumentCommittedMessage{
+				JobID:      jobID,
+				DocumentID: doc.ID(),
+				VersionID:  doc.CurrentVersion(),
+				Scheme:     doc.Scheme(),
+			},
+		})
+
+		if utils.IsEmptyByteSlice(doc.PreviousVersion()) {
+			break
+		}
+
+		old, err := a.repo.Get(did[:], doc.PreviousVersion())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get previous version: %w", err)
+		}
+
+		msg, err := collaboratorsChangedMessage(old, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		if msg != nil {
+			msgs = append(msgs, *msg)
+		}
+	}
+
+	return msgs, nil
 }
 
 // initiateAnchorJob initiate document anchor job
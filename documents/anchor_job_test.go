@@ -0,0 +1,67 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/notification"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// docRepository returns the same document for every version.
+type docRepository struct {
+	Repository
+	doc Document
+}
+
+func (r docRepository) Get(accountID, id []byte) (Document, error) {
+	return r.doc, nil
+}
+
+func TestAnchorJob_NotifyProgress(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	signer := testingidentity.GenerateRandomDID()
+	docID, versionID, jobID := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+	doc := &mockModel{sigs: []*coredocumentpb.Signature{{SignerId: signer[:]}, {SignerId: did[:]}}}
+	doc.On("ID").Return(docID)
+	doc.On("CurrentVersion").Return(versionID)
+	doc.On("Signatures").Return()
+	acc := new(config.MockAccount)
+	configSrv := new(config.MockService)
+	configSrv.On("GetAccount", did[:]).Return(acc, nil)
+	var msgs []notification.Message
+	notifier := new(notification.MockOutbox)
+	notifier.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		msgs = append(msgs, args.Get(1).(notification.Message))
+	}).Return(nil)
+	a := &AnchorJob{configSrv: configSrv, repo: docRepository{doc: doc}, notifier: notifier}
+	args := []interface{}{did, versionID, false}
+	overrides := map[string]interface{}{"job_id": hexutil.Encode(jobID)}
+	runner := func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	// progress of the step
+	_, err := a.notifyProgress("prepare_anchor", runner)(args, overrides)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	assert.Equal(t, notification.EventTypeAnchor, msgs[0].EventType)
+	assert.Equal(t, &notification.AnchorMessage{
+		JobID: jobID, DocumentID: docID, VersionID: versionID, Step: "prepare_anchor"}, msgs[0].Anchor)
+
+	// progress along with the lifecycle events of the step
+	msgs = nil
+	_, err = a.notifyProgress("request_signatures", runner)(args, overrides)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 2)
+	assert.Equal(t, "request_signatures", msgs[0].Anchor.Step)
+	assert.Equal(t, notification.EventTypeSignatureGiven, msgs[1].EventType)
+	assert.Equal(t, signer[:], []byte(msgs[1].SignatureGiven.Signer))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -52,9 +53,16 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return ErrDocumentConfigNotInitialised
 	}
 
+	outbox, ok := ctx[notification.BootstrappedOutbox].(notification.Sender)
+	if !ok {
+		return errors.New("notification outbox not initialised")
+	}
+
 	dispatcher := ctx[jobs.BootstrappedDispatcher].(jobs.Dispatcher)
-	ctx[BootstrappedDocumentService] = DefaultService(
-		cfg, repo, anchorSrv, registry, didService, dispatcher)
+	srv := DefaultService(cfg, repo, anchorSrv, registry, didService, dispatcher).(service)
+	srv.notifier = outbox
+	ctx[BootstrappedDocumentService] = srv
+	dispatcher.RegisterScheduledFunc(RecomputeDocumentAction, recomputeDocument(srv))
 	ctx[BootstrappedRegistry] = registry
 	ctx[BootstrappedDocumentRepository] = repo
 	return nil
@@ -98,11 +106,13 @@ func (PostBootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	dp := DefaultProcessor(didService, p2pClient, anchorSrv, cfg)
 	ctx[BootstrappedAnchorProcessor] = dp
 
+	notifier, _ := ctx[notification.BootstrappedOutbox].(notification.Sender)
 	dispatcher := ctx[jobs.BootstrappedDispatcher].(jobs.Dispatcher)
 	go dispatcher.RegisterRunner(anchorJob, &AnchorJob{
 		configSrv: cfgService,
 		processor: dp,
 		repo:      repo,
+		notifier:  notifier,
 	})
 	return nil
 }
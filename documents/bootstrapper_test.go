@@ -9,11 +9,13 @@ import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestBootstrapper_Bootstrap(t *testing.T) {
@@ -26,11 +28,20 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	ctx[storage.BootstrappedDB] = repo
 	ctx[anchors.BootstrappedAnchorService] = new(anchors.MockAnchorService)
 	ctx[identity.BootstrappedDIDService] = new(testingcommons.MockIdentityService)
-	ctx[jobs.BootstrappedDispatcher] = new(jobs.MockDispatcher)
+	dispatcher := new(jobs.MockDispatcher)
+	dispatcher.On("RegisterScheduledFunc", RecomputeDocumentAction, mock.Anything).Return(true).Once()
+	ctx[jobs.BootstrappedDispatcher] = dispatcher
+
+	// missing outbox
+	err = Bootstrapper{}.Bootstrap(ctx)
+	assert.Error(t, err)
+
+	ctx[notification.BootstrappedOutbox] = new(notification.MockOutbox)
 
 	err = Bootstrapper{}.Bootstrap(ctx)
 	assert.Nil(t, err)
 	assert.NotNil(t, ctx[BootstrappedRegistry])
 	_, ok := ctx[BootstrappedRegistry].(*ServiceRegistry)
 	assert.True(t, ok)
+	dispatcher.AssertExpectations(t)
 }
@@ -0,0 +1,289 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/protobuf/proto"
+)
+
+// BundleFormatVersion is the version of the bundle format.
+const BundleFormatVersion = 1
+
+// Bundle is a portable export of a committed document with all its versions, from the first to the latest.
+// The bundle is signed by the exporting account.
+type Bundle struct {
+	FormatVersion int                `json:"format_version"`
+	DocumentID    byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	Scheme        string             `json:"scheme"`
+	ExportedAt    time.Time          `json:"exported_at" swaggertype:"primitive,string"`
+	Versions      []BundleVersion    `json:"versions"`
+	Signature     *BundleSignature   `json:"signature,omitempty"`
+}
+
+// BundleVersion is a version of the document in a bundle.
+type BundleVersion struct {
+	VersionID byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+
+	// CoreDocument is the protobuf encoded core document of the version along with its signatures.
+	CoreDocument byteutils.HexBytes `json:"core_document" swaggertype:"primitive,string"`
+
+	// Anchor references the anchor of the version on chain.
+	Anchor AnchorReference `json:"anchor"`
+}
+
+// AnchorReference references the anchor of a document version.
+type AnchorReference struct {
+	AnchorID     byteutils.HexBytes `json:"anchor_id" swaggertype:"primitive,string"`
+	DocumentRoot byteutils.HexBytes `json:"document_root" swaggertype:"primitive,string"`
+	AnchoredAt   time.Time          `json:"anchored_at" swaggertype:"primitive,string"`
+}
+
+// BundleSignature is the signature of the exporting account over the bundle.
+type BundleSignature struct {
+	SignerID  byteutils.HexBytes `json:"signer_id" swaggertype:"primitive,string"`
+	PublicKey byteutils.HexBytes `json:"public_key" swaggertype:"primitive,string"`
+	Signature byteutils.HexBytes `json:"signature" swaggertype:"primitive,string"`
+}
+
+// signingPayload returns the hash of the bundle without the signature.
+func (b Bundle) signingPayload() ([]byte, error) {
+	b.Signature = nil
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.Sum256(data)
+	return h[:], nil
+}
+
+// Export returns the signed bundle of the committed document, owned by the account, with all its versions.
+func (s service) Export(ctx context.Context, documentID []byte) (*Bundle, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	accID := acc.GetIdentityID()
+	doc, err := s.repo.GetLatest(accID, documentID)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentNotFound, err)
+	}
+
+	var versions []BundleVersion
+	for {
+		v, err := s.bundleVersion(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append([]BundleVersion{v}, versions...)
+		if utils.IsEmptyByteSlice(doc.PreviousVersion()) {
+			break
+		}
+
+		prev := doc.PreviousVersion()
+		doc, err = s.repo.Get(accID, prev)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentVersionNotFound, errors.New("version %s: %v", hexutil.Encode(prev), err))
+		}
+	}
+
+	b := &Bundle{
+		FormatVersion: BundleFormatVersion,
+		DocumentID:    documentID,
+		Scheme:        doc.Scheme(),
+		ExportedAt:    time.Now().UTC(),
+		Versions:      versions,
+	}
+
+	payload, err := b.signingPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := acc.SignMsg(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	b.Signature = &BundleSignature{
+		SignerID:  sig.SignerId,
+		PublicKey: sig.PublicKey,
+		Signature: sig.Signature,
+	}
+
+	return b, nil
+}
+
+// bundleVersion packs the version of the document along with its anchor.
+func (s service) bundleVersion(doc Document) (v BundleVersion, err error) {
+	if doc.GetStatus() != Committed {
+		return v, errors.NewTypedError(ErrDocumentInvalid, errors.New("version %s is not committed", hexutil.Encode(doc.CurrentVersion())))
+	}
+
+	cd, err := doc.PackCoreDocument()
+	if err != nil {
+		return v, errors.NewTypedError(ErrDocumentPackingCoreDocument, err)
+	}
+
+	data, err := proto.Marshal(&cd)
+	if err != nil {
+		return v, errors.NewTypedError(ErrDocumentPackingCoreDocument, err)
+	}
+
+	anchorID, err := anchors.ToAnchorID(doc.CurrentVersion())
+	if err != nil {
+		return v, errors.NewTypedError(ErrDocumentIdentifier, err)
+	}
+
+	root, anchoredAt, err := s.anchorSrv.GetAnchorData(anchorID)
+	if err != nil {
+		return v, errors.NewTypedError(ErrDocumentAnchoring, err)
+	}
+
+	return BundleVersion{
+		VersionID:    doc.CurrentVersion(),
+		CoreDocument: data,
+		Anchor: AnchorReference{
+			AnchorID:     anchorID[:],
+			DocumentRoot: root[:],
+			AnchoredAt:   anchoredAt.UTC(),
+		},
+	}, nil
+}
+
+// Import validates the bundle and stores the versions of the document, which are not present yet, for the account.
+// Each version is validated against its anchor before any version is stored.
+// Returns the latest version of the document.
+func (s service) Import(ctx context.Context, b *Bundle) (Document, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	if b == nil {
+		return nil, ErrPayloadNil
+	}
+
+	if err := s.validateBundleSignature(b); err != nil {
+		return nil, errors.NewTypedError(ErrBundleInvalid, err)
+	}
+
+	docs := make([]Document, len(b.Versions))
+	for i, v := range b.Versions {
+		var prev Document
+		if i > 0 {
+			prev = docs[i-1]
+		}
+
+		docs[i], err = s.deriveBundleVersion(b, v, prev)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrBundleInvalid, errors.New("version %s: %v", v.VersionID.String(), err))
+		}
+
+		validator := AnchoredVersionValidator(s.idService, s.anchorSrv)
+		if i == len(b.Versions)-1 {
+			validator = PostAnchoredValidator(s.idService, s.anchorSrv)
+		}
+
+		if err := validator.Validate(prev, docs[i]); err != nil {
+			return nil, errors.NewTypedError(ErrDocumentInvalid, errors.New("version %s: %v", v.VersionID.String(), err))
+		}
+	}
+
+	for _, doc := range docs {
+		if s.repo.Exists(did[:], doc.CurrentVersion()) {
+			continue
+		}
+
+		if err := doc.SetStatus(Committed); err != nil {
+			return nil, err
+		}
+
+		if err := s.repo.Create(did[:], doc.CurrentVersion(), doc); err != nil {
+			return nil, errors.NewTypedError(ErrDocumentPersistence, err)
+		}
+	}
+
+	srvLog.Infof("imported document %s with %d versions", b.DocumentID.String(), len(docs))
+	return docs[len(docs)-1], nil
+}
+
+// validateBundleSignature checks the format of the bundle and the signature of the exporting account.
+func (s service) validateBundleSignature(b *Bundle) error {
+	if b.FormatVersion != BundleFormatVersion {
+		return errors.New("unsupported format version %d", b.FormatVersion)
+	}
+
+	if len(b.Versions) < 1 {
+		return errors.New("atleast one version expected")
+	}
+
+	if b.Signature == nil {
+		return errors.New("signature missing")
+	}
+
+	signer, err := identity.NewDIDFromBytes(b.Signature.SignerID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := b.signingPayload()
+	if err != nil {
+		return err
+	}
+
+	return s.idService.ValidateSignature(signer, b.Signature.PublicKey, b.Signature.Signature, payload, b.ExportedAt)
+}
+
+// deriveBundleVersion derives the document of the bundle version and checks it follows prev.
+func (s service) deriveBundleVersion(b *Bundle, v BundleVersion, prev Document) (Document, error) {
+	cd := new(coredocumentpb.CoreDocument)
+	if err := proto.Unmarshal(v.CoreDocument, cd); err != nil {
+		return nil, errors.NewTypedError(ErrDocumentUnPackingCoreDocument, err)
+	}
+
+	doc, err := s.DeriveFromCoreDocument(*cd)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !bytes.Equal(doc.ID(), b.DocumentID):
+		return nil, errors.New("document id mismatch")
+	case doc.Scheme() != b.Scheme:
+		return nil, errors.New("scheme mismatch")
+	case !bytes.Equal(doc.CurrentVersion(), v.VersionID):
+		return nil, errors.New("version id mismatch")
+	case !bytes.Equal(v.Anchor.AnchorID, v.VersionID):
+		return nil, errors.New("anchor id mismatch")
+	case prev == nil && !utils.IsEmptyByteSlice(doc.PreviousVersion()):
+		return nil, errors.New("first version of the document expected")
+	case prev != nil && !bytes.Equal(doc.PreviousVersion(), prev.CurrentVersion()):
+		return nil, errors.New("previous version mismatch")
+	}
+
+	dr, err := doc.CalculateDocumentRoot()
+	if err != nil {
+		return nil, errors.New("failed to get document root: %v", err)
+	}
+
+	if !bytes.Equal(dr, v.Anchor.DocumentRoot) {
+		return nil, errors.New("document root mismatch")
+	}
+
+	return doc, nil
+}
@@ -21,7 +21,8 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
@@ -51,7 +52,8 @@ func TestMain(m *testing.M) {
 	ibootstappers := []bootstrap.TestBootstrapper{
 		&testlogging.TestLoggingBootstrapper{},
 		&config.Bootstrapper{},
-		&leveldb.Bootstrapper{},
+		&backend.Bootstrapper{},
+		notification.Bootstrapper{},
 		jobs.Bootstrapper{},
 		&configstore.Bootstrapper{},
 		&anchors.Bootstrapper{},
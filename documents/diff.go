@@ -0,0 +1,442 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ChangeType is the type of change of a field between two versions.
+type ChangeType string
+
+const (
+	// ChangeAdded is used when the field is only present in the newer version.
+	ChangeAdded ChangeType = "added"
+
+	// ChangeRemoved is used when the field is only present in the older version.
+	ChangeRemoved ChangeType = "removed"
+
+	// ChangeModified is used when the value of the field differs between the versions.
+	ChangeModified ChangeType = "modified"
+)
+
+// Change is a human readable change of a field between two versions of a document.
+type Change struct {
+	Field string     `json:"field"`
+	Type  ChangeType `json:"type" enums:"added,removed,modified"`
+	Old   string     `json:"old,omitempty"`
+	New   string     `json:"new,omitempty"`
+}
+
+// Diff holds the changes between two versions of a document.
+// The changes are the leaves that differ between the precise-proofs trees of the versions.
+type Diff struct {
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	From       byteutils.HexBytes `json:"from" swaggertype:"primitive,string"`
+	To         byteutils.HexBytes `json:"to" swaggertype:"primitive,string"`
+
+	// Data holds the changes of the data leaves keyed by their property name.
+	Data []Change `json:"data"`
+
+	// Attributes holds the changes of the attributes keyed by their label.
+	Attributes []Change `json:"attributes"`
+
+	// Roles holds the changes of the collaborators of the roles keyed by the role key.
+	Roles []Change `json:"roles"`
+
+	// ReadRules holds the changes of the read rule leaves keyed by their property name.
+	ReadRules []Change `json:"read_rules"`
+
+	// TransitionRules holds the changes of the transition rule leaves keyed by their property name.
+	TransitionRules []Change `json:"transition_rules"`
+
+	// Collaborators holds the changes of the access of the collaborators keyed by their DID.
+	Collaborators []Change `json:"collaborators"`
+}
+
+// core document fields compared by the diff, other fields are bookkeeping of the version.
+const (
+	attributesField      = "attributes"
+	rolesField           = "roles"
+	readRulesField       = "read_rules"
+	transitionRulesField = "transition_rules"
+)
+
+// Diff returns the changes between the versions from and to of the document owned by the account.
+// If to is empty, the current version is used. If from is empty, the previous version of to is used.
+// from must be an older version of to.
+func (s service) Diff(ctx context.Context, documentID, from, to []byte) (*Diff, error) {
+	var newer Document
+	var err error
+	if utils.IsEmptyByteSlice(to) {
+		newer, err = s.GetCurrentVersion(ctx, documentID)
+	} else {
+		newer, err = s.getVersion(ctx, documentID, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if utils.IsEmptyByteSlice(from) {
+		from = newer.PreviousVersion()
+		if utils.IsEmptyByteSlice(from) {
+			return nil, errors.NewTypedError(ErrDocumentVersionNotFound, errors.New("version %s has no previous version", hexutil.Encode(newer.CurrentVersion())))
+		}
+	}
+
+	older, err := s.getVersion(ctx, documentID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.isOlderVersion(ctx, newer, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, errors.NewTypedError(ErrDocumentVersionNotAncestor, errors.New("version %s is not older than version %s",
+			hexutil.Encode(from), hexutil.Encode(newer.CurrentVersion())))
+	}
+
+	return DiffVersions(older, newer)
+}
+
+// isOlderVersion returns true if the version is one of the previous versions of the document.
+func (s service) isOlderVersion(ctx context.Context, doc Document, version []byte) (bool, error) {
+	prev := doc.PreviousVersion()
+	for !utils.IsEmptyByteSlice(prev) {
+		if bytes.Equal(prev, version) {
+			return true, nil
+		}
+
+		pd, err := s.getVersion(ctx, doc.ID(), prev)
+		if err != nil {
+			// the chain cannot be followed past a version the account does not have
+			if errors.IsOfType(ErrDocumentVersionNotFound, err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		prev = pd.PreviousVersion()
+	}
+
+	return false, nil
+}
+
+// DiffVersions returns the changes between the older and the newer versions of a document.
+func DiffVersions(older, newer Document) (*Diff, error) {
+	d := &Diff{
+		DocumentID:      newer.ID(),
+		From:            older.CurrentVersion(),
+		To:              newer.CurrentVersion(),
+		Data:            []Change{},
+		Attributes:      []Change{},
+		Roles:           []Change{},
+		ReadRules:       []Change{},
+		TransitionRules: []Change{},
+	}
+
+	ot, err := older.DataTree()
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDataTree, err)
+	}
+
+	nt, err := newer.DataTree()
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDataTree, err)
+	}
+
+	for _, cf := range GetChangedFields(ot, nt) {
+		if c, ok := leafChange(cf.Name, cf); ok {
+			d.Data = append(d.Data, c)
+		}
+	}
+
+	err = diffCoreDocuments(older, newer, d)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Collaborators, err = collaboratorChanges(older, newer)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// diffCoreDocuments adds the changes of the attributes, roles and rules of the core documents of the versions to d.
+// Attributes and roles are compared as a whole when any of their leaves changed, rules leaf by leaf.
+func diffCoreDocuments(older, newer Document, d *Diff) error {
+	ocd, ot, err := coreDocumentTree(older)
+	if err != nil {
+		return err
+	}
+
+	ncd, nt, err := coreDocumentTree(newer)
+	if err != nil {
+		return err
+	}
+
+	attrs := make(map[string]bool)
+	roles := make(map[string]bool)
+	for _, cf := range GetChangedFields(ot, nt) {
+		name := strings.TrimPrefix(cf.Name, CDTreePrefix+".")
+		field, key := mapKey(name)
+		switch field {
+		case attributesField:
+			attrs[key] = true
+		case rolesField:
+			roles[key] = true
+		case readRulesField:
+			if c, ok := leafChange(name, cf); ok {
+				d.ReadRules = append(d.ReadRules, c)
+			}
+		case transitionRulesField:
+			if c, ok := leafChange(name, cf); ok {
+				d.TransitionRules = append(d.TransitionRules, c)
+			}
+		}
+	}
+
+	oa, err := attributeValues(older, attrs)
+	if err != nil {
+		return err
+	}
+
+	na, err := attributeValues(newer, attrs)
+	if err != nil {
+		return err
+	}
+
+	d.Attributes = diffFields(oa, na)
+	d.Roles = diffFields(roleValues(ocd, roles), roleValues(ncd, roles))
+	return nil
+}
+
+// coreDocumentTree returns the core document of the version and its generated precise-proofs tree.
+func coreDocumentTree(doc Document) (coredocumentpb.CoreDocument, *proofs.DocumentTree, error) {
+	cd, err := doc.PackCoreDocument()
+	if err != nil {
+		return cd, nil, errors.NewTypedError(ErrDocumentPackingCoreDocument, err)
+	}
+
+	ncd, err := NewCoreDocumentFromProtobuf(cd)
+	if err != nil {
+		return cd, nil, err
+	}
+
+	tree, err := ncd.coredocTree(doc.DocumentType())
+	return cd, tree, err
+}
+
+// mapKey splits a property name such as roles[0x01].collaborators[0] into the field and the key of the entry.
+func mapKey(name string) (field, key string) {
+	i := strings.Index(name, "[")
+	j := strings.Index(name, "]")
+	if i < 0 || j < i {
+		return name, ""
+	}
+
+	return name[:i], name[i+1 : j]
+}
+
+// leafChange returns the change of the leaf, or false if the leaf is empty in both versions.
+func leafChange(field string, cf ChangedField) (Change, bool) {
+	c := Change{Field: field, Old: leafValue(cf.Old), New: leafValue(cf.New)}
+	switch {
+	case len(cf.Old) == 0 && len(cf.New) == 0:
+		return c, false
+	case len(cf.Old) == 0:
+		c.Type = ChangeAdded
+	case len(cf.New) == 0:
+		c.Type = ChangeRemoved
+	default:
+		c.Type = ChangeModified
+	}
+
+	return c, true
+}
+
+// leafValue returns the value of the leaf as text if it is printable, hex encoded otherwise.
+func leafValue(v []byte) string {
+	if len(v) == 0 {
+		return ""
+	}
+
+	if utf8.Valid(v) && strings.IndexFunc(string(v), func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+		return string(v)
+	}
+
+	return hexutil.Encode(v)
+}
+
+// diffFields returns the changes between the fields sorted by the field name.
+func diffFields(older, newer map[string]string) []Change {
+	changes := []Change{}
+	for field, ov := range older {
+		nv, ok := newer[field]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Field: field, Type: ChangeRemoved, Old: ov})
+		case ov != nv:
+			changes = append(changes, Change{Field: field, Type: ChangeModified, Old: ov, New: nv})
+		}
+	}
+
+	for field, nv := range newer {
+		if _, ok := older[field]; !ok {
+			changes = append(changes, Change{Field: field, Type: ChangeAdded, New: nv})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+// attributeValues returns the decoded values of the attributes with the given keys keyed by their label.
+func attributeValues(doc Document, keys map[string]bool) (map[string]string, error) {
+	fields := make(map[string]string)
+	for _, attr := range doc.GetAttributes() {
+		if !keys[attr.Key.String()] {
+			continue
+		}
+
+		v, err := attr.Value.String()
+		if err != nil {
+			return nil, err
+		}
+
+		// the signature holds the identity only, so add the signed value
+		if attr.Value.Type == AttrSigned {
+			v = fmt.Sprintf("%s signed by %s", hexutil.Encode(attr.Value.Signed.Value), v)
+		}
+
+		fields[attr.KeyLabel] = fmt.Sprintf("%s(%s)", attr.Value.Type, v)
+	}
+
+	return fields, nil
+}
+
+// roleValues returns the collaborators of the roles with the given keys keyed by the role key.
+func roleValues(cd coredocumentpb.CoreDocument, keys map[string]bool) map[string]string {
+	fields := make(map[string]string)
+	for _, r := range cd.Roles {
+		key := hexutil.Encode(r.RoleKey)
+		if keys[key] {
+			fields[key] = joinHex(r.Collaborators)
+		}
+	}
+
+	return fields
+}
+
+// collaboratorChanges returns the changes of the access of the collaborators between the versions.
+func collaboratorChanges(older, newer Document) ([]Change, error) {
+	of, err := collaboratorFields(older)
+	if err != nil {
+		return nil, err
+	}
+
+	nf, err := collaboratorFields(newer)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffFields(of, nf), nil
+}
+
+func collaboratorFields(doc Document) (map[string]string, error) {
+	ca, err := doc.GetCollaborators()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	for access, dids := range map[string][]identity.DID{
+		"read":       ca.ReadCollaborators,
+		"read_write": ca.ReadWriteCollaborators,
+	} {
+		for _, did := range dids {
+			fields[did.String()] = access
+		}
+	}
+
+	return fields, nil
+}
+
+// collaboratorsChangedMessage returns the notification of the changes of the roles and the collaborators
+// between the versions, or nil if they are unchanged.
+func collaboratorsChangedMessage(older, newer Document) (*notification.Message, error) {
+	d := new(Diff)
+	err := diffCoreDocuments(older, newer, d)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Collaborators, err = collaboratorChanges(older, newer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d.Roles) == 0 && len(d.Collaborators) == 0 {
+		return nil, nil
+	}
+
+	return &notification.Message{
+		EventType:  notification.EventTypeCollaboratorsChanged,
+		RecordedAt: time.Now().UTC(),
+		CollaboratorsChanged: &notification.CollaboratorsChangedMessage{
+			DocumentID:        newer.ID(),
+			VersionID:         newer.CurrentVersion(),
+			PreviousVersionID: older.CurrentVersion(),
+			Roles:             accessChanges(d.Roles),
+			Collaborators:     accessChanges(d.Collaborators),
+		},
+	}, nil
+}
+
+func accessChanges(changes []Change) []notification.AccessChange {
+	var acs []notification.AccessChange
+	for _, c := range changes {
+		acs = append(acs, notification.AccessChange{
+			Key:  c.Field,
+			Type: string(c.Type),
+			Old:  c.Old,
+			New:  c.New,
+		})
+	}
+
+	return acs
+}
+
+// joinHex returns the sorted hex encoded values joined by comma.
+func joinHex(values [][]byte) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = hexutil.Encode(v)
+	}
+
+	sort.Strings(s)
+	return strings.Join(s, ",")
+}
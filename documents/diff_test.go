@@ -0,0 +1,115 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMapKey(t *testing.T) {
+	field, key := mapKey("roles[0x01].collaborators[0]")
+	assert.Equal(t, "roles", field)
+	assert.Equal(t, "0x01", key)
+
+	field, key = mapKey("current_version")
+	assert.Equal(t, "current_version", field)
+	assert.Empty(t, key)
+}
+
+func TestLeafChange(t *testing.T) {
+	// empty in both versions
+	_, ok := leafChange("a", ChangedField{Old: []byte{}})
+	assert.False(t, ok)
+
+	c, ok := leafChange("a", ChangedField{New: []byte("test")})
+	assert.True(t, ok)
+	assert.Equal(t, Change{Field: "a", Type: ChangeAdded, New: "test"}, c)
+
+	c, ok = leafChange("a", ChangedField{Old: []byte{1, 2}})
+	assert.True(t, ok)
+	assert.Equal(t, Change{Field: "a", Type: ChangeRemoved, Old: "0x0102"}, c)
+
+	c, ok = leafChange("a", ChangedField{Old: []byte("old"), New: []byte("new")})
+	assert.True(t, ok)
+	assert.Equal(t, Change{Field: "a", Type: ChangeModified, Old: "old", New: "new"}, c)
+}
+
+func TestDiffFields(t *testing.T) {
+	// no changes
+	assert.Equal(t, []Change{}, diffFields(nil, map[string]string{}))
+	assert.Empty(t, diffFields(map[string]string{"a": "1"}, map[string]string{"a": "1"}))
+
+	changes := diffFields(map[string]string{
+		"a": "1",
+		"b": "2",
+		"c": "3",
+	}, map[string]string{
+		"b": "2",
+		"c": "4",
+		"d": "5",
+	})
+	assert.Equal(t, []Change{
+		{Field: "a", Type: ChangeRemoved, Old: "1"},
+		{Field: "c", Type: ChangeModified, Old: "3", New: "4"},
+		{Field: "d", Type: ChangeAdded, New: "5"},
+	}, changes)
+}
+
+func TestCollaboratorsChangedMessage(t *testing.T) {
+	did1, did2 := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	role := utils.RandomSlice(32)
+	mockVersion := func(version []byte, roles []*coredocumentpb.Role, ca CollaboratorsAccess) *MockModel {
+		m := new(MockModel)
+		m.On("ID").Return([]byte{1})
+		m.On("CurrentVersion").Return(version)
+		m.On("DocumentType").Return("generic")
+		cd, err := newCoreDocument()
+		assert.NoError(t, err)
+		cd.Document.Roles = roles
+		// generates the salts of the roles
+		_, err = cd.coredocTree("generic")
+		assert.NoError(t, err)
+		m.On("PackCoreDocument").Return(cd.PackCoreDocument(nil), nil)
+		m.On("GetAttributes").Return(nil)
+		m.On("GetCollaborators", mock.Anything).Return(ca, nil)
+		return m
+	}
+
+	older := mockVersion([]byte{2}, []*coredocumentpb.Role{{RoleKey: role, Collaborators: [][]byte{did1[:]}}},
+		CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{did1}})
+
+	// unchanged
+	msg, err := collaboratorsChangedMessage(older, older)
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+
+	newer := mockVersion([]byte{3}, []*coredocumentpb.Role{{RoleKey: role, Collaborators: [][]byte{did1[:], did2[:]}}},
+		CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{did1}, ReadCollaborators: []identity.DID{did2}})
+	msg, err = collaboratorsChangedMessage(older, newer)
+	assert.NoError(t, err)
+	assert.Equal(t, notification.EventTypeCollaboratorsChanged, msg.EventType)
+	cc := msg.CollaboratorsChanged
+	assert.Equal(t, []byte{1}, []byte(cc.DocumentID))
+	assert.Equal(t, []byte{3}, []byte(cc.VersionID))
+	assert.Equal(t, []byte{2}, []byte(cc.PreviousVersionID))
+	assert.Equal(t, []notification.AccessChange{{
+		Key:  hexutil.Encode(role),
+		Type: string(ChangeModified),
+		Old:  joinHex([][]byte{did1[:]}),
+		New:  joinHex([][]byte{did1[:], did2[:]}),
+	}}, cc.Roles)
+	assert.Equal(t, []notification.AccessChange{{
+		Key:  did2.String(),
+		Type: string(ChangeAdded),
+		New:  "read",
+	}}, cc.Collaborators)
+}
@@ -8,6 +8,7 @@ import (
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/precise-proofs/proofs"
 	"github.com/ethereum/go-ethereum/common"
 	logging "github.com/ipfs/go-log"
 )
@@ -113,6 +114,9 @@ type Document interface {
 	// CollaboratorCanUpdate returns an error if indicated identity does not have the capacity to update the document.
 	CollaboratorCanUpdate(updated Document, collaborator identity.DID) error
 
+	// DataTree returns the generated precise-proofs tree of the document data.
+	DataTree() (*proofs.DocumentTree, error)
+
 	// IsDIDCollaborator returns true if the did is a collaborator of the document
 	IsDIDCollaborator(did identity.DID) (bool, error)
 
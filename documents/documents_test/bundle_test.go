@@ -0,0 +1,162 @@
+// +build unit
+
+package documents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/documenttypes"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBundleService(t *testing.T, repo documents.Repository, ar anchors.Service, idSrv identity.Service) documents.Service {
+	registry := documents.NewServiceRegistry()
+	srv := documents.DefaultService(cfg, repo, ar, registry, idSrv, nil)
+	gsrv := generic.DefaultService(srv, repo, ar)
+	assert.NoError(t, registry.Register(documenttypes.GenericDataTypeUrl, gsrv))
+	assert.NoError(t, registry.Register(generic.Scheme, gsrv))
+	return srv
+}
+
+func newGenericRepo(t *testing.T) documents.Repository {
+	ldb, err := leveldb.NewLevelDBStorage(t.TempDir())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, ldb.Close())
+	})
+
+	repo := documents.NewDBRepository(leveldb.NewLevelDBRepository(ldb))
+	repo.Register(new(generic.Generic))
+	return repo
+}
+
+// mockAnchors mocks the anchors of the versions and the missing anchor of the next version of the latest.
+func mockAnchors(t *testing.T, docs ...documents.Document) *anchors.MockAnchorService {
+	ar := new(anchors.MockAnchorService)
+	for _, doc := range docs {
+		aid, err := anchors.ToAnchorID(doc.CurrentVersion())
+		assert.NoError(t, err)
+		dr, err := doc.CalculateDocumentRoot()
+		assert.NoError(t, err)
+		root, err := anchors.ToDocumentRoot(dr)
+		assert.NoError(t, err)
+		ar.On("GetAnchorData", aid).Return(root, nil)
+	}
+
+	nextAid, err := anchors.ToAnchorID(docs[len(docs)-1].NextVersion())
+	assert.NoError(t, err)
+	ar.On("GetAnchorData", nextAid).Return(anchors.DocumentRoot{}, errors.New("missing"))
+	return ar
+}
+
+// createNextVersion stores the next version of the doc, signed by the account, with the status.
+func createNextVersion(t *testing.T, ctx context.Context, doc documents.Document, status documents.Status) documents.Document {
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	next, err := testRepo().Get(accountID, doc.CurrentVersion())
+	assert.NoError(t, err)
+	attr, err := documents.NewStringAttribute("label", documents.AttrString, hexutil.Encode(utils.RandomSlice(8)))
+	assert.NoError(t, err)
+	assert.NoError(t, next.AddAttributes(documents.CollaboratorsAccess{}, true, attr))
+	assert.NoError(t, next.AddUpdateLog(did))
+	sr, err := next.CalculateSigningRoot()
+	assert.NoError(t, err)
+	sig, err := acc.SignMsg(documents.ConsensusSignaturePayload(sr, false))
+	assert.NoError(t, err)
+	next.AppendSignatures(sig)
+	_, err = next.CalculateDocumentRoot()
+	assert.NoError(t, err)
+	assert.NoError(t, next.SetStatus(status))
+	assert.NoError(t, testRepo().Create(accountID, next.CurrentVersion(), next))
+	return next
+}
+
+func TestService_ExportImport(t *testing.T) {
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+
+	// first version
+	v1, _ := createCDWithEmbeddedDocument(t, ctxh, []identity.DID{testingidentity.GenerateRandomDID()}, false)
+
+	// second version
+	v2 := createNextVersion(t, ctxh, v1, documents.Committed)
+
+	// missing document
+	ar := mockAnchors(t, v1, v2)
+	idSrv := new(testingcommons.MockIdentityService)
+	srv := newBundleService(t, testRepo(), ar, idSrv)
+	_, err := srv.Export(ctxh, utils.RandomSlice(32))
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// export
+	b, err := srv.Export(ctxh, v1.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, documents.BundleFormatVersion, b.FormatVersion)
+	assert.Equal(t, v1.ID(), b.DocumentID.Bytes())
+	assert.Equal(t, generic.Scheme, b.Scheme)
+	assert.Len(t, b.Versions, 2)
+	assert.Equal(t, v1.CurrentVersion(), b.Versions[0].VersionID.Bytes())
+	assert.Equal(t, v2.CurrentVersion(), b.Versions[1].VersionID.Bytes())
+	assert.Equal(t, did[:], b.Signature.SignerID.Bytes())
+
+	// import into an empty node
+	repo := newGenericRepo(t)
+	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	srv = newBundleService(t, repo, ar, idSrv)
+	imported, err := srv.Import(ctxh, b)
+	assert.NoError(t, err)
+	assert.Equal(t, v2.CurrentVersion(), imported.CurrentVersion())
+	latest, err := srv.GetCurrentVersion(ctxh, v1.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, v2.CurrentVersion(), latest.CurrentVersion())
+	assert.Equal(t, documents.Committed, latest.GetStatus())
+	assert.True(t, repo.Exists(accountID, v1.CurrentVersion()))
+
+	// importing again is a no-op
+	_, err = srv.Import(ctxh, b)
+	assert.NoError(t, err)
+	ar.AssertExpectations(t)
+	idSrv.AssertExpectations(t)
+
+	// tampered bundle
+	b.Versions = b.Versions[1:]
+	idSrv = new(testingcommons.MockIdentityService)
+	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("invalid signature")).Once()
+	srv = newBundleService(t, repo, ar, idSrv)
+	_, err = srv.Import(ctxh, b)
+	assert.True(t, errors.IsOfType(documents.ErrBundleInvalid, err))
+
+	// missing first version
+	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	_, err = srv.Import(ctxh, b)
+	assert.True(t, errors.IsOfType(documents.ErrBundleInvalid, err))
+	assert.Contains(t, err.Error(), "first version of the document expected")
+
+	// anchored root mismatch
+	b, err = newBundleService(t, testRepo(), ar, idSrv).Export(ctxh, v1.ID())
+	assert.NoError(t, err)
+	ar = mockAnchors(t, v2)
+	aid, err := anchors.ToAnchorID(v1.CurrentVersion())
+	assert.NoError(t, err)
+	ar.On("GetAnchorData", aid).Return(anchors.DocumentRoot{}, nil)
+	repo = newGenericRepo(t)
+	srv = newBundleService(t, repo, ar, idSrv)
+	_, err = srv.Import(ctxh, b)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+	assert.Contains(t, err.Error(), "mismatched document roots")
+	assert.False(t, repo.Exists(accountID, v1.CurrentVersion()))
+}
@@ -0,0 +1,70 @@
+// +build unit
+
+package documents_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Diff(t *testing.T) {
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	v1, _ := createCDWithEmbeddedDocument(t, ctxh, []identity.DID{testingidentity.GenerateRandomDID()}, false)
+	v2 := createNextVersion(t, ctxh, v1, documents.Committed)
+	v3 := createNextVersion(t, ctxh, v2, documents.Committed)
+	srv := newBundleService(t, testRepo(), nil, new(testingcommons.MockIdentityService))
+
+	// missing document
+	_, err := srv.Diff(ctxh, utils.RandomSlice(32), nil, nil)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// missing version
+	_, err = srv.Diff(ctxh, v1.ID(), utils.RandomSlice(32), nil)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentVersionNotFound, err))
+
+	// first version has no previous version
+	_, err = srv.Diff(ctxh, v1.ID(), nil, v1.CurrentVersion())
+	assert.True(t, errors.IsOfType(documents.ErrDocumentVersionNotFound, err))
+
+	// from must be older than to
+	_, err = srv.Diff(ctxh, v1.ID(), v3.CurrentVersion(), v2.CurrentVersion())
+	assert.True(t, errors.IsOfType(documents.ErrDocumentVersionNotAncestor, err))
+	_, err = srv.Diff(ctxh, v1.ID(), v2.CurrentVersion(), v2.CurrentVersion())
+	assert.True(t, errors.IsOfType(documents.ErrDocumentVersionNotAncestor, err))
+
+	// current version against its previous version
+	diff, err := srv.Diff(ctxh, v1.ID(), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, v1.ID(), diff.DocumentID.Bytes())
+	assert.Equal(t, v2.CurrentVersion(), diff.From.Bytes())
+	assert.Equal(t, v3.CurrentVersion(), diff.To.Bytes())
+	assert.Len(t, diff.Attributes, 1)
+	assert.Equal(t, "label", diff.Attributes[0].Field)
+	assert.Equal(t, documents.ChangeModified, diff.Attributes[0].Type)
+	assert.Empty(t, diff.Data)
+	assert.Empty(t, diff.Collaborators)
+
+	// attribute added since the first version
+	diff, err = srv.Diff(ctxh, v1.ID(), v1.CurrentVersion(), v2.CurrentVersion())
+	assert.NoError(t, err)
+	assert.Len(t, diff.Attributes, 1)
+	assert.Equal(t, documents.ChangeAdded, diff.Attributes[0].Type)
+	assert.Empty(t, diff.Attributes[0].Old)
+	assert.Contains(t, diff.Attributes[0].New, "string(")
+
+	// versions further apart
+	diff, err = srv.Diff(ctxh, v1.ID(), v1.CurrentVersion(), v3.CurrentVersion())
+	assert.NoError(t, err)
+	assert.Len(t, diff.Attributes, 1)
+	assert.Equal(t, documents.ChangeAdded, diff.Attributes[0].Type)
+	assert.Empty(t, diff.Attributes[0].Old)
+	assert.Contains(t, diff.Attributes[0].New, "string(")
+}
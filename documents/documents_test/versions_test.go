@@ -0,0 +1,69 @@
+// +build unit
+
+package documents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Versions(t *testing.T) {
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	v1, _ := createCDWithEmbeddedDocument(t, ctxh, []identity.DID{testingidentity.GenerateRandomDID()}, false)
+	v2 := createNextVersion(t, ctxh, v1, documents.Committed)
+	v3 := createNextVersion(t, ctxh, v2, documents.Committing)
+
+	// v3 is not anchored yet
+	ar := mockAnchors(t, v1, v2)
+	srv := newBundleService(t, testRepo(), ar, new(testingcommons.MockIdentityService))
+
+	// missing account
+	_, err := srv.Versions(context.Background(), v1.ID())
+	assert.True(t, errors.IsOfType(documents.ErrDocumentConfigAccountID, err))
+
+	// missing document
+	_, err = srv.Versions(ctxh, utils.RandomSlice(32))
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	versions, err := srv.Versions(ctxh, v1.ID())
+	assert.NoError(t, err)
+	assert.Len(t, versions, 3)
+	for i, doc := range []documents.Document{v1, v2, v3} {
+		v := versions[i]
+		assert.Equal(t, doc.CurrentVersion(), v.VersionID)
+		assert.Equal(t, doc.PreviousVersion(), v.PreviousVersion)
+		assert.Equal(t, did, *v.Author)
+		assert.False(t, v.Timestamp.IsZero())
+		assert.Equal(t, len(doc.Signatures()), v.Signatures)
+	}
+
+	assert.Equal(t, documents.Committed, versions[1].Status)
+	assert.True(t, versions[1].Anchored)
+	assert.Equal(t, documents.Committing, versions[2].Status)
+	assert.False(t, versions[2].Anchored)
+	assert.Empty(t, versions[0].PreviousVersion)
+	ar.AssertExpectations(t)
+
+	// missing first version
+	aid, err := anchors.ToAnchorID(v1.CurrentVersion())
+	assert.NoError(t, err)
+	repo := newGenericRepo(t)
+	for _, doc := range []documents.Document{v2, v3} {
+		assert.NoError(t, repo.Create(accountID, doc.CurrentVersion(), doc))
+	}
+	srv = newBundleService(t, repo, ar, nil)
+	versions, err = srv.Versions(ctxh, v1.ID())
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, aid[:], versions[0].PreviousVersion)
+}
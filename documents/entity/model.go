@@ -224,6 +224,11 @@ func (e *Entity) getRawDataTree() (*proofs.DocumentTree, error) {
 	return t, nil
 }
 
+// DataTree returns the generated precise-proofs tree of the document data.
+func (e *Entity) DataTree() (*proofs.DocumentTree, error) {
+	return e.getDocumentDataTree()
+}
+
 // getDocumentDataTree creates precise-proofs data tree for the model
 func (e *Entity) getDocumentDataTree() (tree *proofs.DocumentTree, err error) {
 	eProto := e.createP2PProtobuf()
@@ -27,6 +27,9 @@ const (
 
 	// ErrEntityRelationshipUpdate is a sentinel error for update failure.
 	ErrEntityRelationshipUpdate = errors.Error("Entity relationship doesn't support updates.")
+
+	// EntityIdentifierIndex is the name of the storage index on the entity identifier of the relationship.
+	EntityIdentifierIndex = "entity_relationship_entity_identifier"
 )
 
 // tree prefixes for specific documents use the second byte of a 4 byte slice by convention
@@ -130,6 +133,11 @@ func (e *EntityRelationship) Type() reflect.Type {
 	return reflect.TypeOf(e)
 }
 
+// Indexes returns the indexed fields of the EntityRelationship.
+func (e *EntityRelationship) Indexes() map[string][]byte {
+	return map[string][]byte{EntityIdentifierIndex: e.Data.EntityIdentifier}
+}
+
 func (e *EntityRelationship) getDataLeaves() ([]proofs.LeafNode, error) {
 	t, err := e.getRawDataTree()
 	if err != nil {
@@ -154,6 +162,21 @@ func (e *EntityRelationship) getRawDataTree() (*proofs.DocumentTree, error) {
 	return t, nil
 }
 
+// DataTree returns the generated precise-proofs tree of the document data.
+func (e *EntityRelationship) DataTree() (*proofs.DocumentTree, error) {
+	t, err := e.getRawDataTree()
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.Generate()
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDataTree, err)
+	}
+
+	return t, nil
+}
+
 // CreateNFTProofs is not implemented for EntityRelationship.
 func (e *EntityRelationship) CreateNFTProofs(identity.DID, common.Address, []byte, bool, bool) (prf *documents.DocumentProof, err error) {
 	return nil, documents.ErrNotImplemented
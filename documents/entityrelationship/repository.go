@@ -32,23 +32,46 @@ func newDBRepository(db storage.Repository, docRepo documents.Repository) reposi
 	return r
 }
 
-// FindEntityRelationshipIdentifier returns the identifier of an EntityRelationship based on a entity id and a targetDID
-func (r *repo) FindEntityRelationshipIdentifier(entityIdentifier []byte, ownerDID, targetDID identity.DID) ([]byte, error) {
-	relationships, err := r.db.GetAllByPrefix(documents.DocPrefix + hexutil.Encode(ownerDID[:]))
+// getRelationships returns the relationships, owned by ownerDID, of the entity.
+// Relationships are looked up through the entity identifier index.
+func (r *repo) getRelationships(entityIdentifier []byte, ownerDID identity.DID) ([]*EntityRelationship, error) {
+	keys, err := r.db.GetKeysByIndex(EntityIdentifierIndex, entityIdentifier)
 	if err != nil {
 		return nil, err
 	}
 
-	if relationships == nil {
-		return nil, documents.ErrDocumentNotFound
-	}
+	prefix := []byte(documents.DocPrefix + hexutil.Encode(ownerDID[:]))
+	var relationships []*EntityRelationship
+	for _, key := range keys {
+		if !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+
+		m, err := r.db.Get(key)
+		if err != nil {
+			continue
+		}
 
-	for _, r := range relationships {
-		e, ok := r.(*EntityRelationship)
+		e, ok := m.(*EntityRelationship)
 		if !ok {
 			continue
 		}
-		if bytes.Equal(e.Data.EntityIdentifier, entityIdentifier) && targetDID.Equal(*e.Data.TargetIdentity) {
+
+		relationships = append(relationships, e)
+	}
+
+	return relationships, nil
+}
+
+// FindEntityRelationshipIdentifier returns the identifier of an EntityRelationship based on a entity id and a targetDID
+func (r *repo) FindEntityRelationshipIdentifier(entityIdentifier []byte, ownerDID, targetDID identity.DID) ([]byte, error) {
+	relationships, err := r.getRelationships(entityIdentifier, ownerDID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range relationships {
+		if targetDID.Equal(*e.Data.TargetIdentity) {
 			return e.ID(), nil
 		}
 	}
@@ -57,30 +80,15 @@ func (r *repo) FindEntityRelationshipIdentifier(entityIdentifier []byte, ownerDI
 
 // ListAllRelationships returns a list of all entity relationship identifiers in which a given entity is involved
 func (r *repo) ListAllRelationships(entityIdentifier []byte, ownerDID identity.DID) (map[string][]byte, error) {
-	allDocuments, err := r.db.GetAllByPrefix(documents.DocPrefix + hexutil.Encode(ownerDID[:]))
+	relationships, err := r.getRelationships(entityIdentifier, ownerDID)
 	if err != nil {
 		return nil, err
 	}
 
-	if allDocuments == nil {
-		return map[string][]byte{}, nil
-	}
-
-	relationships := make(map[string][]byte)
-	for _, r := range allDocuments {
-		e, ok := r.(*EntityRelationship)
-		if !ok {
-			continue
-		}
-		_, found := relationships[string(e.Document.DocumentIdentifier)]
-		if bytes.Equal(e.Data.EntityIdentifier, entityIdentifier) && !found {
-			relationships[string(e.Document.DocumentIdentifier)] = e.Document.DocumentIdentifier
-		}
+	ids := make(map[string][]byte)
+	for _, e := range relationships {
+		ids[string(e.Document.DocumentIdentifier)] = e.Document.DocumentIdentifier
 	}
 
-	if len(relationships) == 0 {
-		return map[string][]byte{}, nil
-	}
-
-	return relationships, nil
+	return ids, nil
 }
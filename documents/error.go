@@ -38,6 +38,9 @@ const (
 	// ErrDocumentVersionNotFound must be used to indicate that the specified version of the document for provided id is not found in the system
 	ErrDocumentVersionNotFound = errors.Error("specified version of the document not found in the system database")
 
+	// ErrDocumentVersionNotAncestor must be used when a version is compared against a version that is not older than it
+	ErrDocumentVersionNotAncestor = errors.Error("specified version is not an older version of the document")
+
 	// ErrDocumentPersistence must be used when creating or updating a document in the system database failed
 	ErrDocumentPersistence = errors.Error("error encountered when storing document in the system database")
 
@@ -56,6 +59,9 @@ const (
 	// ErrNotPatcher must be used if an expected patcher model does not support patching
 	ErrNotPatcher = errors.Error("document doesn't support patching")
 
+	// ErrBundleInvalid must be used when an imported bundle is malformed or its signature is invalid
+	ErrBundleInvalid = errors.Error("document bundle is invalid")
+
 	// Coredoc errors
 
 	// ErrCDCreate must be used for coredoc creation/generation errors
@@ -109,6 +109,11 @@ func (g *Generic) getRawDataTree() (*proofs.DocumentTree, error) {
 	return t, nil
 }
 
+// DataTree returns the generated precise-proofs tree of the document data.
+func (g *Generic) DataTree() (*proofs.DocumentTree, error) {
+	return g.getDocumentDataTree()
+}
+
 // getDocumentDataTree creates precise-proofs data tree for the model
 func (g *Generic) getDocumentDataTree() (tree *proofs.DocumentTree, err error) {
 	if g.CoreDocument == nil {
@@ -0,0 +1,179 @@
+package documents
+
+import (
+	"sort"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// DefaultListLimit is the number of documents returned per page when no limit is provided.
+	DefaultListLimit = 50
+
+	// MaxListLimit is the maximum number of documents returned per page.
+	MaxListLimit = 500
+)
+
+// ListFilter holds the criteria to list the documents owned by an account.
+// Empty criteria are ignored.
+type ListFilter struct {
+	// Schemes filters documents by their scheme. Ex: generic, entity, entity_relationship
+	Schemes []string
+
+	// Statuses filters documents by their status. Only Pending and Committed are listed.
+	Statuses []Status
+
+	// Author filters documents by the author of the version.
+	Author *identity.DID
+
+	// Collaborator filters documents where the DID is a read or write collaborator.
+	Collaborator *identity.DID
+
+	// AttributeKey filters documents which contain the attribute.
+	AttributeKey *AttrKey
+
+	// AttributeValue filters documents where the string value of the attribute with AttributeKey matches.
+	// Ignored if AttributeKey is not set.
+	AttributeValue string
+
+	// From filters documents with timestamp after or equal to From.
+	From time.Time
+
+	// To filters documents with timestamp before or equal to To.
+	To time.Time
+
+	// Cursor is the cursor returned with the previous page.
+	Cursor string
+
+	// Limit is the maximum number of documents to be returned.
+	Limit int
+}
+
+// ListResult holds a page of the listed documents.
+type ListResult struct {
+	Documents []Document
+
+	// NextCursor is the cursor to fetch the next page. Empty if this is the last page.
+	NextCursor string
+}
+
+// HasStatus returns true if the documents with status st are allowed by the filter.
+func (f ListFilter) HasStatus(st Status) bool {
+	if len(f.Statuses) == 0 {
+		return st == Pending || st == Committed
+	}
+
+	for _, s := range f.Statuses {
+		if s == st {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match returns true if the document satisfies all the criteria of the filter.
+// Cursor and Limit are not considered here.
+func (f ListFilter) Match(doc Document) bool {
+	if !f.HasStatus(doc.GetStatus()) {
+		return false
+	}
+
+	if len(f.Schemes) > 0 {
+		var found bool
+		for _, s := range f.Schemes {
+			if s == doc.Scheme() {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if f.Author != nil {
+		author, err := doc.Author()
+		if err != nil || !author.Equal(*f.Author) {
+			return false
+		}
+	}
+
+	if f.Collaborator != nil {
+		ok, err := doc.IsDIDCollaborator(*f.Collaborator)
+		if err != nil || !ok {
+			return false
+		}
+	}
+
+	if f.AttributeKey != nil {
+		attr, err := doc.GetAttribute(*f.AttributeKey)
+		if err != nil {
+			return false
+		}
+
+		if f.AttributeValue != "" {
+			val, err := attr.Value.String()
+			if err != nil || val != f.AttributeValue {
+				return false
+			}
+		}
+	}
+
+	if !f.From.IsZero() || !f.To.IsZero() {
+		ts, err := doc.Timestamp()
+		if err != nil {
+			return false
+		}
+
+		if !f.From.IsZero() && ts.Before(f.From) {
+			return false
+		}
+
+		if !f.To.IsZero() && ts.After(f.To) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListCursor returns the cursor of the document in a list.
+// A document can be both pending and committed, so the status is part of the cursor.
+func ListCursor(doc Document) string {
+	return hexutil.Encode(doc.ID()) + ":" + string(doc.GetStatus())
+}
+
+// Paginate sorts the docs by their cursor and returns the page of documents following filter.Cursor.
+func Paginate(docs []Document, filter ListFilter) ListResult {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		return ListCursor(docs[i]) < ListCursor(docs[j])
+	})
+
+	start := sort.Search(len(docs), func(i int) bool {
+		return ListCursor(docs[i]) > filter.Cursor
+	})
+
+	var res ListResult
+	end := start + limit
+	if end < len(docs) {
+		res.NextCursor = ListCursor(docs[end-1])
+	} else {
+		end = len(docs)
+	}
+
+	res.Documents = docs[start:end]
+	return res
+}
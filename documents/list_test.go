@@ -0,0 +1,103 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+	"time"
+
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListFilter_HasStatus(t *testing.T) {
+	var f ListFilter
+	assert.True(t, f.HasStatus(Pending))
+	assert.True(t, f.HasStatus(Committed))
+	assert.False(t, f.HasStatus(Committing))
+
+	f.Statuses = []Status{Pending}
+	assert.True(t, f.HasStatus(Pending))
+	assert.False(t, f.HasStatus(Committed))
+}
+
+func TestListFilter_Match(t *testing.T) {
+	author := testingidentity.GenerateRandomDID()
+	collab := testingidentity.GenerateRandomDID()
+	tm := time.Now().UTC()
+	attr, err := NewStringAttribute("label", AttrString, "value")
+	assert.NoError(t, err)
+
+	m := new(MockModel)
+	m.On("GetStatus").Return(Committed)
+	m.On("Scheme").Return("generic")
+	m.On("Author").Return(author, nil)
+	m.On("IsDIDCollaborator", collab).Return(true, nil)
+	m.On("IsDIDCollaborator", author).Return(false, nil)
+	m.On("GetAttribute", attr.Key).Return(attr, nil)
+	m.On("Timestamp").Return(tm, nil)
+
+	// empty filter
+	assert.True(t, ListFilter{}.Match(m))
+
+	// status
+	assert.False(t, ListFilter{Statuses: []Status{Pending}}.Match(m))
+
+	// scheme
+	assert.True(t, ListFilter{Schemes: []string{"entity", "generic"}}.Match(m))
+	assert.False(t, ListFilter{Schemes: []string{"entity"}}.Match(m))
+
+	// author
+	assert.True(t, ListFilter{Author: &author}.Match(m))
+	assert.False(t, ListFilter{Author: &collab}.Match(m))
+
+	// collaborator
+	assert.True(t, ListFilter{Collaborator: &collab}.Match(m))
+	assert.False(t, ListFilter{Collaborator: &author}.Match(m))
+
+	// attribute
+	assert.True(t, ListFilter{AttributeKey: &attr.Key}.Match(m))
+	assert.True(t, ListFilter{AttributeKey: &attr.Key, AttributeValue: "value"}.Match(m))
+	assert.False(t, ListFilter{AttributeKey: &attr.Key, AttributeValue: "other"}.Match(m))
+
+	// timestamp
+	assert.True(t, ListFilter{From: tm.Add(-time.Hour), To: tm.Add(time.Hour)}.Match(m))
+	assert.False(t, ListFilter{From: tm.Add(time.Hour)}.Match(m))
+	assert.False(t, ListFilter{To: tm.Add(-time.Hour)}.Match(m))
+	m.AssertExpectations(t)
+}
+
+func TestPaginate(t *testing.T) {
+	var docs []Document
+	for i := 0; i < 5; i++ {
+		m := new(MockModel)
+		m.On("ID").Return(utils.RandomSlice(32))
+		m.On("GetStatus").Return(Committed)
+		docs = append(docs, m)
+	}
+
+	// all in one page
+	res := Paginate(docs, ListFilter{})
+	assert.Len(t, res.Documents, 5)
+	assert.Empty(t, res.NextCursor)
+
+	// pages of two
+	var got []Document
+	filter := ListFilter{Limit: 2}
+	for {
+		res = Paginate(docs, filter)
+		got = append(got, res.Documents...)
+		if res.NextCursor == "" {
+			break
+		}
+
+		assert.Len(t, res.Documents, 2)
+		filter.Cursor = res.NextCursor
+	}
+
+	assert.Len(t, got, 5)
+	for i := 1; i < len(got); i++ {
+		assert.True(t, ListCursor(got[i-1]) < ListCursor(got[i]))
+	}
+}
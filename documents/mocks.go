@@ -149,6 +149,36 @@ func (m *MockService) New(scheme string) (Document, error) {
 	return doc, args.Error(1)
 }
 
+func (m *MockService) List(ctx context.Context, filter ListFilter) ([]Document, error) {
+	args := m.Called(ctx, filter)
+	docs, _ := args.Get(0).([]Document)
+	return docs, args.Error(1)
+}
+
+func (m *MockService) Export(ctx context.Context, documentID []byte) (*Bundle, error) {
+	args := m.Called(ctx, documentID)
+	b, _ := args.Get(0).(*Bundle)
+	return b, args.Error(1)
+}
+
+func (m *MockService) Import(ctx context.Context, bundle *Bundle) (Document, error) {
+	args := m.Called(ctx, bundle)
+	doc, _ := args.Get(0).(Document)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) Versions(ctx context.Context, documentID []byte) ([]VersionInfo, error) {
+	args := m.Called(ctx, documentID)
+	versions, _ := args.Get(0).([]VersionInfo)
+	return versions, args.Error(1)
+}
+
+func (m *MockService) Diff(ctx context.Context, documentID, from, to []byte) (*Diff, error) {
+	args := m.Called(ctx, documentID, from, to)
+	d, _ := args.Get(0).(*Diff)
+	return d, args.Error(1)
+}
+
 func (m *MockModel) ID() []byte {
 	args := m.Called()
 	id, _ := args.Get(0).([]byte)
@@ -179,6 +209,11 @@ func (m *MockModel) GetCollaborators(filterIDs ...identity.DID) (CollaboratorsAc
 	return cas, args.Error(1)
 }
 
+func (m *MockModel) DocumentType() string {
+	args := m.Called()
+	return args.String(0)
+}
+
 func (m *MockModel) GetAttributes() []Attribute {
 	args := m.Called()
 	attrs, _ := args.Get(0).([]Attribute)
@@ -284,6 +319,12 @@ func (m *MockRepository) GetLatest(accountID, docID []byte) (Document, error) {
 	return doc, args.Error(1)
 }
 
+func (m *MockRepository) List(accountID []byte, filter ListFilter) ([]Document, error) {
+	args := m.Called(accountID, filter)
+	docs, _ := args.Get(0).([]Document)
+	return docs, args.Error(1)
+}
+
 func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
 	if _, ok := context[storage.BootstrappedDB]; !ok {
 		return errors.New("initializing LevelDB repository failed")
@@ -2,6 +2,7 @@ package documents
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
@@ -141,7 +142,7 @@ func (dp defaultProcessor) RequestSignatures(ctx context.Context, model Document
 	// we ignore signature collection errors and anchor anyways
 	signs, _, err := dp.p2pClient.GetSignaturesForDocument(ctx, model)
 	if err != nil {
-		return errors.New("failed to collect signatures from the collaborators: %v", err)
+		return fmt.Errorf("failed to collect signatures from the collaborators: %w", err)
 	}
 
 	model.AppendSignatures(signs...)
@@ -179,7 +180,7 @@ func (dp defaultProcessor) PreAnchorDocument(ctx context.Context, model Document
 	log.Infof("Pre-anchoring document with identifiers: [document: %#x, current: %#x, next: %#x], signingRoot: %#x", model.ID(), model.CurrentVersion(), model.NextVersion(), sRoot)
 	err = dp.anchorSrv.PreCommitAnchor(ctx, anchorID, sRoot)
 	if err != nil {
-		return errors.New("failed to pre-commit anchor: %v", err)
+		return fmt.Errorf("failed to pre-commit anchor: %w", err)
 	}
 
 	log.Infof("Pre-anchored document with identifiers: [document: %#x, current: %#x, next: %#x], signingRoot: %#x", model.ID(), model.CurrentVersion(), model.NextVersion(), sRoot)
@@ -222,7 +223,7 @@ func (dp defaultProcessor) AnchorDocument(ctx context.Context, model Document) e
 	log.Infof("Anchoring document with identifiers: [document: %#x, current: %#x, next: %#x], rootHash: %#x", model.ID(), model.CurrentVersion(), model.NextVersion(), dr)
 	err = dp.anchorSrv.CommitAnchor(ctx, anchorIDPreimage, rootHash, signaturesRootHash)
 	if err != nil {
-		return errors.New("failed to commit anchor: %v", err)
+		return fmt.Errorf("failed to commit anchor: %w", err)
 	}
 
 	log.Infof("Anchored document with identifiers: [document: %#x, current: %#x, next: %#x], rootHash: %#x", model.ID(), model.CurrentVersion(), model.NextVersion(), dr)
@@ -270,6 +271,11 @@ func (dp defaultProcessor) SendDocument(ctx context.Context, model Document) err
 	}
 
 	for _, c := range cs {
+		// the job stops sending once the attempt times out
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		err := dp.Send(ctx, cd, c)
 		if err != nil {
 			log.Error(err)
@@ -15,6 +15,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/crypto"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
@@ -308,13 +309,14 @@ func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	model.sigs = append(model.sigs, sig)
 	c = new(p2pClient)
 	srv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	c.On("GetSignaturesForDocument", ctxh, model).Return(nil, errors.New("failed to get signatures")).Once()
+	c.On("GetSignaturesForDocument", ctxh, model).Return(nil, jobs.Transient(errors.New("failed to get signatures"))).Once()
 	dp.p2pClient = c
 	err = dp.RequestSignatures(ctxh, model)
 	model.AssertExpectations(t)
 	c.AssertExpectations(t)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get signatures")
+	assert.True(t, jobs.IsTransient(err))
 
 	// success
 	model = new(mockModel)
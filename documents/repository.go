@@ -68,6 +68,9 @@ type Repository interface {
 
 	// GetLatest returns the latest version of the document.
 	GetLatest(accountID, docID []byte) (Document, error)
+
+	// List returns the latest version of the documents, owned by accountID, that match the filter.
+	List(accountID []byte, filter ListFilter) ([]Document, error)
 }
 
 // NewDBRepository creates an instance of the documents Repository
@@ -113,30 +116,36 @@ func (r *repo) Get(accountID, id []byte) (Document, error) {
 
 // Create creates the model if not present in the DB.
 // should error out if the document exists.
+// The document and its latest version index are written atomically.
 func (r *repo) Create(accountID, id []byte, model Document) error {
 	key := r.getKey(accountID, id)
-	if err := r.db.Create(key, model); err != nil {
-		return err
-	}
+	return storage.RunTransaction(r.db, func(tx storage.Transaction) error {
+		if err := tx.Create(key, model); err != nil {
+			return err
+		}
 
-	return r.updateLatestIndex(accountID, model)
+		return r.updateLatestIndex(tx, accountID, model)
+	})
 }
 
 // Update strictly updates the model.
 // Will error out when the model doesn't exist in the DB.
+// The document and its latest version index are written atomically.
 func (r *repo) Update(accountID, id []byte, model Document) error {
 	key := r.getKey(accountID, id)
-	if err := r.db.Update(key, model); err != nil {
-		return err
-	}
+	return storage.RunTransaction(r.db, func(tx storage.Transaction) error {
+		if err := tx.Update(key, model); err != nil {
+			return err
+		}
 
-	return r.updateLatestIndex(accountID, model)
+		return r.updateLatestIndex(tx, accountID, model)
+	})
 }
 
 // GetLatest returns thee latest version of the document.
 func (r *repo) GetLatest(accountID, docID []byte) (Document, error) {
 	key := r.getLatestKey(accountID, docID)
-	lv, err := r.getLatest(key)
+	lv, err := r.getLatest(r.db, key)
 	if err != nil {
 		return nil, err
 	}
@@ -144,8 +153,42 @@ func (r *repo) GetLatest(accountID, docID []byte) (Document, error) {
 	return r.Get(accountID, lv.CurrentVersion)
 }
 
-func (r *repo) getLatest(key []byte) (*latestVersion, error) {
-	val, err := r.db.Get(key)
+// List returns the latest version of the documents, owned by accountID, that match the filter.
+// Documents are looked up through the latest version index, so only committed documents are listed.
+func (r *repo) List(accountID []byte, filter ListFilter) ([]Document, error) {
+	if !filter.HasStatus(Committed) {
+		return nil, nil
+	}
+
+	prefix := LatestPrefix + hexutil.Encode(accountID)
+	models, err := r.db.GetAllByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []Document
+	for _, m := range models {
+		lv, ok := m.(*latestVersion)
+		if !ok {
+			continue
+		}
+
+		doc, err := r.Get(accountID, lv.CurrentVersion)
+		if err != nil {
+			log.Warnf("failed to fetch latest version %s: %v", hexutil.Encode(lv.CurrentVersion), err)
+			continue
+		}
+
+		if filter.Match(doc) {
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs, nil
+}
+
+func (r *repo) getLatest(db storage.Store, key []byte) (*latestVersion, error) {
+	val, err := db.Get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +199,7 @@ func (r *repo) getLatest(key []byte) (*latestVersion, error) {
 	}
 
 	// delete key val if the type mismatches
-	err = r.db.Delete(key)
+	err = db.Delete(key)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +217,7 @@ func (r *repo) getLatestKey(accountID, docID []byte) []byte {
 // storeLatestIndex stores the latestVersion to db.
 // If update is true, it is assumed that index is overwritten
 // else, index is created first time.
-func (r *repo) storeLatestIndex(key []byte, model Document, update bool) error {
+func (r *repo) storeLatestIndex(db storage.Store, key []byte, model Document, update bool) error {
 	lv := &latestVersion{
 		CurrentVersion: model.CurrentVersion(),
 		NextVersion:    model.NextVersion(),
@@ -188,10 +231,10 @@ func (r *repo) storeLatestIndex(key []byte, model Document, update bool) error {
 	lv.Timestamp = tm
 
 	if update {
-		return r.db.Update(key, lv)
+		return db.Update(key, lv)
 	}
 
-	return r.db.Create(key, lv)
+	return db.Create(key, lv)
 }
 
 // updateLatestIndex updates the latest version index.
@@ -203,20 +246,20 @@ func (r *repo) storeLatestIndex(key []byte, model Document, update bool) error {
 // If not matches, check the model timestamp is greater than stored timestamp.
 // If greater update the latestVersion and return
 // If not, skip update and return.
-func (r *repo) updateLatestIndex(accID []byte, model Document) error {
+func (r *repo) updateLatestIndex(db storage.Store, accID []byte, model Document) error {
 	if model.GetStatus() != Committed {
 		return nil
 	}
 
 	key := r.getLatestKey(accID, model.ID())
-	lv, err := r.getLatest(key)
+	lv, err := r.getLatest(db, key)
 	if err != nil {
 		// no index is created yet. create one
-		return r.storeLatestIndex(key, model, false)
+		return r.storeLatestIndex(db, key, model, false)
 	}
 
 	if bytes.Equal(lv.NextVersion, model.CurrentVersion()) {
-		return r.storeLatestIndex(key, model, true)
+		return r.storeLatestIndex(db, key, model, true)
 	}
 
 	// compare timestamps
@@ -227,7 +270,7 @@ func (r *repo) updateLatestIndex(accID []byte, model Document) error {
 
 	if lv.Timestamp.Before(ts) {
 		// newer version found. so update
-		return r.storeLatestIndex(key, model, true)
+		return r.storeLatestIndex(db, key, model, true)
 	}
 
 	// must be an old version.
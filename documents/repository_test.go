@@ -207,10 +207,10 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 		status:  Committed,
 	}
 	assert.False(t, rr.db.Exists(rr.getLatestKey(acc, id)))
-	err := rr.updateLatestIndex(acc, d)
+	err := rr.updateLatestIndex(rr.db, acc, d)
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
-	lv, err := rr.getLatest(rr.getLatestKey(acc, id))
+	lv, err := rr.getLatest(rr.db, rr.getLatestKey(acc, id))
 	assert.NoError(t, err)
 	assert.Equal(t, &latestVersion{
 		CurrentVersion: id,
@@ -222,10 +222,10 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	d.Current = next
 	d.Next = utils.RandomSlice(32)
 	d.Time = time.Now().UTC()
-	err = rr.updateLatestIndex(acc, d)
+	err = rr.updateLatestIndex(rr.db, acc, d)
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
-	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
+	lv, err = rr.getLatest(rr.db, rr.getLatestKey(acc, id))
 	assert.NoError(t, err)
 	assert.Equal(t, &latestVersion{
 		CurrentVersion: next,
@@ -239,10 +239,10 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	tm = time.Now().UTC()
 	assert.False(t, d.Time.Equal(tm))
 	d.Time = tm
-	err = rr.updateLatestIndex(acc, d)
+	err = rr.updateLatestIndex(rr.db, acc, d)
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
-	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
+	lv, err = rr.getLatest(rr.db, rr.getLatestKey(acc, id))
 	assert.NoError(t, err)
 	assert.Equal(t, &latestVersion{
 		CurrentVersion: d.Current,
@@ -256,10 +256,10 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	oldN := d.Next
 	d.Current = utils.RandomSlice(32)
 	d.Next = utils.RandomSlice(32)
-	err = rr.updateLatestIndex(acc, d)
+	err = rr.updateLatestIndex(rr.db, acc, d)
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
-	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
+	lv, err = rr.getLatest(rr.db, rr.getLatestKey(acc, id))
 	assert.NoError(t, err)
 	assert.Equal(t, &latestVersion{
 		CurrentVersion: oldC,
@@ -267,3 +267,61 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 		NextVersion:    oldN,
 	}, lv)
 }
+
+// statusDoc persists the status along with the doc.
+type statusDoc struct {
+	doc
+	Status Status
+}
+
+func (m *statusDoc) JSON() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *statusDoc) FromJSON(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (m *statusDoc) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
+
+func (m *statusDoc) GetStatus() Status {
+	return m.Status
+}
+
+func TestRepo_List(t *testing.T) {
+	r := getRepository(ctx)
+	r.Register(new(statusDoc))
+	acc := utils.RandomSlice(20)
+
+	// no documents
+	docs, err := r.List(acc, ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 0)
+
+	// committed document is listed with latest version
+	id := utils.RandomSlice(32)
+	d := &statusDoc{doc: doc{DocID: id, Current: id, Next: utils.RandomSlice(32), Time: time.Now().UTC()}, Status: Committed}
+	assert.NoError(t, r.Create(acc, id, d))
+	nd := &statusDoc{doc: doc{DocID: id, Current: d.Next, Next: utils.RandomSlice(32), Time: time.Now().UTC()}, Status: Committed}
+	assert.NoError(t, r.Create(acc, nd.Current, nd))
+
+	// committing document is not listed
+	cid := utils.RandomSlice(32)
+	assert.NoError(t, r.Create(acc, cid, &statusDoc{doc: doc{DocID: cid, Current: cid}, Status: Committing}))
+
+	// other account
+	oacc := utils.RandomSlice(20)
+	assert.NoError(t, r.Create(oacc, cid, &statusDoc{doc: doc{DocID: cid, Current: cid}, Status: Committed}))
+
+	docs, err = r.List(acc, ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, nd.Current, docs[0].CurrentVersion())
+
+	// pending only
+	docs, err = r.List(acc, ListFilter{Statuses: []Status{Pending}})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 0)
+}
@@ -3,6 +3,7 @@ package documents
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
@@ -14,6 +15,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/centrifuge/gocelery/v2"
 	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
 	"github.com/ethereum/go-ethereum/common"
@@ -80,6 +82,23 @@ type Service interface {
 
 	// New returns a new uninitialised document.
 	New(scheme string) (Document, error)
+
+	// List returns the latest committed version of the documents, owned by the account, that match the filter.
+	List(ctx context.Context, filter ListFilter) ([]Document, error)
+
+	// Export returns the signed bundle of the committed document, owned by the account, with all its versions.
+	Export(ctx context.Context, documentID []byte) (*Bundle, error)
+
+	// Import validates the bundle and stores the versions of the document for the account.
+	// Returns the latest version of the document.
+	Import(ctx context.Context, bundle *Bundle) (Document, error)
+
+	// Versions returns the versions of the document, owned by the account, ordered from the oldest to the newest.
+	Versions(ctx context.Context, documentID []byte) ([]VersionInfo, error)
+
+	// Diff returns the changes between the versions from and to of the document owned by the account.
+	// If to is empty, the current version is used. If from is empty, the previous version of to is used.
+	Diff(ctx context.Context, documentID, from, to []byte) (*Diff, error)
 }
 
 // service implements Service
@@ -275,16 +294,28 @@ func (s service) ReceiveAnchoredDocument(ctx context.Context, doc Document, coll
 		Document: &notification.DocumentMessage{
 			ID:        doc.ID(),
 			VersionID: doc.CurrentVersion(),
+			Scheme:    doc.Scheme(),
 			From:      collaborator[:],
 			To:        did[:],
 		},
 	}
 
+	msgs := []notification.Message{notificationMsg}
+	if old != nil {
+		msg, err := collaboratorsChangedMessage(old, doc)
+		if err != nil {
+			log.Errorf("failed to compare the collaborators of the versions: %v", err)
+		} else if msg != nil {
+			msgs = append(msgs, *msg)
+		}
+	}
+
 	// async so that we don't return an error as the p2p reply
 	go func() {
-		err = s.notifier.Send(ctx, notificationMsg)
-		if err != nil {
-			log.Error(err)
+		for _, msg := range msgs {
+			if err := s.notifier.Send(ctx, msg); err != nil {
+				log.Error(err)
+			}
 		}
 	}()
 
@@ -451,3 +482,52 @@ func (s service) New(scheme string) (Document, error) {
 
 	return srv.New(scheme)
 }
+
+// List returns the latest committed version of the documents, owned by the account, that match the filter.
+func (s service) List(ctx context.Context, filter ListFilter) ([]Document, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	return s.repo.List(acc.GetIdentityID(), filter)
+}
+
+// RecomputeDocumentAction is the scheduled action committing a new version of the document, unchanged but for
+// its compute fields, which are re-evaluated when the version is anchored.
+// The params of the schedule are {"document_id": "0x..."}.
+const RecomputeDocumentAction = "recompute_document"
+
+type recomputeDocumentParams struct {
+	DocumentID byteutils.HexBytes `json:"document_id"`
+}
+
+// recomputeDocument returns the scheduled func committing a new version of the document of the params.
+func recomputeDocument(srv Service) jobs.ScheduledFunc {
+	return func(ctx context.Context, params json.RawMessage) (gocelery.JobID, error) {
+		var p recomputeDocumentParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		old, err := srv.GetCurrentVersion(ctx, p.DocumentID)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(old.GetData())
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := srv.Derive(ctx, UpdatePayload{
+			DocumentID:    p.DocumentID,
+			CreatePayload: CreatePayload{Scheme: old.Scheme(), Data: data},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return srv.Commit(ctx, doc)
+	}
+}
@@ -511,14 +511,24 @@ func PreAnchorValidator(idService identity.Service, anchorSrv anchors.Service) V
 }
 
 // PostAnchoredValidator is a validator group with following validators
-// PreAnchorValidator
-// anchoredValidator
+// AnchoredVersionValidator
+// LatestVersionValidator
 // should be called after anchoring the document/when received anchored document
 func PostAnchoredValidator(idService identity.Service, anchorSrv anchors.Service) ValidatorGroup {
+	return ValidatorGroup{
+		AnchoredVersionValidator(idService, anchorSrv),
+		LatestVersionValidator(anchorSrv),
+	}
+}
+
+// AnchoredVersionValidator is a validator group with following validators
+// PreAnchorValidator
+// anchoredValidator
+// should be called on the anchored versions of a document which are not the latest
+func AnchoredVersionValidator(idService identity.Service, anchorSrv anchors.Service) ValidatorGroup {
 	return ValidatorGroup{
 		PreAnchorValidator(idService, anchorSrv),
 		anchoredValidator(anchorSrv),
-		LatestVersionValidator(anchorSrv),
 	}
 }
 
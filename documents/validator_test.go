@@ -657,7 +657,8 @@ func TestValidator_anchoredValidator(t *testing.T) {
 
 func TestPostAnchoredValidator(t *testing.T) {
 	pav := PostAnchoredValidator(nil, nil)
-	assert.Len(t, pav, 3)
+	assert.Len(t, pav, 2)
+	assert.Len(t, AnchoredVersionValidator(nil, nil), 2)
 }
 
 func TestDocumentAuthorValidator(t *testing.T) {
@@ -0,0 +1,100 @@
+package documents
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+)
+
+// VersionInfo describes a version of a document.
+type VersionInfo struct {
+	VersionID       []byte
+	PreviousVersion []byte
+
+	// Author is nil if the version has no author.
+	Author    *identity.DID
+	Timestamp time.Time
+	Status    Status
+
+	// Anchored is true if the version is anchored on chain at AnchoredAt.
+	Anchored   bool
+	AnchoredAt time.Time
+	Signatures int
+}
+
+// Versions returns the versions of the document, owned by the account, ordered from the oldest to the newest.
+// The versions are found by walking the chain of versions from the latest committed version.
+// The walk stops at the first version missing in the repository, so the oldest version may have a PreviousVersion.
+func (s service) Versions(ctx context.Context, documentID []byte) ([]VersionInfo, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	accID := acc.GetIdentityID()
+	latest, err := s.repo.GetLatest(accID, documentID)
+	if err != nil {
+		// the document is not committed yet
+		latest, err = s.repo.Get(accID, documentID)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentNotFound, err)
+		}
+	}
+
+	versions := []VersionInfo{s.versionInfo(latest)}
+	for doc := latest; !utils.IsEmptyByteSlice(doc.PreviousVersion()); {
+		doc, err = s.repo.Get(accID, doc.PreviousVersion())
+		if err != nil {
+			break
+		}
+
+		versions = append([]VersionInfo{s.versionInfo(doc)}, versions...)
+	}
+
+	// versions after the latest committed version which are being committed or failed to commit
+	for doc := latest; s.repo.Exists(accID, doc.NextVersion()); {
+		doc, err = s.repo.Get(accID, doc.NextVersion())
+		if err != nil {
+			break
+		}
+
+		versions = append(versions, s.versionInfo(doc))
+	}
+
+	return versions, nil
+}
+
+func (s service) versionInfo(doc Document) VersionInfo {
+	v := VersionInfo{
+		VersionID:       doc.CurrentVersion(),
+		PreviousVersion: doc.PreviousVersion(),
+		Status:          doc.GetStatus(),
+		Signatures:      len(doc.Signatures()),
+	}
+
+	if author, err := doc.Author(); err == nil {
+		v.Author = &author
+	}
+
+	if tm, err := doc.Timestamp(); err == nil {
+		v.Timestamp = tm
+	}
+
+	anchorID, err := anchors.ToAnchorID(doc.CurrentVersion())
+	if err != nil {
+		return v
+	}
+
+	// the version is not anchored if the anchor data is missing
+	if _, anchoredAt, err := s.anchorSrv.GetAnchorData(anchorID); err == nil {
+		v.Anchored = true
+		v.AnchoredAt = anchoredAt
+	}
+
+	return v
+}
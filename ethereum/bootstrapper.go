@@ -1,6 +1,7 @@
 package ethereum
 
 import (
+	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/config"
 )
 
@@ -17,7 +18,13 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return err
 	}
 
-	client, err := NewGethClient(cfg)
+	// the simulator is a setting of the file config only
+	newClient := NewGethClient
+	if fcfg, ok := ctx[bootstrap.BootstrappedConfig].(config.Configuration); ok && fcfg.IsEthereumSimulatorEnabled() {
+		newClient = NewSimulatedClient
+	}
+
+	client, err := newClient(cfg)
 	if err != nil {
 		return err
 	}
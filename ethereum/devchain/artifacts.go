@@ -0,0 +1,109 @@
+package devchain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// identityFactoryArtifact is the truffle build of the identity factory, relative to the contracts dir.
+	identityFactoryArtifact = "centrifuge-ethereum-contracts/build/contracts/IdentityFactory.json"
+
+	// nftArtifactsDir holds the dapp build of the AssetNFT registry, relative to the contracts dir.
+	nftArtifactsDir = "privacy-enabled-erc721/out"
+
+	// oracleArtifactsDir holds the dapp build of the NFTUpdate and NFTOracle contracts, relative to the contracts dir.
+	oracleArtifactsDir = "oracle-contracts/out"
+)
+
+// artifact is a compiled contract ready to be deployed.
+type artifact struct {
+	name string
+	abi  abi.ABI
+	bin  []byte
+}
+
+// truffleArtifact is the subset of the truffle build output used for deployment.
+type truffleArtifact struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bytecode     string          `json:"bytecode"`
+}
+
+// loadTruffleArtifact loads the contract compiled by truffle at path.
+func loadTruffleArtifact(path string) (artifact, error) {
+	data, err := readArtifact(path)
+	if err != nil {
+		return artifact{}, err
+	}
+
+	var ta truffleArtifact
+	err = json.Unmarshal(data, &ta)
+	if err != nil {
+		return artifact{}, errors.NewTypedError(ErrInvalidArtifact, errors.New("%s: %v", path, err))
+	}
+
+	return newArtifact(ta.ContractName, string(ta.ABI), ta.Bytecode)
+}
+
+// loadDappArtifact loads the contract compiled by dapp into dir with the given name.
+func loadDappArtifact(dir, name string) (artifact, error) {
+	abiJSON, err := readArtifact(filepath.Join(dir, name+".abi"))
+	if err != nil {
+		return artifact{}, err
+	}
+
+	bin, err := readArtifact(filepath.Join(dir, name+".bin"))
+	if err != nil {
+		return artifact{}, err
+	}
+
+	return newArtifact(name, string(abiJSON), string(bin))
+}
+
+func readArtifact(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NewTypedError(ErrArtifactNotFound, errors.New("%s", path))
+	}
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidArtifact, errors.New("%s: %v", path, err))
+	}
+
+	return data, nil
+}
+
+func newArtifact(name, abiJSON, bytecode string) (artifact, error) {
+	cabi, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return artifact{}, errors.NewTypedError(ErrInvalidArtifact, errors.New("%s abi: %v", name, err))
+	}
+
+	bytecode = strings.TrimSpace(bytecode)
+	if !strings.HasPrefix(bytecode, "0x") {
+		bytecode = "0x" + bytecode
+	}
+
+	// unlinked libraries are left as __<name>__ placeholders by the compilers
+	if strings.Contains(bytecode, "__") {
+		return artifact{}, errors.NewTypedError(ErrInvalidArtifact, errors.New("%s bytecode has unlinked libraries", name))
+	}
+
+	bin, err := hexutil.Decode(bytecode)
+	if err != nil {
+		return artifact{}, errors.NewTypedError(ErrInvalidArtifact, errors.New("%s bytecode: %v", name, err))
+	}
+
+	if len(bin) == 0 {
+		return artifact{}, errors.NewTypedError(ErrInvalidArtifact, errors.New("%s bytecode is empty", name))
+	}
+
+	return artifact{name: name, abi: cabi, bin: bin}, nil
+}
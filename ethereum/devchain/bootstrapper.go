@@ -0,0 +1,126 @@
+package devchain
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/ethereum/go-ethereum/common"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("devchain")
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+// It deploys the identity factory, the node identity, the NFT registry and the oracle contracts
+// on the simulated Ethereum chain and points the config to them.
+// Bootstrapper must run after the ethereum bootstrapper and before any bootstrapper that binds the contracts.
+type Bootstrapper struct{}
+
+// Bootstrap deploys the contracts if the Ethereum simulator is enabled.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	// the deployed addresses are set on the file config, before the config is stored
+	cfg, ok := ctx[bootstrap.BootstrappedConfig].(config.Configuration)
+	if !ok {
+		return errors.NewTypedError(config.ErrConfigBootstrap, errors.New("could not find the bootstrapped config"))
+	}
+
+	if !cfg.IsEthereumSimulatorEnabled() {
+		return nil
+	}
+
+	client, ok := ctx[ethereum.BootstrappedEthereumClient].(ethereum.Client)
+	if !ok {
+		return errors.New("ethereum client not initialised")
+	}
+
+	d := deployer{client: client, account: cfg.GetEthereumDefaultAccountName()}
+	return deployContracts(cfg, d)
+}
+
+// deployContracts deploys the contracts from the compiled artifacts in the contracts dir.
+// All the artifacts are loaded before any deployment, the dev chain is of no use without the NFT registry and the oracle.
+func deployContracts(cfg config.Configuration, d deployer) error {
+	dir := cfg.GetEthereumSimulatorContractsDir()
+	factoryArt, err := loadTruffleArtifact(filepath.Join(dir, identityFactoryArtifact))
+	if err != nil {
+		return err
+	}
+
+	nftArt, err := loadDappArtifact(filepath.Join(dir, nftArtifactsDir), "AssetNFT")
+	if err != nil {
+		return err
+	}
+
+	updateArt, err := loadDappArtifact(filepath.Join(dir, oracleArtifactsDir), "NFTUpdate")
+	if err != nil {
+		return err
+	}
+
+	oracleArt, err := loadDappArtifact(filepath.Join(dir, oracleArtifactsDir), "NFTOracle")
+	if err != nil {
+		return err
+	}
+
+	factoryAddr, err := d.deploy(factoryArt)
+	if err != nil {
+		return err
+	}
+	setContractAddress(cfg, config.IdentityFactory, factoryAddr)
+
+	did, err := createNodeIdentity(cfg, d, factoryAddr)
+	if err != nil {
+		return err
+	}
+	setContractAddress(cfg, config.Identity, did.ToAddress())
+	cfg.Set("identityId", did.String())
+
+	// no asset manager on the dev chain
+	registry, err := d.deploy(nftArt, common.Address{}, factoryAddr)
+	if err != nil {
+		return err
+	}
+	setContractAddress(cfg, config.NFTRegistry, registry)
+
+	update, err := d.deploy(updateArt)
+	if err != nil {
+		return err
+	}
+
+	// the node identity is the ward of the oracle, the fingerprint is not bound to any document
+	oracle, err := d.deploy(oracleArt, update, registry, [32]byte{}, []common.Address{did.ToAddress()})
+	if err != nil {
+		return err
+	}
+	setContractAddress(cfg, config.NFTOracle, oracle)
+	return nil
+}
+
+// createNodeIdentity creates the identity of the node with the keys of the default account.
+func createNodeIdentity(cfg config.Configuration, d deployer, factoryAddr common.Address) (did identity.DID, err error) {
+	acc, err := configstore.TempAccount(cfg.GetEthereumDefaultAccountName(), cfg)
+	if err != nil {
+		return did, err
+	}
+
+	accKeys, err := acc.GetKeys()
+	if err != nil {
+		return did, errors.New("failed to fetch keys from the account: %v", err)
+	}
+
+	keys, err := identity.ConvertAccountKeysToKeyDID(accKeys)
+	if err != nil {
+		return did, errors.New("failed to convert keys: %v", err)
+	}
+
+	return d.createIdentity(factoryAddr, keys)
+}
+
+func setContractAddress(cfg config.Configuration, name config.ContractName, addr common.Address) {
+	cfg.Set(cfg.GetNetworkKey(fmt.Sprintf("contractAddresses.%s", name)), addr.Hex())
+}
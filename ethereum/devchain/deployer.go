@@ -0,0 +1,96 @@
+package devchain
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/identity/ideth"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// deployTimeout is the timeout to fetch the receipt of a deployment.
+const deployTimeout = 15 * time.Second
+
+// deployer deploys contracts and sends transactions from an ethereum account.
+// Transactions are expected to be mined as soon as they are sent, as on the simulated chain.
+type deployer struct {
+	client  ethereum.Client
+	account string
+}
+
+// deploy deploys the contract with the constructor params and returns its address.
+func (d deployer) deploy(art artifact, params ...interface{}) (common.Address, error) {
+	opts, err := d.client.GetTxOpts(context.Background(), d.account)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	create := func(opts *bind.TransactOpts, params ...interface{}) (*types.Transaction, error) {
+		_, tx, _, err := bind.DeployContract(opts, art.abi, art.bin, d.client.GetEthClient(), params...)
+		return tx, err
+	}
+
+	tx, err := d.client.SubmitTransactionWithRetries(create, opts, params...)
+	if err != nil {
+		return common.Address{}, errors.NewTypedError(ErrContractDeploy, errors.New("%s: %v", art.name, err))
+	}
+
+	addr, err := d.wait(tx)
+	if err != nil {
+		return common.Address{}, errors.NewTypedError(ErrContractDeploy, errors.New("%s: %v", art.name, err))
+	}
+
+	log.Infof("Deployed %s at %s", art.name, addr.Hex())
+	return addr, nil
+}
+
+// createIdentity creates an identity managed by the account through the factory with the given keys.
+func (d deployer) createIdentity(factoryAddr common.Address, keys []identity.Key) (did identity.DID, err error) {
+	factory, err := ideth.NewFactoryContract(factoryAddr, d.client.GetEthClient())
+	if err != nil {
+		return did, err
+	}
+
+	nonce, err := d.client.GetEthClient().PendingNonceAt(context.Background(), factoryAddr)
+	if err != nil {
+		return did, errors.New("failed to fetch identity factory nonce: %v", err)
+	}
+	did = identity.NewDID(ideth.CalculateCreatedAddress(factoryAddr, nonce))
+
+	opts, err := d.client.GetTxOpts(context.Background(), d.account)
+	if err != nil {
+		return did, err
+	}
+
+	var ethKeys [][32]byte
+	var purposes []*big.Int
+	for _, k := range keys {
+		ethKeys = append(ethKeys, k.GetKey())
+		purposes = append(purposes, k.GetPurpose())
+	}
+
+	tx, err := d.client.SubmitTransactionWithRetries(factory.CreateIdentityFor, opts, opts.From, ethKeys, purposes)
+	if err != nil {
+		return did, errors.New("failed to create identity: %v", err)
+	}
+
+	_, err = d.wait(tx)
+	if err != nil {
+		return did, errors.New("failed to create identity: %v", err)
+	}
+
+	log.Infof("Created identity %s", did.String())
+	return did, nil
+}
+
+func (d deployer) wait(tx *types.Transaction) (common.Address, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), deployTimeout)
+	defer cancel()
+	return ethereum.IsTxnSuccessful(ctx, d.client.GetEthClient(), tx.Hash())
+}
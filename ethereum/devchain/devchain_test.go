@@ -0,0 +1,135 @@
+// +build unit
+
+package devchain
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBytecode deploys a contract with the single byte 0x00 as code.
+const testBytecode = "600060005360016000f3"
+
+var cfg config.Configuration
+
+func TestMain(m *testing.M) {
+	ctx := map[string]interface{}{}
+	ibootstappers := []bootstrap.TestBootstrapper{
+		&testlogging.TestLoggingBootstrapper{},
+		&config.Bootstrapper{},
+	}
+	bootstrap.RunTestBootstrappers(ibootstappers, ctx)
+	cfg = ctx[bootstrap.BootstrappedConfig].(config.Configuration)
+	result := m.Run()
+	bootstrap.RunTestTeardown(ibootstappers)
+	os.Exit(result)
+}
+
+func writeFile(t *testing.T, path, data string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+}
+
+func TestLoadArtifacts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devchain")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// truffle
+	path := filepath.Join(dir, "Test.json")
+	_, err = loadTruffleArtifact(path)
+	assert.True(t, errors.IsOfType(ErrArtifactNotFound, err))
+	writeFile(t, path, `{"contractName": "Test", "abi": [], "bytecode": "0x__Lib__"}`)
+	_, err = loadTruffleArtifact(path)
+	assert.True(t, errors.IsOfType(ErrInvalidArtifact, err))
+	writeFile(t, path, `{"contractName": "Test", "abi": [], "bytecode": "0x`+testBytecode+`"}`)
+	art, err := loadTruffleArtifact(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", art.name)
+	assert.Len(t, art.bin, 10)
+
+	// dapp
+	_, err = loadDappArtifact(dir, "Test")
+	assert.True(t, errors.IsOfType(ErrArtifactNotFound, err))
+	writeFile(t, filepath.Join(dir, "Test.abi"), `[]`)
+	writeFile(t, filepath.Join(dir, "Test.bin"), "")
+	_, err = loadDappArtifact(dir, "Test")
+	assert.True(t, errors.IsOfType(ErrInvalidArtifact, err))
+	writeFile(t, filepath.Join(dir, "Test.bin"), testBytecode+"\n")
+	art, err = loadDappArtifact(dir, "Test")
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", art.name)
+	assert.Len(t, art.bin, 10)
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	client, err := ethereum.NewSimulatedClient(cfg)
+	assert.NoError(t, err)
+	art, err := newArtifact("Test", "[]", testBytecode)
+	assert.NoError(t, err)
+	d := deployer{client: client, account: cfg.GetEthereumDefaultAccountName()}
+	addr, err := d.deploy(art)
+	assert.NoError(t, err)
+	code, err := client.GetEthClient().CodeAt(context.Background(), addr, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0}, code)
+
+	// constructor params must match the abi
+	_, err = d.deploy(art, addr)
+	assert.True(t, errors.IsOfType(ErrContractDeploy, err))
+}
+
+func TestDeployContracts_MissingArtifacts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "devchain")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cfg.Set("ethereum.simulator.contractsDir", dir)
+	factory := cfg.GetContractAddress(config.IdentityFactory)
+	client, err := ethereum.NewSimulatedClient(cfg)
+	assert.NoError(t, err)
+	d := deployer{client: client, account: cfg.GetEthereumDefaultAccountName()}
+
+	// identity factory
+	err = deployContracts(cfg, d)
+	assert.True(t, errors.IsOfType(ErrArtifactNotFound, err))
+	writeFile(t, filepath.Join(dir, identityFactoryArtifact), `{"contractName": "IdentityFactory", "abi": [], "bytecode": "0x`+testBytecode+`"}`)
+
+	// NFT registry
+	err = deployContracts(cfg, d)
+	assert.True(t, errors.IsOfType(ErrArtifactNotFound, err))
+	writeFile(t, filepath.Join(dir, nftArtifactsDir, "AssetNFT.abi"), `[]`)
+	writeFile(t, filepath.Join(dir, nftArtifactsDir, "AssetNFT.bin"), testBytecode)
+
+	// oracle
+	err = deployContracts(cfg, d)
+	assert.True(t, errors.IsOfType(ErrArtifactNotFound, err))
+	writeFile(t, filepath.Join(dir, oracleArtifactsDir, "NFTUpdate.abi"), `[]`)
+	writeFile(t, filepath.Join(dir, oracleArtifactsDir, "NFTUpdate.bin"), testBytecode)
+	err = deployContracts(cfg, d)
+	assert.True(t, errors.IsOfType(ErrArtifactNotFound, err))
+
+	// nothing is deployed unless all the artifacts are found
+	assert.Equal(t, factory, cfg.GetContractAddress(config.IdentityFactory))
+}
+
+func TestBootstrapper_Bootstrap(t *testing.T) {
+	assert.Error(t, Bootstrapper{}.Bootstrap(map[string]interface{}{}))
+
+	// no-op unless the simulator is enabled
+	ctx := map[string]interface{}{bootstrap.BootstrappedConfig: cfg}
+	assert.NoError(t, Bootstrapper{}.Bootstrap(ctx))
+
+	cfg.Set("ethereum.simulator.enabled", true)
+	defer cfg.Set("ethereum.simulator.enabled", false)
+	assert.Error(t, Bootstrapper{}.Bootstrap(ctx))
+}
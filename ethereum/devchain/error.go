@@ -0,0 +1,14 @@
+package devchain
+
+import "github.com/centrifuge/go-centrifuge/errors"
+
+const (
+	// ErrArtifactNotFound is returned when the compiled contract is missing from the contracts dir.
+	ErrArtifactNotFound = errors.Error("compiled contract not found")
+
+	// ErrInvalidArtifact is returned when the compiled contract cannot be decoded.
+	ErrInvalidArtifact = errors.Error("invalid compiled contract")
+
+	// ErrContractDeploy is returned when a contract deployment fails.
+	ErrContractDeploy = errors.Error("failed to deploy contract")
+)
@@ -13,7 +13,9 @@ import (
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -39,6 +41,7 @@ type Config interface {
 	GetEthereumMaxGasPrice() *big.Int
 	GetEthereumNodeURL() string
 	GetEthereumAccount(accountName string) (account *config.AccountConfig, err error)
+	GetEthereumDefaultAccountName() string
 	GetEthereumIntervalRetry() time.Duration
 	GetEthereumMaxRetries() int
 	GetEthereumContextReadWaitTimeout() time.Duration
@@ -71,8 +74,12 @@ type Client interface {
 	GetBlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
 
 	// GetTxOpts returns a cached options if available else creates and returns new options
+	// The account in ctx, if any, is carried in the context of the options.
 	GetTxOpts(ctx context.Context, accountName string) (*bind.TransactOpts, error)
 
+	// GetOptimalGasPrice returns the suggested gas price times the gas multiplier, capped by the max gas price.
+	GetOptimalGasPrice(ctx context.Context) (*big.Int, error)
+
 	// SubmitTransactionWithRetries submits transaction to the ethereum chain
 	// Blocking Function that sends transaction using reflection wrapped in a retrial block. It is based on the ErrTransactionUnderpriced error,
 	// meaning that a transaction is being attempted to run twice, and the logic is to override the existing one. As we have constant
@@ -163,11 +170,16 @@ func (gc *gethClient) defaultReadContext() (ctx context.Context, cancelFunc cont
 }
 
 // GetTxOpts returns a cached options if available else creates and returns new options
-func (gc *gethClient) GetTxOpts(_ context.Context, accountName string) (opts *bind.TransactOpts, err error) {
+func (gc *gethClient) GetTxOpts(actx context.Context, accountName string) (opts *bind.TransactOpts, err error) {
 	gc.accMu.Lock()
 	defer gc.accMu.Unlock()
 
+	// keep the account only, the options outlive the caller's context
 	ctx := context.Background()
+	if acc, err := contextutil.Account(actx); err == nil {
+		ctx = contextutil.WithAccount(ctx, acc)
+	}
+
 	if opts, ok := gc.accounts[accountName]; ok {
 		return gc.copyOpts(ctx, opts)
 	}
@@ -187,7 +199,7 @@ func (gc *gethClient) copyOpts(ctx context.Context, original *bind.TransactOpts)
 		From:   original.From,
 		Signer: original.Signer,
 	}
-	nOpts.GasPrice, err = gc.getOptimalGasPrice(ctx) // use oracle
+	nOpts.GasPrice, err = gc.GetOptimalGasPrice(ctx) // use oracle
 	if err != nil {
 		return nil, errors.NewTypedError(ErrEthTransaction, errors.New("failed to create new transaction opts: %v", err))
 	}
@@ -256,9 +268,9 @@ func (gc *gethClient) getGethTxOpts(accountName string) (*bind.TransactOpts, err
 	return opts, nil
 }
 
-// getOptimalGasPrice get the optimal current gas price from eth client
-func (gc *gethClient) getOptimalGasPrice(ctx context.Context) (*big.Int, error) {
-	// we don't acquire the exclusive lock since this method must only be called by other public methods that has the lock
+// GetOptimalGasPrice get the optimal current gas price from eth client
+func (gc *gethClient) GetOptimalGasPrice(ctx context.Context) (*big.Int, error) {
+	// no lock is needed since the client and the config are only read
 	suggested, err := gc.client.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, err
@@ -325,7 +337,9 @@ func (gc *gethClient) SubmitTransactionWithRetries(contractMethod interface{}, o
 		return nil, err
 	}
 
-	return nil, errors.NewTypedError(ErrEthTransaction, errors.New("max concurrent transaction tries reached: %v", err))
+	// the nonces are taken by the concurrent transactions, which may be done on retry
+	return nil, jobs.Transient(
+		errors.NewTypedError(ErrEthTransaction, errors.New("max concurrent transaction tries reached: %v", err)))
 }
 
 // GetGethCallOpts returns the Call options with default
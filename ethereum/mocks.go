@@ -45,6 +45,12 @@ func (m *MockEthClient) GetTxOpts(ctx context.Context, accountName string) (*bin
 	return args.Get(0).(*bind.TransactOpts), args.Error(1)
 }
 
+func (m *MockEthClient) GetOptimalGasPrice(ctx context.Context) (*big.Int, error) {
+	args := m.Called(ctx)
+	price, _ := args.Get(0).(*big.Int)
+	return price, args.Error(1)
+}
+
 func (m *MockEthClient) SubmitTransactionWithRetries(contractMethod interface{}, opts *bind.TransactOpts, params ...interface{}) (tx *types.Transaction, err error) {
 	args := m.Called(contractMethod, opts, params)
 	return args.Get(0).(*types.Transaction), args.Error(1)
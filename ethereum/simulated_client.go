@@ -0,0 +1,85 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"net/url"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// simulatedGasLimit is the block gas limit of the simulated chain, large enough to deploy any of the contracts.
+	simulatedGasLimit = 12500000
+
+	// simulatedHost is the node URL reported by the simulated client.
+	simulatedHost = "simulated://ethereum"
+)
+
+// simulatedBalance is the balance in wei the default account is funded with on the simulated chain.
+var simulatedBalance = new(big.Int).Mul(big.NewInt(1000000), big.NewInt(1e18))
+
+// simulatedBackend wraps the go-ethereum simulated backend to implement EthClient.
+// Every transaction is mined in a block of its own as soon as it is sent.
+type simulatedBackend struct {
+	*backends.SimulatedBackend
+}
+
+// SyncProgress returns nil since the simulated chain is always in sync.
+func (b simulatedBackend) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return nil, nil
+}
+
+// SendTransaction sends the transaction and mines a block with it.
+func (b simulatedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	err := b.SimulatedBackend.SendTransaction(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	b.Commit()
+	return nil
+}
+
+// NewSimulatedClient returns a Client backed by an in-process simulated chain.
+// The default account is funded at genesis so that it can deploy contracts and send transactions.
+func NewSimulatedClient(config Config) (Client, error) {
+	name := config.GetEthereumDefaultAccountName()
+	acc, err := config.GetEthereumAccount(name)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Key == "" {
+		return nil, ErrEthKeyNotProvided
+	}
+
+	key, err := keystore.DecryptKey([]byte(acc.Key), acc.Password)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrEthTransaction, errors.New("failed to decrypt %s account key: %v", name, err))
+	}
+
+	u, err := url.Parse(simulatedHost)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrEthURL, err)
+	}
+
+	log.Warnf("Running against a simulated Ethereum chain, %s account %s", name, key.Address.Hex())
+	sb := backends.NewSimulatedBackend(core.GenesisAlloc{
+		key.Address: {Balance: simulatedBalance},
+	}, simulatedGasLimit)
+	return &gethClient{
+		client:   simulatedBackend{SimulatedBackend: sb},
+		host:     u,
+		accounts: make(map[string]*bind.TransactOpts),
+		txMu:     sync.Mutex{},
+		config:   config,
+		accMu:    sync.Mutex{},
+	}, nil
+}
@@ -0,0 +1,45 @@
+// +build unit
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSimulatedClient(t *testing.T) {
+	client, err := NewSimulatedClient(cfg)
+	assert.NoError(t, err)
+	c := context.Background()
+	progress, err := client.GetEthClient().SyncProgress(c)
+	assert.NoError(t, err)
+	assert.Nil(t, progress)
+
+	// default account is funded at genesis
+	opts, err := client.GetTxOpts(c, cfg.GetEthereumDefaultAccountName())
+	assert.NoError(t, err)
+	balance, err := client.GetEthClient().(simulatedBackend).BalanceAt(c, opts.From, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, simulatedBalance, balance)
+
+	// transactions are mined as soon as they are sent
+	to := common.BytesToAddress(utils.RandomSlice(common.AddressLength))
+	opts.Value = big.NewInt(1e18)
+	opts.GasLimit = 21000
+	tx, err := client.SubmitTransactionWithRetries(BindContract(to, abi.ABI{}, client).Transfer, opts)
+	assert.NoError(t, err)
+	_, err = IsTxnSuccessful(c, client.GetEthClient(), tx.Hash())
+	assert.NoError(t, err)
+	balance, err = client.GetEthClient().(simulatedBackend).BalanceAt(c, to, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, opts.Value, balance)
+	blk, err := client.GetBlockByNumber(c, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), blk.NumberU64())
+}
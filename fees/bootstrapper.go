@@ -0,0 +1,34 @@
+package fees
+
+import (
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// BootstrappedFeeService is used as a key to map the configured fee service through context.
+const BootstrappedFeeService string = "BootstrappedFeeService"
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap initialises the fee service, and replaces the centchain and ethereum clients in the context with
+// clients charging the fees to the budgets of the accounts. Must run before the bootstrappers using the clients.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	cfg, err := config.RetrieveConfig(false, ctx)
+	if err != nil {
+		return err
+	}
+
+	repo := ctx[storage.BootstrappedDB].(storage.Repository)
+	centAPI := ctx[centchain.BootstrappedCentChainClient].(centchain.API)
+	client := ctx[ethereum.BootstrappedEthereumClient].(ethereum.Client)
+	srv := newService(repo, cfg, centAPI, client)
+	ctx[BootstrappedFeeService] = srv
+	ctx[centchain.BootstrappedCentChainClient] = centChainAPI{API: centAPI, budgets: srv}
+	client = ethClient{Client: client, budgets: srv}
+	ethereum.SetClient(client)
+	ctx[ethereum.BootstrappedEthereumClient] = client
+	return nil
+}
@@ -0,0 +1,184 @@
+package fees
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	budgetPrefix = "fee_budget_"
+
+	dayLayout = "2006-01-02"
+)
+
+// Budget is the daily fee budget of an account.
+type Budget struct {
+	AccountID identity.DID `json:"account_id"`
+
+	// Limits are the fees the account can spend a day by chain. The fees on chains without a limit aren't capped.
+	Limits map[Chain]*big.Int `json:"limits"`
+
+	// Day is the UTC day the fees were spent on.
+	Day string `json:"day"`
+
+	// Spent are the fees spent on the Day by chain.
+	Spent map[Chain]*big.Int `json:"spent"`
+}
+
+// JSON marshals Budget to json bytes.
+func (b *Budget) JSON() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+// FromJSON loads json bytes to Budget.
+func (b *Budget) FromJSON(data []byte) error {
+	return json.Unmarshal(data, b)
+}
+
+// Type returns the type of Budget.
+func (b *Budget) Type() reflect.Type {
+	return reflect.TypeOf(b)
+}
+
+// Remaining returns the fees the account can still spend today on the chain.
+// Returns false if the fees on the chain aren't capped.
+func (b Budget) Remaining(chain Chain) (*big.Int, bool) {
+	limit, ok := b.Limits[chain]
+	if !ok {
+		return nil, false
+	}
+
+	remaining := new(big.Int).Sub(limit, b.spent(chain))
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+
+	return remaining, true
+}
+
+func (b Budget) spent(chain Chain) *big.Int {
+	if spent, ok := b.Spent[chain]; ok {
+		return spent
+	}
+
+	return big.NewInt(0)
+}
+
+func budgetKey(accountID identity.DID) []byte {
+	return []byte(budgetPrefix + hexutil.Encode(accountID[:]))
+}
+
+func today() string {
+	return time.Now().UTC().Format(dayLayout)
+}
+
+// budget returns the budget of the account, with the fees spent before today dropped. Caller must hold the lock.
+func (s *service) budget(accountID identity.DID) (Budget, error) {
+	b := Budget{AccountID: accountID, Limits: make(map[Chain]*big.Int)}
+	key := budgetKey(accountID)
+	if s.repo.Exists(key) {
+		m, err := s.repo.Get(key)
+		if err != nil {
+			return b, err
+		}
+
+		b = *m.(*Budget)
+	}
+
+	if b.Day != today() {
+		b.Day = today()
+		b.Spent = make(map[Chain]*big.Int)
+	}
+
+	return b, nil
+}
+
+// save persists the budget of the account. Caller must hold the lock.
+func (s *service) save(b Budget) error {
+	key := budgetKey(b.AccountID)
+	if s.repo.Exists(key) {
+		return s.repo.Update(key, &b)
+	}
+
+	return s.repo.Create(key, &b)
+}
+
+func (s *service) GetBudget(accountID identity.DID) (Budget, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.budget(accountID)
+}
+
+func (s *service) SetLimits(accountID identity.DID, limits map[Chain]*big.Int) (Budget, error) {
+	for chain, limit := range limits {
+		if chain != ChainCentrifuge && chain != ChainEthereum {
+			return Budget{}, errors.NewTypedError(ErrInvalidBudget, errors.New("unknown chain %s", chain))
+		}
+
+		if limit == nil || limit.Sign() < 0 {
+			return Budget{}, errors.NewTypedError(ErrInvalidBudget, errors.New("invalid limit for chain %s", chain))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.budget(accountID)
+	if err != nil {
+		return b, err
+	}
+
+	b.Limits = limits
+	if b.Limits == nil {
+		b.Limits = make(map[Chain]*big.Int)
+	}
+
+	return b, s.save(b)
+}
+
+func (s *service) Reserve(accountID identity.DID, chain Chain, fee *big.Int) (Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.budget(accountID)
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	if remaining, ok := b.Remaining(chain); ok && fee.Cmp(remaining) > 0 {
+		return Reservation{}, errors.NewTypedError(ErrBudgetExceeded, errors.New(
+			"fee %s exceeds the remaining %s of the %s budget of account %s", fee, remaining, chain, accountID))
+	}
+
+	b.Spent[chain] = new(big.Int).Add(b.spent(chain), fee)
+	return Reservation{AccountID: accountID, Chain: chain, Fee: fee, Day: b.Day}, s.save(b)
+}
+
+func (s *service) Settle(r Reservation, fee *big.Int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.budget(r.AccountID)
+	if err != nil {
+		return err
+	}
+
+	if b.Day != r.Day {
+		return nil
+	}
+
+	spent := new(big.Int).Sub(b.spent(r.Chain), r.Fee)
+	if fee != nil {
+		spent.Add(spent, fee)
+	}
+
+	if spent.Sign() < 0 {
+		spent.SetInt64(0)
+	}
+
+	b.Spent[r.Chain] = spent
+	return s.save(b)
+}
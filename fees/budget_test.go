@@ -0,0 +1,113 @@
+// +build unit
+
+package fees
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRepo(t *testing.T) storage.Repository {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	return leveldb.NewLevelDBRepository(db)
+}
+
+func randomDID() identity.DID {
+	return identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+}
+
+func TestService_SetLimits(t *testing.T) {
+	srv := newService(newTestRepo(t), nil, nil, nil)
+	did := randomDID()
+
+	// no budget
+	b, err := srv.GetBudget(did)
+	assert.NoError(t, err)
+	assert.Equal(t, today(), b.Day)
+	_, ok := b.Remaining(ChainCentrifuge)
+	assert.False(t, ok)
+
+	// unknown chain
+	_, err = srv.SetLimits(did, map[Chain]*big.Int{"bitcoin": big.NewInt(1)})
+	assert.True(t, errors.IsOfType(ErrInvalidBudget, err))
+
+	// negative limit
+	_, err = srv.SetLimits(did, map[Chain]*big.Int{ChainEthereum: big.NewInt(-1)})
+	assert.True(t, errors.IsOfType(ErrInvalidBudget, err))
+
+	// success
+	_, err = srv.SetLimits(did, map[Chain]*big.Int{ChainCentrifuge: big.NewInt(100)})
+	assert.NoError(t, err)
+	b, err = srv.GetBudget(did)
+	assert.NoError(t, err)
+	remaining, ok := b.Remaining(ChainCentrifuge)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(100), remaining)
+	_, ok = b.Remaining(ChainEthereum)
+	assert.False(t, ok)
+}
+
+func TestService_ReserveSettle(t *testing.T) {
+	srv := newService(newTestRepo(t), nil, nil, nil)
+	did := randomDID()
+	_, err := srv.SetLimits(did, map[Chain]*big.Int{ChainCentrifuge: big.NewInt(100)})
+	assert.NoError(t, err)
+
+	// uncapped chain
+	_, err = srv.Reserve(did, ChainEthereum, big.NewInt(1000))
+	assert.NoError(t, err)
+
+	r, err := srv.Reserve(did, ChainCentrifuge, big.NewInt(60))
+	assert.NoError(t, err)
+	assert.Equal(t, Reservation{AccountID: did, Chain: ChainCentrifuge, Fee: big.NewInt(60), Day: today()}, r)
+	_, err = srv.Reserve(did, ChainCentrifuge, big.NewInt(50))
+	assert.True(t, errors.IsOfType(ErrBudgetExceeded, err))
+	_, err = srv.Reserve(did, ChainCentrifuge, big.NewInt(40))
+	assert.NoError(t, err)
+
+	b, err := srv.GetBudget(did)
+	assert.NoError(t, err)
+	remaining, _ := b.Remaining(ChainCentrifuge)
+	assert.Equal(t, 0, remaining.Sign())
+	assert.Equal(t, big.NewInt(1000), b.Spent[ChainEthereum])
+
+	// settled with the fee paid
+	assert.NoError(t, srv.Settle(r, big.NewInt(45)))
+	b, err = srv.GetBudget(did)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(85), b.Spent[ChainCentrifuge])
+
+	// refunded
+	r.Fee = big.NewInt(45)
+	assert.NoError(t, srv.Settle(r, nil))
+	b, err = srv.GetBudget(did)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(40), b.Spent[ChainCentrifuge])
+
+	// spent fees are dropped on the next day
+	b.Day = "2006-01-02"
+	assert.NoError(t, srv.save(b))
+	_, err = srv.Reserve(did, ChainCentrifuge, big.NewInt(100))
+	assert.NoError(t, err)
+	b, err = srv.GetBudget(did)
+	assert.NoError(t, err)
+	assert.Equal(t, today(), b.Day)
+	assert.Equal(t, big.NewInt(100), b.Spent[ChainCentrifuge])
+	assert.Nil(t, b.Spent[ChainEthereum])
+
+	// reservations of an earlier day don't change the fees spent today
+	r.Day = "2006-01-02"
+	assert.NoError(t, srv.Settle(r, nil))
+	b, err = srv.GetBudget(did)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), b.Spent[ChainCentrifuge])
+}
@@ -0,0 +1,134 @@
+package fees
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// receiptPollInterval is the interval between the polls of the receipt of a transaction.
+	receiptPollInterval = 5 * time.Second
+
+	// receiptTimeout is the time after which the fee of a transaction without a receipt stays reserved.
+	receiptTimeout = 30 * time.Minute
+)
+
+// centChainAPI charges the fees of the extrinsics submitted to the budget of the account submitting them.
+type centChainAPI struct {
+	centchain.API
+	budgets Service
+}
+
+// SubmitAndWatch rejects the extrinsic if its fee exceeds what is left of the daily budget of the account in ctx.
+// The fee is reserved until the extrinsic finishes, and refunded if the extrinsic wasn't submitted.
+// The fee isn't queried if the fees of the account on Centrifuge chain aren't capped.
+func (a centChainAPI) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) error {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return err
+	}
+
+	b, err := a.budgets.GetBudget(did)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := b.Remaining(ChainCentrifuge); !ok {
+		return a.API.SubmitAndWatch(ctx, meta, c, krp)
+	}
+
+	info, err := a.QueryFeeInfo(meta, c, krp.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	r, err := a.budgets.Reserve(did, ChainCentrifuge, info.Fee)
+	if err != nil {
+		return err
+	}
+
+	err = a.API.SubmitAndWatch(ctx, meta, c, krp)
+	if errors.IsOfType(centchain.ErrExtrinsicNotSubmitted, err) {
+		if serr := a.budgets.Settle(r, nil); serr != nil {
+			log.Errorf("failed to refund the fee of the extrinsic: %v", serr)
+		}
+	}
+
+	return err
+}
+
+// ethClient charges the fees of the transactions submitted to the budget of the account the options were created for.
+type ethClient struct {
+	ethereum.Client
+	budgets Service
+}
+
+// SubmitTransactionWithRetries rejects the transaction if its max fee exceeds what is left of the daily budget of
+// the account in the context of opts. Transactions of no account, like the creation of an identity, aren't charged.
+// The max fee is reserved until the transaction is mined, and then settled with the gas used.
+// The fee is refunded if the transaction wasn't submitted.
+func (c ethClient) SubmitTransactionWithRetries(contractMethod interface{}, opts *bind.TransactOpts,
+	params ...interface{}) (*ethtypes.Transaction, error) {
+	if opts.Context == nil || opts.GasPrice == nil {
+		return c.Client.SubmitTransactionWithRetries(contractMethod, opts, params...)
+	}
+
+	did, err := contextutil.AccountDID(opts.Context)
+	if err != nil {
+		return c.Client.SubmitTransactionWithRetries(contractMethod, opts, params...)
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(opts.GasLimit), opts.GasPrice)
+	r, err := c.budgets.Reserve(did, ChainEthereum, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.Client.SubmitTransactionWithRetries(contractMethod, opts, params...)
+	if err != nil {
+		if serr := c.budgets.Settle(r, nil); serr != nil {
+			log.Errorf("failed to refund the fee of the transaction: %v", serr)
+		}
+
+		return nil, err
+	}
+
+	go c.settle(r, tx)
+	return tx, nil
+}
+
+// settle settles the reservation with the gas used by the transaction once it is mined.
+func (c ethClient) settle(r Reservation, tx *ethtypes.Transaction) {
+	ctx, cancel := context.WithTimeout(context.Background(), receiptTimeout)
+	defer cancel()
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		rec, err := c.TransactionReceipt(ctx, tx.Hash())
+		if err == nil {
+			fee := new(big.Int).Mul(new(big.Int).SetUint64(rec.GasUsed), tx.GasPrice())
+			if err := c.budgets.Settle(r, fee); err != nil {
+				log.Errorf("failed to settle the fee of transaction %s: %v", tx.Hash().Hex(), err)
+			}
+
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warnf("no receipt of transaction %s, its max fee stays reserved: %v", tx.Hash().Hex(), err)
+			return
+		case <-ticker.C:
+		}
+	}
+}
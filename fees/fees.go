@@ -0,0 +1,109 @@
+package fees
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	logging "github.com/ipfs/go-log"
+)
+
+const (
+	// ErrUnknownOperation is returned when the fee of an unknown operation is estimated.
+	ErrUnknownOperation = errors.Error("unknown operation")
+
+	// ErrBudgetExceeded is returned when the fee of an operation exceeds what is left of the daily budget
+	// of the account.
+	ErrBudgetExceeded = errors.Error("daily fee budget exceeded")
+
+	// ErrInvalidBudget is returned when a limit of the budget is negative or set for an unknown chain.
+	ErrInvalidBudget = errors.Error("invalid fee budget")
+)
+
+var log = logging.Logger("fees")
+
+// Chain is a chain the operations are paid on.
+type Chain string
+
+// Chains the operations are paid on. Fees are in the smallest unit of the chain currency,
+// the smallest unit of CFG on Centrifuge chain and wei on Ethereum.
+const (
+	ChainCentrifuge Chain = "centrifuge"
+	ChainEthereum   Chain = "ethereum"
+)
+
+// Operation is a chain operation with a fee.
+type Operation string
+
+// Operations with a fee.
+const (
+	OperationAnchorCommit      Operation = "anchor_commit"
+	OperationNFTMint           Operation = "nft_mint"
+	OperationOraclePush        Operation = "oracle_push"
+	OperationIdentityAddKey    Operation = "identity_add_key"
+	OperationIdentityRevokeKey Operation = "identity_revoke_key"
+)
+
+// operationChains are the chains the operations are paid on.
+var operationChains = map[Operation]Chain{
+	OperationAnchorCommit:      ChainCentrifuge,
+	OperationNFTMint:           ChainCentrifuge,
+	OperationOraclePush:        ChainEthereum,
+	OperationIdentityAddKey:    ChainEthereum,
+	OperationIdentityRevokeKey: ChainEthereum,
+}
+
+// contractOps are the contract operations of the Ethereum operations, their gas limit is the limit of the operation.
+var contractOps = map[Operation]config.ContractOp{
+	OperationOraclePush:        config.PushToOracle,
+	OperationIdentityAddKey:    config.IDAddKey,
+	OperationIdentityRevokeKey: config.IDRevokeKey,
+}
+
+// Estimate is the fee of an operation.
+// Weight is set for the operations on Centrifuge chain, GasLimit and GasPrice for the operations on Ethereum.
+type Estimate struct {
+	Operation Operation
+	Chain     Chain
+	Fee       *big.Int
+	Weight    uint64
+	GasLimit  uint64
+	GasPrice  *big.Int
+}
+
+// Config defines functions to get the gas limits of the Ethereum operations.
+type Config interface {
+	GetEthereumGasLimit(op config.ContractOp) uint64
+}
+
+// Service estimates the fees of the operations, and keeps the daily fee budgets of the accounts.
+type Service interface {
+	// Estimate returns the fee of the operation when submitted by the account in ctx.
+	Estimate(ctx context.Context, op Operation) (Estimate, error)
+
+	// GetBudget returns the budget of the account along with the fees spent today.
+	GetBudget(accountID identity.DID) (Budget, error)
+
+	// SetLimits replaces the daily limits of the budget of the account.
+	SetLimits(accountID identity.DID, limits map[Chain]*big.Int) (Budget, error)
+
+	// Reserve adds the fee to the fees spent today by the account on the chain, until the reservation is settled.
+	// Returns ErrBudgetExceeded, without adding the fee, if it exceeds what is left of the daily limit.
+	Reserve(accountID identity.DID, chain Chain, fee *big.Int) (Reservation, error)
+
+	// Settle replaces the reserved fee with the fee paid once the operation finishes. A zero fee refunds it.
+	// The fees spent today aren't changed by the reservations of an earlier day.
+	Settle(r Reservation, fee *big.Int) error
+}
+
+// Reservation is a fee reserved on the daily budget of an account until the operation paying it finishes.
+type Reservation struct {
+	AccountID identity.DID
+	Chain     Chain
+	Fee       *big.Int
+
+	// Day is the UTC day the fee was reserved on.
+	Day string
+}
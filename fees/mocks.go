@@ -0,0 +1,52 @@
+// +build integration unit testworld
+
+package fees
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/stretchr/testify/mock"
+)
+
+func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
+	return b.Bootstrap(context)
+}
+
+func (Bootstrapper) TestTearDown() error {
+	return nil
+}
+
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Estimate(ctx context.Context, op Operation) (Estimate, error) {
+	args := m.Called(ctx, op)
+	e, _ := args.Get(0).(Estimate)
+	return e, args.Error(1)
+}
+
+func (m *MockService) GetBudget(accountID identity.DID) (Budget, error) {
+	args := m.Called(accountID)
+	b, _ := args.Get(0).(Budget)
+	return b, args.Error(1)
+}
+
+func (m *MockService) SetLimits(accountID identity.DID, limits map[Chain]*big.Int) (Budget, error) {
+	args := m.Called(accountID, limits)
+	b, _ := args.Get(0).(Budget)
+	return b, args.Error(1)
+}
+
+func (m *MockService) Reserve(accountID identity.DID, chain Chain, fee *big.Int) (Reservation, error) {
+	args := m.Called(accountID, chain, fee)
+	r, _ := args.Get(0).(Reservation)
+	return r, args.Error(1)
+}
+
+func (m *MockService) Settle(r Reservation, fee *big.Int) error {
+	args := m.Called(r, fee)
+	return args.Error(0)
+}
@@ -0,0 +1,125 @@
+package fees
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+)
+
+const (
+	// anchorCommit is centrifuge chain module function name for commit call.
+	anchorCommit = "Anchor.commit"
+
+	// mintProofs and mintProofDepth size the NFT mint call estimated, as a mint of a few attributes of a
+	// document with a few dozen fields.
+	mintProofs     = 4
+	mintProofDepth = 8
+)
+
+type service struct {
+	repo      storage.Repository
+	config    Config
+	centAPI   centchain.API
+	ethClient ethereum.Client
+
+	// mu serialises the updates of the budgets
+	mu sync.Mutex
+}
+
+func newService(repo storage.Repository, config Config, centAPI centchain.API, ethClient ethereum.Client) *service {
+	repo.Register(new(Budget))
+	return &service{repo: repo, config: config, centAPI: centAPI, ethClient: ethClient}
+}
+
+func (s *service) Estimate(ctx context.Context, op Operation) (Estimate, error) {
+	chain, ok := operationChains[op]
+	if !ok {
+		return Estimate{}, errors.NewTypedError(ErrUnknownOperation, errors.New("%s", op))
+	}
+
+	if chain == ChainEthereum {
+		return s.estimateEthereum(ctx, op)
+	}
+
+	return s.estimateCentChain(ctx, op)
+}
+
+// estimateCentChain returns the fee of the call of the operation, with placeholder args, as computed by the chain.
+func (s *service) estimateCentChain(ctx context.Context, op Operation) (Estimate, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	krp, err := acc.GetCentChainAccount().KeyRingPair()
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	meta, err := s.centAPI.GetMetadataLatest()
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	c, err := sampleCall(meta, op)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	info, err := s.centAPI.QueryFeeInfo(meta, c, krp.PublicKey)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	return Estimate{Operation: op, Chain: ChainCentrifuge, Fee: info.Fee, Weight: info.Weight}, nil
+}
+
+// sampleCall returns the call of the operation with args of the same size as the actual ones.
+func sampleCall(meta *types.Metadata, op Operation) (types.Call, error) {
+	hash := func() types.Hash {
+		return types.NewHash(utils.RandomSlice(32))
+	}
+
+	if op == OperationAnchorCommit {
+		return types.NewCall(meta, anchorCommit, hash(), hash(), hash(), types.NewMoment(time.Now()))
+	}
+
+	proofs := make([]nft.SubstrateProof, mintProofs)
+	for i := range proofs {
+		proofs[i].LeafHash = utils.RandomByte32()
+		for j := 0; j < mintProofDepth; j++ {
+			proofs[i].SortedHashes = append(proofs[i].SortedHashes, utils.RandomByte32())
+		}
+	}
+
+	var depositAddress [20]byte
+	var staticProofs [3][32]byte
+	return types.NewCall(meta, nft.ValidateMint, hash(), depositAddress, proofs, staticProofs, types.NewU8(nft.TargetChainID))
+}
+
+// estimateEthereum returns the gas limit of the operation times the gas price used for the transactions.
+func (s *service) estimateEthereum(ctx context.Context, op Operation) (Estimate, error) {
+	gasLimit := s.config.GetEthereumGasLimit(contractOps[op])
+	gasPrice, err := s.ethClient.GetOptimalGasPrice(ctx)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	return Estimate{
+		Operation: op,
+		Chain:     ChainEthereum,
+		Fee:       new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice),
+		GasLimit:  gasLimit,
+		GasPrice:  gasPrice,
+	}, nil
+}
@@ -0,0 +1,145 @@
+// +build unit
+
+package fees
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_Estimate(t *testing.T) {
+	cfg := new(config.MockConfig)
+	client := new(ethereum.MockEthClient)
+	srv := newService(newTestRepo(t), cfg, nil, client)
+
+	// unknown operation
+	_, err := srv.Estimate(context.Background(), "transfer")
+	assert.True(t, errors.IsOfType(ErrUnknownOperation, err))
+
+	// ethereum operation
+	cfg.On("GetEthereumGasLimit", config.PushToOracle).Return(uint64(200000)).Once()
+	client.On("GetOptimalGasPrice", mock.Anything).Return(big.NewInt(2e9), nil).Once()
+	e, err := srv.Estimate(context.Background(), OperationOraclePush)
+	assert.NoError(t, err)
+	assert.Equal(t, ChainEthereum, e.Chain)
+	assert.Equal(t, uint64(200000), e.GasLimit)
+	assert.Equal(t, big.NewInt(4e14), e.Fee)
+
+	// failed gas price
+	cfg.On("GetEthereumGasLimit", config.IDAddKey).Return(uint64(100000)).Once()
+	client.On("GetOptimalGasPrice", mock.Anything).Return(nil, errors.New("failed")).Once()
+	_, err = srv.Estimate(context.Background(), OperationIdentityAddKey)
+	assert.Error(t, err)
+
+	// centrifuge operation without an account
+	_, err = srv.Estimate(context.Background(), OperationAnchorCommit)
+	assert.Error(t, err)
+	cfg.AssertExpectations(t)
+	client.AssertExpectations(t)
+}
+
+func TestCentChainAPI_SubmitAndWatch(t *testing.T) {
+	srv := newService(newTestRepo(t), nil, nil, nil)
+	api := new(centchain.MockAPI)
+	capi := centChainAPI{API: api, budgets: srv}
+	did := randomDID()
+	acc := new(config.MockAccount)
+	acc.On("GetIdentityID").Return(did[:])
+	ctx, err := contextutil.New(context.Background(), acc)
+	assert.NoError(t, err)
+	var c types.Call
+	krp := signature.KeyringPair{PublicKey: []byte{1}}
+
+	// missing account
+	err = capi.SubmitAndWatch(context.Background(), nil, c, krp)
+	assert.Error(t, err)
+
+	// uncapped fees aren't queried
+	api.On("SubmitAndWatch", c, krp).Return(nil).Once()
+	assert.NoError(t, capi.SubmitAndWatch(ctx, nil, c, krp))
+
+	// within budget
+	_, err = srv.SetLimits(did, map[Chain]*big.Int{ChainCentrifuge: big.NewInt(100)})
+	assert.NoError(t, err)
+	api.On("QueryFeeInfo", mock.Anything, c, krp.PublicKey).Return(centchain.FeeInfo{Fee: big.NewInt(60)}, nil).Times(3)
+	api.On("SubmitAndWatch", c, krp).Return(nil).Once()
+	assert.NoError(t, capi.SubmitAndWatch(ctx, nil, c, krp))
+
+	// budget exceeded
+	err = capi.SubmitAndWatch(ctx, nil, c, krp)
+	assert.True(t, errors.IsOfType(ErrBudgetExceeded, err))
+
+	// fee of the extrinsic not submitted is refunded
+	_, err = srv.SetLimits(did, map[Chain]*big.Int{ChainCentrifuge: big.NewInt(200)})
+	assert.NoError(t, err)
+	api.On("SubmitAndWatch", c, krp).Return(
+		errors.NewTypedError(centchain.ErrExtrinsicNotSubmitted, errors.New("rejected"))).Once()
+	err = capi.SubmitAndWatch(ctx, nil, c, krp)
+	assert.True(t, errors.IsOfType(centchain.ErrExtrinsicNotSubmitted, err))
+	b, err := srv.GetBudget(did)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(60), b.Spent[ChainCentrifuge])
+	api.AssertExpectations(t)
+}
+
+func TestEthClient_SubmitTransactionWithRetries(t *testing.T) {
+	srv := newService(newTestRepo(t), nil, nil, nil)
+	client := new(ethereum.MockEthClient)
+	eclient := ethClient{Client: client, budgets: srv}
+	did := randomDID()
+	acc := new(config.MockAccount)
+	acc.On("GetIdentityID").Return(did[:])
+	ctx, err := contextutil.New(context.Background(), acc)
+	assert.NoError(t, err)
+	_, err = srv.SetLimits(did, map[Chain]*big.Int{ChainEthereum: big.NewInt(1e15)})
+	assert.NoError(t, err)
+
+	// transactions of no account aren't charged
+	opts := &bind.TransactOpts{GasLimit: 1e6, GasPrice: big.NewInt(1e10)}
+	client.On("SubmitTransactionWithRetries", mock.Anything, opts, mock.Anything).Return(new(ethtypes.Transaction), nil).Once()
+	_, err = eclient.SubmitTransactionWithRetries(nil, opts)
+	assert.NoError(t, err)
+
+	// within budget, the max fee is settled with the gas used once the transaction is mined
+	opts = &bind.TransactOpts{GasLimit: 5e4, GasPrice: big.NewInt(1e10), Context: ctx}
+	tx := ethtypes.NewTransaction(0, common.Address{}, nil, 5e4, big.NewInt(1e10), nil)
+	client.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(&ethtypes.Receipt{GasUsed: 3e4}, nil).Once()
+	client.On("SubmitTransactionWithRetries", mock.Anything, opts, mock.Anything).Return(tx, nil).Once()
+	_, err = eclient.SubmitTransactionWithRetries(nil, opts)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		b, err := srv.GetBudget(did)
+		return err == nil && b.Spent[ChainEthereum].Cmp(big.NewInt(3e14)) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// budget exceeded
+	opts = &bind.TransactOpts{GasLimit: 1e6, GasPrice: big.NewInt(1e10), Context: ctx}
+	_, err = eclient.SubmitTransactionWithRetries(nil, opts)
+	assert.True(t, errors.IsOfType(ErrBudgetExceeded, err))
+
+	// fee of the transaction not submitted is refunded
+	opts = &bind.TransactOpts{GasLimit: 5e4, GasPrice: big.NewInt(1e10), Context: ctx}
+	client.On("SubmitTransactionWithRetries", mock.Anything, opts, mock.Anything).Return(
+		(*ethtypes.Transaction)(nil), errors.New("rejected")).Once()
+	_, err = eclient.SubmitTransactionWithRetries(nil, opts)
+	assert.Error(t, err)
+	b, err := srv.GetBudget(did)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(3e14), b.Spent[ChainEthereum])
+	client.AssertExpectations(t)
+}
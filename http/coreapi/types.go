@@ -6,6 +6,7 @@ import (
 	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -90,6 +91,27 @@ type ResponseHeader struct {
 	NFTs        []NFT              `json:"nfts"`
 	Status      string             `json:"status,omitempty"`
 	Fingerprint byteutils.HexBytes `json:"fingerprint,omitempty" swaggertype:"primitive,string"`
+	Anchor      *Anchor            `json:"anchor,omitempty"`
+}
+
+// Anchor is the anchor of a document version along with the block it was committed in.
+type Anchor struct {
+	ID           byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`
+	DocumentRoot byteutils.HexBytes `json:"document_root" swaggertype:"primitive,string"`
+	BlockNumber  uint32             `json:"block_number"`
+	BlockHash    byteutils.HexBytes `json:"block_hash" swaggertype:"primitive,string"`
+	AnchoredAt   time.Time          `json:"anchored_at" swaggertype:"primitive,string"`
+}
+
+// ToAnchor converts the anchor to the client api format.
+func ToAnchor(a anchors.Anchor) Anchor {
+	return Anchor{
+		ID:           a.ID[:],
+		DocumentRoot: a.DocumentRoot[:],
+		BlockNumber:  a.BlockNumber,
+		BlockHash:    a.BlockHash[:],
+		AnchoredAt:   a.AnchoredAt.UTC(),
+	}
 }
 
 // DocumentResponse is the common response for Document APIs.
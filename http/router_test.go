@@ -85,5 +85,5 @@ func TestRouter(t *testing.T) {
 	// health pattern
 	assert.Equal(t, "/ping", r.Routes()[0].Pattern)
 	// v2 routes
-	assert.Len(t, r.Routes()[1].SubRoutes.Routes(), 28)
+	assert.Len(t, r.Routes()[1].SubRoutes.Routes(), 48)
 }
@@ -0,0 +1,72 @@
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+)
+
+// ErrNotAdmin is a sentinel error when the account of the request is not the node identity.
+const ErrNotAdmin = errors.Error("admin APIs are restricted to the node identity")
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w http.ResponseWriter
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// Backup streams a consistent backup of the node databases.
+// @summary Streams a backup of the node databases.
+// @description Streams a versioned and checksummed archive of the node databases.
+// @description The archive can be restored with the restore command while the node is stopped.
+// @description Restricted to the node identity.
+// @id admin_backup
+// @tags Admin
+// @param authorization header string true "Hex encoded centrifuge ID of the node identity"
+// @produce application/gzip
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {file} file
+// @router /v2/admin/backup [get]
+func (h handler) Backup(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, err := contextutil.DIDFromContext(r.Context())
+	if err != nil || !h.srv.IsNodeIdentity(did) {
+		err = ErrNotAdmin
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	name := fmt.Sprintf("centrifuge-backup-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	cw := &countingWriter{w: w}
+	_, err = h.srv.Backup(cw)
+	if err == nil {
+		return
+	}
+
+	log.Error(err)
+	if cw.n > 0 {
+		// the archive is partially streamed, the client detects the truncated archive.
+		err = nil
+		return
+	}
+
+	w.Header().Del("Content-Disposition")
+	code = http.StatusInternalServerError
+}
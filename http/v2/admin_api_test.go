@@ -0,0 +1,65 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/centrifuge/go-centrifuge/storage/backup"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Backup(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/admin/backup", nil).WithContext(ctx)
+	}
+
+	// missing account
+	w, r := getHTTPReqAndResp(context.Background())
+	h := handler{}
+	h.Backup(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), ErrNotAdmin.Error())
+
+	// not the node identity
+	did := testingidentity.GenerateRandomDID()
+	cfg := new(config.MockConfig)
+	cfg.On("GetIdentityID").Return(did[:], nil)
+	ctx := context.WithValue(context.Background(), config.AccountHeaderKey, testingidentity.GenerateRandomDID().String())
+	w, r = getHTTPReqAndResp(ctx)
+	h = handler{srv: Service{cfg: cfg}}
+	h.Backup(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), ErrNotAdmin.Error())
+
+	// dbs missing
+	ctx = context.WithValue(context.Background(), config.AccountHeaderKey, did.String())
+	w, r = getHTTPReqAndResp(ctx)
+	h.Backup(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// success
+	db, err := backend.Open(backend.LevelDB, leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	defer db.Close()
+	assert.NoError(t, db.KV.Set([]byte("key"), []byte("value")))
+	w, r = getHTTPReqAndResp(ctx)
+	h = handler{srv: Service{cfg: cfg, dbs: map[string]storage.KV{backend.MainDB: db.KV}}}
+	h.Backup(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/gzip", w.Header().Get("Content-Type"))
+	m, err := backup.Read(bytes.NewReader(w.Body.Bytes()), t.TempDir())
+	assert.NoError(t, err)
+	assert.Equal(t, backend.MainDB, m.DBs[0].Name)
+	assert.Equal(t, 1, m.DBs[0].Entries)
+	cfg.AssertExpectations(t)
+}
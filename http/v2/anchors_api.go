@@ -0,0 +1,71 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/http/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+const (
+	// ErrInvalidAnchorID is a sentinel error when the anchor_id passed is invalid.
+	ErrInvalidAnchorID = errors.Error("Invalid anchor ID")
+
+	// ErrAnchorNotFound is a sentinel error when the anchor is not found on chain.
+	ErrAnchorNotFound = errors.Error("Anchor not found")
+
+	anchorIDParam = "anchor_id"
+)
+
+// Anchor is the anchor of a document version along with the block it was committed in.
+type Anchor = coreapi.Anchor
+
+// GetAnchor returns the anchor along with the block it was committed in.
+// @summary Returns the anchor along with the block it was committed in.
+// @description Returns the document root of the anchor, and the number, the hash and the timestamp of the block it was committed in.
+// @id get_anchor
+// @tags Anchors
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param anchor_id path string true "Hex encoded anchor ID"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.Anchor
+// @router /v2/anchors/{anchor_id} [get]
+func (h handler) GetAnchor(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	id, err := hexutil.Decode(chi.URLParam(r, anchorIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidAnchorID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	anchorID, err := anchors.ToAnchorID(id)
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidAnchorID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	a, err := h.srv.GetAnchor(anchorID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = ErrAnchorNotFound
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, coreapi.ToAnchor(a))
+}
@@ -0,0 +1,96 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomAnchor() anchors.Anchor {
+	id, _ := anchors.ToAnchorID(utils.RandomSlice(anchors.AnchorIDLength))
+	return anchors.Anchor{
+		ID:           id,
+		DocumentRoot: anchors.RandomDocumentRoot(),
+		BlockNumber:  42,
+		BlockHash:    utils.RandomByte32(),
+		AnchoredAt:   time.Now().UTC(),
+	}
+}
+
+func TestHandler_GetAnchor(t *testing.T) {
+	getHTTPReqAndResp := func(anchorID string) (*httptest.ResponseRecorder, *http.Request) {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Keys = []string{anchorIDParam}
+		rctx.URLParams.Values = []string{anchorID}
+		ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/anchors/{anchor_id}", nil).WithContext(ctx)
+	}
+
+	anchorSrv := new(anchors.MockAnchorService)
+	h := handler{srv: Service{anchorSrv: anchorSrv}}
+
+	// invalid anchor ID
+	for _, id := range []string{"invalid", hexutil.Encode(utils.RandomSlice(20))} {
+		w, r := getHTTPReqAndResp(id)
+		h.GetAnchor(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrInvalidAnchorID.Error())
+	}
+
+	// missing anchor
+	a := randomAnchor()
+	anchorSrv.On("GetAnchor", a.ID).Return(nil, errors.New("anchor data empty")).Once()
+	w, r := getHTTPReqAndResp(hexutil.Encode(a.ID[:]))
+	h.GetAnchor(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrAnchorNotFound.Error())
+
+	// success
+	anchorSrv.On("GetAnchor", a.ID).Return(a, nil).Once()
+	w, r = getHTTPReqAndResp(hexutil.Encode(a.ID[:]))
+	h.GetAnchor(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), hexutil.Encode(a.DocumentRoot[:]))
+	assert.Contains(t, w.Body.String(), hexutil.Encode(a.BlockHash[:]))
+	assert.Contains(t, w.Body.String(), `"block_number":42`)
+	anchorSrv.AssertExpectations(t)
+}
+
+func TestService_documentAnchor(t *testing.T) {
+	anchorSrv := new(anchors.MockAnchorService)
+	srv := Service{anchorSrv: anchorSrv}
+	a := randomAnchor()
+
+	// pending document
+	doc := new(documents.MockModel)
+	doc.On("GetStatus").Return(documents.Pending).Once()
+	assert.Nil(t, srv.documentAnchor(doc))
+
+	// anchor missing
+	doc.On("GetStatus").Return(documents.Committed)
+	doc.On("CurrentVersion").Return(a.ID[:])
+	anchorSrv.On("GetAnchor", a.ID).Return(nil, errors.New("anchor data empty")).Once()
+	assert.Nil(t, srv.documentAnchor(doc))
+
+	// committed document
+	anchorSrv.On("GetAnchor", a.ID).Return(a, nil).Once()
+	ca := srv.documentAnchor(doc)
+	assert.NotNil(t, ca)
+	assert.Equal(t, a.ID[:], ca.ID.Bytes())
+	assert.Equal(t, a.BlockNumber, ca.BlockNumber)
+	assert.Equal(t, a.AnchoredAt, ca.AnchoredAt)
+	anchorSrv.AssertExpectations(t)
+	doc.AssertExpectations(t)
+}
@@ -0,0 +1,118 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/batch"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+const (
+	// ErrInvalidBatchID is a sentinel error when the batch_id passed is invalid.
+	ErrInvalidBatchID = errors.Error("Invalid batch ID")
+
+	batchIDParam = "batch_id"
+)
+
+// BatchRequest is the ordered list of the operations of the batch.
+type BatchRequest struct {
+	Operations []batch.Operation `json:"operations"`
+}
+
+// Batch is the batch along with the results of its operations.
+// The ID of the batch is the ID of the job running it.
+type Batch = batch.Batch
+
+// SubmitBatch submits the batch of operations.
+// @summary Submits the batch of operations.
+// @description Runs the ordered create, update, add_attributes and commit operations on the pending documents under one job.
+// @description An operation works on the document with document_id, or the document of the earlier operation ref.
+// @description Failed operations don't stop the batch, and are reported in the results along with their error.
+// @description Commits are run after the other operations, and each document is committed once with its latest pending version.
+// @id submit_batch
+// @tags Batch
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.BatchRequest true "Batch request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 202 {object} v2.Batch
+// @router /v2/batch [post]
+func (h handler) SubmitBatch(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	_, err = contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	var req BatchRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	b, err := h.srv.SubmitBatch(r.Context(), req.Operations)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if errors.IsOfType(batch.ErrInvalidBatch, err) {
+			code = http.StatusBadRequest
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, b)
+}
+
+// GetBatch returns the batch along with the results of its operations.
+// @summary Returns the batch along with the results of its operations.
+// @description Returns the batch along with the status, the document, the version, the anchor job and the error of each operation.
+// @id get_batch
+// @tags Batch
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param batch_id path string true "Hex encoded batch ID"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.Batch
+// @router /v2/batch/{batch_id} [get]
+func (h handler) GetBatch(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	batchID, err := hexutil.Decode(chi.URLParam(r, batchIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidBatchID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	b, err := h.srv.GetBatch(r.Context(), batchID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = batch.ErrBatchNotFound
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, b)
+}
@@ -0,0 +1,125 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/batch"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func batchContext(authorization, batchID string) context.Context {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{batchIDParam}
+	rctx.URLParams.Values = []string{batchID}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	if authorization != "" {
+		ctx = context.WithValue(ctx, config.AccountHeaderKey, authorization)
+	}
+
+	return ctx
+}
+
+func TestHandler_SubmitBatch(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body []byte) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/batch", bytes.NewReader(body)).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	batchSrv := new(batch.MockService)
+	h := handler{srv: Service{batchSrv: batchSrv}}
+
+	// missing account
+	w, r := getHTTPReqAndResp(batchContext("", ""), nil)
+	h.SubmitBatch(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// invalid body
+	ctx := batchContext(did.String(), "")
+	w, r = getHTTPReqAndResp(ctx, []byte("invalid"))
+	h.SubmitBatch(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid batch
+	ops := []batch.Operation{{Type: batch.OperationCommit, DocumentID: utils.RandomSlice(32)}}
+	body, err := json.Marshal(BatchRequest{Operations: ops})
+	assert.NoError(t, err)
+	batchSrv.On("Submit", mock.Anything, mock.Anything).Return(
+		nil, errors.NewTypedError(batch.ErrInvalidBatch, errors.New("operation 0"))).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.SubmitBatch(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), batch.ErrInvalidBatch.Error())
+
+	// failed to dispatch
+	batchSrv.On("Submit", mock.Anything, mock.Anything).Return(nil, errors.New("failed to dispatch")).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.SubmitBatch(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// success
+	b := batch.Batch{
+		ID:         utils.RandomSlice(32),
+		AccountID:  did[:],
+		Operations: ops,
+		Results:    []batch.Result{{Status: batch.StatusPending}},
+	}
+	batchSrv.On("Submit", mock.Anything, mock.Anything).Return(b, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.SubmitBatch(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), b.ID.String())
+	assert.Contains(t, w.Body.String(), string(batch.StatusPending))
+	batchSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetBatch(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/batch/{batch_id}", nil).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	batchSrv := new(batch.MockService)
+	h := handler{srv: Service{batchSrv: batchSrv}}
+
+	// invalid batch ID
+	w, r := getHTTPReqAndResp(batchContext(did.String(), "invalid"))
+	h.GetBatch(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidBatchID.Error())
+
+	// missing batch
+	batchID := utils.RandomSlice(32)
+	ctx := batchContext(did.String(), hexutil.Encode(batchID))
+	batchSrv.On("Get", mock.Anything, batchID).Return(nil, batch.ErrBatchNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetBatch(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), batch.ErrBatchNotFound.Error())
+
+	// success
+	b := batch.Batch{
+		ID:        batchID,
+		AccountID: did[:],
+		Results:   []batch.Result{{Status: batch.StatusFailed, Error: "failed to commit"}},
+	}
+	batchSrv.On("Get", mock.Anything, batchID).Return(b, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetBatch(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to commit")
+	batchSrv.AssertExpectations(t)
+}
@@ -1,15 +1,21 @@
 package v2
 
 import (
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/batch"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/entity"
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
+	"github.com/centrifuge/go-centrifuge/fees"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/oracle"
 	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 )
 
 // BootstrappedService key maps to the Service implementation in Bootstrap context.
@@ -28,6 +34,12 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	entitySrv := ctx[entity.BootstrappedEntityService].(entity.Service)
 	erSrv := ctx[entityrelationship.BootstrappedEntityRelationshipService].(entityrelationship.Service)
 	docSrv := ctx[documents.BootstrappedDocumentService].(documents.Service)
+	cfg, _ := ctx[bootstrap.BootstrappedConfig].(config.Configuration)
+	dbs, _ := ctx[backend.BootstrappedRawDBs].(map[string]storage.KV)
+	outbox, _ := ctx[notification.BootstrappedOutbox].(notification.Outbox)
+	batchSrv, _ := ctx[batch.BootstrappedBatchService].(batch.Service)
+	anchorSrv, _ := ctx[anchors.BootstrappedAnchorService].(anchors.Service)
+	feeSrv, _ := ctx[fees.BootstrappedFeeService].(fees.Service)
 	ctx[BootstrappedService] = Service{
 		pendingDocSrv: pendingDocSrv,
 		tokenRegistry: nftSrv.(documents.TokenRegistry),
@@ -38,6 +50,12 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		entitySrv:     entitySrv,
 		erSrv:         erSrv,
 		docSrv:        docSrv,
+		cfg:           cfg,
+		dbs:           dbs,
+		outbox:        outbox,
+		batchSrv:      batchSrv,
+		anchorSrv:     anchorSrv,
+		feeSrv:        feeSrv,
 	}
 	return nil
 }
@@ -3,11 +3,19 @@ package v2
 import (
 	"encoding/json"
 	"io/ioutil"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/http/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 )
 
@@ -82,3 +90,228 @@ func toDocumentAttributes(attrs coreapi.AttributeMapRequest) ([]documents.Attrib
 
 	return rattrs, nil
 }
+
+// toListFilter converts the query params of the list documents request to documents.ListFilter.
+func toListFilter(q url.Values) (filter documents.ListFilter, err error) {
+	filter.Schemes = q["scheme"]
+	for _, st := range q["status"] {
+		st := documents.Status(st)
+		if st != documents.Pending && st != documents.Committed {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, errors.New("unknown status %s", st))
+		}
+
+		filter.Statuses = append(filter.Statuses, st)
+	}
+
+	for param, dst := range map[string]**identity.DID{
+		"author":       &filter.Author,
+		"collaborator": &filter.Collaborator,
+	} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+
+		did, err := identity.NewDIDFromString(v)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, errors.New("invalid %s: %v", param, err))
+		}
+
+		*dst = &did
+	}
+
+	if label := q.Get("attribute"); label != "" {
+		key, err := documents.AttrKeyFromLabel(label)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+
+		filter.AttributeKey = &key
+		filter.AttributeValue = q.Get("attribute_value")
+	}
+
+	for param, dst := range map[string]*time.Time{
+		"from": &filter.From,
+		"to":   &filter.To,
+	} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+
+		*dst, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, errors.New("invalid %s: %v", param, err))
+		}
+	}
+
+	if v := q.Get("limit"); v != "" {
+		filter.Limit, err = strconv.Atoi(v)
+		if err != nil || filter.Limit < 1 {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, errors.New("invalid limit: %s", v))
+		}
+	}
+
+	filter.Cursor = q.Get("cursor")
+	return filter, nil
+}
+
+func toDocumentVersions(docID []byte, versions []documents.VersionInfo) DocumentVersions {
+	resp := DocumentVersions{DocumentID: docID, Versions: []DocumentVersion{}}
+	for _, v := range versions {
+		dv := DocumentVersion{
+			VersionID:       v.VersionID,
+			PreviousVersion: v.PreviousVersion,
+			Status:          string(v.Status),
+			Anchored:        v.Anchored,
+			Signatures:      v.Signatures,
+		}
+
+		if v.Author != nil {
+			dv.Author = v.Author.String()
+		}
+
+		if !v.Timestamp.IsZero() {
+			dv.Timestamp = v.Timestamp.UTC().Format(time.RFC3339)
+		}
+
+		if v.Anchored && !v.AnchoredAt.IsZero() {
+			dv.AnchoredAt = v.AnchoredAt.UTC().Format(time.RFC3339)
+		}
+
+		resp.Versions = append(resp.Versions, dv)
+	}
+
+	return resp
+}
+
+func toJobFilter(q url.Values) (filter jobs.Filter, err error) {
+	filter.Runners = q["runner"]
+	for _, st := range q["status"] {
+		st := jobs.Status(st)
+		switch st {
+		case jobs.StatusPending, jobs.StatusSuccess, jobs.StatusFailed, jobs.StatusCancelled:
+			filter.Statuses = append(filter.Statuses, st)
+		default:
+			return filter, errors.NewTypedError(ErrInvalidJobFilter, errors.New("unknown status %s", st))
+		}
+	}
+
+	for param, dst := range map[string]*time.Time{
+		"from": &filter.From,
+		"to":   &filter.To,
+	} {
+		v := q.Get(param)
+		if v == "" {
+			continue
+		}
+
+		*dst, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidJobFilter, errors.New("invalid %s: %v", param, err))
+		}
+	}
+
+	return filter, nil
+}
+
+func toJobInfo(info jobs.Info) JobInfo {
+	ji := JobInfo{
+		Job:         *info.Job,
+		RunnerType:  info.Runner,
+		Status:      string(info.Status),
+		CreatedAt:   info.CreatedAt,
+		CancelledAt: info.CancelledAt,
+		FailedAt:    info.FailedAt,
+		Attempts:    info.Attempts,
+	}
+
+	if ji.Attempts == nil {
+		ji.Attempts = []jobs.Attempt{}
+	}
+
+	return ji
+}
+
+func toJobList(infos []jobs.Info) JobList {
+	list := JobList{Data: []JobInfo{}}
+	for _, info := range infos {
+		list.Data = append(list.Data, toJobInfo(info))
+	}
+
+	return list
+}
+
+func toSubscription(req WebhookSubscriptionRequest) notification.Subscription {
+	sub := notification.Subscription{
+		URL:             req.URL,
+		EventTypes:      req.EventTypes,
+		Schemes:         req.Schemes,
+		JobDescriptions: req.JobDescriptions,
+		Enabled:         true,
+		Secret:          req.Secret,
+	}
+
+	if req.Enabled != nil {
+		sub.Enabled = *req.Enabled
+	}
+
+	return sub
+}
+
+func toJobsSchedule(req ScheduleRequest) (jobs.Schedule, error) {
+	sch := jobs.Schedule{Action: req.Action, Params: req.Params}
+	if req.RunAt != nil {
+		sch.RunAt = *req.RunAt
+	}
+
+	interval, err := toInterval(req.Interval)
+	if err != nil {
+		return sch, errors.NewTypedError(jobs.ErrInvalidSchedule, err)
+	}
+
+	sch.Interval = interval
+	return sch, nil
+}
+
+// toInterval converts the interval of the schedule request, a duration string or a number of seconds.
+func toInterval(raw json.RawMessage) (time.Duration, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		if str == "" {
+			return 0, nil
+		}
+
+		return time.ParseDuration(str)
+	}
+
+	var secs uint64
+	if err := json.Unmarshal(raw, &secs); err != nil || secs > math.MaxInt64/uint64(time.Second) {
+		return 0, errors.New("interval must be a duration string or a number of seconds")
+	}
+
+	return time.Duration(secs) * time.Second, nil
+}
+
+func toSchedule(sch jobs.Schedule) Schedule {
+	s := Schedule{
+		ID:        sch.ID,
+		Action:    sch.Action,
+		Params:    sch.Params,
+		RunAt:     sch.RunAt,
+		CreatedAt: sch.CreatedAt,
+		LastRunAt: sch.LastRunAt,
+		LastJobID: sch.LastJobID,
+		LastError: sch.LastError,
+	}
+
+	if sch.Interval > 0 {
+		s.Interval = sch.Interval.String()
+	}
+
+	return s
+}
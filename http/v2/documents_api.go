@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/http/coreapi"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
@@ -31,6 +32,34 @@ type UpdateDocumentRequest struct {
 	coreapi.CreateDocumentRequest
 }
 
+// ErrInvalidListFilter is a sentinel error when the query params of the list documents request are invalid.
+const ErrInvalidListFilter = errors.Error("Invalid list filter")
+
+// DocumentList holds a page of documents.
+// NextCursor is empty when there are no more documents.
+type DocumentList struct {
+	Data       []coreapi.DocumentResponse `json:"data"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+}
+
+// DocumentVersion describes a version of a document.
+type DocumentVersion struct {
+	VersionID       byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	PreviousVersion byteutils.HexBytes `json:"previous_version,omitempty" swaggertype:"primitive,string"`
+	Author          string             `json:"author,omitempty"`
+	Timestamp       string             `json:"timestamp,omitempty"`
+	Status          string             `json:"status"`
+	Anchored        bool               `json:"anchored"`
+	AnchoredAt      string             `json:"anchored_at,omitempty"`
+	Signatures      int                `json:"signatures"`
+}
+
+// DocumentVersions holds the versions of a document ordered from the oldest to the newest.
+type DocumentVersions struct {
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	Versions   []DocumentVersion  `json:"versions"`
+}
+
 // CreateDocument creates a document.
 // @summary Creates a new document.
 // @description Creates a new document.
@@ -84,6 +113,65 @@ func (h handler) CreateDocument(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, resp)
 }
 
+// ListDocuments lists the documents of the account.
+// @summary Lists the pending and committed documents of the account.
+// @description Lists the pending and committed documents of the account.
+// @description Committed documents are listed with their latest version.
+// @id list_documents
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param scheme query []string false "Document scheme" collectionFormat(multi) Enums(generic, entity, entity_relationship)
+// @param status query []string false "Document status" collectionFormat(multi) Enums(pending, committed)
+// @param author query string false "Hex encoded DID of the author"
+// @param collaborator query string false "Hex encoded DID of a collaborator"
+// @param attribute query string false "Attribute label"
+// @param attribute_value query string false "Attribute value. Requires attribute"
+// @param from query string false "Documents with timestamp after (RFC3339)"
+// @param to query string false "Documents with timestamp before (RFC3339)"
+// @param cursor query string false "Cursor returned with the previous page"
+// @param limit query int false "Number of documents per page"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 200 {object} v2.DocumentList
+// @router /v2/documents [get]
+func (h handler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	filter, err := toListFilter(r.URL.Query())
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	res, err := h.srv.ListDocuments(r.Context(), filter)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	list := DocumentList{NextCursor: res.NextCursor, Data: []coreapi.DocumentResponse{}}
+	for _, doc := range res.Documents {
+		var resp coreapi.DocumentResponse
+		resp, err = toDocumentResponse(doc, h.srv.tokenRegistry, "")
+		if err != nil {
+			code = http.StatusInternalServerError
+			log.Error(err)
+			return
+		}
+
+		list.Data = append(list.Data, resp)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
 // CloneDocument creates a new cloned document from an existing Template document.
 // @summary Creates a new cloned document from an existing Template document.
 // @description Creates a new cloned document from an existing Template document.
@@ -280,6 +368,7 @@ func (h handler) getDocumentWithStatus(w http.ResponseWriter, r *http.Request, s
 		return
 	}
 
+	resp.Header.Anchor = h.srv.documentAnchor(doc)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
@@ -369,10 +458,115 @@ func (h handler) GetDocumentVersion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	resp.Header.Anchor = h.srv.documentAnchor(doc)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
 
+// GetDocumentVersions returns the versions of the document.
+// @summary Returns the versions of the document.
+// @description Returns the versions of the document, ordered from the oldest to the newest, found by walking the chain of versions.
+// @description The oldest version has a previous version if the node doesn't have the earlier versions.
+// @id get_document_versions
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.DocumentVersions
+// @router /v2/documents/{document_id}/versions [get]
+func (h handler) GetDocumentVersions(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	versions, err := h.srv.GetDocumentVersions(r.Context(), docID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toDocumentVersions(docID, versions))
+}
+
+// GetDocumentDiff returns the changes between two versions of the document.
+// @summary Returns the changes between two versions of the document.
+// @description Returns the changes of the data fields, attributes, roles, read rules, transition rules and collaborators between two versions of the document.
+// @description If to is not provided, the current version is used. If from is not provided, the previous version of to is used.
+// @description from must be an older version of to.
+// @id get_document_diff
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param from query string false "Hex encoded older version identifier"
+// @param to query string false "Hex encoded newer version identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} documents.Diff
+// @router /v2/documents/{document_id}/diff [get]
+func (h handler) GetDocumentDiff(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	versions := make(map[string][]byte)
+	for _, param := range []string{"from", "to"} {
+		v := r.URL.Query().Get(param)
+		if v == "" {
+			continue
+		}
+
+		versions[param], err = hexutil.Decode(v)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = coreapi.ErrInvalidDocumentID
+			return
+		}
+	}
+
+	diff, err := h.srv.DiffDocument(r.Context(), docID, versions["from"], versions["to"])
+	if err != nil {
+		code = http.StatusInternalServerError
+		switch {
+		case errors.IsOfType(documents.ErrDocumentNotFound, err) || errors.IsOfType(documents.ErrDocumentVersionNotFound, err):
+			code = http.StatusNotFound
+		case errors.IsOfType(documents.ErrDocumentVersionNotAncestor, err):
+			code = http.StatusBadRequest
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, diff)
+}
+
 // RemoveCollaboratorsRequest contains the list of collaborators that are to be removed from the document
 type RemoveCollaboratorsRequest struct {
 	Collaborators []identity.DID `json:"collaborators" swaggertype:"array,string"`
@@ -544,3 +738,103 @@ func (h handler) GenerateProofsForVersion(w http.ResponseWriter, r *http.Request
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, coreapi.ConvertProofs(proofs))
 }
+
+// ExportDocument returns the signed bundle of the committed document with all its versions.
+// @summary Exports the committed document with all its versions.
+// @description Exports the committed document with all its versions, signatures and anchor references in a bundle signed by the account.
+// @description The bundle can be imported on another node.
+// @id export_document
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} documents.Bundle
+// @router /v2/documents/{document_id}/export [get]
+func (h handler) ExportDocument(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	bundle, err := h.srv.ExportDocument(r.Context(), docID)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+			err = coreapi.ErrDocumentNotFound
+		}
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, bundle)
+}
+
+// ImportDocument imports the document from a bundle exported by a node.
+// @summary Imports the document from a bundle.
+// @description Imports the versions of the document in a bundle exported by a node.
+// @description Each version is validated against its anchor before any version is stored.
+// @id import_document
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body documents.Bundle true "Document Bundle"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 201 {object} coreapi.DocumentResponse
+// @router /v2/documents/import [post]
+func (h handler) ImportDocument(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	bundle := new(documents.Bundle)
+	err = json.Unmarshal(data, bundle)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	doc, err := h.srv.ImportDocument(r.Context(), bundle)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		if errors.IsOfType(documents.ErrBundleInvalid, err) || errors.IsOfType(documents.ErrDocumentInvalid, err) {
+			code = http.StatusBadRequest
+		}
+
+		return
+	}
+
+	resp, err := toDocumentResponse(doc, h.srv.tokenRegistry, "")
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
@@ -9,8 +9,11 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -473,6 +476,170 @@ func TestHandler_GetDocumentVersion(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	pendingSrv.AssertExpectations(t)
 	doc.AssertExpectations(t)
+
+	// success committed, along with the anchor
+	a := randomAnchor()
+	versionID = a.ID[:]
+	rctx.URLParams.Values[1] = hexutil.Encode(versionID)
+	committed := new(testingdocuments.MockModel)
+	committed.On("GetData").Return(generic.Data{})
+	committed.On("Scheme").Return("generic")
+	committed.On("GetAttributes").Return(nil)
+	committed.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil)
+	committed.On("ID").Return(docID)
+	committed.On("CurrentVersion").Return(versionID)
+	committed.On("Author").Return(nil, errors.New("somerror"))
+	committed.On("Timestamp").Return(nil, errors.New("somerror"))
+	committed.On("NFTs").Return(nil)
+	committed.On("GetStatus").Return(documents.Committed)
+	committed.On("CalculateTransitionRulesFingerprint").Return(utils.RandomSlice(32), nil)
+	pendingSrv.On("GetVersion", ctx, docID, versionID).Return(committed, nil).Once()
+	anchorSrv := new(anchors.MockAnchorService)
+	anchorSrv.On("GetAnchor", a.ID).Return(a, nil).Once()
+	h.srv.anchorSrv = anchorSrv
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentVersion(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), hexutil.Encode(a.BlockHash[:]))
+	anchorSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetDocumentVersions(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions", nil).WithContext(ctx)
+	}
+
+	// empty document_id and invalid
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = make([]string, 1, 1)
+	rctx.URLParams.Values = make([]string, 1, 1)
+	rctx.URLParams.Keys[0] = "document_id"
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+
+	for _, id := range []string{"", "invalid"} {
+		rctx.URLParams.Values[0] = id
+		w, r := getHTTPReqAndResp(ctx)
+		h.GetDocumentVersions(w, r)
+		assert.Equal(t, w.Code, http.StatusBadRequest)
+		assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+	}
+
+	// missing document
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("Versions", mock.Anything, docID).Return(nil, documents.ErrDocumentNotFound).Once()
+	h = handler{srv: Service{docSrv: docSrv}}
+	w, r := getHTTPReqAndResp(ctx)
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// success
+	author := testingidentity.GenerateRandomDID()
+	tm := time.Now().UTC()
+	versions := []documents.VersionInfo{
+		{
+			VersionID:  docID,
+			Author:     &author,
+			Timestamp:  tm,
+			Status:     documents.Committed,
+			Anchored:   true,
+			AnchoredAt: tm,
+			Signatures: 2,
+		},
+		{
+			VersionID:       utils.RandomSlice(32),
+			PreviousVersion: docID,
+			Status:          documents.Committing,
+		},
+	}
+	docSrv.On("Versions", mock.Anything, docID).Return(versions, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	var resp DocumentVersions
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, docID, resp.DocumentID.Bytes())
+	assert.Len(t, resp.Versions, 2)
+	assert.Equal(t, author.String(), resp.Versions[0].Author)
+	assert.Equal(t, tm.Format(time.RFC3339), resp.Versions[0].AnchoredAt)
+	assert.Equal(t, 2, resp.Versions[0].Signatures)
+	assert.Equal(t, docID, resp.Versions[1].PreviousVersion.Bytes())
+	assert.Equal(t, string(documents.Committing), resp.Versions[1].Status)
+	assert.False(t, resp.Versions[1].Anchored)
+	assert.Empty(t, resp.Versions[1].Author)
+	docSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetDocumentDiff(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/diff"+query, nil).WithContext(ctx)
+	}
+
+	// empty document_id and invalid
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = make([]string, 1, 1)
+	rctx.URLParams.Values = make([]string, 1, 1)
+	rctx.URLParams.Keys[0] = "document_id"
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+
+	for _, id := range []string{"", "invalid"} {
+		rctx.URLParams.Values[0] = id
+		w, r := getHTTPReqAndResp(ctx, "")
+		h.GetDocumentDiff(w, r)
+		assert.Equal(t, w.Code, http.StatusBadRequest)
+		assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+	}
+
+	// invalid versions
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	for _, query := range []string{"?from=invalid", "?to=invalid"} {
+		w, r := getHTTPReqAndResp(ctx, query)
+		h.GetDocumentDiff(w, r)
+		assert.Equal(t, w.Code, http.StatusBadRequest)
+	}
+
+	// missing version
+	from, to := utils.RandomSlice(32), utils.RandomSlice(32)
+	query := "?from=" + hexutil.Encode(from) + "&to=" + hexutil.Encode(to)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("Diff", mock.Anything, docID, from, to).Return(nil, documents.ErrDocumentVersionNotFound).Once()
+	h = handler{srv: Service{docSrv: docSrv}}
+	w, r := getHTTPReqAndResp(ctx, query)
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+
+	// from is not older than to
+	docSrv.On("Diff", mock.Anything, docID, from, to).Return(nil, documents.ErrDocumentVersionNotAncestor).Once()
+	w, r = getHTTPReqAndResp(ctx, query)
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+
+	// failed diff
+	docSrv.On("Diff", mock.Anything, docID, from, to).Return(nil, errors.New("failed to pack")).Once()
+	w, r = getHTTPReqAndResp(ctx, query)
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+
+	// success with the current version
+	diff := &documents.Diff{
+		DocumentID: docID,
+		From:       from,
+		To:         to,
+		Attributes: []documents.Change{{Field: "label", Type: documents.ChangeAdded, New: "string(value)"}},
+	}
+	docSrv.On("Diff", mock.Anything, docID, []byte(nil), []byte(nil)).Return(diff, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "")
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	var resp documents.Diff
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, *diff, resp)
+	docSrv.AssertExpectations(t)
 }
 
 func TestHandler_RemoveCollaborators(t *testing.T) {
@@ -705,3 +872,205 @@ func TestHandler_GenerateProofsForVersion(t *testing.T) {
 	assert.Contains(t, w.Body.String(), hexutil.Encode(id))
 	docSrv.AssertExpectations(t)
 }
+
+func TestHandler_ListDocuments(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents?"+query, nil).WithContext(ctx)
+	}
+
+	// invalid filter
+	ctx := context.Background()
+	h := handler{}
+	for _, q := range []string{"status=committing", "author=0x12", "from=yesterday", "limit=-1"} {
+		w, r := getHTTPReqAndResp(ctx, q)
+		h.ListDocuments(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrInvalidListFilter.Error())
+	}
+
+	// service error
+	filter := documents.ListFilter{Schemes: []string{"generic"}, Statuses: []documents.Status{documents.Pending}, Limit: 10}
+	pendingSrv := new(pending.MockService)
+	pendingSrv.On("List", ctx, filter).Return(nil, errors.New("failed to list")).Once()
+	h.srv.pendingDocSrv = pendingSrv
+	w, r := getHTTPReqAndResp(ctx, "scheme=generic&status=pending&limit=10")
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to list")
+
+	// success
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{})
+	doc.On("Scheme").Return("generic")
+	doc.On("GetAttributes").Return(nil)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil)
+	doc.On("ID").Return(utils.RandomSlice(32))
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32))
+	doc.On("Author").Return(nil, errors.New("somerror"))
+	doc.On("Timestamp").Return(nil, errors.New("somerror"))
+	doc.On("NFTs").Return(nil)
+	doc.On("GetStatus").Return(documents.Pending)
+	doc.On("CalculateTransitionRulesFingerprint").Return(utils.RandomSlice(32), nil)
+	pendingSrv.On("List", ctx, filter).Return(documents.ListResult{
+		Documents:  []documents.Document{doc},
+		NextCursor: "next",
+	}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "scheme=generic&status=pending&limit=10")
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list DocumentList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+	assert.Equal(t, "next", list.NextCursor)
+
+	// committed documents are listed without their anchors
+	filter.Statuses = []documents.Status{documents.Committed}
+	committed := new(testingdocuments.MockModel)
+	committed.On("GetData").Return(generic.Data{})
+	committed.On("Scheme").Return("generic")
+	committed.On("GetAttributes").Return(nil)
+	committed.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil)
+	committed.On("ID").Return(utils.RandomSlice(32))
+	committed.On("CurrentVersion").Return(utils.RandomSlice(32))
+	committed.On("Author").Return(nil, errors.New("somerror"))
+	committed.On("Timestamp").Return(nil, errors.New("somerror"))
+	committed.On("NFTs").Return(nil)
+	committed.On("GetStatus").Return(documents.Committed)
+	committed.On("CalculateTransitionRulesFingerprint").Return(utils.RandomSlice(32), nil)
+	pendingSrv.On("List", ctx, filter).Return(documents.ListResult{
+		Documents: []documents.Document{committed},
+	}, nil).Once()
+	anchorSrv := new(anchors.MockAnchorService)
+	h.srv.anchorSrv = anchorSrv
+	w, r = getHTTPReqAndResp(ctx, "scheme=generic&status=committed&limit=10")
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"anchor"`)
+	anchorSrv.AssertNotCalled(t, "GetAnchor", mock.Anything)
+	pendingSrv.AssertExpectations(t)
+}
+
+func TestHandler_ExportDocument(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/export", nil).WithContext(ctx)
+	}
+
+	// empty document_id and invalid
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = make([]string, 1, 1)
+	rctx.URLParams.Values = make([]string, 1, 1)
+	rctx.URLParams.Keys[0] = "document_id"
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+
+	for _, id := range []string{"", "invalid"} {
+		rctx.URLParams.Values[0] = id
+		w, r := getHTTPReqAndResp(ctx)
+		h.ExportDocument(w, r)
+		assert.Equal(t, w.Code, http.StatusBadRequest)
+		assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+	}
+
+	// missing document
+	id := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("Export", mock.Anything, id).Return(nil, documents.ErrDocumentNotFound).Once()
+	h = handler{srv: Service{docSrv: docSrv}}
+	w, r := getHTTPReqAndResp(ctx)
+	h.ExportDocument(w, r)
+	assert.Equal(t, w.Code, http.StatusNotFound)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// failed export
+	docSrv.On("Export", mock.Anything, id).Return(nil, errors.New("failed to sign")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ExportDocument(w, r)
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+	assert.Contains(t, w.Body.String(), "failed to sign")
+
+	// success
+	bundle := &documents.Bundle{FormatVersion: documents.BundleFormatVersion, DocumentID: id, Scheme: generic.Scheme}
+	docSrv.On("Export", mock.Anything, id).Return(bundle, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ExportDocument(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	got := new(documents.Bundle)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), got))
+	assert.Equal(t, bundle.DocumentID, got.DocumentID)
+	docSrv.AssertExpectations(t)
+}
+
+func TestHandler_ImportDocument(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/import", body).WithContext(ctx)
+	}
+
+	// invalid body
+	ctx := context.Background()
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.ImportDocument(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+
+	// invalid bundle
+	bundle := &documents.Bundle{FormatVersion: documents.BundleFormatVersion, DocumentID: utils.RandomSlice(32)}
+	d, err := json.Marshal(bundle)
+	assert.NoError(t, err)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("Import", mock.Anything, bundle).Return(nil, errors.NewTypedError(documents.ErrBundleInvalid, errors.New("signature missing"))).Once()
+	h = handler{srv: Service{docSrv: docSrv}}
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.ImportDocument(w, r)
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+	assert.Contains(t, w.Body.String(), "signature missing")
+
+	// failed to store
+	docSrv.On("Import", mock.Anything, bundle).Return(nil, documents.ErrDocumentPersistence).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.ImportDocument(w, r)
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+
+	// success
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{})
+	doc.On("Scheme").Return(generic.Scheme)
+	doc.On("GetAttributes").Return(nil)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil)
+	doc.On("ID").Return(bundle.DocumentID.Bytes())
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32))
+	doc.On("Author").Return(nil, errors.New("somerror"))
+	doc.On("Timestamp").Return(nil, errors.New("somerror"))
+	doc.On("NFTs").Return(nil)
+	doc.On("GetStatus").Return(documents.Committed)
+	doc.On("CalculateTransitionRulesFingerprint").Return(utils.RandomSlice(32), nil)
+	docSrv.On("Import", mock.Anything, bundle).Return(doc, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.ImportDocument(w, r)
+	assert.Equal(t, w.Code, http.StatusCreated)
+	assert.Contains(t, w.Body.String(), bundle.DocumentID.String())
+	docSrv.AssertExpectations(t)
+}
+
+func TestToListFilter(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	q := url.Values{}
+	q.Set("author", did.String())
+	q.Set("collaborator", did.String())
+	q.Set("attribute", "label")
+	q.Set("attribute_value", "value")
+	q.Set("from", "2020-01-01T00:00:00Z")
+	q.Set("to", "2020-01-02T00:00:00Z")
+	q.Set("cursor", "cursor")
+	filter, err := toListFilter(q)
+	assert.NoError(t, err)
+	key, err := documents.AttrKeyFromLabel("label")
+	assert.NoError(t, err)
+	assert.Equal(t, did, *filter.Author)
+	assert.Equal(t, did, *filter.Collaborator)
+	assert.Equal(t, key, *filter.AttributeKey)
+	assert.Equal(t, "value", filter.AttributeValue)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), filter.From.UTC())
+	assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), filter.To.UTC())
+	assert.Equal(t, "cursor", filter.Cursor)
+}
@@ -0,0 +1,147 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+)
+
+const (
+	// ErrInvalidEventOffset is a sentinel error when the offset to resume the event stream from is invalid.
+	ErrInvalidEventOffset = errors.Error("Invalid event offset")
+
+	// ErrStreamingUnsupported is a sentinel error when the response can't be streamed.
+	ErrStreamingUnsupported = errors.Error("Streaming unsupported")
+
+	// keepAliveInterval is the interval between the comments sent to keep the idle event stream open.
+	keepAliveInterval = 15 * time.Second
+)
+
+// eventOffset returns the offset of the last event received by the client from the
+// Last-Event-ID header set by reconnecting EventSource clients, or the offset query param.
+func eventOffset(r *http.Request) (uint64, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("offset")
+	}
+
+	if v == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, errors.NewTypedError(ErrInvalidEventOffset, err)
+	}
+
+	return offset, nil
+}
+
+func writeEvent(w io.Writer, e notification.Event) error {
+	data, err := json.Marshal(e.Message)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Offset, e.Message.EventType, data)
+	return err
+}
+
+// Events streams the events of the account as server-sent events.
+// @summary Streams the events of the account.
+// @description Streams the events of the account as server-sent events. The id of each event is its offset.
+// @description Reconnecting clients resume after the offset in the Last-Event-ID header or the offset query param.
+// @description The latest 1000 events of the account are retained for resuming.
+// @description The data of each event is the notification message, and the event name is its event type.
+// @id events
+// @tags Events
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param Last-Event-ID header string false "Offset of the last event received"
+// @param offset query string false "Offset of the last event received"
+// @produce text/event-stream
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} notification.Message
+// @router /v2/events [get]
+func (h handler) Events(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	offset, err := eventOffset(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err = ErrStreamingUnsupported
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	backlog, events, unsubscribe, err := h.srv.SubscribeEvents(account, offset)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	for _, e := range backlog {
+		if err := writeEvent(w, e); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				// subscriber fell behind, the client reconnects and resumes from the last event.
+				return
+			}
+
+			if err := writeEvent(w, e); err != nil {
+				log.Error(err)
+				return
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				log.Error(err)
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,77 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/notification"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Events(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/events"+query, nil).WithContext(ctx)
+	}
+
+	// missing account
+	h := handler{}
+	w, r := getHTTPReqAndResp(context.Background(), "")
+	h.Events(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// invalid offset
+	did := testingidentity.GenerateRandomDID()
+	ctx := context.WithValue(context.Background(), config.AccountHeaderKey, did.String())
+	w, r = getHTTPReqAndResp(ctx, "?offset=invalid")
+	h.Events(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidEventOffset.Error())
+
+	// failed subscription
+	outbox := new(notification.MockOutbox)
+	h = handler{srv: Service{outbox: outbox}}
+	outbox.On("Subscribe", did, uint64(0)).Return(nil, nil, errors.New("failed")).Once()
+	w, r = getHTTPReqAndResp(ctx, "")
+	h.Events(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// resume from Last-Event-ID with backlog and a live event
+	backlog := []notification.Event{{Offset: 3, Message: notification.Message{EventType: notification.EventTypeJob}}}
+	events := make(chan notification.Event, 1)
+	events <- notification.Event{Offset: 4, Message: notification.Message{EventType: notification.EventTypeAnchor}}
+	close(events)
+	outbox.On("Subscribe", did, uint64(2)).Return(backlog, events, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "?offset=1")
+	r.Header.Set("Last-Event-ID", "2")
+	h.Events(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "id: 3\nevent: job\ndata: {\"event_type\":\"job\"")
+	assert.Contains(t, w.Body.String(), "id: 4\nevent: anchor\ndata: {\"event_type\":\"anchor\"")
+
+	// stream ends once the client disconnects
+	cctx, cancel := context.WithCancel(ctx)
+	outbox.On("Subscribe", did, uint64(0)).Return(nil, make(chan notification.Event), nil).Once()
+	w, r = getHTTPReqAndResp(cctx, "")
+	done := make(chan struct{})
+	go func() {
+		h.Events(w, r)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event stream didn't end")
+	}
+
+	outbox.AssertExpectations(t)
+}
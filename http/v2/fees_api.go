@@ -0,0 +1,269 @@
+package v2
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/fees"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/render"
+)
+
+// ErrNoFeeOperations is a sentinel error when the fee estimate request has no operations.
+const ErrNoFeeOperations = errors.Error("no operations to estimate")
+
+// FeeEstimateRequest is the list of the operations to estimate the fees of.
+type FeeEstimateRequest struct {
+	Operations []fees.Operation `json:"operations" enums:"anchor_commit,nft_mint,oracle_push,identity_add_key,identity_revoke_key"`
+}
+
+// FeeEstimate is the fee of an operation, in the smallest unit of the currency of the chain.
+// Weight is set for the operations on the centrifuge chain, gas limit and gas price for the operations on ethereum.
+type FeeEstimate struct {
+	Operation fees.Operation `json:"operation"`
+	Chain     fees.Chain     `json:"chain" enums:"centrifuge,ethereum"`
+	Fee       string         `json:"fee"`
+	Weight    uint64         `json:"weight,omitempty"`
+	GasLimit  uint64         `json:"gas_limit,omitempty"`
+	GasPrice  string         `json:"gas_price,omitempty"`
+
+	// WithinBudget is false if the fee exceeds what is left of the daily budget of the account on the chain.
+	WithinBudget bool `json:"within_budget"`
+}
+
+// FeeEstimateResponse holds the fees of the operations along with their totals by chain.
+type FeeEstimateResponse struct {
+	Estimates []FeeEstimate         `json:"estimates"`
+	Totals    map[fees.Chain]string `json:"totals"`
+}
+
+// FeeBudgetRequest holds the daily limits of the fees of the account by chain, in the smallest unit of the
+// currency of the chain. The fees on the chains without a limit are not capped.
+type FeeBudgetRequest struct {
+	Limits map[fees.Chain]string `json:"limits"`
+}
+
+// FeeBudget is the daily fee budget of the account along with the fees spent on the UTC day.
+type FeeBudget struct {
+	Limits    map[fees.Chain]string `json:"limits"`
+	Day       string                `json:"day"`
+	Spent     map[fees.Chain]string `json:"spent"`
+	Remaining map[fees.Chain]string `json:"remaining"`
+}
+
+func toFeeBudget(b fees.Budget) FeeBudget {
+	fb := FeeBudget{
+		Limits:    make(map[fees.Chain]string),
+		Day:       b.Day,
+		Spent:     make(map[fees.Chain]string),
+		Remaining: make(map[fees.Chain]string),
+	}
+
+	for chain, limit := range b.Limits {
+		fb.Limits[chain] = limit.String()
+		remaining, _ := b.Remaining(chain)
+		fb.Remaining[chain] = remaining.String()
+	}
+
+	for chain, spent := range b.Spent {
+		fb.Spent[chain] = spent.String()
+	}
+
+	return fb
+}
+
+// EstimateFees estimates the fees of the operations.
+// @summary Estimates the fees of the operations.
+// @description Estimates the fees of anchoring and NFT minting on the centrifuge chain, as computed by the chain, and of
+// @description oracle pushes and identity key operations on ethereum, as the gas limit times the current gas price.
+// @description Fees are in the smallest unit of the currency of the chain.
+// @id estimate_fees
+// @tags Fees
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.FeeEstimateRequest true "Fee estimate request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.FeeEstimateResponse
+// @router /v2/fees/estimate [post]
+func (h handler) EstimateFees(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	var req FeeEstimateRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	if len(req.Operations) == 0 {
+		code = http.StatusBadRequest
+		err = ErrNoFeeOperations
+		return
+	}
+
+	budget, err := h.srv.GetFeeBudget(did)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	resp := FeeEstimateResponse{Totals: make(map[fees.Chain]string)}
+	totals := make(map[fees.Chain]*big.Int)
+	for _, op := range req.Operations {
+		var e fees.Estimate
+		e, err = h.srv.EstimateFee(r.Context(), op)
+		if err != nil {
+			code = http.StatusInternalServerError
+			if errors.IsOfType(fees.ErrUnknownOperation, err) {
+				code = http.StatusBadRequest
+			}
+
+			log.Error(err)
+			return
+		}
+
+		fe := FeeEstimate{
+			Operation:    e.Operation,
+			Chain:        e.Chain,
+			Fee:          e.Fee.String(),
+			Weight:       e.Weight,
+			GasLimit:     e.GasLimit,
+			WithinBudget: true,
+		}
+
+		if e.GasPrice != nil {
+			fe.GasPrice = e.GasPrice.String()
+		}
+
+		if remaining, ok := budget.Remaining(e.Chain); ok {
+			fe.WithinBudget = e.Fee.Cmp(remaining) <= 0
+		}
+
+		if _, ok := totals[e.Chain]; !ok {
+			totals[e.Chain] = new(big.Int)
+		}
+
+		totals[e.Chain].Add(totals[e.Chain], e.Fee)
+		resp.Estimates = append(resp.Estimates, fe)
+	}
+
+	for chain, total := range totals {
+		resp.Totals[chain] = total.String()
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// GetFeeBudget returns the daily fee budget of the account.
+// @summary Returns the daily fee budget of the account.
+// @description Returns the daily limits of the fees of the account by chain, along with the fees spent and left on the UTC day.
+// @id get_fee_budget
+// @tags Fees
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.FeeBudget
+// @router /v2/fees/budget [get]
+func (h handler) GetFeeBudget(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	b, err := h.srv.GetFeeBudget(did)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toFeeBudget(b))
+}
+
+// SetFeeBudget replaces the daily limits of the fee budget of the account.
+// @summary Replaces the daily limits of the fee budget of the account.
+// @description Replaces the daily limits of the fees of the account by chain. Operations of the account whose fee exceeds
+// @description what is left of the limit on the UTC day are rejected, and their jobs fail.
+// @id set_fee_budget
+// @tags Fees
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.FeeBudgetRequest true "Fee budget request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.FeeBudget
+// @router /v2/fees/budget [put]
+func (h handler) SetFeeBudget(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	var req FeeBudgetRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	limits := make(map[fees.Chain]*big.Int)
+	for chain, l := range req.Limits {
+		limit, ok := new(big.Int).SetString(l, 10)
+		if !ok {
+			code = http.StatusBadRequest
+			err = errors.NewTypedError(fees.ErrInvalidBudget, errors.New("invalid limit %q for chain %s", l, chain))
+			log.Error(err)
+			return
+		}
+
+		limits[chain] = limit
+	}
+
+	b, err := h.srv.SetFeeLimits(did, limits)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if errors.IsOfType(fees.ErrInvalidBudget, err) {
+			code = http.StatusBadRequest
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toFeeBudget(b))
+}
@@ -0,0 +1,130 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/fees"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func feesContext(authorization string) context.Context {
+	ctx := context.Background()
+	if authorization != "" {
+		ctx = context.WithValue(ctx, config.AccountHeaderKey, authorization)
+	}
+
+	return ctx
+}
+
+func TestHandler_EstimateFees(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/fees/estimate", bytes.NewReader([]byte(body))).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	feeSrv := new(fees.MockService)
+	h := handler{srv: Service{feeSrv: feeSrv}}
+
+	// missing account
+	w, r := getHTTPReqAndResp(feesContext(""), "")
+	h.EstimateFees(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// no operations
+	ctx := feesContext(did.String())
+	w, r = getHTTPReqAndResp(ctx, `{"operations": []}`)
+	h.EstimateFees(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrNoFeeOperations.Error())
+
+	// unknown operation
+	budget := fees.Budget{AccountID: did, Limits: map[fees.Chain]*big.Int{fees.ChainCentrifuge: big.NewInt(100)}}
+	feeSrv.On("GetBudget", did).Return(budget, nil)
+	feeSrv.On("Estimate", mock.Anything, fees.Operation("transfer")).Return(
+		nil, errors.NewTypedError(fees.ErrUnknownOperation, errors.New("transfer"))).Once()
+	w, r = getHTTPReqAndResp(ctx, `{"operations": ["transfer"]}`)
+	h.EstimateFees(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	feeSrv.On("Estimate", mock.Anything, fees.OperationAnchorCommit).Return(fees.Estimate{
+		Operation: fees.OperationAnchorCommit,
+		Chain:     fees.ChainCentrifuge,
+		Fee:       big.NewInt(150),
+		Weight:    1000,
+	}, nil).Once()
+	feeSrv.On("Estimate", mock.Anything, fees.OperationOraclePush).Return(fees.Estimate{
+		Operation: fees.OperationOraclePush,
+		Chain:     fees.ChainEthereum,
+		Fee:       big.NewInt(2000),
+		GasLimit:  1000,
+		GasPrice:  big.NewInt(2),
+	}, nil).Twice()
+	w, r = getHTTPReqAndResp(ctx, `{"operations": ["anchor_commit", "oracle_push", "oracle_push"]}`)
+	h.EstimateFees(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"fee":"150","weight":1000,"within_budget":false`)
+	assert.Contains(t, w.Body.String(), `"gas_price":"2","within_budget":true`)
+	assert.Contains(t, w.Body.String(), `"totals":{"centrifuge":"150","ethereum":"4000"}`)
+	feeSrv.AssertExpectations(t)
+}
+
+func TestHandler_FeeBudget(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	feeSrv := new(fees.MockService)
+	h := handler{srv: Service{feeSrv: feeSrv}}
+	budget := fees.Budget{
+		AccountID: did,
+		Limits:    map[fees.Chain]*big.Int{fees.ChainCentrifuge: big.NewInt(100)},
+		Day:       "2020-01-02",
+		Spent:     map[fees.Chain]*big.Int{fees.ChainCentrifuge: big.NewInt(40)},
+	}
+
+	// missing account
+	w, r := httptest.NewRecorder(), httptest.NewRequest("GET", "/fees/budget", nil).WithContext(feesContext(""))
+	h.GetFeeBudget(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// get budget
+	ctx := feesContext(did.String())
+	feeSrv.On("GetBudget", did).Return(budget, nil).Once()
+	w, r = httptest.NewRecorder(), httptest.NewRequest("GET", "/fees/budget", nil).WithContext(ctx)
+	h.GetFeeBudget(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"remaining":{"centrifuge":"60"}`)
+
+	setBudget := func(body string) *httptest.ResponseRecorder {
+		w, r := httptest.NewRecorder(), httptest.NewRequest("PUT", "/fees/budget", bytes.NewReader([]byte(body))).WithContext(ctx)
+		h.SetFeeBudget(w, r)
+		return w
+	}
+
+	// invalid limit
+	w = setBudget(`{"limits": {"centrifuge": "1.5"}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), fees.ErrInvalidBudget.Error())
+
+	// invalid budget
+	limits := map[fees.Chain]*big.Int{"bitcoin": big.NewInt(1)}
+	feeSrv.On("SetLimits", did, limits).Return(nil, errors.NewTypedError(fees.ErrInvalidBudget, errors.New("bitcoin"))).Once()
+	w = setBudget(`{"limits": {"bitcoin": "1"}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	feeSrv.On("SetLimits", did, budget.Limits).Return(budget, nil).Once()
+	w = setBudget(`{"limits": {"centrifuge": "100"}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"limits":{"centrifuge":"100"}`)
+	feeSrv.AssertExpectations(t)
+}
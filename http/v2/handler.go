@@ -19,12 +19,17 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	h := handler{srv: srv}
 
 	r.Post("/documents", h.CreateDocument)
+	r.Get("/documents", h.ListDocuments)
+	r.Post("/documents/import", h.ImportDocument)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/clone", h.CloneDocument)
 	r.Patch("/documents/{"+coreapi.DocumentIDParam+"}", h.UpdateDocument)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/commit", h.Commit)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/pending", h.GetPendingDocument)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/committed", h.GetCommittedDocument)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions", h.GetDocumentVersions)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/diff", h.GetDocumentDiff)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}", h.GetDocumentVersion)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/export", h.ExportDocument)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/signed_attribute", h.AddSignedAttribute)
 	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/collaborators", h.RemoveCollaborators)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/roles/{"+RoleIDParam+"}", h.GetRole)
@@ -37,10 +42,31 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/attributes", h.AddAttributes)
 	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/attributes/{"+AttributeKeyParam+"}", h.DeleteAttribute)
 	r.Post("/accounts/generate", h.GenerateAccount)
+	r.Get("/jobs", h.ListJobs)
 	r.Get("/jobs/{"+jobIDParam+"}", h.Job)
+	r.Post("/jobs/{"+jobIDParam+"}/cancel", h.CancelJob)
+	r.Get("/jobs/{"+jobIDParam+"}/logs", h.JobLogs)
+	r.Get("/anchors/{"+anchorIDParam+"}", h.GetAnchor)
+	r.Post("/batch", h.SubmitBatch)
+	r.Get("/batch/{"+batchIDParam+"}", h.GetBatch)
+	r.Post("/fees/estimate", h.EstimateFees)
+	r.Get("/fees/budget", h.GetFeeBudget)
+	r.Put("/fees/budget", h.SetFeeBudget)
+	r.Post("/schedules", h.CreateSchedule)
+	r.Get("/schedules", h.ListSchedules)
+	r.Delete("/schedules/{"+scheduleIDParam+"}", h.DeleteSchedule)
+	r.Get("/events", h.Events)
+	r.Get("/notifications/failed", h.FailedNotifications)
+	r.Post("/notifications/failed/{"+deliveryIDParam+"}/replay", h.ReplayNotification)
+	r.Get("/admin/backup", h.Backup)
 	r.Post("/accounts/{"+coreapi.AccountIDParam+"}/sign", h.SignPayload)
 	r.Get("/accounts/{"+coreapi.AccountIDParam+"}", h.GetAccount)
 	r.Get("/accounts", h.GetAccounts)
+	r.Post("/accounts/{"+coreapi.AccountIDParam+"}/webhooks", h.CreateWebhook)
+	r.Get("/accounts/{"+coreapi.AccountIDParam+"}/webhooks", h.ListWebhooks)
+	r.Get("/accounts/{"+coreapi.AccountIDParam+"}/webhooks/{"+webhookIDParam+"}", h.GetWebhook)
+	r.Put("/accounts/{"+coreapi.AccountIDParam+"}/webhooks/{"+webhookIDParam+"}", h.UpdateWebhook)
+	r.Delete("/accounts/{"+coreapi.AccountIDParam+"}/webhooks/{"+webhookIDParam+"}", h.DeleteWebhook)
 	r.Post("/nfts/registries/{"+coreapi.RegistryAddressParam+"}/mint", h.MintNFT)
 	r.Post("/nfts/registries/{"+coreapi.RegistryAddressParam+"}/tokens/{"+coreapi.TokenIDParam+"}/transfer", h.TransferNFT)
 	r.Get("/nfts/registries/{"+coreapi.RegistryAddressParam+"}/tokens/{"+coreapi.TokenIDParam+"}/owner", h.OwnerOfNFT)
@@ -2,9 +2,11 @@ package v2
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils/httputils"
 	"github.com/centrifuge/gocelery/v2"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -19,15 +21,41 @@ const (
 	// ErrJobNotFound is a sentinel error when job associated with job_id is not found.
 	ErrJobNotFound = errors.Error("Job not found")
 
+	// ErrInvalidJobFilter is a sentinel error when the query params of the list jobs request are invalid.
+	ErrInvalidJobFilter = errors.Error("Invalid job filter")
+
 	jobIDParam = "job_id"
 )
 
 // Job is an alias for gocelery Job for swagger generation
 type Job = gocelery.Job
 
+// JobInfo is a job of the account along with its status.
+type JobInfo struct {
+	Job
+	RunnerType  string     `json:"runner_type"`
+	Status      string     `json:"status" enums:"pending,success,failed,cancelled"`
+	CreatedAt   time.Time  `json:"created_at" swaggertype:"primitive,string"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty" swaggertype:"primitive,string"`
+	FailedAt    *time.Time `json:"failed_at,omitempty" swaggertype:"primitive,string"`
+
+	// Attempts holds the attempts of the tasks of the job, oldest first.
+	Attempts []jobs.Attempt `json:"attempts"`
+}
+
+// JobList holds the jobs of the account.
+type JobList struct {
+	Data []JobInfo `json:"data"`
+}
+
+// JobLogs holds the messages logged by the tasks of the job, oldest first.
+type JobLogs struct {
+	Data []jobs.LogEntry `json:"data"`
+}
+
 // Job returns the details of a given job.
 // @summary Returns the details of a given Job.
-// @description Returns the details of a given Job.
+// @description Returns the details of a given Job, along with its status and the attempts of its tasks.
 // @id get_job
 // @tags Jobs
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
@@ -36,7 +64,7 @@ type Job = gocelery.Job
 // @Failure 403 {object} httputils.HTTPError
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 404 {object} httputils.HTTPError
-// @success 200 {object} v2.Job
+// @success 200 {object} v2.JobInfo
 // @router /v2/jobs/{job_id} [get]
 func (h handler) Job(w http.ResponseWriter, r *http.Request) {
 	var err error
@@ -59,7 +87,151 @@ func (h handler) Job(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.srv.Job(account, jobID)
+	info, err := h.srv.JobInfo(account, jobID)
+	if err != nil {
+		log.Error(err)
+		err = ErrJobNotFound
+		code = http.StatusNotFound
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toJobInfo(info))
+}
+
+// ListJobs lists the jobs of the account.
+// @summary Lists the jobs of the account.
+// @description Lists the jobs of the account, newest first.
+// @id list_jobs
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param runner query []string false "Runner of the job, or the task of the job without a runner" collectionFormat(multi)
+// @param status query []string false "Job status" collectionFormat(multi) Enums(pending, success, failed, cancelled)
+// @param from query string false "Jobs created after (RFC3339)"
+// @param to query string false "Jobs created before (RFC3339)"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.JobList
+// @router /v2/jobs [get]
+func (h handler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	filter, err := toJobFilter(r.URL.Query())
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	infos, err := h.srv.ListJobs(account, filter)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toJobList(infos))
+}
+
+// CancelJob cancels the job.
+// @summary Cancels the job.
+// @description Cancels the job. The job stops before running its next task, so the task being run is completed.
+// @id cancel_job
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param job_id path string true "Hex encoded Job ID"
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 202
+// @router /v2/jobs/{job_id}/cancel [post]
+func (h handler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	jobID, err := hexutil.Decode(chi.URLParam(r, jobIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidJobID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		log.Error(err)
+		err = ErrJobNotFound
+		code = http.StatusNotFound
+		return
+	}
+
+	err = h.srv.CancelJob(account, jobID)
+	if err != nil {
+		log.Error(err)
+		switch {
+		case errors.IsOfType(jobs.ErrJobFinished, err):
+			code = http.StatusBadRequest
+		case err == gocelery.ErrNotFound:
+			code = http.StatusNotFound
+			err = ErrJobNotFound
+		default:
+			code = http.StatusInternalServerError
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// JobLogs returns the messages logged by the tasks of the job.
+// @summary Returns the messages logged by the tasks of the job.
+// @description Returns the timestamped messages logged by the tasks of the job, oldest first.
+// @description The start and the end of each attempt of a task are logged, along with the progress reported by the task.
+// @id get_job_logs
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param job_id path string true "Hex encoded Job ID"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.JobLogs
+// @router /v2/jobs/{job_id}/logs [get]
+func (h handler) JobLogs(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	jobID, err := hexutil.Decode(chi.URLParam(r, jobIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidJobID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		log.Error(err)
+		err = ErrJobNotFound
+		code = http.StatusNotFound
+		return
+	}
+
+	entries, err := h.srv.JobLogs(account, jobID)
 	if err != nil {
 		log.Error(err)
 		err = ErrJobNotFound
@@ -67,6 +239,11 @@ func (h handler) Job(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	logs := JobLogs{Data: entries}
+	if logs.Data == nil {
+		logs.Data = []jobs.LogEntry{}
+	}
+
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, resp)
+	render.JSON(w, r, logs)
 }
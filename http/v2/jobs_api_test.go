@@ -4,9 +4,11 @@ package v2
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -58,7 +60,7 @@ func TestService_GetJob(t *testing.T) {
 	ctx = context.WithValue(ctx, config.AccountHeaderKey, did.String())
 	w, r = getHTTPReqAndResp(ctx)
 	dispatcher := new(jobs.MockDispatcher)
-	dispatcher.On("Job", did, jobID).Return(nil, errors.New("missing job")).Once()
+	dispatcher.On("Info", did, jobID).Return(nil, errors.New("missing job")).Once()
 	h = handler{srv: Service{dispatcher: dispatcher}}
 	h.Job(w, r)
 	assert.Equal(t, w.Code, http.StatusNotFound)
@@ -66,8 +68,199 @@ func TestService_GetJob(t *testing.T) {
 
 	// success
 	w, r = getHTTPReqAndResp(ctx)
-	dispatcher.On("Job", did, jobID).Return(new(gocelery.Job), nil).Once()
+	now := time.Now().UTC()
+	dispatcher.On("Info", did, jobID).Return(jobs.Info{
+		Job:      &gocelery.Job{ID: jobID},
+		Status:   jobs.StatusFailed,
+		FailedAt: &now,
+		Attempts: []jobs.Attempt{{Task: "anchor", Attempt: 1, Error: "timeout"}},
+	}, nil).Once()
 	h.Job(w, r)
 	assert.Equal(t, w.Code, http.StatusOK)
+	var info struct {
+		Status   string         `json:"status"`
+		FailedAt *time.Time     `json:"failed_at"`
+		Attempts []jobs.Attempt `json:"attempts"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, string(jobs.StatusFailed), info.Status)
+	assert.NotNil(t, info.FailedAt)
+	assert.Equal(t, []jobs.Attempt{{Task: "anchor", Attempt: 1, Error: "timeout"}}, info.Attempts)
+	dispatcher.AssertExpectations(t)
+}
+
+func TestHandler_ListJobs(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/jobs"+query, nil).WithContext(ctx)
+	}
+
+	// missing account
+	h := handler{}
+	w, r := getHTTPReqAndResp(context.Background(), "")
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// invalid filters
+	did := testingidentity.GenerateRandomDID()
+	ctx := context.WithValue(context.Background(), config.AccountHeaderKey, did.String())
+	for _, query := range []string{"?status=unknown", "?from=yesterday", "?to=1"} {
+		w, r = getHTTPReqAndResp(ctx, query)
+		h.ListJobs(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrInvalidJobFilter.Error())
+	}
+
+	// failed list
+	from := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	query := "?runner=add&status=pending&status=cancelled&from=" + from.Format(time.RFC3339)
+	filter := jobs.Filter{
+		Runners:  []string{"add"},
+		Statuses: []jobs.Status{jobs.StatusPending, jobs.StatusCancelled},
+		From:     from,
+	}
+	dispatcher := new(jobs.MockDispatcher)
+	dispatcher.On("List", did, filter).Return(nil, errors.New("failed")).Once()
+	h = handler{srv: Service{dispatcher: dispatcher}}
+	w, r = getHTTPReqAndResp(ctx, query)
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// success
+	cancelledAt := time.Now().UTC()
+	job := gocelery.NewRunnerFuncJob("Test", "add", nil, nil, time.Time{})
+	dispatcher.On("List", did, filter).Return([]jobs.Info{{
+		Job:         job,
+		Runner:      "add",
+		Status:      jobs.StatusCancelled,
+		CreatedAt:   from,
+		CancelledAt: &cancelledAt,
+	}}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, query)
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []struct {
+			JobID       string `json:"JobID"`
+			RunnerType  string `json:"runner_type"`
+			Status      string `json:"status"`
+			CreatedAt   string `json:"created_at"`
+			CancelledAt string `json:"cancelled_at"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, job.HexID(), resp.Data[0].JobID)
+	assert.Equal(t, "add", resp.Data[0].RunnerType)
+	assert.Equal(t, "cancelled", resp.Data[0].Status)
+	assert.Equal(t, from.Format(time.RFC3339), resp.Data[0].CreatedAt)
+	assert.NotEmpty(t, resp.Data[0].CancelledAt)
+
+	// no jobs
+	dispatcher.On("List", did, jobs.Filter{}).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "")
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+	dispatcher.AssertExpectations(t)
+}
+
+func TestHandler_CancelJob(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/jobs/{job_id}/cancel", nil).WithContext(ctx)
+	}
+
+	// invalid job_id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{"job_id"}
+	rctx.URLParams.Values = []string{"invalid"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx)
+	h.CancelJob(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidJobID.Error())
+
+	// missing account
+	jobID := gocelery.JobID(utils.RandomSlice(32))
+	rctx.URLParams.Values[0] = hexutil.Encode(jobID)
+	w, r = getHTTPReqAndResp(ctx)
+	h.CancelJob(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	did := testingidentity.GenerateRandomDID()
+	ctx = context.WithValue(ctx, config.AccountHeaderKey, did.String())
+	dispatcher := new(jobs.MockDispatcher)
+	h = handler{srv: Service{dispatcher: dispatcher}}
+	for _, c := range []struct {
+		err  error
+		code int
+	}{
+		{gocelery.ErrNotFound, http.StatusNotFound},
+		{jobs.ErrJobFinished, http.StatusBadRequest},
+		{errors.New("failed"), http.StatusInternalServerError},
+		{nil, http.StatusAccepted},
+	} {
+		dispatcher.On("Cancel", did, jobID).Return(c.err).Once()
+		w, r = getHTTPReqAndResp(ctx)
+		h.CancelJob(w, r)
+		assert.Equal(t, c.code, w.Code)
+	}
+
+	dispatcher.AssertExpectations(t)
+}
+
+func TestHandler_JobLogs(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/jobs/{job_id}/logs", nil).WithContext(ctx)
+	}
+
+	// invalid job_id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{"job_id"}
+	rctx.URLParams.Values = []string{"invalid"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx)
+	h.JobLogs(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidJobID.Error())
+
+	// missing account
+	jobID := gocelery.JobID(utils.RandomSlice(32))
+	rctx.URLParams.Values[0] = hexutil.Encode(jobID)
+	w, r = getHTTPReqAndResp(ctx)
+	h.JobLogs(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// missing job
+	did := testingidentity.GenerateRandomDID()
+	ctx = context.WithValue(ctx, config.AccountHeaderKey, did.String())
+	dispatcher := new(jobs.MockDispatcher)
+	h = handler{srv: Service{dispatcher: dispatcher}}
+	dispatcher.On("Logs", did, jobID).Return(nil, gocelery.ErrNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.JobLogs(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrJobNotFound.Error())
+
+	// no logs
+	dispatcher.On("Logs", did, jobID).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.JobLogs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+
+	// success
+	entries := []jobs.LogEntry{
+		{Task: "request_signatures", Level: jobs.LogInfo, Message: "task started", LoggedAt: time.Now().UTC()},
+		{Task: "request_signatures", Level: jobs.LogWarn, Message: "attempt 1 failed, retrying in 5s", LoggedAt: time.Now().UTC()},
+	}
+	dispatcher.On("Logs", did, jobID).Return(entries, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.JobLogs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var logs JobLogs
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &logs))
+	assert.Equal(t, entries, logs.Data)
 	dispatcher.AssertExpectations(t)
 }
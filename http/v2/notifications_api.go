@@ -0,0 +1,107 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+const (
+	// ErrInvalidDeliveryID is a sentinel error when the delivery_id passed is invalid.
+	ErrInvalidDeliveryID = errors.Error("Invalid delivery ID")
+
+	deliveryIDParam = "delivery_id"
+)
+
+// FailedNotificationList holds the failed notifications of the account.
+type FailedNotificationList struct {
+	Data []notification.Delivery `json:"data"`
+}
+
+// FailedNotifications lists the notifications of the account that exhausted their delivery attempts.
+// @summary Lists the failed notifications of the account.
+// @description Lists the notifications of the account that exhausted their delivery attempts.
+// @id list_failed_notifications
+// @tags Notifications
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.FailedNotificationList
+// @router /v2/notifications/failed [get]
+func (h handler) FailedNotifications(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	deliveries, err := h.srv.FailedNotifications(account)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	list := FailedNotificationList{Data: []notification.Delivery{}}
+	list.Data = append(list.Data, deliveries...)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
+// ReplayNotification schedules the failed notification for delivery again.
+// @summary Replays a failed notification.
+// @description Moves the failed notification back to the outbox and resets its delivery attempts.
+// @id replay_notification
+// @tags Notifications
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param delivery_id path string true "Hex encoded delivery ID"
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 202
+// @router /v2/notifications/failed/{delivery_id}/replay [post]
+func (h handler) ReplayNotification(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	deliveryID, err := hexutil.Decode(chi.URLParam(r, deliveryIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidDeliveryID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	err = h.srv.ReplayNotification(account, deliveryID)
+	if err != nil {
+		log.Error(err)
+		code = http.StatusInternalServerError
+		if err == notification.ErrDeliveryNotFound {
+			code = http.StatusNotFound
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
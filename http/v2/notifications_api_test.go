@@ -0,0 +1,107 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/notification"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_FailedNotifications(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/notifications/failed", nil).WithContext(ctx)
+	}
+
+	// missing account
+	h := handler{}
+	w, r := getHTTPReqAndResp(context.Background())
+	h.FailedNotifications(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	did := testingidentity.GenerateRandomDID()
+	ctx := context.WithValue(context.Background(), config.AccountHeaderKey, did.String())
+	outbox := new(notification.MockOutbox)
+	h = handler{srv: Service{outbox: outbox}}
+
+	// failed fetch
+	outbox.On("FailedDeliveries", did).Return(nil, errors.New("failed")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.FailedNotifications(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// no failed deliveries
+	outbox.On("FailedDeliveries", did).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.FailedNotifications(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+
+	// success
+	d := notification.Delivery{ID: utils.RandomSlice(32), AccountID: did[:], URL: "http://localhost", Attempts: 10}
+	outbox.On("FailedDeliveries", did).Return([]notification.Delivery{d}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.FailedNotifications(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp FailedNotificationList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, d.ID, resp.Data[0].ID)
+	assert.Equal(t, 10, resp.Data[0].Attempts)
+	outbox.AssertExpectations(t)
+}
+
+func TestHandler_ReplayNotification(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/notifications/failed/{delivery_id}/replay", nil).WithContext(ctx)
+	}
+
+	// invalid delivery_id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{"delivery_id"}
+	rctx.URLParams.Values = []string{"invalid"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx)
+	h.ReplayNotification(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidDeliveryID.Error())
+
+	// missing account
+	deliveryID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(deliveryID)
+	w, r = getHTTPReqAndResp(ctx)
+	h.ReplayNotification(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	did := testingidentity.GenerateRandomDID()
+	ctx = context.WithValue(ctx, config.AccountHeaderKey, did.String())
+	outbox := new(notification.MockOutbox)
+	h = handler{srv: Service{outbox: outbox}}
+	for _, c := range []struct {
+		err  error
+		code int
+	}{
+		{notification.ErrDeliveryNotFound, http.StatusNotFound},
+		{errors.New("failed"), http.StatusInternalServerError},
+		{nil, http.StatusAccepted},
+	} {
+		outbox.On("Replay", did, deliveryID).Return(c.err).Once()
+		w, r = getHTTPReqAndResp(ctx)
+		h.ReplayNotification(w, r)
+		assert.Equal(t, c.code, w.Code)
+	}
+
+	outbox.AssertExpectations(t)
+}
@@ -0,0 +1,201 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+const (
+	// ErrInvalidScheduleID is a sentinel error when the schedule_id passed is invalid.
+	ErrInvalidScheduleID = errors.Error("Invalid schedule ID")
+
+	scheduleIDParam = "schedule_id"
+)
+
+// ScheduleRequest is the request to schedule the job of an action.
+type ScheduleRequest struct {
+	// Action is one of commit_document, push_to_oracle or recompute_document.
+	Action string `json:"action" enums:"commit_document,push_to_oracle,recompute_document"`
+
+	// Params of the action. commit_document and recompute_document take {"document_id"}, push_to_oracle takes
+	// the push to oracle request along with the "document_id".
+	Params json.RawMessage `json:"params" swaggertype:"object"`
+
+	// RunAt is the time of the first run (RFC3339). Defaults to now.
+	RunAt *time.Time `json:"run_at" swaggertype:"primitive,string"`
+
+	// Interval between the runs, either a duration string, like "24h", or a number of seconds, like 86400.
+	// The job is run once if empty.
+	Interval json.RawMessage `json:"interval" swaggertype:"primitive,string"`
+}
+
+// Schedule is a schedule of the account.
+type Schedule struct {
+	ID        byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`
+	Action    string             `json:"action"`
+	Params    json.RawMessage    `json:"params,omitempty" swaggertype:"object"`
+	RunAt     time.Time          `json:"run_at" swaggertype:"primitive,string"`
+	Interval  string             `json:"interval,omitempty"`
+	CreatedAt time.Time          `json:"created_at" swaggertype:"primitive,string"`
+	LastRunAt *time.Time         `json:"last_run_at,omitempty" swaggertype:"primitive,string"`
+	LastJobID byteutils.HexBytes `json:"last_job_id,omitempty" swaggertype:"primitive,string"`
+	LastError string             `json:"last_error,omitempty"`
+}
+
+// ScheduleList holds the schedules of the account.
+type ScheduleList struct {
+	Data []Schedule `json:"data"`
+}
+
+// CreateSchedule schedules the job of an action.
+// @summary Schedules the job of an action.
+// @description Schedules the job of an action at a future time, and then after every interval if set.
+// @description Schedules persist across the restarts of the node. Runs missed while the node was down are skipped.
+// @id create_schedule
+// @tags Schedules
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.ScheduleRequest true "Schedule request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 201 {object} v2.Schedule
+// @router /v2/schedules [post]
+func (h handler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	var req ScheduleRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	sch, err := toJobsSchedule(req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	sch, err = h.srv.CreateSchedule(account, sch)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if errors.IsOfType(jobs.ErrInvalidSchedule, err) {
+			code = http.StatusBadRequest
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, toSchedule(sch))
+}
+
+// ListSchedules lists the schedules of the account.
+// @summary Lists the schedules of the account.
+// @description Lists the schedules of the account, oldest first.
+// @id list_schedules
+// @tags Schedules
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.ScheduleList
+// @router /v2/schedules [get]
+func (h handler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	schs, err := h.srv.ListSchedules(account)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	list := ScheduleList{Data: []Schedule{}}
+	for _, sch := range schs {
+		list.Data = append(list.Data, toSchedule(sch))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
+// DeleteSchedule deletes the schedule of the account.
+// @summary Deletes the schedule of the account.
+// @description Deletes the schedule of the account. The jobs dispatched by the schedule already are not cancelled.
+// @id delete_schedule
+// @tags Schedules
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param schedule_id path string true "Hex encoded schedule ID"
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 204
+// @router /v2/schedules/{schedule_id} [delete]
+func (h handler) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	id, err := hexutil.Decode(chi.URLParam(r, scheduleIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidScheduleID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	account, err := contextutil.DIDFromContext(r.Context())
+	if err != nil {
+		code = http.StatusForbidden
+		log.Error(err)
+		return
+	}
+
+	err = h.srv.DeleteSchedule(account, id)
+	if err != nil {
+		code = http.StatusInternalServerError
+		if err == jobs.ErrScheduleNotFound {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
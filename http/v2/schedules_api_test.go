@@ -0,0 +1,178 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func scheduleContext(authorization, scheduleID string) context.Context {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{scheduleIDParam}
+	rctx.URLParams.Values = []string{scheduleID}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	if authorization != "" {
+		ctx = context.WithValue(ctx, config.AccountHeaderKey, authorization)
+	}
+
+	return ctx
+}
+
+func TestHandler_CreateSchedule(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body []byte) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/schedules", bytes.NewReader(body)).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	dispatcher := new(jobs.MockDispatcher)
+	h := handler{srv: Service{dispatcher: dispatcher}}
+
+	// missing account
+	w, r := getHTTPReqAndResp(scheduleContext("", ""), nil)
+	h.CreateSchedule(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// invalid body
+	ctx := scheduleContext(did.String(), "")
+	w, r = getHTTPReqAndResp(ctx, []byte("invalid"))
+	h.CreateSchedule(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid intervals
+	for _, interval := range []string{`"daily"`, `-60`, `1.5`, `{}`} {
+		body, err := json.Marshal(ScheduleRequest{Action: "push_to_oracle", Interval: json.RawMessage(interval)})
+		assert.NoError(t, err)
+		w, r = getHTTPReqAndResp(ctx, body)
+		h.CreateSchedule(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+
+	// invalid schedule
+	runAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	params := json.RawMessage(`{"document_id":"0x01"}`)
+	sch := jobs.Schedule{Action: "push_to_oracle", Params: params, RunAt: runAt, Interval: 24 * time.Hour}
+	body, err := json.Marshal(ScheduleRequest{Action: sch.Action, Params: params, RunAt: &runAt, Interval: json.RawMessage(`"24h"`)})
+	assert.NoError(t, err)
+	dispatcher.On("Schedule", did, sch).Return(nil, errors.NewTypedError(jobs.ErrInvalidSchedule, errors.New("invalid"))).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.CreateSchedule(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	created := sch
+	created.ID = utils.RandomSlice(32)
+	dispatcher.On("Schedule", did, sch).Return(created, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.CreateSchedule(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp Schedule
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, created.ID, resp.ID)
+	assert.Equal(t, "24h0m0s", resp.Interval)
+	assert.JSONEq(t, string(params), string(resp.Params))
+
+	// interval in seconds
+	body, err = json.Marshal(ScheduleRequest{Action: sch.Action, Params: params, RunAt: &runAt, Interval: json.RawMessage(`86400`)})
+	assert.NoError(t, err)
+	dispatcher.On("Schedule", did, sch).Return(created, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.CreateSchedule(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	dispatcher.AssertExpectations(t)
+}
+
+func TestHandler_ListSchedules(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/schedules", nil).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	dispatcher := new(jobs.MockDispatcher)
+	h := handler{srv: Service{dispatcher: dispatcher}}
+
+	// missing account
+	w, r := getHTTPReqAndResp(scheduleContext("", ""))
+	h.ListSchedules(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// failed
+	ctx := scheduleContext(did.String(), "")
+	dispatcher.On("Schedules", did).Return(nil, errors.New("failed")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ListSchedules(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// empty
+	dispatcher.On("Schedules", did).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ListSchedules(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+
+	// success
+	dispatcher.On("Schedules", did).Return([]jobs.Schedule{
+		{ID: utils.RandomSlice(32), Action: "commit_document"},
+		{ID: utils.RandomSlice(32), Action: "push_to_oracle", Interval: time.Hour, LastError: "failed"},
+	}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ListSchedules(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list ScheduleList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 2)
+	assert.Empty(t, list.Data[0].Interval)
+	assert.Equal(t, "1h0m0s", list.Data[1].Interval)
+	assert.Equal(t, "failed", list.Data[1].LastError)
+	dispatcher.AssertExpectations(t)
+}
+
+func TestHandler_DeleteSchedule(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("DELETE", "/schedules/{schedule_id}", nil).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	dispatcher := new(jobs.MockDispatcher)
+	h := handler{srv: Service{dispatcher: dispatcher}}
+
+	// invalid schedule ID
+	w, r := getHTTPReqAndResp(scheduleContext(did.String(), "invalid"))
+	h.DeleteSchedule(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidScheduleID.Error())
+
+	// missing account
+	id := utils.RandomSlice(32)
+	w, r = getHTTPReqAndResp(scheduleContext("", hexutil.Encode(id)))
+	h.DeleteSchedule(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// missing schedule
+	ctx := scheduleContext(did.String(), hexutil.Encode(id))
+	dispatcher.On("DeleteSchedule", did, id).Return(jobs.ErrScheduleNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.DeleteSchedule(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	dispatcher.On("DeleteSchedule", did, id).Return(nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.DeleteSchedule(w, r)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	dispatcher.AssertExpectations(t)
+}
@@ -2,17 +2,27 @@ package v2
 
 import (
 	"context"
+	"io"
+	"math/big"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/batch"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/entity"
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/fees"
+	"github.com/centrifuge/go-centrifuge/http/coreapi"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/oracle"
 	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backup"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/centrifuge/gocelery/v2"
 	"github.com/ethereum/go-ethereum/common"
@@ -29,6 +39,12 @@ type Service struct {
 	entitySrv     entity.Service
 	erSrv         entityrelationship.Service
 	docSrv        documents.Service
+	cfg           config.Configuration
+	dbs           map[string]storage.KV
+	outbox        notification.Outbox
+	batchSrv      batch.Service
+	anchorSrv     anchors.Service
+	feeSrv        fees.Service
 }
 
 // CreateDocument creates a pending document from the given payload.
@@ -57,6 +73,11 @@ func (s Service) GetDocument(ctx context.Context, docID []byte, status documents
 	return s.pendingDocSrv.Get(ctx, docID, status)
 }
 
+// ListDocuments returns a page of the documents that match the filter.
+func (s Service) ListDocuments(ctx context.Context, filter documents.ListFilter) (documents.ListResult, error) {
+	return s.pendingDocSrv.List(ctx, filter)
+}
+
 // GetDocumentVersion returns the specific version of the document.
 func (s Service) GetDocumentVersion(ctx context.Context, docID, versionID []byte) (documents.Document, error) {
 	return s.pendingDocSrv.GetVersion(ctx, docID, versionID)
@@ -119,9 +140,132 @@ func (s Service) DeleteAttribute(ctx context.Context, docID []byte, key document
 	return s.pendingDocSrv.DeleteAttribute(ctx, docID, key)
 }
 
-// Job returns the job details
-func (s Service) Job(accID identity.DID, jobID []byte) (*gocelery.Job, error) {
-	return s.dispatcher.Job(accID, jobID)
+// SubmitBatch submits the batch of operations of the account in context.
+func (s Service) SubmitBatch(ctx context.Context, ops []batch.Operation) (batch.Batch, error) {
+	return s.batchSrv.Submit(ctx, ops)
+}
+
+// GetBatch returns the batch of the account in context.
+func (s Service) GetBatch(ctx context.Context, batchID []byte) (batch.Batch, error) {
+	return s.batchSrv.Get(ctx, batchID)
+}
+
+// GetAnchor returns the anchor along with the block it was committed in.
+func (s Service) GetAnchor(anchorID anchors.AnchorID) (anchors.Anchor, error) {
+	return s.anchorSrv.GetAnchor(anchorID)
+}
+
+// EstimateFee returns the fee of the operation when submitted by the account in context.
+func (s Service) EstimateFee(ctx context.Context, op fees.Operation) (fees.Estimate, error) {
+	return s.feeSrv.Estimate(ctx, op)
+}
+
+// GetFeeBudget returns the daily fee budget of the account.
+func (s Service) GetFeeBudget(accountID identity.DID) (fees.Budget, error) {
+	return s.feeSrv.GetBudget(accountID)
+}
+
+// SetFeeLimits replaces the daily limits of the fee budget of the account.
+func (s Service) SetFeeLimits(accountID identity.DID, limits map[fees.Chain]*big.Int) (fees.Budget, error) {
+	return s.feeSrv.SetLimits(accountID, limits)
+}
+
+// documentAnchor returns the anchor of the committed document.
+// Returns nil if the document is not committed, or its anchor is not found on chain.
+func (s Service) documentAnchor(doc documents.Document) *coreapi.Anchor {
+	if s.anchorSrv == nil || doc.GetStatus() != documents.Committed {
+		return nil
+	}
+
+	anchorID, err := anchors.ToAnchorID(doc.CurrentVersion())
+	if err != nil {
+		return nil
+	}
+
+	a, err := s.anchorSrv.GetAnchor(anchorID)
+	if err != nil {
+		log.Warnf("failed to get the anchor %s of the document: %v", anchorID.String(), err)
+		return nil
+	}
+
+	ca := coreapi.ToAnchor(a)
+	return &ca
+}
+
+// JobInfo returns the job along with its status and the attempts of its tasks.
+func (s Service) JobInfo(accID identity.DID, jobID []byte) (jobs.Info, error) {
+	return s.dispatcher.Info(accID, jobID)
+}
+
+// JobLogs returns the messages logged by the tasks of the job.
+func (s Service) JobLogs(accID identity.DID, jobID []byte) ([]jobs.LogEntry, error) {
+	return s.dispatcher.Logs(accID, jobID)
+}
+
+// ListJobs returns the jobs of the account matching the filter.
+func (s Service) ListJobs(accID identity.DID, filter jobs.Filter) ([]jobs.Info, error) {
+	return s.dispatcher.List(accID, filter)
+}
+
+// CancelJob requests the cancellation of the job.
+func (s Service) CancelJob(accID identity.DID, jobID []byte) error {
+	return s.dispatcher.Cancel(accID, jobID)
+}
+
+// CreateSchedule schedules the job of an action for the account.
+func (s Service) CreateSchedule(accID identity.DID, sch jobs.Schedule) (jobs.Schedule, error) {
+	return s.dispatcher.Schedule(accID, sch)
+}
+
+// ListSchedules returns the schedules of the account.
+func (s Service) ListSchedules(accID identity.DID) ([]jobs.Schedule, error) {
+	return s.dispatcher.Schedules(accID)
+}
+
+// DeleteSchedule deletes the schedule of the account.
+func (s Service) DeleteSchedule(accID identity.DID, scheduleID []byte) error {
+	return s.dispatcher.DeleteSchedule(accID, scheduleID)
+}
+
+// FailedNotifications returns the notifications of the account that exhausted their delivery attempts.
+func (s Service) FailedNotifications(accID identity.DID) ([]notification.Delivery, error) {
+	return s.outbox.FailedDeliveries(accID)
+}
+
+// ReplayNotification schedules the failed notification for delivery again.
+func (s Service) ReplayNotification(accID identity.DID, deliveryID []byte) error {
+	return s.outbox.Replay(accID, deliveryID)
+}
+
+// CreateWebhook creates a webhook subscription for the account.
+func (s Service) CreateWebhook(accID identity.DID, sub notification.Subscription) (notification.Subscription, error) {
+	return s.outbox.CreateSubscription(accID, sub)
+}
+
+// GetWebhook returns the webhook subscription of the account.
+func (s Service) GetWebhook(accID identity.DID, id []byte) (notification.Subscription, error) {
+	return s.outbox.GetSubscription(accID, id)
+}
+
+// ListWebhooks returns the webhook subscriptions of the account.
+func (s Service) ListWebhooks(accID identity.DID) ([]notification.Subscription, error) {
+	return s.outbox.ListSubscriptions(accID)
+}
+
+// UpdateWebhook replaces the webhook subscription of the account.
+// The secret is kept if sub has none, unless clearSecret is set.
+func (s Service) UpdateWebhook(accID identity.DID, sub notification.Subscription, clearSecret bool) (notification.Subscription, error) {
+	return s.outbox.UpdateSubscription(accID, sub, clearSecret)
+}
+
+// DeleteWebhook deletes the webhook subscription of the account.
+func (s Service) DeleteWebhook(accID identity.DID, id []byte) error {
+	return s.outbox.DeleteSubscription(accID, id)
+}
+
+// SubscribeEvents returns the events of the account after the offset and a channel of the new events.
+func (s Service) SubscribeEvents(accID identity.DID, offset uint64) ([]notification.Event, <-chan notification.Event, func(), error) {
+	return s.outbox.Subscribe(accID, offset)
 }
 
 // GenerateAccount generates a new account
@@ -171,6 +315,26 @@ func (s Service) GetAccounts() ([]config.Account, error) {
 	return s.accountSrv.GetAccounts()
 }
 
+// GetDocumentVersions returns the versions of the document ordered from the oldest to the newest.
+func (s Service) GetDocumentVersions(ctx context.Context, docID []byte) ([]documents.VersionInfo, error) {
+	return s.docSrv.Versions(ctx, docID)
+}
+
+// DiffDocument returns the changes between the versions from and to of the document.
+func (s Service) DiffDocument(ctx context.Context, docID, from, to []byte) (*documents.Diff, error) {
+	return s.docSrv.Diff(ctx, docID, from, to)
+}
+
+// ExportDocument returns the signed bundle of the committed document with all its versions.
+func (s Service) ExportDocument(ctx context.Context, docID []byte) (*documents.Bundle, error) {
+	return s.docSrv.Export(ctx, docID)
+}
+
+// ImportDocument imports the versions of the document in the bundle.
+func (s Service) ImportDocument(ctx context.Context, bundle *documents.Bundle) (documents.Document, error) {
+	return s.docSrv.Import(ctx, bundle)
+}
+
 // GenerateProofs returns the proofs for the latest version of the document.
 func (s Service) GenerateProofs(ctx context.Context, docID []byte, fields []string) (*documents.DocumentProof, error) {
 	return s.docSrv.CreateProofs(ctx, docID, fields)
@@ -180,3 +344,26 @@ func (s Service) GenerateProofs(ctx context.Context, docID []byte, fields []stri
 func (s Service) GenerateProofsForVersion(ctx context.Context, docID, versionID []byte, fields []string) (*documents.DocumentProof, error) {
 	return s.docSrv.CreateProofsForVersion(ctx, docID, versionID, fields)
 }
+
+// IsNodeIdentity returns true if did is the identity of the node.
+func (s Service) IsNodeIdentity(did identity.DID) bool {
+	if s.cfg == nil {
+		return false
+	}
+
+	id, err := s.cfg.GetIdentityID()
+	if err != nil {
+		return false
+	}
+
+	return did.Equal(identity.NewDID(common.BytesToAddress(id)))
+}
+
+// Backup writes a backup of the node databases to w.
+func (s Service) Backup(w io.Writer) (*backup.Manifest, error) {
+	if len(s.dbs) == 0 {
+		return nil, errors.New("databases not initialised")
+	}
+
+	return backup.Write(w, s.dbs)
+}
@@ -0,0 +1,312 @@
+package v2
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/http/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+const (
+	// ErrInvalidWebhookID is a sentinel error when the webhook_id passed is invalid.
+	ErrInvalidWebhookID = errors.Error("Invalid webhook ID")
+
+	// ErrAccountMismatch is a sentinel error when the account of the path is not the account of the request.
+	ErrAccountMismatch = errors.Error("account doesn't match the authorization header")
+
+	webhookIDParam = "webhook_id"
+)
+
+// WebhookSubscriptionRequest is the request to create or update a webhook subscription.
+type WebhookSubscriptionRequest struct {
+	URL             string                   `json:"url"`
+	EventTypes      []notification.EventType `json:"event_types" enums:"job,document,anchor,nft,signature_requested,signature_given,document_anchored,document_committed,nft_minted,nft_transferred,collaborators_changed,oracle_pushed"`
+	Schemes         []string                 `json:"schemes"`
+	JobDescriptions []string                 `json:"job_descriptions"`
+
+	// Enabled defaults to true.
+	Enabled *bool `json:"enabled"`
+
+	// Secret signs the payloads delivered to the webhook. The node secret is used if empty.
+	// On update, the secret of the subscription is kept if empty.
+	Secret string `json:"secret"`
+
+	// ClearSecret removes the secret of the subscription on update, so that the node secret is used.
+	ClearSecret bool `json:"clear_secret"`
+}
+
+// WebhookSubscription is a webhook subscription of the account.
+// The secret is only returned when the subscription is created.
+type WebhookSubscription = notification.Subscription
+
+// WebhookSubscriptionList holds the webhook subscriptions of the account.
+type WebhookSubscriptionList struct {
+	Data []WebhookSubscription `json:"data"`
+}
+
+// webhookAccount returns the account of the path if it is the account of the request.
+func webhookAccount(r *http.Request) (did identity.DID, code int, err error) {
+	accID, err := hexutil.Decode(chi.URLParam(r, coreapi.AccountIDParam))
+	if err != nil {
+		return did, http.StatusBadRequest, coreapi.ErrAccountIDInvalid
+	}
+
+	did, err = contextutil.DIDFromContext(r.Context())
+	if err != nil || !bytes.Equal(did[:], accID) {
+		return did, http.StatusForbidden, ErrAccountMismatch
+	}
+
+	return did, 0, nil
+}
+
+func webhookSubscriptionCode(err error) int {
+	switch {
+	case err == notification.ErrSubscriptionNotFound:
+		return http.StatusNotFound
+	case errors.IsOfType(notification.ErrInvalidSubscription, err):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CreateWebhook creates a webhook subscription for the account.
+// @summary Creates a webhook subscription for the account.
+// @description Creates a webhook subscription for the account, delivering the events of the subscribed types matching the filters.
+// @id create_webhook
+// @tags Webhooks
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param account_id path string true "Account ID"
+// @param body body v2.WebhookSubscriptionRequest true "Webhook subscription request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 201 {object} v2.WebhookSubscription
+// @router /v2/accounts/{account_id}/webhooks [post]
+func (h handler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, code, err := webhookAccount(r)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	var req WebhookSubscriptionRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	sub, err := h.srv.CreateWebhook(did, toSubscription(req))
+	if err != nil {
+		code = webhookSubscriptionCode(err)
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, sub)
+}
+
+// ListWebhooks lists the webhook subscriptions of the account.
+// @summary Lists the webhook subscriptions of the account.
+// @description Lists the webhook subscriptions of the account, oldest first.
+// @id list_webhooks
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param account_id path string true "Account ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.WebhookSubscriptionList
+// @router /v2/accounts/{account_id}/webhooks [get]
+func (h handler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, code, err := webhookAccount(r)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	subs, err := h.srv.ListWebhooks(did)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	list := WebhookSubscriptionList{Data: []WebhookSubscription{}}
+	for _, sub := range subs {
+		sub.Secret = ""
+		list.Data = append(list.Data, sub)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, list)
+}
+
+// GetWebhook returns the webhook subscription of the account.
+// @summary Returns the webhook subscription of the account.
+// @description Returns the webhook subscription of the account.
+// @id get_webhook
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param account_id path string true "Account ID"
+// @param webhook_id path string true "Hex encoded webhook subscription ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.WebhookSubscription
+// @router /v2/accounts/{account_id}/webhooks/{webhook_id} [get]
+func (h handler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, code, err := webhookAccount(r)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	id, err := hexutil.Decode(chi.URLParam(r, webhookIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidWebhookID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	sub, err := h.srv.GetWebhook(did, id)
+	if err != nil {
+		code = webhookSubscriptionCode(err)
+		log.Error(err)
+		return
+	}
+
+	sub.Secret = ""
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, sub)
+}
+
+// UpdateWebhook replaces the webhook subscription of the account.
+// @summary Updates the webhook subscription of the account.
+// @description Replaces the url, event types, filters, flag and secret of the webhook subscription.
+// @description The secret is kept if not provided, unless clear_secret is set.
+// @id update_webhook
+// @tags Webhooks
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param account_id path string true "Account ID"
+// @param webhook_id path string true "Hex encoded webhook subscription ID"
+// @param body body v2.WebhookSubscriptionRequest true "Webhook subscription request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.WebhookSubscription
+// @router /v2/accounts/{account_id}/webhooks/{webhook_id} [put]
+func (h handler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, code, err := webhookAccount(r)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	id, err := hexutil.Decode(chi.URLParam(r, webhookIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidWebhookID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	var req WebhookSubscriptionRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	sub := toSubscription(req)
+	sub.ID = id
+	sub, err = h.srv.UpdateWebhook(did, sub, req.ClearSecret)
+	if err != nil {
+		code = webhookSubscriptionCode(err)
+		log.Error(err)
+		return
+	}
+
+	sub.Secret = ""
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, sub)
+}
+
+// DeleteWebhook deletes the webhook subscription of the account.
+// @summary Deletes the webhook subscription of the account.
+// @description Deletes the webhook subscription of the account. Pending deliveries to the webhook are dropped.
+// @id delete_webhook
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param account_id path string true "Account ID"
+// @param webhook_id path string true "Hex encoded webhook subscription ID"
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 204
+// @router /v2/accounts/{account_id}/webhooks/{webhook_id} [delete]
+func (h handler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, code, err := webhookAccount(r)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	id, err := hexutil.Decode(chi.URLParam(r, webhookIDParam))
+	if err != nil {
+		err = errors.NewTypedError(ErrInvalidWebhookID, err)
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	err = h.srv.DeleteWebhook(did, id)
+	if err != nil {
+		code = webhookSubscriptionCode(err)
+		log.Error(err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,189 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/http/coreapi"
+	"github.com/centrifuge/go-centrifuge/notification"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func webhookContext(accountID, authorization, webhookID string) context.Context {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.AccountIDParam, webhookIDParam}
+	rctx.URLParams.Values = []string{accountID, webhookID}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	if authorization != "" {
+		ctx = context.WithValue(ctx, config.AccountHeaderKey, authorization)
+	}
+
+	return ctx
+}
+
+func TestHandler_CreateWebhook(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body []byte) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/accounts/{account_id}/webhooks", bytes.NewReader(body)).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	outbox := new(notification.MockOutbox)
+	h := handler{srv: Service{outbox: outbox}}
+
+	// invalid account
+	w, r := getHTTPReqAndResp(webhookContext("invalid", did.String(), ""), nil)
+	h.CreateWebhook(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// account mismatch
+	w, r = getHTTPReqAndResp(webhookContext(testingidentity.GenerateRandomDID().String(), did.String(), ""), nil)
+	h.CreateWebhook(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), ErrAccountMismatch.Error())
+
+	// invalid body
+	ctx := webhookContext(did.String(), did.String(), "")
+	w, r = getHTTPReqAndResp(ctx, []byte("invalid"))
+	h.CreateWebhook(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid subscription
+	sub := notification.Subscription{URL: "http://localhost", EventTypes: []notification.EventType{notification.EventTypeJob}, Enabled: true}
+	body, err := json.Marshal(WebhookSubscriptionRequest{URL: sub.URL, EventTypes: sub.EventTypes})
+	assert.NoError(t, err)
+	outbox.On("CreateSubscription", did, sub).Return(nil, errors.NewTypedError(notification.ErrInvalidSubscription, errors.New("invalid"))).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.CreateWebhook(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success, disabled with secret
+	sub.Enabled = false
+	sub.Secret = "secret"
+	enabled := false
+	body, err = json.Marshal(WebhookSubscriptionRequest{URL: sub.URL, EventTypes: sub.EventTypes, Enabled: &enabled, Secret: sub.Secret})
+	assert.NoError(t, err)
+	created := sub
+	created.ID = utils.RandomSlice(32)
+	outbox.On("CreateSubscription", did, sub).Return(created, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.CreateWebhook(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp WebhookSubscription
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, created.ID, resp.ID)
+	assert.Equal(t, "secret", resp.Secret)
+	outbox.AssertExpectations(t)
+}
+
+func TestHandler_ListGetWebhooks(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	outbox := new(notification.MockOutbox)
+	h := handler{srv: Service{outbox: outbox}}
+	sub := notification.Subscription{ID: utils.RandomSlice(32), URL: "http://localhost", Secret: "secret"}
+
+	// list
+	ctx := webhookContext(did.String(), did.String(), "")
+	outbox.On("ListSubscriptions", did).Return(nil, errors.New("failed")).Once()
+	w, r := httptest.NewRecorder(), httptest.NewRequest("GET", "/accounts/{account_id}/webhooks", nil).WithContext(ctx)
+	h.ListWebhooks(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	outbox.On("ListSubscriptions", did).Return([]notification.Subscription{sub}, nil).Once()
+	w, r = httptest.NewRecorder(), httptest.NewRequest("GET", "/accounts/{account_id}/webhooks", nil).WithContext(ctx)
+	h.ListWebhooks(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list WebhookSubscriptionList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 1)
+	assert.Equal(t, sub.ID, list.Data[0].ID)
+	assert.Empty(t, list.Data[0].Secret)
+
+	// get
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/accounts/{account_id}/webhooks/{webhook_id}", nil).WithContext(ctx)
+	}
+
+	w, r = getHTTPReqAndResp(webhookContext(did.String(), did.String(), "invalid"))
+	h.GetWebhook(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidWebhookID.Error())
+
+	ctx = webhookContext(did.String(), did.String(), hexutil.Encode(sub.ID))
+	outbox.On("GetSubscription", did, []byte(sub.ID)).Return(nil, notification.ErrSubscriptionNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetWebhook(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	outbox.On("GetSubscription", did, []byte(sub.ID)).Return(sub, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetWebhook(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp WebhookSubscription
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, sub.URL, resp.URL)
+	assert.Empty(t, resp.Secret)
+	outbox.AssertExpectations(t)
+}
+
+func TestHandler_UpdateDeleteWebhook(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	outbox := new(notification.MockOutbox)
+	h := handler{srv: Service{outbox: outbox}}
+	id := utils.RandomSlice(32)
+	ctx := webhookContext(did.String(), did.String(), hexutil.Encode(id))
+
+	// update
+	sub := notification.Subscription{ID: id, URL: "http://localhost", Schemes: []string{"generic"}, Enabled: true}
+	body, err := json.Marshal(WebhookSubscriptionRequest{URL: sub.URL, Schemes: sub.Schemes})
+	assert.NoError(t, err)
+	for _, c := range []struct {
+		err  error
+		code int
+	}{
+		{notification.ErrSubscriptionNotFound, http.StatusNotFound},
+		{errors.NewTypedError(notification.ErrInvalidSubscription, errors.New("invalid")), http.StatusBadRequest},
+		{nil, http.StatusOK},
+	} {
+		outbox.On("UpdateSubscription", did, sub, false).Return(sub, c.err).Once()
+		w, r := httptest.NewRecorder(), httptest.NewRequest("PUT", "/accounts/{account_id}/webhooks/{webhook_id}", bytes.NewReader(body)).WithContext(ctx)
+		h.UpdateWebhook(w, r)
+		assert.Equal(t, c.code, w.Code)
+	}
+
+	// clear the secret
+	body, err = json.Marshal(WebhookSubscriptionRequest{URL: sub.URL, Schemes: sub.Schemes, ClearSecret: true})
+	assert.NoError(t, err)
+	outbox.On("UpdateSubscription", did, sub, true).Return(sub, nil).Once()
+	w, r := httptest.NewRecorder(), httptest.NewRequest("PUT", "/accounts/{account_id}/webhooks/{webhook_id}", bytes.NewReader(body)).WithContext(ctx)
+	h.UpdateWebhook(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// delete
+	for _, c := range []struct {
+		err  error
+		code int
+	}{
+		{notification.ErrSubscriptionNotFound, http.StatusNotFound},
+		{errors.New("failed"), http.StatusInternalServerError},
+		{nil, http.StatusNoContent},
+	} {
+		outbox.On("DeleteSubscription", did, id).Return(c.err).Once()
+		w, r := httptest.NewRecorder(), httptest.NewRequest("DELETE", "/accounts/{account_id}/webhooks/{webhook_id}", nil).WithContext(ctx)
+		h.DeleteWebhook(w, r)
+		assert.Equal(t, c.code, w.Code)
+	}
+
+	outbox.AssertExpectations(t)
+}
@@ -1,6 +1,8 @@
 package jobs
 
 import (
+	"time"
+
 	"github.com/centrifuge/gocelery/v2"
 )
 
@@ -8,11 +10,25 @@ import (
 type Task struct {
 	RunnerFunc gocelery.RunnerFunc
 	Next       string
+
+	// Retry retries the failed attempts of the task, and fails the job once the task runs out of attempts.
+	// The task is retried until the job expires if nil.
+	Retry *RetryPolicy
+
+	// Timeout of each attempt of the task. Zero doesn't time out.
+	// The attempt is expected to stop once its context, returned by ContextFromOverrides, is done.
+	Timeout time.Duration
 }
 
 // Base can be embeded to implement base interface functions.
+// The dispatcher stops the chain of tasks once the job is cancelled,
+// and runs the tasks with their retry policy and timeout.
 type Base struct {
 	tasks map[string]Task
+
+	// logs and task are set by the dispatcher before the task is run.
+	logs *verifier
+	task string
 }
 
 // NewBase returns a new base with given tasks
@@ -30,3 +46,20 @@ func (b Base) Next(task string) (next string, ok bool) {
 	next = b.tasks[task].Next
 	return next, next != ""
 }
+
+// Task returns the task with the given name.
+func (b Base) Task(task string) (Task, bool) {
+	t, ok := b.tasks[task]
+	return t, ok
+}
+
+// Logger returns the logger of the task being run, given the overrides passed to its runner func.
+// The logged messages are exposed along with the job.
+func (b Base) Logger(overrides map[string]interface{}) Logger {
+	jobID, _ := overrides[jobIDOverride].(string)
+	return taskLogger{v: b.logs, jobID: jobID, task: b.task}
+}
+
+func (b *Base) setLogger(v verifier, task string) {
+	b.logs, b.task = &v, task
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 
 	"github.com/centrifuge/go-centrifuge/config"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
-	ldb "github.com/syndtr/goleveldb/leveldb"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
 )
 
 // BootstrappedDispatcher is a key to access dispatcher
@@ -16,13 +16,22 @@ type Bootstrapper struct{}
 
 // Bootstrap adds transaction.Repository into context.
 func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
-	db := ctx[leveldb.BootstrappedLevelDB].(*ldb.DB)
+	kv, ok := ctx[storage.BootstrappedKV].(storage.KV)
+	if !ok {
+		return errors.New("storage kv not initialised")
+	}
+
+	repo, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("storage repository not initialised")
+	}
+
 	cfg, err := config.RetrieveConfig(false, ctx)
 	if err != nil {
 		return err
 	}
 
-	d, err := NewDispatcher(db, cfg.GetNumWorkers(), defaultReQueueTimeout)
+	d, err := NewDispatcher(kv, repo, cfg.GetNumWorkers(), defaultReQueueTimeout)
 	if err != nil {
 		return fmt.Errorf("failed to init dispatcher: %w", err)
 	}
@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/gocelery/v2"
+)
+
+// cancelledResult is the result of the task skipped due to the cancellation of the job.
+const cancelledResult = "job cancelled"
+
+// Cancel requests the cancellation of the job owned by the account.
+func (d *dispatcher) Cancel(acc identity.DID, jobID gocelery.JobID) error {
+	if !d.isJobOwner(acc, jobID) {
+		return gocelery.ErrNotFound
+	}
+
+	job, err := d.Dispatcher.Job(jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.HasCompleted() {
+		return ErrJobFinished
+	}
+
+	err = d.setCancelled(jobID)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("cancellation of the job[%s] requested", job.HexID())
+	return nil
+}
+
+// RegisterRunner registers the runner whose tasks are skipped once the job is cancelled.
+func (d *dispatcher) RegisterRunner(name string, runner gocelery.Runner) bool {
+	return d.Dispatcher.RegisterRunner(name, &cancellableRunner{Runner: runner, verifier: d.verifier})
+}
+
+// RegisterRunnerFunc registers the runnerFunc which is skipped once the job is cancelled.
+func (d *dispatcher) RegisterRunnerFunc(name string, runnerFunc gocelery.RunnerFunc) bool {
+	return d.Dispatcher.RegisterRunnerFunc(name, cancellable(d.verifier, logged(d.verifier, name, runnerFunc), nil))
+}
+
+// cancellableRunner stops the chain of tasks of the runner once the job is cancelled.
+// The task being run when the job is cancelled is completed.
+// The tasks of the runners exposing their retry policy are run with it, and stop the chain once they fail for good.
+type cancellableRunner struct {
+	gocelery.Runner
+	verifier  verifier
+	cancelled bool
+	failed    bool
+}
+
+// New returns a new instance of the runner.
+func (r *cancellableRunner) New() gocelery.Runner {
+	return &cancellableRunner{Runner: r.Runner.New(), verifier: r.verifier}
+}
+
+// RunnerFunc returns the runner func of the task which is skipped once the job is cancelled.
+func (r *cancellableRunner) RunnerFunc(task string) gocelery.RunnerFunc {
+	if lr, ok := r.Runner.(logRunner); ok {
+		lr.setLogger(r.verifier, task)
+	}
+
+	runnerFunc := logged(r.verifier, task, r.Runner.RunnerFunc(task))
+	if tr, ok := r.Runner.(taskRunner); ok {
+		if t, ok := tr.Task(task); ok {
+			t.RunnerFunc = runnerFunc
+			runnerFunc = withPolicy(r.verifier, task, t, func() {
+				r.failed = true
+			})
+		}
+	}
+
+	return cancellable(r.verifier, runnerFunc, func() {
+		r.cancelled = true
+	})
+}
+
+// Next returns the next task unless the job is cancelled or failed.
+func (r *cancellableRunner) Next(task string) (next string, ok bool) {
+	if r.cancelled || r.failed {
+		return "", false
+	}
+
+	return r.Runner.Next(task)
+}
+
+// cancellable returns a runnerFunc which skips runnerFunc if the job is cancelled.
+// The skipped task succeeds with cancelledResult so that the job finishes.
+func cancellable(v verifier, runnerFunc gocelery.RunnerFunc, onCancel func()) gocelery.RunnerFunc {
+	return func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+		jobID, ok := overrides[jobIDOverride].(string)
+		if ok && v.isCancelled(jobID) {
+			log.Infof("job[%s] cancelled", jobID)
+			if onCancel != nil {
+				onCancel()
+			}
+
+			return cancelledResult, nil
+		}
+
+		return runnerFunc(args, overrides)
+	}
+}
@@ -1,8 +1,9 @@
 package jobs
 
 import (
-	"bytes"
 	"context"
+	"encoding/json"
+	"reflect"
 	"sync"
 	"time"
 
@@ -12,16 +13,26 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/centrifuge/gocelery/v2"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	logging "github.com/ipfs/go-log"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 const (
 	prefix                = "jobs_v2_"
+	statePrefix           = "jobs_state_"
 	defaultReQueueTimeout = 30 * time.Minute
+
+	// ownerIndex indexes the jobs by the DID of their owner.
+	ownerIndex = "jobs_owner"
+
+	// jobIDOverride is the override holding the hex encoded ID of the job, so that the tasks can find their job.
+	jobIDOverride = "job_id"
+
+	// ErrJobFinished is returned when a finished job is cancelled.
+	ErrJobFinished = errors.Error("job has finished already")
 )
 
 var log = logging.Logger("jobs")
@@ -41,24 +52,71 @@ type Dispatcher interface {
 	Dispatch(acc identity.DID, job *gocelery.Job) (Result, error)
 	Job(acc identity.DID, jobID gocelery.JobID) (*gocelery.Job, error)
 	Result(acc identity.DID, jobID gocelery.JobID) (Result, error)
+
+	// List returns the jobs of the account matching the filter, newest first.
+	List(acc identity.DID, filter Filter) ([]Info, error)
+
+	// Cancel requests the cancellation of the job. The job stops before running its next task.
+	// Returns ErrJobFinished if the job has finished already.
+	Cancel(acc identity.DID, jobID gocelery.JobID) error
+
+	// Info returns the job along with its status and the attempts of its tasks.
+	Info(acc identity.DID, jobID gocelery.JobID) (Info, error)
+
+	// Logs returns the messages logged by the tasks of the job, oldest first.
+	Logs(acc identity.DID, jobID gocelery.JobID) ([]LogEntry, error)
+
+	// RegisterScheduledFunc registers the func dispatching the jobs of the scheduled action.
+	// Returns false if the action is registered already.
+	RegisterScheduledFunc(action string, f ScheduledFunc) bool
+
+	// Schedule stores the schedule of the account, dispatching the job of its action at RunAt,
+	// and then after every Interval if set. Schedules persist across restarts.
+	Schedule(acc identity.DID, s Schedule) (Schedule, error)
+
+	// Schedules returns the schedules of the account, oldest first.
+	Schedules(acc identity.DID) ([]Schedule, error)
+
+	// DeleteSchedule deletes the schedule of the account.
+	DeleteSchedule(acc identity.DID, scheduleID []byte) error
 }
 
 type dispatcher struct {
 	verifier
 	*gocelery.Dispatcher
+	*scheduler
 }
 
-// NewDispatcher returns a new dispatcher with levelDB storage
-func NewDispatcher(db *leveldb.DB, workerCount int, requeueTimeout time.Duration) (Dispatcher, error) {
-	storage := gocelery.NewLevelDBStorage(db)
-	queue := gocelery.NewQueue(storage, requeueTimeout)
-	v := verifier{db: db}
+// NewDispatcher returns a new dispatcher with jobs stored in kv.
+// Job owners and the state of the jobs are stored in repo.
+func NewDispatcher(kv storage.KV, repo storage.Repository, workerCount int, requeueTimeout time.Duration) (Dispatcher, error) {
+	store := celeryStorage{KV: kv}
+	delays := newRetryDelays()
+	queue := retryQueue{Queue: gocelery.NewQueue(store, requeueTimeout), delays: delays}
+	repo.Register(new(Owner))
+	repo.Register(new(jobState))
+	repo.Register(new(jobLogs))
+	v := verifier{db: repo, delays: delays}
 	return &dispatcher{
 		verifier:   v,
-		Dispatcher: gocelery.NewDispatcher(workerCount, storage, queue),
+		Dispatcher: gocelery.NewDispatcher(workerCount, store, queue),
+		scheduler:  newScheduler(repo),
 	}, nil
 }
 
+// celeryStorage adapts storage.KV to the storage of gocelery, which doesn't handle iteration errors.
+type celeryStorage struct {
+	storage.KV
+}
+
+// Iterate calls f for each key with the prefix, logging the error the scan fails with.
+func (s celeryStorage) Iterate(prefix []byte, f func(key, value []byte)) {
+	err := s.KV.Iterate(prefix, f)
+	if err != nil {
+		log.Warnf("Error iterating jobs: %v", err)
+	}
+}
+
 func (d *dispatcher) Job(acc identity.DID, jobID gocelery.JobID) (*gocelery.Job, error) {
 	if !d.isJobOwner(acc, jobID) {
 		return nil, gocelery.ErrNotFound
@@ -68,17 +126,42 @@ func (d *dispatcher) Job(acc identity.DID, jobID gocelery.JobID) (*gocelery.Job,
 }
 
 func (d *dispatcher) Dispatch(acc identity.DID, job *gocelery.Job) (Result, error) {
-	// if there is a job already, error out
-	if d.isJobOwner(acc, job.ID) {
-		return nil, errors.New("job dispatched already")
+	if job.Overrides == nil {
+		job.Overrides = make(map[string]interface{})
 	}
+	job.Overrides[jobIDOverride] = job.HexID()
 
+	// if there is a job already, error out
 	err := d.setJobOwner(acc, job.ID)
 	if err != nil {
+		if errors.IsOfType(storage.ErrRepositoryModelCreateKeyExists, err) {
+			return nil, errors.New("job dispatched already")
+		}
+
 		return nil, err
 	}
 
-	return d.Dispatcher.Dispatch(job)
+	res, err := d.Dispatcher.Dispatch(job)
+	if err != nil {
+		return nil, err
+	}
+
+	return result{Result: res, verifier: d.verifier}, nil
+}
+
+// JobIDFromOverrides returns the ID of the job from the overrides passed to the tasks of the job.
+func JobIDFromOverrides(overrides map[string]interface{}) []byte {
+	hexID, ok := overrides[jobIDOverride].(string)
+	if !ok {
+		return nil
+	}
+
+	jobID, err := hexutil.Decode(hexID)
+	if err != nil {
+		return nil
+	}
+
+	return jobID
 }
 
 func (d *dispatcher) Result(acc identity.DID, jobID gocelery.JobID) (Result, error) {
@@ -86,12 +169,36 @@ func (d *dispatcher) Result(acc identity.DID, jobID gocelery.JobID) (Result, err
 		return nil, gocelery.ErrNotFound
 	}
 
-	return gocelery.Result{
-		JobID:      jobID,
-		Dispatcher: d.Dispatcher,
+	return result{
+		Result: gocelery.Result{
+			JobID:      jobID,
+			Dispatcher: d.Dispatcher,
+		},
+		verifier: d.verifier,
 	}, nil
 }
 
+// result fails once a task of the job fails for good.
+type result struct {
+	gocelery.Result
+	verifier verifier
+}
+
+// Await blocks until job is finished to return its results.
+// Returns ErrJobFailed if a task of the job failed for good.
+func (r result) Await(ctx context.Context) (res interface{}, err error) {
+	res, err = r.Result.Await(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	if err := r.verifier.state(hexutil.Encode(r.JobID)).failure(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (d *dispatcher) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
 	// start job finished notifier
 	wg.Add(1)
@@ -99,6 +206,10 @@ func (d *dispatcher) Start(ctx context.Context, wg *sync.WaitGroup, startupErr c
 		initJobWebhooks(ctx, d, wg)
 	}()
 
+	// start scheduler
+	wg.Add(1)
+	go d.scheduler.start(ctx, wg, scheduleCheckInterval)
+
 	// start dispatcher
 	defer wg.Done()
 	d.Dispatcher.Start(ctx)
@@ -122,7 +233,12 @@ func initJobWebhooks(ctx context.Context, dispatcher *dispatcher, wg *sync.WaitG
 		return
 	}
 
-	sender := notification.NewWebhookSender()
+	sender, ok := cctx[notification.BootstrappedOutbox].(notification.Sender)
+	if !ok {
+		log.Debug("jobs: failed to find notification outbox")
+		return
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -159,23 +275,101 @@ func initJobWebhooks(ctx context.Context, dispatcher *dispatcher, wg *sync.WaitG
 	}
 }
 
+// Owner is the account that dispatched the job.
+type Owner struct {
+	DID identity.DID `json:"did"`
+}
+
+// JSON marshals Owner to json bytes.
+func (o *Owner) JSON() ([]byte, error) {
+	return json.Marshal(o)
+}
+
+// FromJSON loads json bytes to Owner.
+func (o *Owner) FromJSON(data []byte) error {
+	return json.Unmarshal(data, o)
+}
+
+// Type returns the type of Owner.
+func (o *Owner) Type() reflect.Type {
+	return reflect.TypeOf(o)
+}
+
+// Indexes returns the indexed fields of the Owner.
+func (o *Owner) Indexes() map[string][]byte {
+	return map[string][]byte{ownerIndex: o.DID[:]}
+}
+
+// jobState holds the progress of the job recorded by the dispatcher.
+type jobState struct {
+	// CreatedAt is zero for the jobs dispatched before it was recorded.
+	CreatedAt time.Time `json:"created_at"`
+
+	// CancelledAt is set once the cancellation of the job is requested.
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+
+	// Attempts holds the attempts of the tasks run with their retry policy, oldest first.
+	Attempts []Attempt `json:"attempts,omitempty"`
+
+	// FailedAt is set once a task of the job fails for good.
+	FailedAt *time.Time `json:"failed_at,omitempty"`
+}
+
+// failure returns the error of the last attempt of the job which failed for good, nil otherwise.
+func (s *jobState) failure() error {
+	if s.FailedAt == nil || len(s.Attempts) == 0 {
+		return nil
+	}
+
+	a := s.Attempts[len(s.Attempts)-1]
+	return errors.NewTypedError(ErrJobFailed, errors.New("task %s failed after %d attempts: %s", a.Task, a.Attempt, a.Error))
+}
+
+// JSON marshals jobState to json bytes.
+func (s *jobState) JSON() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromJSON loads json bytes to jobState.
+func (s *jobState) FromJSON(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// Type returns the type of jobState.
+func (s *jobState) Type() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+func stateKey(jobID string) []byte {
+	return []byte(statePrefix + jobID)
+}
+
 type verifier struct {
-	db *leveldb.DB
+	db     storage.Repository
+	delays *retryDelays
 }
 
 func (v verifier) isJobOwner(acc identity.DID, jobID []byte) bool {
-	key := v.getKey(jobID)
-	val, err := v.db.Get(key, nil)
+	owner, err := v.jobOwner(jobID)
 	if err != nil {
 		return false
 	}
 
-	return bytes.Equal(acc[:], val)
+	return owner.Equal(acc)
 }
 
+// setJobOwner sets the owner of the job.
+// Errors out if the job has an owner already, even if the owner was set concurrently.
 func (v verifier) setJobOwner(acc identity.DID, jobID []byte) error {
 	key := v.getKey(jobID)
-	return v.db.Put(key, acc[:], nil)
+	return storage.RunTransaction(v.db, func(tx storage.Transaction) error {
+		err := tx.Create(key, &Owner{DID: acc})
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(stateKey(hexutil.Encode(jobID)), &jobState{CreatedAt: time.Now().UTC()})
+	})
 }
 
 func (v verifier) getKey(jobID []byte) []byte {
@@ -183,11 +377,110 @@ func (v verifier) getKey(jobID []byte) []byte {
 }
 
 func (v verifier) jobOwner(jobID []byte) (owner identity.DID, err error) {
-	key := v.getKey(jobID)
-	val, err := v.db.Get(key, nil)
+	o, err := v.owner(v.getKey(jobID))
+	if err != nil {
+		return owner, err
+	}
+
+	return o.DID, nil
+}
+
+func (v verifier) owner(key []byte) (*Owner, error) {
+	m, err := v.db.Get(key)
 	if err != nil {
-		return owner, gocelery.ErrNotFound
+		return nil, gocelery.ErrNotFound
 	}
 
-	return identity.NewDIDFromBytes(val)
+	o, ok := m.(*Owner)
+	if !ok {
+		return nil, gocelery.ErrNotFound
+	}
+
+	return o, nil
+}
+
+// ownedJobs returns the keys of the jobs owned by the account.
+func (v verifier) ownedJobs(acc identity.DID) ([][]byte, error) {
+	return v.db.GetKeysByIndex(ownerIndex, acc[:])
+}
+
+// state returns the state of the job with the hex encoded ID.
+// The state is empty for the jobs dispatched before it was recorded.
+func (v verifier) state(jobID string) *jobState {
+	m, err := v.db.Get(stateKey(jobID))
+	if err != nil {
+		return new(jobState)
+	}
+
+	st, ok := m.(*jobState)
+	if !ok {
+		return new(jobState)
+	}
+
+	return st
+}
+
+// updateState applies update to the state of the job with the hex encoded ID.
+func (v verifier) updateState(jobID string, update func(st *jobState)) error {
+	key := stateKey(jobID)
+	return storage.RunTransaction(v.db, func(tx storage.Transaction) error {
+		m, err := tx.Get(key)
+		if err != nil {
+			// the state of the jobs dispatched before it was recorded
+			st := new(jobState)
+			update(st)
+			return tx.Create(key, st)
+		}
+
+		st, ok := m.(*jobState)
+		if !ok {
+			return gocelery.ErrNotFound
+		}
+
+		update(st)
+		return tx.Update(key, st)
+	})
+}
+
+// setCancelled records the cancellation of the job, unless it was cancelled already.
+func (v verifier) setCancelled(jobID []byte) error {
+	return v.updateState(hexutil.Encode(jobID), func(st *jobState) {
+		if st.CancelledAt != nil {
+			return
+		}
+
+		now := time.Now().UTC()
+		st.CancelledAt = &now
+	})
+}
+
+// taskAttempts returns the number of the attempts of the task of the job with the hex encoded ID.
+func (v verifier) taskAttempts(jobID, task string) (attempts uint) {
+	for _, a := range v.state(jobID).Attempts {
+		if a.Task == task {
+			attempts++
+		}
+	}
+
+	return attempts
+}
+
+// recordAttempt records the attempt of the task of the job with the hex encoded ID,
+// and the failure of the job if the task failed for good.
+func (v verifier) recordAttempt(jobID string, a Attempt, failed bool) error {
+	if jobID == "" {
+		return nil
+	}
+
+	return v.updateState(jobID, func(st *jobState) {
+		st.Attempts = append(st.Attempts, a)
+		if failed {
+			st.FailedAt = &a.FinishedAt
+		}
+	})
+}
+
+// isCancelled returns true if the cancellation of the job with the hex encoded ID is requested.
+func (v verifier) isCancelled(jobID string) bool {
+	return v.state(jobID).CancelledAt != nil
 }
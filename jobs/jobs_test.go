@@ -22,6 +22,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/gocelery/v2"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -93,7 +94,7 @@ func setup(t *testing.T, webhook bool) (context.Context, *http.Server, chan []by
 	did := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
 	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
 	assert.NoError(t, err)
-	d, err := NewDispatcher(db, 10, 2*time.Minute)
+	d, err := NewDispatcher(leveldb.NewLevelDBKV(db), leveldb.NewLevelDBRepository(db), 10, 2*time.Minute)
 	assert.NoError(t, err)
 	resChan := make(chan []byte)
 	s := prepareServer(t, resChan)
@@ -132,7 +133,10 @@ func getContext(t *testing.T, did identity.DID, url string) (context.Context, fu
 	cfgSrv.On("GetAccount", did[:]).Return(acc, nil).Once()
 	ctx := context.WithValue(
 		context.Background(),
-		bootstrap.NodeObjRegistry, map[string]interface{}{config.BootstrappedConfigStorage: cfgSrv})
+		bootstrap.NodeObjRegistry, map[string]interface{}{
+			config.BootstrappedConfigStorage: cfgSrv,
+			notification.BootstrappedOutbox:  notification.NewWebhookSender(),
+		})
 	assert := func() {
 		cfgSrv.AssertExpectations(t)
 		acc.AssertExpectations(t)
@@ -140,3 +144,333 @@ func getContext(t *testing.T, did identity.DID, url string) (context.Context, fu
 
 	return ctx, assert
 }
+
+type testRunner struct {
+	Base
+}
+
+func (r testRunner) New() gocelery.Runner {
+	return r
+}
+
+func TestDispatcher_ListCancel(t *testing.T) {
+	_, _, _, did, d, _ := setup(t, false)
+	cfgSrv := new(config.MockService)
+	acc := new(config.MockAccount)
+	acc.On("GetReceiveEventNotificationEndpoint").Return("")
+	cfgSrv.On("GetAccount", did[:]).Return(acc, nil)
+	ctx := context.WithValue(
+		context.Background(),
+		bootstrap.NodeObjRegistry, map[string]interface{}{config.BootstrappedConfigStorage: cfgSrv})
+	ctx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go d.Start(ctx, wg, nil)
+
+	started, proceed := make(chan struct{}), make(chan struct{})
+	var secondRan bool
+	assert.True(t, d.RegisterRunner("test", testRunner{NewBase(map[string]Task{
+		"first": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				close(started)
+				<-proceed
+				return nil, nil
+			},
+			Next: "second",
+		},
+		"second": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				secondRan = true
+				return nil, nil
+			},
+		},
+	})}))
+	assert.True(t, d.RegisterRunnerFunc("add", func(args []interface{}, overrides map[string]interface{}) (interface{},
+		error) {
+		return args[0].(int) + args[1].(int), nil
+	}))
+
+	// finished job
+	addJob := gocelery.NewRunnerFuncJob("Test", "add", []interface{}{1, 2}, nil, time.Now())
+	res, err := d.Dispatch(did, addJob)
+	assert.NoError(t, err)
+	_, err = res.Await(ctx)
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(d.Cancel(did, addJob.ID), ErrJobFinished))
+
+	// cancel job between tasks
+	job := gocelery.NewRunnerJob("Test", "test", "first", nil, nil, time.Now())
+	res, err = d.Dispatch(did, job)
+	assert.NoError(t, err)
+	<-started
+
+	infos, err := d.List(did, Filter{Statuses: []Status{StatusPending}})
+	assert.NoError(t, err)
+	assert.Len(t, infos, 1)
+	assert.Equal(t, job.ID, infos[0].Job.ID)
+	assert.Equal(t, "test", infos[0].Runner)
+
+	// not owner
+	other := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+	assert.True(t, errors.Is(d.Cancel(other, job.ID), gocelery.ErrNotFound))
+
+	assert.NoError(t, d.Cancel(did, job.ID))
+	close(proceed)
+	r, err := res.Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, cancelledResult, r)
+	assert.False(t, secondRan)
+
+	// list
+	infos, err = d.List(did, Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, infos, 2)
+	assert.Equal(t, job.ID, infos[0].Job.ID)
+	assert.Equal(t, StatusCancelled, infos[0].Status)
+	assert.NotNil(t, infos[0].CancelledAt)
+	assert.Equal(t, addJob.ID, infos[1].Job.ID)
+	assert.Equal(t, StatusSuccess, infos[1].Status)
+	assert.Equal(t, "add", infos[1].Runner)
+	assert.False(t, infos[1].CreatedAt.After(infos[0].CreatedAt))
+
+	for _, c := range []struct {
+		filter Filter
+		count  int
+	}{
+		{Filter{Runners: []string{"add"}}, 1},
+		{Filter{Statuses: []Status{StatusCancelled, StatusFailed}}, 1},
+		{Filter{Statuses: []Status{StatusPending}}, 0},
+		{Filter{From: time.Now().Add(time.Hour)}, 0},
+		{Filter{To: time.Now().Add(-time.Hour)}, 0},
+		{Filter{From: time.Now().Add(-time.Hour), To: time.Now()}, 2},
+	} {
+		infos, err = d.List(did, c.filter)
+		assert.NoError(t, err)
+		assert.Len(t, infos, c.count)
+	}
+
+	infos, err = d.List(other, Filter{})
+	assert.NoError(t, err)
+	assert.Empty(t, infos)
+}
+
+func TestVerifier_State(t *testing.T) {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	repo := leveldb.NewLevelDBRepository(db)
+	repo.Register(new(Owner))
+	repo.Register(new(jobState))
+	v := verifier{db: repo}
+	did := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+
+	// the owner holds the DID only
+	jobID := utils.RandomSlice(32)
+	hexID := hexutil.Encode(jobID)
+	assert.NoError(t, v.setJobOwner(did, jobID))
+	m, err := repo.Get(v.getKey(jobID))
+	assert.NoError(t, err)
+	assert.Equal(t, &Owner{DID: did}, m)
+	assert.False(t, v.state(hexID).CreatedAt.IsZero())
+	assert.Error(t, v.setJobOwner(did, jobID))
+
+	// jobs dispatched before the state was recorded
+	jobID = utils.RandomSlice(32)
+	hexID = hexutil.Encode(jobID)
+	assert.NoError(t, repo.Create(v.getKey(jobID), &Owner{DID: did}))
+	assert.Equal(t, new(jobState), v.state(hexID))
+	assert.False(t, v.isCancelled(hexID))
+	assert.NoError(t, v.setCancelled(jobID))
+	assert.True(t, v.isCancelled(hexID))
+	assert.NoError(t, v.recordAttempt(hexID, Attempt{Task: "task", Attempt: 1}, false))
+	assert.Equal(t, uint(1), v.taskAttempts(hexID, "task"))
+	assert.True(t, v.isJobOwner(did, jobID))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 5*time.Second)
+	for attempts, d := range []time.Duration{time.Second, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		assert.Equal(t, d, backoff(uint(attempts)))
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	err := errors.New("unavailable")
+	assert.False(t, IsTransient(err))
+	assert.True(t, IsTransient(Transient(err)))
+	assert.False(t, IsTransient(fmt.Errorf("failed to submit: %v", Transient(err))))
+	assert.True(t, IsTransient(fmt.Errorf("failed to submit: %w", Transient(err))))
+	assert.False(t, DefaultRetryPolicy().retryable(err))
+	assert.True(t, DefaultRetryPolicy().retryable(Transient(err)))
+}
+
+func TestDispatcher_RetryPolicy(t *testing.T) {
+	_, _, _, did, d, _ := setup(t, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go d.Start(ctx, wg, nil)
+
+	errPermanent := errors.New("permanent")
+	var flakyRuns int
+	var nextRan bool
+	slowStopped := make(chan struct{})
+	var cancelledRuns, backoffRuns int
+	var backoffs []uint
+	assert.True(t, d.RegisterRunner("test", testRunner{NewBase(map[string]Task{
+		"flaky": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				flakyRuns++
+				if flakyRuns < 2 {
+					return nil, Transient(errors.New("unavailable"))
+				}
+
+				overrides["flaky"] = flakyRuns
+				return nil, nil
+			},
+			Next:  "permanent",
+			Retry: DefaultRetryPolicy(),
+		},
+		"permanent": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				return nil, errPermanent
+			},
+			Next:  "next",
+			Retry: DefaultRetryPolicy(),
+		},
+		"slow": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				select {
+				case <-time.After(time.Second):
+				case <-ContextFromOverrides(overrides).Done():
+					close(slowStopped)
+				}
+
+				overrides["slow"] = true
+				return nil, nil
+			},
+			Next:    "next",
+			Retry:   &RetryPolicy{MaxAttempts: 1},
+			Timeout: 10 * time.Millisecond,
+		},
+		"backoff": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				backoffRuns++
+				if backoffRuns < 3 {
+					return nil, Transient(errors.New("unavailable"))
+				}
+
+				return nil, nil
+			},
+			Retry: &RetryPolicy{
+				MaxAttempts: 3,
+				Backoff: func(attempts uint) time.Duration {
+					backoffs = append(backoffs, attempts)
+					return 10 * time.Millisecond
+				},
+				Retryable: IsTransient,
+			},
+		},
+		"cancelled": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				cancelledRuns++
+				assert.NoError(t, d.Cancel(did, JobIDFromOverrides(overrides)))
+				return nil, Transient(errors.New("unavailable"))
+			},
+			Next:  "next",
+			Retry: DefaultRetryPolicy(),
+		},
+		"next": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				nextRan = true
+				return nil, nil
+			},
+		},
+	})}))
+
+	// retried until the task succeeds, then fails at once on the permanent error
+	job := gocelery.NewRunnerJob("Test", "test", "flaky", nil, nil, time.Now())
+	res, err := d.Dispatch(did, job)
+	assert.NoError(t, err)
+	_, err = res.Await(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrJobFailed.Error())
+	assert.Contains(t, err.Error(), "task permanent failed after 1 attempts: permanent")
+	assert.False(t, nextRan)
+
+	info, err := d.Info(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, info.Status)
+	assert.NotNil(t, info.FailedAt)
+	assert.Equal(t, 2, info.Job.Overrides["flaky"])
+	assert.Len(t, info.Attempts, 3)
+	for i, a := range info.Attempts[:2] {
+		assert.Equal(t, "flaky", a.Task)
+		assert.Equal(t, uint(i+1), a.Attempt)
+		assert.False(t, a.FinishedAt.Before(a.StartedAt))
+	}
+	assert.Equal(t, "transient error: unavailable", info.Attempts[0].Error)
+	assert.Empty(t, info.Attempts[1].Error)
+	assert.Equal(t, Attempt{
+		Task:       "permanent",
+		Attempt:    1,
+		Error:      "permanent",
+		StartedAt:  info.Attempts[2].StartedAt,
+		FinishedAt: info.Attempts[2].FinishedAt,
+	}, info.Attempts[2])
+
+	// timed out attempts
+	job = gocelery.NewRunnerJob("Test", "test", "slow", nil, nil, time.Now())
+	res, err = d.Dispatch(did, job)
+	assert.NoError(t, err)
+	_, err = res.Await(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrTaskTimeout.Error())
+	assert.False(t, nextRan)
+
+	info, err = d.Info(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, info.Status)
+	assert.Len(t, info.Attempts, 1)
+	assert.Equal(t, ErrTaskTimeout.Error(), info.Attempts[0].Error)
+	assert.Nil(t, info.Job.Overrides["slow"])
+	assert.Nil(t, info.Job.Overrides[contextOverride])
+	<-slowStopped
+
+	// retried after the backoff of the policy, instead of the delay of the dispatcher
+	job = gocelery.NewRunnerJob("Test", "test", "backoff", nil, nil, time.Now())
+	res, err = d.Dispatch(did, job)
+	assert.NoError(t, err)
+	_, err = res.Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, backoffs)
+
+	info, err = d.Info(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusSuccess, info.Status)
+	assert.Len(t, info.Attempts, 3)
+	for i := 1; i < len(info.Attempts); i++ {
+		delay := info.Attempts[i].StartedAt.Sub(info.Attempts[i-1].FinishedAt)
+		assert.True(t, delay >= 10*time.Millisecond && delay < 5*time.Second, delay)
+	}
+
+	// the job cancelled before the retry of the task
+	job = gocelery.NewRunnerJob("Test", "test", "cancelled", nil, nil, time.Now())
+	res, err = d.Dispatch(did, job)
+	assert.NoError(t, err)
+	_, err = res.Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cancelledRuns)
+	assert.False(t, nextRan)
+
+	info, err = d.Info(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCancelled, info.Status)
+	assert.Len(t, info.Attempts, 1)
+
+	// not owner
+	other := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+	_, err = d.Info(other, job.ID)
+	assert.True(t, errors.Is(err, gocelery.ErrNotFound))
+}
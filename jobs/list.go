@@ -0,0 +1,163 @@
+package jobs
+
+import (
+	"sort"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Status is the status of a job.
+type Status string
+
+const (
+	// StatusPending is the status of a job which is not finished yet.
+	StatusPending Status = "pending"
+
+	// StatusSuccess is the status of a job which finished successfully.
+	StatusSuccess Status = "success"
+
+	// StatusFailed is the status of a job which failed or expired, or whose task failed for good.
+	StatusFailed Status = "failed"
+
+	// StatusCancelled is the status of a job which stopped due to its cancellation.
+	StatusCancelled Status = "cancelled"
+)
+
+// Filter filters the jobs of an account.
+// Zero values match all the jobs.
+type Filter struct {
+	// Runners filters the jobs by the runner, or the runnerFunc of the first task if the job has no runner.
+	Runners []string
+
+	// Statuses filters the jobs by the status.
+	Statuses []Status
+
+	// From filters the jobs created after or at From.
+	From time.Time
+
+	// To filters the jobs created before or at To.
+	To time.Time
+}
+
+// Info is a job along with its status.
+type Info struct {
+	Job         *gocelery.Job
+	Runner      string
+	Status      Status
+	CreatedAt   time.Time
+	CancelledAt *time.Time
+	FailedAt    *time.Time
+	Attempts    []Attempt
+}
+
+// List returns the jobs of the account matching the filter, newest first.
+func (d *dispatcher) List(acc identity.DID, filter Filter) ([]Info, error) {
+	keys, err := d.ownedJobs(acc)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []Info
+	for _, key := range keys {
+		// keys hold the hex encoded job ID
+		hexID := string(key[len(prefix):])
+		jobID, err := hexutil.Decode(hexID)
+		if err != nil {
+			continue
+		}
+
+		job, err := d.Dispatcher.Job(jobID)
+		if err != nil {
+			log.Errorf("failed to fetch job[%s]: %v", hexutil.Encode(jobID), err)
+			continue
+		}
+
+		info := newInfo(job, d.state(hexID))
+		if filter.matches(info) {
+			infos = append(infos, info)
+		}
+	}
+
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].CreatedAt.After(infos[j].CreatedAt)
+	})
+
+	return infos, nil
+}
+
+// Info returns the job of the account along with its status and the attempts of its tasks.
+func (d *dispatcher) Info(acc identity.DID, jobID gocelery.JobID) (Info, error) {
+	if !d.isJobOwner(acc, jobID) {
+		return Info{}, gocelery.ErrNotFound
+	}
+
+	job, err := d.Dispatcher.Job(jobID)
+	if err != nil {
+		return Info{}, err
+	}
+
+	return newInfo(job, d.state(hexutil.Encode(jobID))), nil
+}
+
+func newInfo(job *gocelery.Job, st *jobState) Info {
+	info := Info{
+		Job:         job,
+		Runner:      job.Runner,
+		Status:      jobStatus(job, st),
+		CreatedAt:   st.CreatedAt,
+		CancelledAt: st.CancelledAt,
+		FailedAt:    st.FailedAt,
+		Attempts:    st.Attempts,
+	}
+
+	if info.Runner == "" && len(job.Tasks) > 0 {
+		info.Runner = job.Tasks[0].RunnerFunc
+	}
+
+	// the delay of the first task is the creation time unless the task was retried
+	if info.CreatedAt.IsZero() && len(job.Tasks) > 0 {
+		info.CreatedAt = job.Tasks[0].Delay
+	}
+
+	return info
+}
+
+func jobStatus(job *gocelery.Job, st *jobState) Status {
+	switch {
+	case !job.HasCompleted():
+		return StatusPending
+	case st.FailedAt != nil || !job.IsSuccessful():
+		return StatusFailed
+	case job.LastTask().Result == cancelledResult:
+		return StatusCancelled
+	default:
+		return StatusSuccess
+	}
+}
+
+func (f Filter) matches(info Info) bool {
+	if len(f.Runners) > 0 && !utils.ContainsString(f.Runners, info.Runner) {
+		return false
+	}
+
+	if len(f.Statuses) > 0 {
+		var ok bool
+		for _, st := range f.Statuses {
+			ok = ok || st == info.Status
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	if !f.From.IsZero() && info.CreatedAt.Before(f.From) {
+		return false
+	}
+
+	return f.To.IsZero() || !info.CreatedAt.After(f.To)
+}
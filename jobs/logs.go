@@ -0,0 +1,191 @@
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/gocelery/v2"
+)
+
+const (
+	logsPrefix = "jobs_logs_"
+
+	// maxLogEntries is the maximum number of the log entries kept per job. The oldest entries are dropped first.
+	maxLogEntries = 500
+)
+
+// LogLevel is the level of a log entry of the job.
+type LogLevel string
+
+// Log levels of the job.
+const (
+	LogDebug LogLevel = "debug"
+	LogInfo  LogLevel = "info"
+	LogWarn  LogLevel = "warn"
+	LogError LogLevel = "error"
+)
+
+// LogEntry is a message logged by a task of the job.
+type LogEntry struct {
+	Task     string    `json:"task"`
+	Level    LogLevel  `json:"level" enums:"debug,info,warn,error"`
+	Message  string    `json:"message"`
+	LoggedAt time.Time `json:"logged_at" swaggertype:"primitive,string"`
+}
+
+// Logger records human readable messages on the progress of the job.
+// Messages are mirrored to the node log as well.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// jobLogs holds the log entries of the job, oldest first.
+type jobLogs struct {
+	Entries []LogEntry `json:"entries"`
+}
+
+// JSON marshals jobLogs to json bytes.
+func (l *jobLogs) JSON() ([]byte, error) {
+	return json.Marshal(l)
+}
+
+// FromJSON loads json bytes to jobLogs.
+func (l *jobLogs) FromJSON(data []byte) error {
+	return json.Unmarshal(data, l)
+}
+
+// Type returns the type of jobLogs.
+func (l *jobLogs) Type() reflect.Type {
+	return reflect.TypeOf(l)
+}
+
+func logsKey(jobID string) []byte {
+	return []byte(logsPrefix + jobID)
+}
+
+// appendLog appends the entry to the logs of the job with the hex encoded ID.
+func (v verifier) appendLog(jobID string, e LogEntry) error {
+	if jobID == "" {
+		return nil
+	}
+
+	key := logsKey(jobID)
+	return storage.RunTransaction(v.db, func(tx storage.Transaction) error {
+		m, err := tx.Get(key)
+		if err != nil {
+			return tx.Create(key, &jobLogs{Entries: []LogEntry{e}})
+		}
+
+		l, ok := m.(*jobLogs)
+		if !ok {
+			return gocelery.ErrNotFound
+		}
+
+		l.Entries = append(l.Entries, e)
+		if len(l.Entries) > maxLogEntries {
+			l.Entries = l.Entries[len(l.Entries)-maxLogEntries:]
+		}
+
+		return tx.Update(key, l)
+	})
+}
+
+// Logs returns the log entries of the job owned by the account, oldest first.
+func (d *dispatcher) Logs(acc identity.DID, jobID gocelery.JobID) ([]LogEntry, error) {
+	if !d.isJobOwner(acc, jobID) {
+		return nil, gocelery.ErrNotFound
+	}
+
+	m, err := d.verifier.db.Get(logsKey(jobID.Hex()))
+	if err != nil {
+		// job didn't log anything yet
+		return nil, nil
+	}
+
+	l, ok := m.(*jobLogs)
+	if !ok {
+		return nil, gocelery.ErrNotFound
+	}
+
+	return l.Entries, nil
+}
+
+// taskLogger logs the messages of the task of the job with the hex encoded ID.
+// Messages are only logged to the node log if v is nil.
+type taskLogger struct {
+	v     *verifier
+	jobID string
+	task  string
+}
+
+func (l taskLogger) log(level LogLevel, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	switch level {
+	case LogDebug:
+		log.Debugf("job[%s] %s: %s", l.jobID, l.task, msg)
+	case LogWarn:
+		log.Warnf("job[%s] %s: %s", l.jobID, l.task, msg)
+	case LogError:
+		log.Errorf("job[%s] %s: %s", l.jobID, l.task, msg)
+	default:
+		log.Infof("job[%s] %s: %s", l.jobID, l.task, msg)
+	}
+
+	if l.v == nil {
+		return
+	}
+
+	err := l.v.appendLog(l.jobID, LogEntry{Task: l.task, Level: level, Message: msg, LoggedAt: time.Now().UTC()})
+	if err != nil {
+		log.Errorf("failed to store the log of job[%s]: %v", l.jobID, err)
+	}
+}
+
+// Debugf logs the message at debug level.
+func (l taskLogger) Debugf(format string, args ...interface{}) {
+	l.log(LogDebug, format, args...)
+}
+
+// Infof logs the message at info level.
+func (l taskLogger) Infof(format string, args ...interface{}) {
+	l.log(LogInfo, format, args...)
+}
+
+// Warnf logs the message at warn level.
+func (l taskLogger) Warnf(format string, args ...interface{}) {
+	l.log(LogWarn, format, args...)
+}
+
+// Errorf logs the message at error level.
+func (l taskLogger) Errorf(format string, args ...interface{}) {
+	l.log(LogError, format, args...)
+}
+
+// logRunner is a runner logging the messages of its tasks, like Base.
+type logRunner interface {
+	setLogger(v verifier, task string)
+}
+
+// logged returns a runnerFunc which logs the start and the end of the task.
+func logged(v verifier, task string, runnerFunc gocelery.RunnerFunc) gocelery.RunnerFunc {
+	return func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+		jobID, _ := overrides[jobIDOverride].(string)
+		l := taskLogger{v: &v, jobID: jobID, task: task}
+		l.Infof("task started")
+		res, err := runnerFunc(args, overrides)
+		if err != nil {
+			l.Errorf("task failed: %v", err)
+			return res, err
+		}
+
+		l.Infof("task completed")
+		return res, nil
+	}
+}
@@ -0,0 +1,95 @@
+// +build unit
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type logTestRunner struct {
+	Base
+}
+
+func (r *logTestRunner) New() gocelery.Runner {
+	nr := new(logTestRunner)
+	nr.Base = NewBase(map[string]Task{
+		"progress": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				nr.Logger(overrides).Infof("step %d of %d", 1, 2)
+				return nil, nil
+			},
+			Next: "fail",
+		},
+		"fail": {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+				return nil, errors.New("unavailable")
+			},
+			Retry: &RetryPolicy{MaxAttempts: 2},
+		},
+	})
+
+	return nr
+}
+
+func TestDispatcher_Logs(t *testing.T) {
+	_, _, _, did, d, _ := setup(t, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go d.Start(ctx, wg, nil)
+
+	assert.True(t, d.RegisterRunner("log_test", new(logTestRunner)))
+	job := gocelery.NewRunnerJob("Test", "log_test", "progress", nil, nil, time.Now())
+	res, err := d.Dispatch(did, job)
+	assert.NoError(t, err)
+	_, err = res.Await(ctx)
+	assert.Error(t, err)
+
+	entries, err := d.Logs(did, job.ID)
+	assert.NoError(t, err)
+	var got []string
+	for _, e := range entries {
+		assert.False(t, e.LoggedAt.IsZero())
+		got = append(got, fmt.Sprintf("%s %s %s", e.Task, e.Level, e.Message))
+	}
+
+	assert.Equal(t, []string{
+		"progress info task started",
+		"progress info step 1 of 2",
+		"progress info task completed",
+		"fail info task started",
+		"fail error task failed: unavailable",
+		"fail warn attempt 1 failed, retrying: unavailable",
+		"fail info task started",
+		"fail error task failed: unavailable",
+		"fail error job failed after 2 attempts: unavailable",
+	}, got)
+
+	// not owner
+	other := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+	_, err = d.Logs(other, job.ID)
+	assert.Equal(t, gocelery.ErrNotFound, err)
+
+	// oldest entries are dropped
+	v := d.(*dispatcher).verifier
+	for i := 0; i < maxLogEntries; i++ {
+		assert.NoError(t, v.appendLog(job.HexID(), LogEntry{Message: fmt.Sprint(i)}))
+	}
+
+	entries, err = d.Logs(did, job.ID)
+	assert.NoError(t, err)
+	assert.Len(t, entries, maxLogEntries)
+	assert.Equal(t, "0", entries[0].Message)
+}
@@ -37,3 +37,48 @@ func (m *MockDispatcher) Dispatch(did identity.DID, job *gocelery.Job) (Result,
 	res, _ := args.Get(0).(Result)
 	return res, args.Error(1)
 }
+
+func (m *MockDispatcher) List(acc identity.DID, filter Filter) ([]Info, error) {
+	args := m.Called(acc, filter)
+	infos, _ := args.Get(0).([]Info)
+	return infos, args.Error(1)
+}
+
+func (m *MockDispatcher) Cancel(acc identity.DID, jobID gocelery.JobID) error {
+	args := m.Called(acc, jobID)
+	return args.Error(0)
+}
+
+func (m *MockDispatcher) Info(acc identity.DID, jobID gocelery.JobID) (Info, error) {
+	args := m.Called(acc, jobID)
+	info, _ := args.Get(0).(Info)
+	return info, args.Error(1)
+}
+
+func (m *MockDispatcher) RegisterScheduledFunc(action string, f ScheduledFunc) bool {
+	args := m.Called(action, f)
+	return args.Bool(0)
+}
+
+func (m *MockDispatcher) Schedule(acc identity.DID, s Schedule) (Schedule, error) {
+	args := m.Called(acc, s)
+	sch, _ := args.Get(0).(Schedule)
+	return sch, args.Error(1)
+}
+
+func (m *MockDispatcher) Schedules(acc identity.DID) ([]Schedule, error) {
+	args := m.Called(acc)
+	schs, _ := args.Get(0).([]Schedule)
+	return schs, args.Error(1)
+}
+
+func (m *MockDispatcher) DeleteSchedule(acc identity.DID, scheduleID []byte) error {
+	args := m.Called(acc, scheduleID)
+	return args.Error(0)
+}
+
+func (m *MockDispatcher) Logs(acc identity.DID, jobID gocelery.JobID) ([]LogEntry, error) {
+	args := m.Called(acc, jobID)
+	entries, _ := args.Get(0).([]LogEntry)
+	return entries, args.Error(1)
+}
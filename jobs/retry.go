@@ -0,0 +1,239 @@
+package jobs
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrTaskTimeout is returned when an attempt of a task runs past the timeout of the task.
+	ErrTaskTimeout = errors.Error("task timed out")
+
+	// ErrJobFailed is returned when a task of the job fails for good.
+	ErrJobFailed = errors.Error("job failed")
+
+	// ErrTransient is the type of the errors which may go away on retry, like the failures to reach a peer or a chain.
+	ErrTransient = errors.Error("transient error")
+
+	// contextOverride holds the context of the attempt of the task. It is never persisted with the job.
+	contextOverride = "jobs_context"
+)
+
+// RetryPolicy decides which failed attempts of a task are retried, and when.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of the task, including the first one.
+	// Zero retries the task until the job expires.
+	MaxAttempts uint
+
+	// Backoff returns the delay before the retry of the task, given the number of attempts so far.
+	// The dispatcher delays the retry by 5s per try, up to 5 minutes, if nil.
+	Backoff func(attempts uint) time.Duration
+
+	// Retryable returns true if the task is retried after failing with err.
+	// The job fails at once on the errors which aren't retryable. All the errors are retryable if nil.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy returns the policy retrying the tasks failing on transient errors, like the errors of the
+// peers or the chains, up to three attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     ExponentialBackoff(5*time.Second, time.Minute),
+		Retryable:   IsTransient,
+	}
+}
+
+// ExponentialBackoff returns a backoff doubling the delay after every attempt, from initial up to max.
+func ExponentialBackoff(initial, max time.Duration) func(attempts uint) time.Duration {
+	return func(attempts uint) time.Duration {
+		d := initial
+		for i := uint(1); i < attempts && d < max; i++ {
+			d *= 2
+		}
+
+		if d > max {
+			return max
+		}
+
+		return d
+	}
+}
+
+// Transient returns err typed as ErrTransient.
+func Transient(err error) error {
+	return errors.NewTypedError(ErrTransient, err)
+}
+
+// IsTransient returns true if err, or any error wrapped by it, is of type ErrTransient.
+func IsTransient(err error) bool {
+	for ; err != nil; err = stderrors.Unwrap(err) {
+		if errors.IsOfType(ErrTransient, err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContextFromOverrides returns the context of the attempt of the task, which is cancelled once the attempt times out.
+func ContextFromOverrides(overrides map[string]interface{}) context.Context {
+	if ctx, ok := overrides[contextOverride].(context.Context); ok {
+		return ctx
+	}
+
+	return context.Background()
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// retryDelays holds the delays of the retries set by the retry policies, by the hex encoded ID of the job.
+// The delay is taken by retryQueue when the dispatcher enqueues the failed job.
+type retryDelays struct {
+	mu sync.Mutex
+	m  map[string]time.Duration
+}
+
+func newRetryDelays() *retryDelays {
+	return &retryDelays{m: make(map[string]time.Duration)}
+}
+
+func (r *retryDelays) set(jobID string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.m[jobID] = d
+}
+
+// take returns and drops the delay of the retry of the job, if any.
+func (r *retryDelays) take(jobID string) (d time.Duration, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok = r.m[jobID]
+	delete(r.m, jobID)
+	return d, ok
+}
+
+// retryQueue delays the jobs enqueued for a retry by the backoff of the retry policy of the failed task,
+// instead of the delay of the dispatcher.
+type retryQueue struct {
+	gocelery.Queue
+	delays *retryDelays
+}
+
+// EnqueueAfter enqueues the job after the delay of its retry if set, otherwise at t.
+func (q retryQueue) EnqueueAfter(id []byte, t time.Time) error {
+	if d, ok := q.delays.take(hexutil.Encode(id)); ok {
+		t = time.Now().UTC().Add(d)
+	}
+
+	return q.Queue.EnqueueAfter(id, t)
+}
+
+// Finished drops the delay of the retry of the finished job, if any.
+func (q retryQueue) Finished(id []byte) {
+	q.delays.take(hexutil.Encode(id))
+	q.Queue.Finished(id)
+}
+
+// Attempt is a run of a task of the job.
+type Attempt struct {
+	Task       string    `json:"task"`
+	Attempt    uint      `json:"attempt"`
+	StartedAt  time.Time `json:"started_at" swaggertype:"primitive,string"`
+	FinishedAt time.Time `json:"finished_at" swaggertype:"primitive,string"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// taskRunner is a runner exposing the retry policy and the timeout of its tasks, like Base.
+type taskRunner interface {
+	Task(task string) (Task, bool)
+}
+
+// withPolicy returns a runnerFunc which runs an attempt of the task with its timeout and retry policy, recording it.
+// The failed attempt is returned to the dispatcher, which retries the task after the backoff of the policy.
+// onFail is called once the task fails for good, and the task succeeds so that the job finishes.
+func withPolicy(v verifier, name string, t Task, onFail func()) gocelery.RunnerFunc {
+	return func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+		jobID, _ := overrides[jobIDOverride].(string)
+
+		// attempts of the earlier runs of the task are counted
+		attempts := v.taskAttempts(jobID, name) + 1
+		a := Attempt{Task: name, Attempt: attempts, StartedAt: time.Now().UTC()}
+		res, err := runWithTimeout(t.RunnerFunc, t.Timeout, args, overrides)
+		a.FinishedAt = time.Now().UTC()
+		if err != nil {
+			a.Error = err.Error()
+		}
+
+		failed := err != nil && t.Retry != nil &&
+			(!t.Retry.retryable(err) || (t.Retry.MaxAttempts > 0 && attempts >= t.Retry.MaxAttempts))
+		if rerr := v.recordAttempt(jobID, a, failed); rerr != nil {
+			log.Errorf("failed to record attempt %d of task %s of job[%s]: %v", attempts, name, jobID, rerr)
+		}
+
+		switch {
+		case err == nil:
+			return res, nil
+		case failed:
+			taskLogger{v: &v, jobID: jobID, task: name}.Errorf("job failed after %d attempts: %v", attempts, err)
+			onFail()
+			return nil, nil
+		}
+
+		if t.Retry != nil && t.Retry.Backoff != nil && v.delays != nil && jobID != "" {
+			v.delays.set(jobID, t.Retry.Backoff(attempts))
+		}
+
+		taskLogger{v: &v, jobID: jobID, task: name}.Warnf("attempt %d failed, retrying: %v", attempts, err)
+		return nil, err
+	}
+}
+
+// runWithTimeout runs the runnerFunc, failing with ErrTaskTimeout if it runs past the timeout.
+// The context of the attempt, returned by ContextFromOverrides, is cancelled once the attempt times out.
+// The result of the timed out runnerFunc and its changes to the overrides are discarded.
+func runWithTimeout(runnerFunc gocelery.RunnerFunc, timeout time.Duration, args []interface{},
+	overrides map[string]interface{}) (interface{}, error) {
+	if timeout <= 0 {
+		return runnerFunc(args, overrides)
+	}
+
+	type result struct {
+		res interface{}
+		err error
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	attemptOverrides := make(map[string]interface{}, len(overrides)+1)
+	for k, v := range overrides {
+		attemptOverrides[k] = v
+	}
+	attemptOverrides[contextOverride] = ctx
+
+	done := make(chan result, 1)
+	go func() {
+		res, err := runnerFunc(args, attemptOverrides)
+		done <- result{res: res, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		delete(attemptOverrides, contextOverride)
+		for k, v := range attemptOverrides {
+			overrides[k] = v
+		}
+
+		return r.res, r.err
+	case <-ctx.Done():
+		return nil, ErrTaskTimeout
+	}
+}
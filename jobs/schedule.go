@@ -0,0 +1,312 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrScheduleNotFound is returned when the schedule doesn't exist.
+	ErrScheduleNotFound = errors.Error("schedule not found")
+
+	// ErrInvalidSchedule is returned when the schedule is invalid.
+	ErrInvalidSchedule = errors.Error("invalid schedule")
+
+	// MinScheduleInterval is the minimum interval between the runs of a recurring schedule.
+	MinScheduleInterval = time.Minute
+
+	schedulePrefix = "jobs_schedule_"
+
+	// runAtIndex indexes the schedules by the time of their next run.
+	runAtIndex = "jobs_schedule_run_at"
+
+	// scheduleCheckInterval is the interval between the checks for the due schedules.
+	scheduleCheckInterval = 10 * time.Second
+)
+
+// ScheduledFunc dispatches the job of a scheduled action with the params of the schedule.
+// The context carries the account of the schedule.
+type ScheduledFunc func(ctx context.Context, params json.RawMessage) (gocelery.JobID, error)
+
+// Schedule dispatches the job of the action at RunAt, and then after every Interval if set.
+type Schedule struct {
+	ID        byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`
+	AccountID byteutils.HexBytes `json:"account_id" swaggertype:"primitive,string"`
+
+	// Action is the name of the scheduled func dispatching the job.
+	Action string          `json:"action"`
+	Params json.RawMessage `json:"params,omitempty" swaggertype:"object"`
+
+	// RunAt is the time of the next run.
+	RunAt time.Time `json:"run_at" swaggertype:"primitive,string"`
+
+	// Interval between the runs. The schedule is deleted after its run if zero.
+	Interval time.Duration `json:"interval" swaggertype:"primitive,integer"`
+
+	CreatedAt time.Time          `json:"created_at" swaggertype:"primitive,string"`
+	LastRunAt *time.Time         `json:"last_run_at,omitempty" swaggertype:"primitive,string"`
+	LastJobID byteutils.HexBytes `json:"last_job_id,omitempty" swaggertype:"primitive,string"`
+	LastError string             `json:"last_error,omitempty"`
+}
+
+// JSON marshals Schedule to json bytes.
+func (s *Schedule) JSON() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromJSON loads json bytes to Schedule.
+func (s *Schedule) FromJSON(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// Type returns the type of Schedule.
+func (s *Schedule) Type() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// Indexes returns the indexed fields of the Schedule.
+func (s *Schedule) Indexes() map[string][]byte {
+	return map[string][]byte{runAtIndex: runAtValue(s.RunAt)}
+}
+
+// runAtValue returns the index value of the time, ordered like the times from the epoch on.
+func runAtValue(t time.Time) []byte {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(t.UnixNano()))
+	return v
+}
+
+// next moves RunAt to the first run after now. Returns false if the schedule doesn't recur.
+func (s *Schedule) next(now time.Time) bool {
+	if s.Interval <= 0 {
+		return false
+	}
+
+	// runs missed while the node was down are skipped
+	for !s.RunAt.After(now) {
+		s.RunAt = s.RunAt.Add(s.Interval)
+	}
+
+	return true
+}
+
+func accountSchedulesPrefix(accountID []byte) string {
+	return schedulePrefix + hexutil.Encode(accountID) + "_"
+}
+
+func scheduleKey(accountID, scheduleID []byte) []byte {
+	return []byte(accountSchedulesPrefix(accountID) + hexutil.Encode(scheduleID))
+}
+
+// scheduler stores the schedules and dispatches the jobs of the due schedules.
+type scheduler struct {
+	db    storage.Repository
+	mu    sync.RWMutex
+	funcs map[string]ScheduledFunc
+}
+
+func newScheduler(db storage.Repository) *scheduler {
+	db.Register(new(Schedule))
+	return &scheduler{db: db, funcs: make(map[string]ScheduledFunc)}
+}
+
+// RegisterScheduledFunc registers the func dispatching the jobs of the action.
+func (s *scheduler) RegisterScheduledFunc(action string, f ScheduledFunc) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.funcs[action]; ok {
+		return false
+	}
+
+	s.funcs[action] = f
+	return true
+}
+
+func (s *scheduler) scheduledFunc(action string) (ScheduledFunc, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := s.funcs[action]
+	return f, ok
+}
+
+// Schedule stores the schedule of the account. RunAt defaults to now.
+func (s *scheduler) Schedule(acc identity.DID, sch Schedule) (Schedule, error) {
+	if _, ok := s.scheduledFunc(sch.Action); !ok {
+		return sch, errors.NewTypedError(ErrInvalidSchedule, errors.New("unknown action %q", sch.Action))
+	}
+
+	if sch.Interval != 0 && sch.Interval < MinScheduleInterval {
+		return sch, errors.NewTypedError(ErrInvalidSchedule, errors.New("interval is shorter than %s", MinScheduleInterval))
+	}
+
+	if len(sch.Params) > 0 && !json.Valid(sch.Params) {
+		return sch, errors.NewTypedError(ErrInvalidSchedule, errors.New("params are not valid json"))
+	}
+
+	now := time.Now().UTC()
+	if sch.RunAt.IsZero() {
+		sch.RunAt = now
+	}
+
+	sch.ID = utils.RandomSlice(32)
+	sch.AccountID = acc[:]
+	sch.RunAt = sch.RunAt.UTC()
+	sch.CreatedAt = now
+	sch.LastRunAt, sch.LastJobID, sch.LastError = nil, nil, ""
+	return sch, s.db.Create(scheduleKey(acc[:], sch.ID), &sch)
+}
+
+// Schedules returns the schedules of the account, oldest first.
+func (s *scheduler) Schedules(acc identity.DID) ([]Schedule, error) {
+	return s.schedules(accountSchedulesPrefix(acc[:]))
+}
+
+func (s *scheduler) schedules(prefix string) ([]Schedule, error) {
+	models, err := s.db.GetAllByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var schs []Schedule
+	for _, m := range models {
+		sch, ok := m.(*Schedule)
+		if !ok {
+			continue
+		}
+
+		schs = append(schs, *sch)
+	}
+
+	sort.Slice(schs, func(i, j int) bool {
+		return schs[i].CreatedAt.Before(schs[j].CreatedAt)
+	})
+
+	return schs, nil
+}
+
+// DeleteSchedule deletes the schedule of the account.
+func (s *scheduler) DeleteSchedule(acc identity.DID, scheduleID []byte) error {
+	key := scheduleKey(acc[:], scheduleID)
+	m, err := s.db.Get(key)
+	if err != nil {
+		return ErrScheduleNotFound
+	}
+
+	if sch, ok := m.(*Schedule); !ok || !bytes.Equal(sch.AccountID, acc[:]) {
+		return ErrScheduleNotFound
+	}
+
+	return s.db.Delete(key)
+}
+
+// start dispatches the jobs of the due schedules until the context is done.
+func (s *scheduler) start(ctx context.Context, wg *sync.WaitGroup, interval time.Duration) {
+	defer wg.Done()
+	cctx, ok := ctx.Value(bootstrap.NodeObjRegistry).(map[string]interface{})
+	if !ok {
+		log.Debug("jobs: failed to find Node registry")
+		return
+	}
+
+	configSrv, ok := cctx[config.BootstrappedConfigStorage].(config.Service)
+	if !ok {
+		log.Debug("jobs: failed to find config service")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.runDue(ctx, configSrv, time.Now().UTC())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dueSchedules returns the schedules due at now, earliest first.
+func (s *scheduler) dueSchedules(now time.Time) ([]Schedule, error) {
+	keys, err := s.db.GetKeysByIndexRange(runAtIndex, nil, runAtValue(now.Add(time.Nanosecond)))
+	if err != nil {
+		return nil, err
+	}
+
+	var schs []Schedule
+	for _, key := range keys {
+		m, err := s.db.Get(key)
+		if err != nil {
+			// the schedule may have been deleted since
+			log.Warnf("failed to get schedule %s: %v", string(key), err)
+			continue
+		}
+
+		if sch, ok := m.(*Schedule); ok {
+			schs = append(schs, *sch)
+		}
+	}
+
+	return schs, nil
+}
+
+// runDue dispatches the jobs of the schedules due at now, and moves them to their next run.
+func (s *scheduler) runDue(ctx context.Context, configSrv config.Service, now time.Time) {
+	schs, err := s.dueSchedules(now)
+	if err != nil {
+		log.Errorf("failed to fetch the due schedules: %v", err)
+		return
+	}
+
+	for _, sch := range schs {
+		f, ok := s.scheduledFunc(sch.Action)
+		if !ok {
+			// the action may be registered later on
+			continue
+		}
+
+		jobID, err := s.dispatch(ctx, configSrv, sch, f)
+		sch.LastRunAt, sch.LastJobID, sch.LastError = &now, byteutils.HexBytes(jobID), ""
+		if err != nil {
+			sch.LastError = err.Error()
+			log.Errorf("failed to dispatch the job of schedule[%s]: %v", sch.ID.String(), err)
+		}
+
+		key := scheduleKey(sch.AccountID, sch.ID)
+		if !sch.next(now) {
+			err = s.db.Delete(key)
+		} else {
+			err = s.db.Update(key, &sch)
+		}
+
+		if err != nil {
+			log.Errorf("failed to update schedule[%s]: %v", sch.ID.String(), err)
+		}
+	}
+}
+
+func (s *scheduler) dispatch(ctx context.Context, configSrv config.Service, sch Schedule, f ScheduledFunc) (gocelery.JobID, error) {
+	acc, err := configSrv.GetAccount(sch.AccountID)
+	if err != nil {
+		return nil, errors.New("failed to get account: %v", err)
+	}
+
+	return f(contextutil.WithAccount(ctx, acc), sch.Params)
+}
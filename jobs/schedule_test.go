@@ -0,0 +1,152 @@
+// +build unit
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScheduler(t *testing.T) *scheduler {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	return newScheduler(leveldb.NewLevelDBRepository(db))
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	s := newTestScheduler(t)
+	did := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+	other := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+	f := func(context.Context, json.RawMessage) (gocelery.JobID, error) { return nil, nil }
+	assert.True(t, s.RegisterScheduledFunc("action", f))
+	assert.False(t, s.RegisterScheduledFunc("action", f))
+
+	// unknown action
+	_, err := s.Schedule(did, Schedule{Action: "unknown"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidSchedule.Error())
+
+	// short interval
+	_, err = s.Schedule(did, Schedule{Action: "action", Interval: time.Second})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidSchedule.Error())
+
+	// invalid params
+	_, err = s.Schedule(did, Schedule{Action: "action", Params: json.RawMessage("{")})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidSchedule.Error())
+
+	sch1, err := s.Schedule(did, Schedule{Action: "action"})
+	assert.NoError(t, err)
+	assert.Len(t, sch1.ID, 32)
+	assert.Equal(t, did[:], sch1.AccountID.Bytes())
+	assert.False(t, sch1.RunAt.IsZero())
+	sch2, err := s.Schedule(did, Schedule{Action: "action", Interval: time.Hour, Params: json.RawMessage(`{"a":1}`)})
+	assert.NoError(t, err)
+
+	schs, err := s.Schedules(did)
+	assert.NoError(t, err)
+	assert.Len(t, schs, 2)
+	assert.Equal(t, sch1.ID, schs[0].ID)
+	assert.Equal(t, sch2.ID, schs[1].ID)
+	assert.Equal(t, time.Hour, schs[1].Interval)
+
+	// schedules of other accounts
+	schs, err = s.Schedules(other)
+	assert.NoError(t, err)
+	assert.Len(t, schs, 0)
+	assert.Equal(t, ErrScheduleNotFound, s.DeleteSchedule(other, sch1.ID))
+
+	assert.NoError(t, s.DeleteSchedule(did, sch1.ID))
+	assert.Equal(t, ErrScheduleNotFound, s.DeleteSchedule(did, sch1.ID))
+	schs, err = s.Schedules(did)
+	assert.NoError(t, err)
+	assert.Len(t, schs, 1)
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	s := newTestScheduler(t)
+	did := identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+	acc := new(config.MockAccount)
+	acc.On("GetIdentityID").Return(did[:])
+	cfgSrv := new(config.MockService)
+	cfgSrv.On("GetAccount", did[:]).Return(acc, nil)
+
+	var runs []string
+	jobID := gocelery.JobID(utils.RandomSlice(32))
+	s.RegisterScheduledFunc("action", func(ctx context.Context, params json.RawMessage) (gocelery.JobID, error) {
+		accID, err := contextutil.AccountDID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, did, accID)
+		var p struct{ Name string }
+		assert.NoError(t, json.Unmarshal(params, &p))
+		runs = append(runs, p.Name)
+		if p.Name == "failing" {
+			return nil, errors.New("failed to dispatch")
+		}
+
+		return jobID, nil
+	})
+
+	now := time.Now().UTC()
+	once, err := s.Schedule(did, Schedule{Action: "action", Params: json.RawMessage(`{"name":"once"}`)})
+	assert.NoError(t, err)
+	daily, err := s.Schedule(did, Schedule{
+		Action:   "action",
+		Params:   json.RawMessage(`{"name":"daily"}`),
+		RunAt:    now.Add(-49 * time.Hour),
+		Interval: 24 * time.Hour,
+	})
+	assert.NoError(t, err)
+	_, err = s.Schedule(did, Schedule{
+		Action:   "action",
+		Params:   json.RawMessage(`{"name":"failing"}`),
+		Interval: time.Hour,
+	})
+	assert.NoError(t, err)
+	_, err = s.Schedule(did, Schedule{
+		Action: "action",
+		Params: json.RawMessage(`{"name":"later"}`),
+		RunAt:  now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	s.runDue(context.Background(), cfgSrv, now.Add(time.Second))
+	// earliest first
+	assert.Equal(t, []string{"daily", "once", "failing"}, runs)
+
+	schs, err := s.Schedules(did)
+	assert.NoError(t, err)
+	assert.Len(t, schs, 3)
+
+	// one-off schedule is deleted after its run
+	assert.NotEqual(t, once.ID, schs[0].ID)
+
+	// missed runs are skipped
+	assert.Equal(t, daily.ID, schs[0].ID)
+	assert.Equal(t, daily.RunAt.Add(72*time.Hour), schs[0].RunAt)
+	assert.Equal(t, jobID, gocelery.JobID(schs[0].LastJobID))
+	assert.NotNil(t, schs[0].LastRunAt)
+	assert.Empty(t, schs[0].LastError)
+
+	// failed runs are recorded and retried on the next run
+	assert.Equal(t, "failed to dispatch", schs[1].LastError)
+	assert.True(t, schs[1].RunAt.After(now))
+
+	// nothing is due
+	runs = nil
+	s.runDue(context.Background(), cfgSrv, now.Add(time.Minute))
+	assert.Empty(t, runs)
+}
@@ -1,14 +1,14 @@
 package migrationfiles
 
 import (
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	logging "github.com/ipfs/go-log"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 var log = logging.Logger("migrate-files")
 
 // Initial00 Does nothing
-func Initial00(db *leveldb.DB) error {
+func Initial00(db *backend.DB) error {
 	log.Infof("00Initial Migration Run successfully")
 	return nil
 }
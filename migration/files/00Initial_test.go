@@ -5,8 +5,8 @@ import (
 	"testing"
 
 	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/stretchr/testify/assert"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 func TestInitial00(t *testing.T) {
@@ -16,7 +16,7 @@ func TestInitial00(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	db, err := leveldb.OpenFile(targetDir, nil)
+	db, err := backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 
 	assert.NoError(t, Initial00(db))
@@ -4,34 +4,36 @@ import (
 	"encoding/hex"
 	"regexp"
 
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // KeysToHex01 Converts all keys to hex
-func KeysToHex01(db *leveldb.DB) error {
-	iter := db.NewIterator(util.BytesPrefix([]byte{}), nil)
-	for iter.Next() {
-		key := iter.Key()
-		data := iter.Value()
-		// Do nothing if key is already hex
-		if isHexKey(key) || isKnownPlainTextKey(key) {
-			continue
+func KeysToHex01(db *backend.DB) error {
+	var err error
+	ierr := db.KV.Iterate([]byte{}, func(key, data []byte) {
+		// Do nothing if key is already hex or a previous write failed
+		if err != nil || isHexKey(key) || isKnownPlainTextKey(key) {
+			return
 		}
-		err := db.Put([]byte(hexutil.Encode(key)), data, nil)
-		if err != nil {
-			return err
-		}
-		err = db.Delete(key, nil)
+
+		err = db.KV.Set([]byte(hexutil.Encode(key)), data)
 		if err != nil {
-			return err
+			return
 		}
+
+		err = db.KV.Delete(key)
+	})
+	if err != nil {
+		return err
+	}
+
+	if ierr != nil {
+		return ierr
 	}
-	iter.Release()
 
 	log.Infof("01KeysToHex Migration Run successfully")
-	return iter.Error()
+	return nil
 }
 
 func isHexKey(key []byte) bool {
@@ -6,9 +6,9 @@ import (
 	"testing"
 
 	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 type content struct {
@@ -44,7 +44,7 @@ func TestKeysToHex01(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	db, err := leveldb.OpenFile(targetDir, nil)
+	db, err := backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 
 	var keys [][]byte
@@ -54,22 +54,22 @@ func TestKeysToHex01(t *testing.T) {
 		keys = append(keys, key)
 		data, err := json.Marshal(&content{fmt.Sprintf("Val_%d", i)})
 		assert.NoError(t, err)
-		err = db.Put(key, data, nil)
+		err = db.KV.Set(key, data)
 		assert.NoError(t, err)
 	}
 
 	// Add plain text keys
 	data, err := json.Marshal(&content{"Val_Migration"})
 	assert.NoError(t, err)
-	err = db.Put([]byte("migration_123143"), data, nil)
+	err = db.KV.Set([]byte("migration_123143"), data)
 	assert.NoError(t, err)
 	data, err = json.Marshal(&content{"Val_config"})
 	assert.NoError(t, err)
-	err = db.Put([]byte("config"), data, nil)
+	err = db.KV.Set([]byte("config"), data)
 	assert.NoError(t, err)
 	data, err = json.Marshal(&content{"Val_Account"})
 	assert.NoError(t, err)
-	err = db.Put([]byte("account-123143"), data, nil)
+	err = db.KV.Set([]byte("account-123143"), data)
 	assert.NoError(t, err)
 
 	err = KeysToHex01(db)
@@ -77,10 +77,10 @@ func TestKeysToHex01(t *testing.T) {
 
 	for i := 0; i < 5; i++ {
 		// non-hex key not found
-		_, err = db.Get(keys[i], nil)
+		_, err = db.KV.Get(keys[i])
 		assert.Error(t, err)
 
-		v, err := db.Get([]byte(hexutil.Encode(keys[i])), nil)
+		v, err := db.KV.Get([]byte(hexutil.Encode(keys[i])))
 		assert.NoError(t, err)
 		var c content
 		err = json.Unmarshal(v, &c)
@@ -88,11 +88,11 @@ func TestKeysToHex01(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("Val_%d", i), c.Name)
 	}
 
-	_, err = db.Get([]byte("migration_123143"), nil)
+	_, err = db.KV.Get([]byte("migration_123143"))
 	assert.NoError(t, err)
-	_, err = db.Get([]byte("config"), nil)
+	_, err = db.KV.Get([]byte("config"))
 	assert.NoError(t, err)
-	_, err = db.Get([]byte("account-123143"), nil)
+	_, err = db.KV.Get([]byte("account-123143"))
 	assert.NoError(t, err)
 
 }
@@ -4,8 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 
-	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 )
 
 // value is an internal representation of how levelDb stores the model.
@@ -15,40 +14,40 @@ type value struct {
 }
 
 // AddPrefix02 Adds db prefix to documents and jobs
-func AddPrefix02(db *leveldb.DB) error {
-	iter := db.NewIterator(util.BytesPrefix([]byte{}), nil)
-	for iter.Next() {
-		key := iter.Key()
-		data := iter.Value()
-		// Do nothing if entry type is prefixed already
-		if isKnownPlainTextKey(key) {
-			continue
+func AddPrefix02(db *backend.DB) error {
+	var err error
+	ierr := db.KV.Iterate([]byte{}, func(key, data []byte) {
+		// Do nothing if entry type is prefixed already or a previous write failed
+		if err != nil || isKnownPlainTextKey(key) {
+			return
 		}
+
 		v := new(value)
-		err := json.Unmarshal(data, v)
+		err = json.Unmarshal(data, v)
 		if err != nil {
-			return err
+			return
 		}
+
 		prefix := []byte("document_")
 		if strings.Contains(v.Type, "jobs.Job") {
 			prefix = []byte("job_")
 		}
-		err = db.Put(append(prefix, key...), data, nil)
-		if err != nil {
-			return err
-		}
-		err = db.Delete(key, nil)
+
+		err = db.KV.Set(append(prefix, key...), data)
 		if err != nil {
-			return err
+			return
 		}
-	}
-	iter.Release()
 
-	err := iter.Error()
+		err = db.KV.Delete(key)
+	})
 	if err != nil {
 		return err
 	}
 
+	if ierr != nil {
+		return ierr
+	}
+
 	log.Infof("AddPrefix02 Migration Run successfully")
 	return nil
 }
@@ -6,9 +6,9 @@ import (
 	"testing"
 
 	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 func TestAddPrefix02(t *testing.T) {
@@ -18,7 +18,7 @@ func TestAddPrefix02(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	db, err := leveldb.OpenFile(targetDir, nil)
+	db, err := backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 
 	var keys [][]byte
@@ -32,22 +32,22 @@ func TestAddPrefix02(t *testing.T) {
 		}
 		data, err := json.Marshal(&value{Type: innerType, Data: json.RawMessage([]byte(fmt.Sprintf("{\"val\":\"Val_%d\"}", i)))})
 		assert.NoError(t, err)
-		err = db.Put(key, data, nil)
+		err = db.KV.Set(key, data)
 		assert.NoError(t, err)
 	}
 
 	// Add plain text keys
 	data, err := json.Marshal(&content{"Val_Migration"})
 	assert.NoError(t, err)
-	err = db.Put([]byte("migration_123143"), data, nil)
+	err = db.KV.Set([]byte("migration_123143"), data)
 	assert.NoError(t, err)
 	data, err = json.Marshal(&content{"Val_config"})
 	assert.NoError(t, err)
-	err = db.Put([]byte("config"), data, nil)
+	err = db.KV.Set([]byte("config"), data)
 	assert.NoError(t, err)
 	data, err = json.Marshal(&content{"Val_Account"})
 	assert.NoError(t, err)
-	err = db.Put([]byte("account-123143"), data, nil)
+	err = db.KV.Set([]byte("account-123143"), data)
 	assert.NoError(t, err)
 
 	err = AddPrefix02(db)
@@ -55,13 +55,13 @@ func TestAddPrefix02(t *testing.T) {
 
 	for i := 0; i < 5; i++ {
 		// non-prefixed key not found
-		_, err = db.Get(keys[i], nil)
+		_, err = db.KV.Get(keys[i])
 		assert.Error(t, err)
 		innerType := "document_"
 		if i%2 != 0 {
 			innerType = "job_"
 		}
-		v, err := db.Get(append([]byte(innerType), keys[i]...), nil)
+		v, err := db.KV.Get(append([]byte(innerType), keys[i]...))
 		assert.NoError(t, err)
 		var c value
 		err = json.Unmarshal(v, &c)
@@ -71,11 +71,11 @@ func TestAddPrefix02(t *testing.T) {
 		assert.Equal(t, []byte(fmt.Sprintf("{\"val\":\"Val_%d\"}", i)), cData)
 	}
 
-	_, err = db.Get([]byte("migration_123143"), nil)
+	_, err = db.KV.Get([]byte("migration_123143"))
 	assert.NoError(t, err)
-	_, err = db.Get([]byte("config"), nil)
+	_, err = db.KV.Get([]byte("config"))
 	assert.NoError(t, err)
-	_, err = db.Get([]byte("account-123143"), nil)
+	_, err = db.KV.Get([]byte("account-123143"))
 	assert.NoError(t, err)
 
 }
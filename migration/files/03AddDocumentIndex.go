@@ -8,53 +8,56 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	ldb "github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 // AddDocumentIndex03 adds index to the document for efficient fetching.
-func AddDocumentIndex03(db *ldb.DB) error {
-	strRepo := leveldb.NewLevelDBRepository(db)
+func AddDocumentIndex03(db *backend.DB) error {
+	strRepo := db.Repository
 	repo := documents.NewDBRepository(strRepo)
 	repo.Register(new(entityrelationship.EntityRelationship))
 	repo.Register(new(entity.Entity))
 	repo.Register(new(generic.Generic))
-	iter := db.NewIterator(util.BytesPrefix([]byte("document_")), nil)
 	var c, e int
-	for iter.Next() {
-		c++
-		key := iter.Key()
-		acc, id, err := getAccountAndID(key)
+	var err error
+	ierr := db.KV.Iterate([]byte("document_"), func(key, _ []byte) {
 		if err != nil {
+			return
+		}
+
+		c++
+		acc, id, kerr := getAccountAndID(key)
+		if kerr != nil {
 			e++
 			// must have been an older document. skipping
-			continue
+			return
 		}
-		m, err := strRepo.Get(key)
+
+		var m storage.Model
+		m, err = strRepo.Get(key)
 		if err != nil {
-			return err
+			return
 		}
 
 		mm, ok := m.(documents.Document)
 		if !ok {
-			return documents.ErrDocumentInvalidType
+			err = documents.ErrDocumentInvalidType
+			return
 		}
 
 		err = repo.Update(acc, id, mm)
-		if err != nil {
-			return err
-		}
-
-	}
-	log.Infof("Updated index for %d documents\n", c-e)
-	iter.Release()
-	err := iter.Error()
+	})
 	if err != nil {
 		return err
 	}
 
+	if ierr != nil {
+		return ierr
+	}
+
+	log.Infof("Updated index for %d documents\n", c-e)
 	log.Infof("AddDocumentIndex03 Migration Run successfully")
 	return nil
 }
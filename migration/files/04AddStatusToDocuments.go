@@ -5,54 +5,53 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents/entity"
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
-	ldb "github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 )
 
 // AddStatusToDocuments04 adds status to committed.
-func AddStatusToDocuments04(db *ldb.DB) error {
-	strRepo := leveldb.NewLevelDBRepository(db)
+func AddStatusToDocuments04(db *backend.DB) error {
+	strRepo := db.Repository
 	repo := documents.NewDBRepository(strRepo)
 	repo.Register(new(entityrelationship.EntityRelationship))
 	repo.Register(new(entity.Entity))
 	repo.Register(new(generic.Generic))
-	iter := db.NewIterator(util.BytesPrefix([]byte("document_")), nil)
 	var c, e int
-	for iter.Next() {
-		c++
-		key := iter.Key()
-		m, err := strRepo.Get(key)
+	var err error
+	ierr := db.KV.Iterate([]byte("document_"), func(key, _ []byte) {
 		if err != nil {
+			return
+		}
+
+		c++
+		m, gerr := strRepo.Get(key)
+		if gerr != nil {
 			// model fetch failed, skip
 			e++
-			continue
+			return
 		}
 
 		mm, ok := m.(documents.Document)
 		if !ok {
-			return documents.ErrDocumentInvalidType
+			err = documents.ErrDocumentInvalidType
+			return
 		}
 
 		err = mm.SetStatus(documents.Committed)
 		if err != nil {
-			return err
+			return
 		}
 
 		err = strRepo.Update(key, mm)
-		if err != nil {
-			return err
-		}
-
-	}
-
-	log.Infof("Updated status for %d documents\n", c-e)
-	iter.Release()
-	err := iter.Error()
+	})
 	if err != nil {
 		return err
 	}
 
+	if ierr != nil {
+		return ierr
+	}
+
+	log.Infof("Updated status for %d documents\n", c-e)
 	log.Infof("AddStatusToDocuments04 Migration Run successfully")
 	return nil
 }
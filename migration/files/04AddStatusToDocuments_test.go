@@ -9,10 +9,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/stretchr/testify/assert"
-	ldb "github.com/syndtr/goleveldb/leveldb"
 )
 
 func TestAddStatusToDocuments04(t *testing.T) {
@@ -22,9 +21,9 @@ func TestAddStatusToDocuments04(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	db, err := ldb.OpenFile(targetDir, nil)
+	db, err := backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
-	strRepo := leveldb.NewLevelDBRepository(db)
+	strRepo := db.Repository
 	repo := documents.NewDBRepository(strRepo)
 	repo.Register(new(generic.Generic))
 	did := testingidentity.GenerateRandomDID()
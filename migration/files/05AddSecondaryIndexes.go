@@ -0,0 +1,52 @@
+package migrationfiles
+
+import (
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/entity"
+	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+)
+
+// AddSecondaryIndexes05 writes the secondary index entries of the existing documents.
+func AddSecondaryIndexes05(db *backend.DB) error {
+	strRepo := db.Repository
+	repo := documents.NewDBRepository(strRepo)
+	repo.Register(new(entityrelationship.EntityRelationship))
+	repo.Register(new(entity.Entity))
+	repo.Register(new(generic.Generic))
+	var c int
+	var err error
+	ierr := db.KV.Iterate([]byte("document_"), func(key, _ []byte) {
+		if err != nil {
+			return
+		}
+
+		m, gerr := strRepo.Get(key)
+		if gerr != nil {
+			// model fetch failed, skip
+			return
+		}
+
+		if _, ok := m.(storage.IndexedModel); !ok {
+			return
+		}
+
+		err = strRepo.Update(key, m)
+		if err == nil {
+			c++
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if ierr != nil {
+		return ierr
+	}
+
+	log.Infof("Indexed %d documents\n", c)
+	log.Infof("AddSecondaryIndexes05 Migration Run successfully")
+	return nil
+}
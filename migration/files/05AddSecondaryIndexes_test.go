@@ -0,0 +1,59 @@
+// +build unit
+
+package migrationfiles
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSecondaryIndexes05(t *testing.T) {
+	prefix := fmt.Sprintf("/tmp/datadir_%x", migrationutils.RandomByte32())
+	targetDir := fmt.Sprintf("%s.leveldb", prefix)
+
+	// Cleanup after test
+	defer migrationutils.CleanupDBFiles(prefix)
+
+	db, err := backend.Open(backend.LevelDB, targetDir)
+	assert.NoError(t, err)
+	strRepo := db.Repository
+	repo := documents.NewDBRepository(strRepo)
+	repo.Register(new(entityrelationship.EntityRelationship))
+	did := testingidentity.GenerateRandomDID()
+	target := testingidentity.GenerateRandomDID()
+	g := generic.InitGeneric(t, did, generic.CreateGenericPayload(t, nil))
+	er := &entityrelationship.EntityRelationship{
+		CoreDocument: g.CoreDocument,
+		Data: entityrelationship.Data{
+			EntityIdentifier: utils.RandomSlice(32),
+			OwnerIdentity:    &did,
+			TargetIdentity:   &target,
+		},
+	}
+	assert.NoError(t, repo.Create(did[:], er.CurrentVersion(), er))
+
+	// drop the index entries to mimic a db written before the indexes
+	for _, p := range []string{"index_", "indexed_keys_"} {
+		assert.NoError(t, db.KV.Iterate([]byte(p), func(key, _ []byte) {
+			assert.NoError(t, db.KV.Delete(key))
+		}))
+	}
+
+	keys, err := strRepo.GetKeysByIndex(entityrelationship.EntityIdentifierIndex, er.Data.EntityIdentifier)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 0)
+
+	assert.NoError(t, AddSecondaryIndexes05(db))
+	keys, err = strRepo.GetKeysByIndex(entityrelationship.EntityIdentifierIndex, er.Data.EntityIdentifier)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
@@ -0,0 +1,47 @@
+package migrationfiles
+
+import (
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+)
+
+// JobOwnersToModel06 converts the raw job owner entries to jobs.Owner models.
+func JobOwnersToModel06(db *backend.DB) error {
+	strRepo := db.Repository
+	strRepo.Register(new(jobs.Owner))
+	var c int
+	var err error
+	ierr := db.KV.Iterate([]byte("jobs_v2_"), func(key, data []byte) {
+		if err != nil {
+			return
+		}
+
+		if len(data) != identity.DIDLength {
+			// converted already
+			return
+		}
+
+		var did identity.DID
+		did, err = identity.NewDIDFromBytes(data)
+		if err != nil {
+			return
+		}
+
+		err = strRepo.Update(key, &jobs.Owner{DID: did})
+		if err == nil {
+			c++
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if ierr != nil {
+		return ierr
+	}
+
+	log.Infof("Converted %d job owners\n", c)
+	log.Infof("JobOwnersToModel06 Migration Run successfully")
+	return nil
+}
@@ -0,0 +1,41 @@
+// +build unit
+
+package migrationfiles
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/jobs"
+	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobOwnersToModel06(t *testing.T) {
+	prefix := fmt.Sprintf("/tmp/datadir_%x", migrationutils.RandomByte32())
+	targetDir := fmt.Sprintf("%s.leveldb", prefix)
+
+	// Cleanup after test
+	defer migrationutils.CleanupDBFiles(prefix)
+
+	db, err := backend.Open(backend.LevelDB, targetDir)
+	assert.NoError(t, err)
+	did := testingidentity.GenerateRandomDID()
+	key := []byte("jobs_v2_0x01")
+	assert.NoError(t, db.KV.Set(key, did[:]))
+	assert.NoError(t, JobOwnersToModel06(db))
+
+	strRepo := db.Repository
+	strRepo.Register(new(jobs.Owner))
+	m, err := strRepo.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, &jobs.Owner{DID: did}, m)
+
+	// already converted
+	assert.NoError(t, JobOwnersToModel06(db))
+	m, err = strRepo.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, &jobs.Owner{DID: did}, m)
+}
@@ -0,0 +1,41 @@
+package migrationfiles
+
+import (
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+)
+
+// IndexJobOwners07 writes the owner index entries of the existing jobs.
+func IndexJobOwners07(db *backend.DB) error {
+	strRepo := db.Repository
+	strRepo.Register(new(jobs.Owner))
+	var c int
+	var err error
+	ierr := db.KV.Iterate([]byte("jobs_v2_"), func(key, _ []byte) {
+		if err != nil {
+			return
+		}
+
+		m, gerr := strRepo.Get(key)
+		if gerr != nil {
+			// model fetch failed, skip
+			return
+		}
+
+		err = strRepo.Update(key, m)
+		if err == nil {
+			c++
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if ierr != nil {
+		return ierr
+	}
+
+	log.Infof("Indexed %d job owners\n", c)
+	log.Infof("IndexJobOwners07 Migration Run successfully")
+	return nil
+}
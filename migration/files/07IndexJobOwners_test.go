@@ -0,0 +1,47 @@
+// +build unit
+
+package migrationfiles
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/jobs"
+	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexJobOwners07(t *testing.T) {
+	prefix := fmt.Sprintf("/tmp/datadir_%x", migrationutils.RandomByte32())
+	targetDir := fmt.Sprintf("%s.leveldb", prefix)
+
+	// Cleanup after test
+	defer migrationutils.CleanupDBFiles(prefix)
+
+	db, err := backend.Open(backend.LevelDB, targetDir)
+	assert.NoError(t, err)
+	did := testingidentity.GenerateRandomDID()
+	key := []byte("jobs_v2_0x01")
+
+	// owner stored before the index was added
+	db.Repository.Register(new(jobs.Owner))
+	assert.NoError(t, db.Repository.Create(key, &jobs.Owner{DID: did}))
+	data, err := db.KV.Get(key)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Repository.Delete(key))
+	assert.NoError(t, db.KV.Set(key, data))
+	keys, err := db.Repository.GetKeysByIndex("jobs_owner", did[:])
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	assert.NoError(t, IndexJobOwners07(db))
+	keys, err = db.Repository.GetKeysByIndex("jobs_owner", did[:])
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{key}, keys)
+
+	m, err := db.Repository.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, did, m.(*jobs.Owner).DID)
+}
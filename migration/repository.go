@@ -2,18 +2,20 @@ package migration
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/go-errors/errors"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 const dbPrefix = "migration_"
 
 // Repository holds DB info
 type Repository struct {
-	db     *leveldb.DB
-	dbPath string
+	db      *backend.DB
+	backend string
+	dbPath  string
 }
 
 // Item holds migration item info
@@ -24,34 +26,40 @@ type Item struct {
 	Duration time.Duration `json:"duration,string"`
 }
 
-// NewMigrationRepository takes a path and creates a DB repository
-func NewMigrationRepository(path string) (*Repository, error) {
-	i, err := leveldb.OpenFile(path, nil)
+// NewMigrationRepository takes a storage backend and a path and creates a DB repository
+func NewMigrationRepository(backendName, path string) (*Repository, error) {
+	i, err := backend.Open(backendName, path)
 	if err != nil {
 		return nil, err
 	}
-	return &Repository{i, path}, nil
+	return &Repository{i, backendName, path}, nil
 }
 
 func getKeyFromID(id string) []byte {
 	return []byte(dbPrefix + id)
 }
 
+// IDFromKey returns the migration ID if the key holds a migration item.
+func IDFromKey(key []byte) (id string, ok bool) {
+	if !strings.HasPrefix(string(key), dbPrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(string(key), dbPrefix), true
+}
+
 // Exists checks that migrationID has been ran
 func (repo *Repository) Exists(id string) bool {
 	key := getKeyFromID(id)
-	res, err := repo.db.Has(key, nil)
-	if err != nil {
-		return false
-	}
-	return res
+	_, err := repo.db.KV.Get(key)
+	return err == nil
 }
 
 // GetMigrationByID returns migration ID if it exists
 func (repo *Repository) GetMigrationByID(id string) (*Item, error) {
 	v := new(Item)
 	key := getKeyFromID(id)
-	data, err := repo.db.Get(key, nil)
+	data, err := repo.db.KV.Get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -75,12 +83,12 @@ func (repo *Repository) CreateMigration(migrationItem *Item) error {
 	if err != nil {
 		return err
 	}
-	return repo.db.Put(key, data, nil)
+	return repo.db.KV.Set(key, data)
 }
 
 // Open opens a DB, requires it to be closed before or it will error out
 func (repo *Repository) Open() (err error) {
-	repo.db, err = leveldb.OpenFile(repo.dbPath, nil)
+	repo.db, err = backend.Open(repo.backend, repo.dbPath)
 	return err
 }
 
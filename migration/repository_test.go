@@ -6,6 +6,7 @@ import (
 	"time"
 
 	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,12 +17,12 @@ func TestNewMigrationRepository(t *testing.T) {
 	defer migrationutils.CleanupDBFiles(prefix)
 
 	// Succeeds on opening a new DB
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 
 	defer repo.Close()
 	// Fails opening on an already open DB
-	_, err = NewMigrationRepository(targetDir)
+	_, err = NewMigrationRepository(backend.LevelDB, targetDir)
 	assert.Error(t, err)
 }
 
@@ -31,7 +32,7 @@ func TestMigrationRepo_Exists_DBClosed(t *testing.T) {
 
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 	// Forces error
 	err = repo.Close()
@@ -45,7 +46,7 @@ func TestMigrationRepo_CreateMigration(t *testing.T) {
 
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 
 	defer repo.Close()
@@ -77,7 +78,7 @@ func TestMigrationRepo_CreateMigration(t *testing.T) {
 	assert.Error(t, err)
 
 	// Wrong migration type stored
-	err = repo.db.Put([]byte("migration_blabla"), []byte{0, 1, 2, 3, 4}, nil)
+	err = repo.db.KV.Set([]byte("migration_blabla"), []byte{0, 1, 2, 3, 4})
 	assert.NoError(t, err)
 	_, err = repo.GetMigrationByID("blabla")
 	assert.Error(t, err)
@@ -10,19 +10,25 @@ import (
 	"strings"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/errors"
 	mfiles "github.com/centrifuge/go-centrifuge/migration/files"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+	"github.com/centrifuge/go-centrifuge/utils"
 	logging "github.com/ipfs/go-log"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 var log = logging.Logger("migrate-cmd")
 
-var migrations = map[string]func(*leveldb.DB) error{
+var migrations = map[string]func(*backend.DB) error{
 	"00Initial":              mfiles.Initial00,
 	"01KeysToHex":            mfiles.KeysToHex01,
 	"02AddPrefix":            mfiles.AddPrefix02,
 	"03AddDocumentIndex":     mfiles.AddDocumentIndex03,
 	"04AddStatusToDocuments": mfiles.AddStatusToDocuments04,
+	"05AddSecondaryIndexes":  mfiles.AddSecondaryIndexes05,
+	"06JobOwnersToModel":     mfiles.JobOwnersToModel06,
+	"07IndexJobOwners":       mfiles.IndexJobOwners07,
 }
 
 // Runner is the actor that runs the migrations
@@ -33,22 +39,43 @@ func NewMigrationRunner() *Runner {
 	return &Runner{}
 }
 
-// RunMigrations executes the migrations
-func (mr *Runner) RunMigrations(dbPath string) error {
-	repo, err := NewMigrationRepository(dbPath)
+// encryptionID is the ID of the migration item recorded once a database is encrypted.
+const encryptionID = "Encryption"
+
+// IDs returns the IDs of the migrations known to the node, in the order they are run.
+func IDs() []string {
+	ids := make([]string, 0, len(migrations))
+	for k := range migrations {
+		ids = append(ids, k)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ValidateIDs errors out if any of the migration items ids was recorded by a migration unknown to the node.
+func ValidateIDs(ids []string) error {
+	known := append(IDs(), encryptionID)
+	for _, id := range ids {
+		if !utils.ContainsString(known, id) {
+			return errors.New("unknown migration %s", id)
+		}
+	}
+
+	return nil
+}
+
+// RunMigrations executes the migrations on the database at dbPath opened with the storage backend
+// If the database is encrypted, the migrations run on the values decrypted with secret.
+func (mr *Runner) RunMigrations(backendName, dbPath string, secret *encryption.Secret) error {
+	repo, err := NewMigrationRepository(backendName, dbPath)
 	if err != nil {
 		return err
 	}
 
 	var bkpRepo *Repository
-	migrationList := make([]string, 0, len(migrations))
-	for k := range migrations {
-		migrationList = append(migrationList, k)
-	}
-	sort.Strings(migrationList)
 
 	//For each of them, in order execute
-	for _, k := range migrationList {
+	for _, k := range IDs() {
 		start := time.Now()
 
 		if repo.Exists(k) {
@@ -62,7 +89,7 @@ func (mr *Runner) RunMigrations(dbPath string) error {
 		}
 
 		// execute migration file
-		if err = migrations[k](repo.db); err != nil {
+		if err = runMigration(migrations[k], repo.db, secret); err != nil {
 			log.Errorf("Migration %s failed", k)
 			err1 := revertDBToBackup(repo, bkpRepo)
 			if err1 != nil {
@@ -97,6 +124,72 @@ func (mr *Runner) RunMigrations(dbPath string) error {
 	return repo.Close()
 }
 
+// runMigration runs the migration on db, decrypted with secret if db is encrypted.
+func runMigration(m func(*backend.DB) error, db *backend.DB, secret *encryption.Secret) error {
+	if encryption.IsEncrypted(db.KV) {
+		edb, err := db.Encrypt(secret)
+		if err != nil {
+			return err
+		}
+
+		db = edb
+	}
+
+	return m(db)
+}
+
+// EncryptDB encrypts the plaintext values of the database at dbPath, opened with the storage backend, with secret.
+// The database is backed up before the encryption and restored if the encryption fails.
+func (mr *Runner) EncryptDB(backendName, dbPath string, secret *encryption.Secret) error {
+	repo, err := NewMigrationRepository(backendName, dbPath)
+	if err != nil {
+		return err
+	}
+
+	if repo.Exists(encryptionID) {
+		// make sure the secret is the one the database is encrypted with
+		_, err = repo.db.Encrypt(secret)
+		if cerr := repo.Close(); err == nil {
+			err = cerr
+		}
+
+		return err
+	}
+
+	start := time.Now()
+	bkpRepo, err := backupDB(repo, encryptionID)
+	if err != nil {
+		return err
+	}
+
+	err = encryption.Encrypt(repo.db.KV, secret)
+	if err == nil {
+		err = repo.CreateMigration(&Item{
+			ID:       encryptionID,
+			DateRun:  time.Now().UTC(),
+			Duration: time.Since(start),
+			Hash:     "0x", // Not implemented yet
+		})
+	}
+
+	if err != nil {
+		log.Errorf("Encryption of %s failed", dbPath)
+		err1 := revertDBToBackup(repo, bkpRepo)
+		if err1 != nil {
+			return err1
+		}
+		return err
+	}
+
+	err = bkpRepo.Close()
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Database %s successfully encrypted", dbPath)
+	return repo.Close()
+}
+
 func getBackupName(path, name string) string {
 	bkpPath := strings.TrimSuffix(path, ".leveldb")
 	return fmt.Sprintf("%s_%s.leveldb", bkpPath, name)
@@ -121,7 +214,7 @@ func backupDB(srcRepo *Repository, migrationID string) (bkp *Repository, err err
 		return nil, err
 	}
 
-	return NewMigrationRepository(dstPath)
+	return NewMigrationRepository(srcRepo.backend, dstPath)
 }
 
 func revertDBToBackup(srcDB, bkpDB *Repository) error {
@@ -7,9 +7,10 @@ import (
 	"testing"
 
 	migrationutils "github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
 	"github.com/go-errors/errors"
 	"github.com/stretchr/testify/assert"
-	"github.com/syndtr/goleveldb/leveldb"
 )
 
 type content struct {
@@ -17,8 +18,8 @@ type content struct {
 }
 
 // Test migration items
-func Migration0(db *leveldb.DB) error {
-	err := db.Put([]byte("new"), []byte("sample"), nil)
+func Migration0(db *backend.DB) error {
+	err := db.KV.Set([]byte("new"), []byte("sample"))
 	if err != nil {
 		return err
 	}
@@ -26,8 +27,8 @@ func Migration0(db *leveldb.DB) error {
 	return nil
 }
 
-func Migration1(db *leveldb.DB) error {
-	err := db.Put([]byte("revert"), []byte("shouldbe"), nil)
+func Migration1(db *backend.DB) error {
+	err := db.KV.Set([]byte("revert"), []byte("shouldbe"))
 	if err != nil {
 		return err
 	}
@@ -48,11 +49,11 @@ func TestRunner_RunMigrations_AlreadyOpenError(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	_, err := leveldb.OpenFile(targetDir, nil)
+	_, err := backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 
 	runner := NewMigrationRunner()
-	err = runner.RunMigrations(targetDir)
+	err = runner.RunMigrations(backend.LevelDB, targetDir, nil)
 	assert.Error(t, err)
 }
 
@@ -63,7 +64,7 @@ func TestBackupDB(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 
 	// Force DB close error
@@ -89,7 +90,7 @@ func TestRevertToBackupDB(t *testing.T) {
 	// Cleanup after test
 	defer migrationutils.CleanupDBFiles(prefix)
 
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 
 	bkp, err := backupDB(repo, "SomeID")
@@ -129,36 +130,34 @@ func TestRunMigrations_singleSuccess(t *testing.T) {
 	defer migrationutils.CleanupDBFiles(prefix)
 
 	// Create test leveldb with some random data with non hex bytes as keys
-	db, err := leveldb.OpenFile(targetDir, nil)
+	db, err := backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 	sampleKey := migrationutils.RandomSlice(52)
 	data, err := json.Marshal(&content{"john"})
 	assert.NoError(t, err)
-	err = db.Put(sampleKey, data, nil)
+	err = db.KV.Set(sampleKey, data)
 	assert.NoError(t, err)
 	assert.NoError(t, db.Close())
 
 	// Override migrations for testing purposes
-	migrations = map[string]func(*leveldb.DB) error{
+	migrations = map[string]func(*backend.DB) error{
 		"0SuccessMigration": Migration0,
 	}
 	runner := NewMigrationRunner()
 	// Run migration to convert binary key to hex
-	err = runner.RunMigrations(targetDir)
+	err = runner.RunMigrations(backend.LevelDB, targetDir, nil)
 	assert.NoError(t, err)
 
-	db, err = leveldb.OpenFile(targetDir, nil)
+	db, err = backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
-	has, err := db.Has(sampleKey, nil)
+	_, err = db.KV.Get(sampleKey)
 	assert.NoError(t, err)
-	assert.True(t, has)
-	has, err = db.Has([]byte("new"), nil)
+	_, err = db.KV.Get([]byte("new"))
 	assert.NoError(t, err)
-	assert.True(t, has)
 	assert.NoError(t, db.Close())
 
 	// Check that migration success status is stored
-	repo, err := NewMigrationRepository(targetDir)
+	repo, err := NewMigrationRepository(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 	mi, err := repo.GetMigrationByID("0SuccessMigration")
 	assert.NoError(t, err)
@@ -169,7 +168,7 @@ func TestRunMigrations_singleSuccess(t *testing.T) {
 
 	// Try running again, and run should be skipped
 	dRun := mi.DateRun
-	err = runner.RunMigrations(targetDir)
+	err = runner.RunMigrations(backend.LevelDB, targetDir, nil)
 	assert.NoError(t, err)
 	err = repo.Open()
 	assert.NoError(t, err)
@@ -189,31 +188,84 @@ func TestRunner_RunMigrations_Failure(t *testing.T) {
 	defer migrationutils.CleanupDBFiles(prefix)
 
 	// Create test leveldb with some random data with non hex bytes as keys
-	db, err := leveldb.OpenFile(targetDir, nil)
+	db, err := backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
 	sampleKey := migrationutils.RandomSlice(52)
 	data, err := json.Marshal(&content{"john"})
 	assert.NoError(t, err)
-	err = db.Put(sampleKey, data, nil)
+	err = db.KV.Set(sampleKey, data)
 	assert.NoError(t, err)
 	assert.NoError(t, db.Close())
 
 	// Override migrations for testing purposes
-	migrations = map[string]func(*leveldb.DB) error{
+	migrations = map[string]func(*backend.DB) error{
 		"1FailedMigration": Migration1,
 	}
 	// Run migration to convert binary key to hex
 	runner := NewMigrationRunner()
-	err = runner.RunMigrations(targetDir)
+	err = runner.RunMigrations(backend.LevelDB, targetDir, nil)
 	assert.Error(t, err)
 
-	db, err = leveldb.OpenFile(targetDir, nil)
+	db, err = backend.Open(backend.LevelDB, targetDir)
 	assert.NoError(t, err)
-	has, err := db.Has(sampleKey, nil)
+	_, err = db.KV.Get(sampleKey)
 	assert.NoError(t, err)
-	assert.True(t, has)
-	has, err = db.Has([]byte("revert"), nil)
+	_, err = db.KV.Get([]byte("revert"))
+	assert.Error(t, err)
+	assert.NoError(t, db.Close())
+}
+
+func TestRunner_EncryptDB(t *testing.T) {
+	prefix := fmt.Sprintf("/tmp/datadir_%x", migrationutils.RandomByte32())
+	targetDir := fmt.Sprintf("%s.leveldb", prefix)
+
+	// Cleanup after test
+	defer migrationutils.CleanupDBFiles(prefix)
+
+	db, err := backend.Open(backend.LevelDB, targetDir)
+	assert.NoError(t, err)
+	sampleKey := migrationutils.RandomSlice(52)
+	assert.NoError(t, db.KV.Set(sampleKey, []byte("john")))
+	assert.NoError(t, db.Close())
+
+	secret, err := encryption.NewPassphraseSecret("passphrase")
+	assert.NoError(t, err)
+	runner := NewMigrationRunner()
+	assert.NoError(t, runner.EncryptDB(backend.LevelDB, targetDir, secret))
+
+	// values are encrypted
+	db, err = backend.Open(backend.LevelDB, targetDir)
+	assert.NoError(t, err)
+	v, err := db.KV.Get(sampleKey)
+	assert.NoError(t, err)
+	assert.NotEqual(t, []byte("john"), v)
+	_, err = db.Encrypt(nil)
+	assert.Error(t, err)
+	edb, err := db.Encrypt(secret)
+	assert.NoError(t, err)
+	v, err = edb.KV.Get(sampleKey)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("john"), v)
+	assert.NoError(t, db.Close())
+
+	// encrypting again is a no-op, unless the secret doesn't match
+	assert.NoError(t, runner.EncryptDB(backend.LevelDB, targetDir, secret))
+	wrong, err := encryption.NewPassphraseSecret("wrong")
+	assert.NoError(t, err)
+	assert.Error(t, runner.EncryptDB(backend.LevelDB, targetDir, wrong))
+
+	// migrations run on the decrypted values
+	migrations = map[string]func(*backend.DB) error{
+		"0SuccessMigration": Migration0,
+	}
+	assert.Error(t, runner.RunMigrations(backend.LevelDB, targetDir, nil))
+	assert.NoError(t, runner.RunMigrations(backend.LevelDB, targetDir, secret))
+	db, err = backend.Open(backend.LevelDB, targetDir)
+	assert.NoError(t, err)
+	edb, err = db.Encrypt(secret)
+	assert.NoError(t, err)
+	v, err = edb.KV.Get([]byte("new"))
 	assert.NoError(t, err)
-	assert.False(t, has)
+	assert.Equal(t, []byte("sample"), v)
 	assert.NoError(t, db.Close())
 }
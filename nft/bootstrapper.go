@@ -9,6 +9,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -35,6 +36,7 @@ func (*Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	dispatcher := ctx[jobs.BootstrappedDispatcher].(jobs.Dispatcher)
 	ethClient := ctx[ethereum.BootstrappedEthereumClient].(ethereum.Client)
 	api := api{api: centAPI}
+	notifier, _ := ctx[notification.BootstrappedOutbox].(notification.Sender)
 	go dispatcher.RegisterRunner(nftJob, &MintNFTJob{
 		accountsSrv: accountsSrv,
 		docSrv:      docSrv,
@@ -42,12 +44,14 @@ func (*Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		ethClient:   ethClient,
 		api:         api,
 		identitySrv: idService,
+		notifier:    notifier,
 	})
 
 	go dispatcher.RegisterRunner(transferNFTJob, &TransferNFTJob{
 		identitySrv: idService,
 		accountSrv:  accountsSrv,
 		ethClient:   ethClient,
+		notifier:    notifier,
 	})
 
 	nftSrv := newService(
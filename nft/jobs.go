@@ -10,9 +10,11 @@ import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/gocelery/v2"
 	"github.com/ethereum/go-ethereum/common"
@@ -24,23 +26,19 @@ const (
 	transferNFTJob = "Transfer NFT Job"
 )
 
-type task struct {
-	runnerFunc gocelery.RunnerFunc
-	next       string
-}
-
 // MintNFTJob mints and NFT async.
 // args are as follows
 // accountID, documentID, tokenID, MintNFTRequest
+// the progress of the minting is sent to notifier after every task.
 type MintNFTJob struct {
+	jobs.Base
 	accountsSrv config.Service
 	docSrv      documents.Service
 	dispatcher  jobs.Dispatcher
 	ethClient   ethereum.Client
 	api         API
 	identitySrv identity.Service
-
-	tasks map[string]task
+	notifier    notification.Sender
 }
 
 // New returns a new instance of MintNFTJob
@@ -52,22 +50,12 @@ func (m *MintNFTJob) New() gocelery.Runner {
 		ethClient:   m.ethClient,
 		api:         m.api,
 		identitySrv: m.identitySrv,
+		notifier:    m.notifier,
 	}
-	nm.loadTasks()
+	nm.Base = jobs.NewBase(nm.getTasks())
 	return nm
 }
 
-// RunnerFunc returns runner func associated with the task
-func (m *MintNFTJob) RunnerFunc(task string) gocelery.RunnerFunc {
-	return m.tasks[task].runnerFunc
-}
-
-// Next returns the next task, if there is any, after the given task
-func (m *MintNFTJob) Next(task string) (next string, ok bool) {
-	next = m.tasks[task].next
-	return next, next != ""
-}
-
 func (m *MintNFTJob) convertArgs(
 	args []interface{}) (ctx context.Context, did identity.DID, docID []byte, tokenID TokenID, req MintNFTRequest,
 	err error) {
@@ -84,10 +72,10 @@ func (m *MintNFTJob) convertArgs(
 	return ctx, did, req.DocumentID, tokenID, req, nil
 }
 
-func (m *MintNFTJob) loadTasks() {
-	m.tasks = map[string]task{
+func (m *MintNFTJob) getTasks() map[string]jobs.Task {
+	tasks := map[string]jobs.Task{
 		"add_nft_to_document": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				ctx, _, docID, tokenID, req, err := m.convertArgs(args)
 				if err != nil {
 					return nil, err
@@ -106,30 +94,41 @@ func (m *MintNFTJob) loadTasks() {
 				if err != nil {
 					return nil, fmt.Errorf("failed to commit document: %w", err)
 				}
+
+				m.Logger(overrides).Infof("committing the document with the nft in job %s", jobID.Hex())
 				overrides["document_commit_job"] = jobID
 				return nil, nil
 			},
-			next: "wait_for_document_commit",
+			Next: "wait_for_document_commit",
 		},
 		"wait_for_document_commit": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				did := args[0].(identity.DID)
 				jobID := overrides["document_commit_job"].(gocelery.JobID)
-				job, err := m.dispatcher.Job(did, jobID)
+				info, err := m.dispatcher.Info(did, jobID)
 				if err != nil {
 					return nil, fmt.Errorf("failed to fetch job: %w", err)
 				}
 
-				if !job.IsSuccessful() {
+				switch info.Status {
+				case jobs.StatusSuccess:
+					return nil, nil
+				case jobs.StatusPending:
 					return nil, fmt.Errorf("document not committed yet")
+				default:
+					return nil, errors.NewTypedError(ErrDocumentCommitFailed, errors.New("commit job %s", info.Status))
 				}
-
-				return nil, nil
 			},
-			next: "validate_nft_proofs",
+			Next: "validate_nft_proofs",
+			// polled until the document is committed
+			Retry: &jobs.RetryPolicy{
+				Retryable: func(err error) bool {
+					return !errors.IsOfType(ErrDocumentCommitFailed, err)
+				},
+			},
 		},
 		"validate_nft_proofs": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				ctx, did, _, tokenID, req, err := m.convertArgs(args)
 				if err != nil {
 					return nil, err
@@ -154,13 +153,14 @@ func (m *MintNFTJob) loadTasks() {
 					return nil, fmt.Errorf("failed to validate nft proofs: %w", err)
 				}
 
-				log.Infof("Successfully validated Proofs on cent chain for anchorID: %s", requestData.AnchorID.String())
+				m.Logger(overrides).Infof("validated the proofs on centchain for anchor %s", requestData.AnchorID.String())
 				return nil, nil
 			},
-			next: "wait_for_asset_deposit",
+			Next:  "wait_for_asset_deposit",
+			Retry: jobs.DefaultRetryPolicy(),
 		},
 		"wait_for_asset_deposit": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				ctx, _, _, _, req, err := m.convertArgs(args)
 				if err != nil {
 					return nil, err
@@ -172,7 +172,7 @@ func (m *MintNFTJob) loadTasks() {
 
 				from := overrides["eth_from_block"].(*big.Int)
 				requestData := overrides["mint_request"].(MintRequest)
-				log.Infof("Triggered listener on AssetManager Address %s", req.AssetManagerAddress.Hex())
+				m.Logger(overrides).Infof("waiting for the asset deposit on asset manager %s", req.AssetManagerAddress.Hex())
 				err = ethereum.EventEmitted(
 					ctx,
 					m.ethClient.GetEthClient(),
@@ -183,13 +183,13 @@ func (m *MintNFTJob) loadTasks() {
 					return nil, err
 				}
 
-				log.Infof("Asset[%s] successfully deposited: %v\n", hexutil.Encode(requestData.BundledHash[:]))
+				m.Logger(overrides).Infof("asset %s deposited", hexutil.Encode(requestData.BundledHash[:]))
 				return nil, nil
 			},
-			next: "execute_mint_nft",
+			Next: "execute_mint_nft",
 		},
 		"execute_mint_nft": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				ctx, _, _, _, req, err := m.convertArgs(args)
 				if err != nil {
 					return nil, err
@@ -204,7 +204,7 @@ func (m *MintNFTJob) loadTasks() {
 					return nil, fmt.Errorf("failed to submit txn: %w", err)
 				}
 
-				log.Infof("Sent off ethTX[%s] to mint [tokenID: %s, anchorID: %s, registry: %s, to NFT contract.",
+				m.Logger(overrides).Infof("sent transaction %s to mint token %s of anchor %s on registry %s",
 					tx.Hash().Hex(),
 					hexutil.Encode(requestData.TokenID.Bytes()),
 					hexutil.Encode(requestData.AnchorID[:]),
@@ -212,18 +212,19 @@ func (m *MintNFTJob) loadTasks() {
 				overrides["mint_nft_txn"] = tx.Hash()
 				return nil, nil
 			},
-			next: "wait_mint_nft",
+			Next:  "wait_mint_nft",
+			Retry: jobs.DefaultRetryPolicy(),
 		},
 		"wait_mint_nft": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				tx := overrides["mint_nft_txn"].(common.Hash)
 				_, err = ethereum.IsTxnSuccessful(context.Background(), m.ethClient, tx)
 				return nil, err
 			},
-			next: "check_nft_owner",
+			Next: "check_nft_owner",
 		},
 		"check_nft_owner": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				tokenID := args[1].(TokenID)
 				req := args[2].(MintNFTRequest)
 				owner, err := ownerOf(m.ethClient, req.RegistryAddress, tokenID[:])
@@ -236,11 +237,71 @@ func (m *MintNFTJob) loadTasks() {
 						req.DepositAddress.Hex(), owner.Hex())
 				}
 
-				log.Infof("Document %s minted successfully within transaction %s", hexutil.Encode(req.DocumentID), overrides["mint_nft_txn"])
+				m.Logger(overrides).Infof("document %s minted in transaction %s", hexutil.Encode(req.DocumentID), overrides["mint_nft_txn"])
 				return nil, nil
 			},
 		},
 	}
+
+	for name, t := range tasks {
+		t.RunnerFunc = m.notifyProgress(name, t.RunnerFunc)
+		tasks[name] = t
+	}
+
+	return tasks
+}
+
+// notifyProgress returns a runnerFunc which sends the minting progress once the step succeeds.
+func (m *MintNFTJob) notifyProgress(step string, runnerFunc gocelery.RunnerFunc) gocelery.RunnerFunc {
+	return func(args []interface{}, overrides map[string]interface{}) (interface{}, error) {
+		result, err := runnerFunc(args, overrides)
+		if err != nil || m.notifier == nil {
+			return result, err
+		}
+
+		if err := m.sendProgress(step, args, overrides); err != nil {
+			log.Errorf("failed to send nft progress: %v", err)
+		}
+
+		return result, nil
+	}
+}
+
+func (m *MintNFTJob) sendProgress(step string, args []interface{}, overrides map[string]interface{}) error {
+	ctx, _, docID, tokenID, req, err := m.convertArgs(args)
+	if err != nil {
+		return err
+	}
+
+	jobID := jobs.JobIDFromOverrides(overrides)
+	err = m.notifier.Send(ctx, notification.Message{
+		EventType:  notification.EventTypeNFT,
+		RecordedAt: time.Now().UTC(),
+		NFT: &notification.NFTMessage{
+			JobID:           jobID,
+			DocumentID:      docID,
+			RegistryAddress: req.RegistryAddress.Hex(),
+			TokenID:         tokenID.String(),
+			Step:            step,
+		},
+	})
+	if err != nil || step != "check_nft_owner" {
+		return err
+	}
+
+	txHash, _ := overrides["mint_nft_txn"].(common.Hash)
+	return m.notifier.Send(ctx, notification.Message{
+		EventType:  notification.EventTypeNFTMinted,
+		RecordedAt: time.Now().UTC(),
+		NFTMinted: &notification.NFTMintedMessage{
+			JobID:           jobID,
+			DocumentID:      docID,
+			RegistryAddress: req.RegistryAddress.Hex(),
+			TokenID:         tokenID.String(),
+			Owner:           req.DepositAddress.Hex(),
+			TxHash:          txHash.Hex(),
+		},
+	})
 }
 
 func initiateNFTMint(dispatcher jobs.Dispatcher, did identity.DID, tokenID TokenID,
@@ -259,12 +320,13 @@ func initiateNFTMint(dispatcher jobs.Dispatcher, did identity.DID, tokenID Token
 // TransferNFTJob is a job runner for transferring NFT ownership
 // args are as follows
 // did(from), to, registry, tokenID
+// the completed transfer is sent to notifier.
 type TransferNFTJob struct {
+	jobs.Base
 	identitySrv identity.Service
 	accountSrv  config.Service
 	ethClient   ethereum.Client
-
-	tasks map[string]task
+	notifier    notification.Sender
 }
 
 // New returns a new instance of TransferNFTJob
@@ -273,22 +335,12 @@ func (t *TransferNFTJob) New() gocelery.Runner {
 		identitySrv: t.identitySrv,
 		accountSrv:  t.accountSrv,
 		ethClient:   t.ethClient,
+		notifier:    t.notifier,
 	}
-	nt.loadTasks()
+	nt.Base = jobs.NewBase(nt.getTasks())
 	return nt
 }
 
-// RunnerFunc returns the runner func associated with task
-func (t *TransferNFTJob) RunnerFunc(task string) gocelery.RunnerFunc {
-	return t.tasks[task].runnerFunc
-}
-
-// Next returns the next task after the given task
-func (t *TransferNFTJob) Next(task string) (next string, ok bool) {
-	next = t.tasks[task].next
-	return next, next != ""
-}
-
 func (t *TransferNFTJob) convertArgs(
 	args []interface{}) (ctx context.Context, from, to, registry common.Address, tokenID TokenID, err error) {
 	to, registry, tokenID = args[1].(common.Address), args[2].(common.Address), args[3].(TokenID)
@@ -301,10 +353,10 @@ func (t *TransferNFTJob) convertArgs(
 	return contextutil.WithAccount(context.Background(), acc), did.ToAddress(), to, registry, tokenID, nil
 }
 
-func (t *TransferNFTJob) loadTasks() {
-	t.tasks = map[string]task{
+func (t *TransferNFTJob) getTasks() map[string]jobs.Task {
+	return map[string]jobs.Task{
 		"transfer_ownership": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				ctx, from, to, registry, tokenID, err := t.convertArgs(args)
 				if err != nil {
 					return nil, err
@@ -319,17 +371,18 @@ func (t *TransferNFTJob) loadTasks() {
 				overrides["transfer_owner_txn"] = tx.Hash()
 				return nil, nil
 			},
-			next: "wait_for_txn",
+			Next:  "wait_for_txn",
+			Retry: jobs.DefaultRetryPolicy(),
 		},
 		"wait_for_txn": {
-			runnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
+			RunnerFunc: func(args []interface{}, overrides map[string]interface{}) (result interface{}, err error) {
 				tx := overrides["transfer_owner_txn"].(common.Hash)
 				_, err = ethereum.IsTxnSuccessful(context.Background(), t.ethClient, tx)
 				if err != nil {
 					return nil, fmt.Errorf("txn not complete yet: %w", err)
 				}
 
-				_, _, to, registry, tokenID, err := t.convertArgs(args)
+				ctx, from, to, registry, tokenID, err := t.convertArgs(args)
 				if err != nil {
 					return nil, err
 				}
@@ -342,12 +395,37 @@ func (t *TransferNFTJob) loadTasks() {
 					return nil, fmt.Errorf("new nft owner[%s] doesn't match expected one[%s]", owner, to)
 				}
 
+				t.notifyTransfer(ctx, from, to, registry, tokenID, tx, overrides)
 				return nil, nil
 			},
 		},
 	}
 }
 
+// notifyTransfer sends the completed transfer to the notifier, if any.
+func (t *TransferNFTJob) notifyTransfer(ctx context.Context, from, to, registry common.Address, tokenID TokenID,
+	tx common.Hash, overrides map[string]interface{}) {
+	if t.notifier == nil {
+		return
+	}
+
+	err := t.notifier.Send(ctx, notification.Message{
+		EventType:  notification.EventTypeNFTTransferred,
+		RecordedAt: time.Now().UTC(),
+		NFTTransferred: &notification.NFTTransferredMessage{
+			JobID:           jobs.JobIDFromOverrides(overrides),
+			RegistryAddress: registry.Hex(),
+			TokenID:         tokenID.String(),
+			From:            from.Hex(),
+			To:              to.Hex(),
+			TxHash:          tx.Hex(),
+		},
+	})
+	if err != nil {
+		log.Errorf("failed to send nft transfer: %v", err)
+	}
+}
+
 func initiateTransferNFTJob(dispatcher jobs.Dispatcher, did identity.DID, to, registry common.Address,
 	tokenID TokenID) (gocelery.JobID, error) {
 	job := gocelery.NewRunnerJob(
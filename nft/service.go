@@ -35,6 +35,9 @@ const (
 	// ErrNFTMinted error for NFT already minted for registry
 	ErrNFTMinted = errors.Error("NFT already minted")
 
+	// ErrDocumentCommitFailed is returned when the commit of the document to mint the NFT from fails.
+	ErrDocumentCommitFailed = errors.Error("document commit failed")
+
 	// GenericMintMethodABI constant interface to interact with mint methods
 	GenericMintMethodABI = `[{"constant":false,"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tkn","type":"uint256"},{"internalType":"bytes32","name":"dataRoot","type":"bytes32"},{"internalType":"bytes[]","name":"properties","type":"bytes[]"},{"internalType":"bytes[]","name":"values","type":"bytes[]"},{"internalType":"bytes32[]","name":"salts","type":"bytes32[]"}],"name":"mint","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
 
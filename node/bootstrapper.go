@@ -6,8 +6,10 @@ import (
 	"os/signal"
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/centchain"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -66,7 +68,17 @@ func GetServers(ctx map[string]interface{}) ([]Server, error) {
 		return nil, errors.New("Node: dispatcher server not initialised")
 	}
 
+	outbox, ok := ctx[notification.BootstrappedOutbox].(Server)
+	if !ok {
+		return nil, errors.New("Node: notification outbox not initialised")
+	}
+
+	watcher, ok := ctx[centchain.BootstrappedWatcher].(Server)
+	if !ok {
+		return nil, errors.New("Node: centchain watcher not initialised")
+	}
+
 	var servers []Server
-	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), dispatcher)
+	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), dispatcher, outbox, watcher)
 	return servers, nil
 }
@@ -0,0 +1,29 @@
+package notification
+
+import (
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// BootstrappedOutbox is the key to the notification Outbox in the bootstrap context.
+const BootstrappedOutbox = "BootstrappedOutbox"
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap adds the notification Outbox into context.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	repo, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("storage repository not initialised")
+	}
+
+	cfg, ok := ctx[bootstrap.BootstrappedConfig].(Config)
+	if !ok {
+		return errors.New("notification config not initialised")
+	}
+
+	ctx[BootstrappedOutbox] = NewOutbox(repo, cfg)
+	return nil
+}
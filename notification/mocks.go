@@ -0,0 +1,75 @@
+// +build unit integration
+
+package notification
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/stretchr/testify/mock"
+)
+
+func (b Bootstrapper) TestBootstrap(ctx map[string]interface{}) error {
+	return b.Bootstrap(ctx)
+}
+
+func (b Bootstrapper) TestTearDown() error {
+	return nil
+}
+
+type MockOutbox struct {
+	mock.Mock
+	Outbox
+}
+
+func (m *MockOutbox) Send(ctx context.Context, message Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockOutbox) FailedDeliveries(accountID identity.DID) ([]Delivery, error) {
+	args := m.Called(accountID)
+	deliveries, _ := args.Get(0).([]Delivery)
+	return deliveries, args.Error(1)
+}
+
+func (m *MockOutbox) Replay(accountID identity.DID, deliveryID []byte) error {
+	args := m.Called(accountID, deliveryID)
+	return args.Error(0)
+}
+
+func (m *MockOutbox) CreateSubscription(accountID identity.DID, sub Subscription) (Subscription, error) {
+	args := m.Called(accountID, sub)
+	sub, _ = args.Get(0).(Subscription)
+	return sub, args.Error(1)
+}
+
+func (m *MockOutbox) GetSubscription(accountID identity.DID, subscriptionID []byte) (Subscription, error) {
+	args := m.Called(accountID, subscriptionID)
+	sub, _ := args.Get(0).(Subscription)
+	return sub, args.Error(1)
+}
+
+func (m *MockOutbox) ListSubscriptions(accountID identity.DID) ([]Subscription, error) {
+	args := m.Called(accountID)
+	subs, _ := args.Get(0).([]Subscription)
+	return subs, args.Error(1)
+}
+
+func (m *MockOutbox) UpdateSubscription(accountID identity.DID, sub Subscription, clearSecret bool) (Subscription, error) {
+	args := m.Called(accountID, sub, clearSecret)
+	sub, _ = args.Get(0).(Subscription)
+	return sub, args.Error(1)
+}
+
+func (m *MockOutbox) DeleteSubscription(accountID identity.DID, subscriptionID []byte) error {
+	args := m.Called(accountID, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockOutbox) Subscribe(accountID identity.DID, offset uint64) ([]Event, <-chan Event, func(), error) {
+	args := m.Called(accountID, offset)
+	backlog, _ := args.Get(0).([]Event)
+	events, _ := args.Get(1).(chan Event)
+	return backlog, events, func() {}, args.Error(2)
+}
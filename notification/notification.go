@@ -22,8 +22,32 @@ type EventType string
 const (
 	EventTypeJob      EventType = "job"
 	EventTypeDocument EventType = "document"
+	EventTypeAnchor   EventType = "anchor"
+	EventTypeNFT      EventType = "nft"
+
+	EventTypeSignatureRequested   EventType = "signature_requested"
+	EventTypeSignatureGiven       EventType = "signature_given"
+	EventTypeDocumentAnchored     EventType = "document_anchored"
+	EventTypeDocumentCommitted    EventType = "document_committed"
+	EventTypeNFTMinted            EventType = "nft_minted"
+	EventTypeNFTTransferred       EventType = "nft_transferred"
+	EventTypeCollaboratorsChanged EventType = "collaborators_changed"
+	EventTypeOraclePushed         EventType = "oracle_pushed"
 )
 
+// isLegacy returns true for the event types delivered to the notification endpoint of the account,
+// and to the webhook subscriptions not listing the event types. Other event types must be opted into.
+func (t EventType) isLegacy() bool {
+	return t == EventTypeJob || t == EventTypeDocument
+}
+
+// eventTypes are the known event types.
+var eventTypes = []EventType{
+	EventTypeJob, EventTypeDocument, EventTypeAnchor, EventTypeNFT,
+	EventTypeSignatureRequested, EventTypeSignatureGiven, EventTypeDocumentAnchored, EventTypeDocumentCommitted,
+	EventTypeNFTMinted, EventTypeNFTTransferred, EventTypeCollaboratorsChanged, EventTypeOraclePushed,
+}
+
 type JobMessage struct {
 	ID         byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`    // job identifier
 	Owner      byteutils.HexBytes `json:"owner" swaggertype:"primitive,string"` // job owner
@@ -35,13 +59,112 @@ type JobMessage struct {
 type DocumentMessage struct {
 	ID        byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`         // document identifier
 	VersionID byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"` // version identifier
+	Scheme    string             `json:"scheme"`                                    // scheme of the document
 	From      byteutils.HexBytes `json:"from" swaggertype:"primitive,string"`       // document received from
 	To        byteutils.HexBytes `json:"to" swaggertype:"primitive,string"`         // document sent to
 }
 
+// AnchorMessage is the progress of the anchoring of a document version.
+type AnchorMessage struct {
+	JobID      byteutils.HexBytes `json:"job_id" swaggertype:"primitive,string"`      // anchor job identifier
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"` // document identifier
+	VersionID  byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`  // version being anchored
+	Step       string             `json:"step"`                                       // anchoring step completed
+}
+
+// NFTMessage is the progress of the minting of an NFT.
+type NFTMessage struct {
+	JobID           byteutils.HexBytes `json:"job_id" swaggertype:"primitive,string"`      // mint job identifier
+	DocumentID      byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"` // document identifier
+	RegistryAddress string             `json:"registry_address"`                           // NFT registry
+	TokenID         string             `json:"token_id"`                                   // hex encoded token identifier
+	Step            string             `json:"step"`                                       // minting step completed
+}
+
+// SignatureRequestedMessage is a signature of a document version requested from the account.
+type SignatureRequestedMessage struct {
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"` // document identifier
+	VersionID  byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`  // version to be signed
+	Requester  byteutils.HexBytes `json:"requester" swaggertype:"primitive,string"`   // collaborator requesting the signature
+}
+
+// SignatureGivenMessage is a signature of a document version of the account given by a collaborator.
+type SignatureGivenMessage struct {
+	JobID       byteutils.HexBytes `json:"job_id" swaggertype:"primitive,string"`       // anchor job identifier
+	DocumentID  byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`  // document identifier
+	VersionID   byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`   // version signed
+	Signer      byteutils.HexBytes `json:"signer" swaggertype:"primitive,string"`       // collaborator who signed
+	SignatureID byteutils.HexBytes `json:"signature_id" swaggertype:"primitive,string"` // signature identifier
+}
+
+// DocumentAnchoredMessage is a document version of the account anchored on chain.
+type DocumentAnchoredMessage struct {
+	JobID        byteutils.HexBytes `json:"job_id" swaggertype:"primitive,string"`        // anchor job identifier
+	DocumentID   byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`   // document identifier
+	VersionID    byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`    // version anchored
+	AnchorID     byteutils.HexBytes `json:"anchor_id" swaggertype:"primitive,string"`     // anchor identifier
+	DocumentRoot byteutils.HexBytes `json:"document_root" swaggertype:"primitive,string"` // anchored document root
+}
+
+// DocumentCommittedMessage is a document version of the account committed after anchoring.
+type DocumentCommittedMessage struct {
+	JobID      byteutils.HexBytes `json:"job_id" swaggertype:"primitive,string"`      // anchor job identifier
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"` // document identifier
+	VersionID  byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`  // version committed
+	Scheme     string             `json:"scheme"`                                     // scheme of the document
+}
+
+// NFTMintedMessage is an NFT minted for a document of the account.
+type NFTMintedMessage struct {
+	JobID           byteutils.HexBytes `json:"job_id" swaggertype:"primitive,string"`      // mint job identifier
+	DocumentID      byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"` // document identifier
+	RegistryAddress string             `json:"registry_address"`                           // NFT registry
+	TokenID         string             `json:"token_id"`                                   // hex encoded token identifier
+	Owner           string             `json:"owner"`                                      // owner of the NFT
+	TxHash          string             `json:"tx_hash"`                                    // mint transaction
+}
+
+// NFTTransferredMessage is an NFT transferred by the account.
+type NFTTransferredMessage struct {
+	JobID           byteutils.HexBytes `json:"job_id" swaggertype:"primitive,string"` // transfer job identifier
+	RegistryAddress string             `json:"registry_address"`                      // NFT registry
+	TokenID         string             `json:"token_id"`                              // hex encoded token identifier
+	From            string             `json:"from"`                                  // previous owner
+	To              string             `json:"to"`                                    // new owner
+	TxHash          string             `json:"tx_hash"`                               // transfer transaction
+}
+
+// AccessChange is a change of the collaborators of a role, or of the access of a collaborator.
+type AccessChange struct {
+	Key  string `json:"key"`                                 // role key or collaborator DID
+	Type string `json:"type" enums:"added,removed,modified"` // type of the change
+	Old  string `json:"old,omitempty"`                       // collaborators or access before
+	New  string `json:"new,omitempty"`                       // collaborators or access after
+}
+
+// CollaboratorsChangedMessage is a change of the roles or the collaborators of a document of the account.
+type CollaboratorsChangedMessage struct {
+	DocumentID        byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`         // document identifier
+	VersionID         byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`          // version with the changes
+	PreviousVersionID byteutils.HexBytes `json:"previous_version_id" swaggertype:"primitive,string"` // version compared to
+	Roles             []AccessChange     `json:"roles,omitempty"`                                    // changes of the roles
+	Collaborators     []AccessChange     `json:"collaborators,omitempty"`                            // changes of the collaborators
+}
+
+// OraclePushedMessage is a value of an NFT pushed to an oracle by the account.
+type OraclePushedMessage struct {
+	JobID         byteutils.HexBytes `json:"job_id" swaggertype:"primitive,string"`      // push job identifier
+	OracleAddress string             `json:"oracle_address"`                             // oracle updated
+	TokenID       string             `json:"token_id"`                                   // hex encoded token identifier
+	Fingerprint   byteutils.HexBytes `json:"fingerprint" swaggertype:"primitive,string"` // fingerprint of the pushed value
+	Value         byteutils.HexBytes `json:"value" swaggertype:"primitive,string"`       // pushed value
+	TxHash        string             `json:"tx_hash"`                                    // oracle update transaction
+}
+
 // Message is the payload used to send the notifications.
+// The payload matching the event type is set.
 type Message struct {
-	EventType  EventType `json:"event_type" enums:"job,document"`
+	EventType  EventType `json:"event_type" enums:"job,document,anchor,nft,signature_requested,signature_given,document_anchored,document_committed,nft_minted,nft_transferred,collaborators_changed,oracle_pushed"`
 	RecordedAt time.Time `json:"recorded_at" swaggertype:"primitive,string"`
 
 	// Job contains jobs specific details. Ensure event type is job
@@ -49,6 +172,33 @@ type Message struct {
 
 	// Document contains recently received document. Ensure event type is document
 	Document *DocumentMessage `json:"document,omitempty"`
+
+	// Anchor contains the anchoring progress. Ensure event type is anchor
+	Anchor *AnchorMessage `json:"anchor,omitempty"`
+
+	// NFT contains the minting progress. Ensure event type is nft
+	NFT *NFTMessage `json:"nft,omitempty"`
+
+	SignatureRequested   *SignatureRequestedMessage   `json:"signature_requested,omitempty"`
+	SignatureGiven       *SignatureGivenMessage       `json:"signature_given,omitempty"`
+	DocumentAnchored     *DocumentAnchoredMessage     `json:"document_anchored,omitempty"`
+	DocumentCommitted    *DocumentCommittedMessage    `json:"document_committed,omitempty"`
+	NFTMinted            *NFTMintedMessage            `json:"nft_minted,omitempty"`
+	NFTTransferred       *NFTTransferredMessage       `json:"nft_transferred,omitempty"`
+	CollaboratorsChanged *CollaboratorsChangedMessage `json:"collaborators_changed,omitempty"`
+	OraclePushed         *OraclePushedMessage         `json:"oracle_pushed,omitempty"`
+}
+
+// scheme returns the scheme of the document of the message, if any.
+func (m Message) scheme() (string, bool) {
+	switch {
+	case m.Document != nil:
+		return m.Document.Scheme, true
+	case m.DocumentCommitted != nil:
+		return m.DocumentCommitted.Scheme, true
+	default:
+		return "", false
+	}
 }
 
 // Sender defines methods that can handle a notification.
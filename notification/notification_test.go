@@ -5,9 +5,9 @@ package notification
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sync"
 	"testing"
@@ -60,14 +60,11 @@ func sendAndVerify(t *testing.T, message Message) {
 		}
 	})
 
-	addr, _, err := utils.GetFreeAddrPort()
-	assert.NoError(t, err)
-	server := &http.Server{Addr: addr, Handler: mux}
-	go server.ListenAndServe()
+	server := httptest.NewServer(mux)
 	defer server.Close()
 
 	wb := NewWebhookSender()
-	url := fmt.Sprintf("http://%s/webhook", addr)
+	url := server.URL + "/webhook"
 	cfg.Set("notifications.endpoint", url)
 	acc := new(config.MockAccount)
 	acc.On("GetReceiveEventNotificationEndpoint").Return(url).Once()
@@ -0,0 +1,435 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// SignatureHeader is the header holding the HMAC-SHA256 of the payload, hex encoded with the sha256= prefix.
+	SignatureHeader = "X-Centrifuge-Signature"
+
+	// DeliveryHeader is the header holding the ID of the delivery. Retries of a delivery carry the same ID.
+	DeliveryHeader = "X-Centrifuge-Delivery"
+
+	// ErrDeliveryNotFound is returned when the failed delivery doesn't exist.
+	ErrDeliveryNotFound = errors.Error("delivery not found")
+
+	outboxPrefix     = "notification_outbox_"
+	deadLetterPrefix = "notification_dead_letter_"
+
+	// maxRetryDelay caps the exponential backoff of the retries.
+	maxRetryDelay = time.Hour
+
+	// pollInterval is the interval between the checks for deliveries due.
+	pollInterval = time.Second
+
+	// maxConcurrentDeliveries is the number of deliveries attempted at once.
+	maxConcurrentDeliveries = 10
+)
+
+// Config defines the config required by the outbox.
+type Config interface {
+	GetNotificationSecret() string
+	GetNotificationMaxAttempts() int
+	GetNotificationRetryInterval() time.Duration
+}
+
+// Delivery is a notification to be delivered to the webhook of an account.
+type Delivery struct {
+	ID        byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`
+	AccountID byteutils.HexBytes `json:"account_id" swaggertype:"primitive,string"`
+
+	// SubscriptionID is the webhook subscription of the delivery. Empty for the account webhook.
+	SubscriptionID byteutils.HexBytes `json:"subscription_id,omitempty" swaggertype:"primitive,string"`
+	URL            string             `json:"url"`
+	Message        Message            `json:"message"`
+	Attempts       int                `json:"attempts"`
+	NextAttempt    time.Time          `json:"next_attempt" swaggertype:"primitive,string"`
+	LastError      string             `json:"last_error,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" swaggertype:"primitive,string"`
+}
+
+// JSON marshals Delivery to json bytes.
+func (d *Delivery) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// FromJSON loads json bytes to Delivery.
+func (d *Delivery) FromJSON(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+// Type returns the type of Delivery.
+func (d *Delivery) Type() reflect.Type {
+	return reflect.TypeOf(d)
+}
+
+// Outbox stores the notifications in the node db and delivers them to the webhooks, retrying with exponential backoff.
+// Notifications still failing after the max attempts are moved to the dead-letter queue.
+type Outbox interface {
+	Sender
+	Subscriptions
+	Stream
+
+	Name() string
+	Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error)
+
+	// FailedDeliveries returns the deliveries of the account in the dead-letter queue, oldest first.
+	FailedDeliveries(accountID identity.DID) ([]Delivery, error)
+
+	// Replay moves the failed delivery of the account back to the outbox.
+	Replay(accountID identity.DID, deliveryID []byte) error
+}
+
+type outbox struct {
+	*stream
+	repo   storage.Repository
+	config Config
+	wake   chan struct{}
+}
+
+// NewOutbox returns an Outbox storing the deliveries in repo.
+func NewOutbox(repo storage.Repository, config Config) Outbox {
+	repo.Register(new(Delivery))
+	repo.Register(new(Subscription))
+	return &outbox{
+		stream: newStream(repo),
+		repo:   repo,
+		config: config,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+func outboxKey(id []byte) []byte {
+	return []byte(outboxPrefix + hexutil.Encode(id))
+}
+
+func deadLetterKey(id []byte) []byte {
+	return []byte(deadLetterPrefix + hexutil.Encode(id))
+}
+
+// Send publishes the message to the event stream of the account and stores it to be delivered
+// to the webhook subscriptions of the account matching the message, and to the webhook of the account
+// for the job and document events.
+func (o *outbox) Send(ctx context.Context, message Message) error {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return err
+	}
+
+	accountID := acc.GetIdentityID()
+	did, err := identity.NewDIDFromBytes(accountID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := o.Publish(did, message); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	subs, err := o.subscriptions(accountID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch webhook subscriptions: %w", err)
+	}
+
+	now := time.Now().UTC()
+	var deliveries []*Delivery
+	newDelivery := func(url string, subscriptionID []byte) {
+		deliveries = append(deliveries, &Delivery{
+			ID:             utils.RandomSlice(32),
+			AccountID:      accountID,
+			SubscriptionID: subscriptionID,
+			URL:            url,
+			Message:        message,
+			NextAttempt:    now,
+			CreatedAt:      now,
+		})
+	}
+
+	if url := acc.GetReceiveEventNotificationEndpoint(); url != "" && message.EventType.isLegacy() {
+		newDelivery(url, nil)
+	}
+
+	for _, sub := range subs {
+		if sub.Matches(message) {
+			newDelivery(sub.URL, sub.ID)
+		}
+	}
+
+	if len(subs) == 0 && len(deliveries) == 0 {
+		log.Warnf("Webhook URL not defined, manually fetch received document")
+		return nil
+	}
+
+	for _, d := range deliveries {
+		err = o.repo.Create(outboxKey(d.ID), d)
+		if err != nil {
+			return fmt.Errorf("failed to store notification: %w", err)
+		}
+	}
+
+	if len(deliveries) > 0 {
+		o.notify()
+	}
+
+	return nil
+}
+
+// notify wakes up the delivery loop.
+func (o *outbox) notify() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *outbox) Name() string {
+	return "Notification Outbox"
+}
+
+// Start delivers the notifications until the context is done.
+func (o *outbox) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	if o.config.GetNotificationSecret() == "" {
+		log.Warnf("no notification secret set, webhook payloads are not signed unless their subscription has a secret")
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		o.deliverDue()
+		select {
+		case <-ctx.Done():
+			log.Debugf("stopping notification outbox: %v", ctx.Err())
+			return
+		case <-ticker.C:
+		case <-o.wake:
+		}
+	}
+}
+
+// deliverDue attempts the deliveries due and waits for the attempts to finish.
+func (o *outbox) deliverDue() {
+	models, err := o.repo.GetAllByPrefix(outboxPrefix)
+	if err != nil {
+		log.Errorf("failed to fetch notifications: %v", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	sem := make(chan struct{}, maxConcurrentDeliveries)
+	var wg sync.WaitGroup
+	for _, m := range models {
+		d, ok := m.(*Delivery)
+		if !ok || d.NextAttempt.After(now) {
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(d *Delivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			o.attempt(d)
+		}(d)
+	}
+
+	wg.Wait()
+}
+
+// attempt delivers d. On failure, the delivery is scheduled for a retry or moved to the dead-letter queue.
+func (o *outbox) attempt(d *Delivery) {
+	secret := o.config.GetNotificationSecret()
+	if len(d.SubscriptionID) > 0 {
+		sub, err := o.subscription(d)
+		if err != nil {
+			log.Warnf("dropping notification %s: %v", d.ID.String(), err)
+			if err := o.repo.Delete(outboxKey(d.ID)); err != nil {
+				log.Errorf("failed to delete notification %s: %v", d.ID.String(), err)
+			}
+
+			return
+		}
+
+		if sub.Secret != "" {
+			secret = sub.Secret
+		}
+	}
+
+	err := o.post(d, secret)
+	if err == nil {
+		log.Infof("Sent Webhook message with Payload [%v] to [%s]", d.Message, d.URL)
+		if err := o.repo.Delete(outboxKey(d.ID)); err != nil {
+			log.Errorf("failed to delete notification %s: %v", d.ID.String(), err)
+		}
+
+		return
+	}
+
+	d.Attempts++
+	d.LastError = err.Error()
+	if d.Attempts >= o.config.GetNotificationMaxAttempts() {
+		log.Warnf("moving notification %s to the dead-letter queue after %d attempts: %v", d.ID.String(), d.Attempts, err)
+		err = storage.RunTransaction(o.repo, func(tx storage.Transaction) error {
+			if err := tx.Delete(outboxKey(d.ID)); err != nil {
+				return err
+			}
+
+			return tx.Create(deadLetterKey(d.ID), d)
+		})
+	} else {
+		d.NextAttempt = time.Now().UTC().Add(retryDelay(o.config.GetNotificationRetryInterval(), d.Attempts))
+		err = o.repo.Update(outboxKey(d.ID), d)
+	}
+
+	if err != nil {
+		log.Errorf("failed to update notification %s: %v", d.ID.String(), err)
+	}
+}
+
+// subscription returns the enabled webhook subscription of the delivery.
+func (o *outbox) subscription(d *Delivery) (Subscription, error) {
+	did, err := identity.NewDIDFromBytes(d.AccountID)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	sub, err := o.GetSubscription(did, d.SubscriptionID)
+	if err != nil {
+		return sub, err
+	}
+
+	if !sub.Enabled {
+		return sub, errors.New("webhook subscription %s is disabled", sub.ID.String())
+	}
+
+	return sub, nil
+}
+
+func (o *outbox) post(d *Delivery, secret string) error {
+	payload, err := json.Marshal(d.Message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/json",
+		DeliveryHeader: d.ID.String(),
+	}
+
+	if secret != "" {
+		headers[SignatureHeader] = Sign([]byte(secret), payload)
+	}
+
+	statusCode, err := utils.SendPOSTRequestWithHeaders(d.URL, headers, payload)
+	if err != nil {
+		return fmt.Errorf("failed to post message: %w", err)
+	}
+
+	if !utils.InRange(statusCode, 200, 299) {
+		return errors.New("failed to send webhook: status = %v", statusCode)
+	}
+
+	return nil
+}
+
+// retryDelay returns the delay before the next attempt, doubling the interval on every attempt.
+func retryDelay(interval time.Duration, attempts int) time.Duration {
+	delay := interval
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+
+	return delay
+}
+
+// FailedDeliveries returns the deliveries of the account in the dead-letter queue, oldest first.
+func (o *outbox) FailedDeliveries(accountID identity.DID) ([]Delivery, error) {
+	models, err := o.repo.GetAllByPrefix(deadLetterPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []Delivery
+	for _, m := range models {
+		d, ok := m.(*Delivery)
+		if !ok || !bytes.Equal(d.AccountID, accountID[:]) {
+			continue
+		}
+
+		deliveries = append(deliveries, *d)
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+
+	return deliveries, nil
+}
+
+// Replay moves the failed delivery of the account back to the outbox with its attempts reset.
+func (o *outbox) Replay(accountID identity.DID, deliveryID []byte) error {
+	key := deadLetterKey(deliveryID)
+	err := storage.RunTransaction(o.repo, func(tx storage.Transaction) error {
+		m, err := tx.Get(key)
+		if err != nil {
+			return ErrDeliveryNotFound
+		}
+
+		d, ok := m.(*Delivery)
+		if !ok || !bytes.Equal(d.AccountID, accountID[:]) {
+			return ErrDeliveryNotFound
+		}
+
+		d.Attempts = 0
+		d.NextAttempt = time.Now().UTC()
+		if err := tx.Delete(key); err != nil {
+			return err
+		}
+
+		return tx.Create(outboxKey(d.ID), d)
+	})
+	if err != nil {
+		return err
+	}
+
+	o.notify()
+	return nil
+}
+
+// Sign returns the value of the SignatureHeader for the payload signed with the secret.
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature returns true if the signature, the value of the SignatureHeader, is valid for the payload.
+func VerifySignature(secret, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
@@ -0,0 +1,203 @@
+// +build unit
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type outboxConfig struct {
+	secret      string
+	maxAttempts int
+}
+
+func (c outboxConfig) GetNotificationSecret() string               { return c.secret }
+func (c outboxConfig) GetNotificationMaxAttempts() int             { return c.maxAttempts }
+func (c outboxConfig) GetNotificationRetryInterval() time.Duration { return 0 }
+
+func newTestOutbox(t *testing.T, cfg outboxConfig) *outbox {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	return NewOutbox(leveldb.NewLevelDBRepository(db), cfg).(*outbox)
+}
+
+func randomDID() identity.DID {
+	return identity.NewDID(common.BytesToAddress(utils.RandomSlice(20)))
+}
+
+func accountContext(t *testing.T, did identity.DID, url string) context.Context {
+	acc := new(config.MockAccount)
+	acc.On("GetReceiveEventNotificationEndpoint").Return(url)
+	acc.On("GetIdentityID").Return(did[:])
+	ctx, err := contextutil.New(context.Background(), acc)
+	assert.NoError(t, err)
+	return ctx
+}
+
+func jobMessage() Message {
+	return Message{
+		EventType:  EventTypeJob,
+		RecordedAt: time.Now().UTC(),
+		Job: &JobMessage{
+			ID:    utils.RandomSlice(32),
+			Owner: utils.RandomSlice(20),
+			Desc:  "Sample Job",
+		},
+	}
+}
+
+func TestOutbox_Send(t *testing.T) {
+	secret := "secret"
+	var mu sync.Mutex
+	var received []*http.Request
+	var payloads [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		received = append(received, r)
+		payloads = append(payloads, data)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	o := newTestOutbox(t, outboxConfig{secret: secret, maxAttempts: 3})
+	did := randomDID()
+	msg := jobMessage()
+
+	// missing account
+	assert.Error(t, o.Send(context.Background(), msg))
+
+	// no webhook, published to the event stream only
+	assert.NoError(t, o.Send(accountContext(t, did, ""), msg))
+	models, err := o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+	events, err := o.events(did, 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	// the webhook of the account only receives the job and document events
+	anchored := Message{EventType: EventTypeDocumentAnchored, DocumentAnchored: &DocumentAnchoredMessage{}}
+	assert.NoError(t, o.Send(accountContext(t, did, server.URL), anchored))
+	models, err = o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+	events, err = o.events(did, 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	assert.NoError(t, o.Send(accountContext(t, did, server.URL), msg))
+	models, err = o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 1)
+	d := models[0].(*Delivery)
+	assert.Equal(t, did[:], []byte(d.AccountID))
+	assert.Equal(t, server.URL, d.URL)
+
+	o.deliverDue()
+	assert.Len(t, received, 1)
+	assert.Equal(t, d.ID.String(), received[0].Header.Get(DeliveryHeader))
+	assert.True(t, VerifySignature([]byte(secret), payloads[0], received[0].Header.Get(SignatureHeader)))
+	var resp Message
+	assert.NoError(t, json.Unmarshal(payloads[0], &resp))
+	assert.Equal(t, *msg.Job, *resp.Job)
+
+	// delivered notifications are removed
+	models, err = o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+}
+
+func TestOutbox_RetryDeadLetterReplay(t *testing.T) {
+	var mu sync.Mutex
+	status := http.StatusInternalServerError
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		assert.Empty(t, r.Header.Get(SignatureHeader))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	o := newTestOutbox(t, outboxConfig{maxAttempts: 2})
+	did := randomDID()
+	assert.NoError(t, o.Send(accountContext(t, did, server.URL), jobMessage()))
+
+	// first attempt fails and is scheduled for a retry
+	o.deliverDue()
+	assert.Equal(t, 1, calls)
+	models, err := o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 1)
+	d := models[0].(*Delivery)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Contains(t, d.LastError, "status = 500")
+
+	// second attempt moves the delivery to the dead-letter queue
+	o.deliverDue()
+	assert.Equal(t, 2, calls)
+	models, err = o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+	failed, err := o.FailedDeliveries(did)
+	assert.NoError(t, err)
+	assert.Len(t, failed, 1)
+	assert.Equal(t, d.ID, failed[0].ID)
+	assert.Equal(t, 2, failed[0].Attempts)
+
+	// failed deliveries of other accounts
+	other := randomDID()
+	failed, err = o.FailedDeliveries(other)
+	assert.NoError(t, err)
+	assert.Len(t, failed, 0)
+	assert.Equal(t, ErrDeliveryNotFound, o.Replay(other, d.ID))
+	assert.Equal(t, ErrDeliveryNotFound, o.Replay(did, utils.RandomSlice(32)))
+
+	// replay
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	assert.NoError(t, o.Replay(did, d.ID))
+	failed, err = o.FailedDeliveries(did)
+	assert.NoError(t, err)
+	assert.Len(t, failed, 0)
+	o.deliverDue()
+	assert.Equal(t, 3, calls)
+	models, err = o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Second, retryDelay(time.Second, 1))
+	assert.Equal(t, 2*time.Second, retryDelay(time.Second, 2))
+	assert.Equal(t, 8*time.Second, retryDelay(time.Second, 4))
+	assert.Equal(t, maxRetryDelay, retryDelay(time.Second, 100))
+}
+
+func TestSignature(t *testing.T) {
+	secret, payload := []byte("secret"), []byte(`{"event_type":"job"}`)
+	sig := Sign(secret, payload)
+	assert.Contains(t, sig, "sha256=")
+	assert.True(t, VerifySignature(secret, payload, sig))
+	assert.False(t, VerifySignature([]byte("other"), payload, sig))
+	assert.False(t, VerifySignature(secret, []byte("{}"), sig))
+}
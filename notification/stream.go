@@ -0,0 +1,185 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	eventPrefix = "notification_event_"
+
+	// eventRetention is the number of the latest events of an account kept for resuming the stream.
+	eventRetention = 1000
+
+	// subscriberBuffer is the number of events buffered for a subscriber.
+	// Slow subscribers exceeding the buffer are disconnected and resume from their last offset.
+	subscriberBuffer = 100
+)
+
+// Event is a message recorded in the event stream of an account.
+type Event struct {
+	// Offset is the position of the event in the stream of the account, starting at 1.
+	Offset    uint64             `json:"offset"`
+	AccountID byteutils.HexBytes `json:"account_id" swaggertype:"primitive,string"`
+	Message   Message            `json:"message"`
+}
+
+// JSON marshals Event to json bytes.
+func (e *Event) JSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// FromJSON loads json bytes to Event.
+func (e *Event) FromJSON(data []byte) error {
+	return json.Unmarshal(data, e)
+}
+
+// Type returns the type of Event.
+func (e *Event) Type() reflect.Type {
+	return reflect.TypeOf(e)
+}
+
+// Stream records the events of the accounts and feeds them to the live subscribers.
+type Stream interface {
+	// Publish records the message in the stream of the account.
+	Publish(accountID identity.DID, message Message) (Event, error)
+
+	// Subscribe returns the retained events of the account after the offset and a channel of the new events.
+	// The channel is closed if the subscriber falls behind. unsubscribe must be called once done.
+	Subscribe(accountID identity.DID, offset uint64) (backlog []Event, events <-chan Event, unsubscribe func(), err error)
+}
+
+type stream struct {
+	repo storage.Repository
+
+	mu          sync.Mutex
+	offsets     map[identity.DID]uint64
+	subscribers map[identity.DID]map[chan Event]struct{}
+}
+
+func newStream(repo storage.Repository) *stream {
+	repo.Register(new(Event))
+	return &stream{
+		repo:        repo,
+		offsets:     make(map[identity.DID]uint64),
+		subscribers: make(map[identity.DID]map[chan Event]struct{}),
+	}
+}
+
+func accountEventsPrefix(accountID identity.DID) string {
+	return eventPrefix + hexutil.Encode(accountID[:]) + "_"
+}
+
+// eventKey pads the offset so that the keys are sorted by offset.
+func eventKey(accountID identity.DID, offset uint64) []byte {
+	return []byte(fmt.Sprintf("%s%020d", accountEventsPrefix(accountID), offset))
+}
+
+// events returns the retained events of the account after the offset, oldest first.
+func (s *stream) events(accountID identity.DID, offset uint64) ([]Event, error) {
+	models, err := s.repo.GetAllByPrefix(accountEventsPrefix(accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, m := range models {
+		e, ok := m.(*Event)
+		if !ok || e.Offset <= offset {
+			continue
+		}
+
+		events = append(events, *e)
+	}
+
+	return events, nil
+}
+
+// lastOffset returns the offset of the latest event of the account. Caller must hold the lock.
+func (s *stream) lastOffset(accountID identity.DID) (uint64, error) {
+	if offset, ok := s.offsets[accountID]; ok {
+		return offset, nil
+	}
+
+	events, err := s.events(accountID, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	var offset uint64
+	for _, e := range events {
+		if e.Offset > offset {
+			offset = e.Offset
+		}
+	}
+
+	s.offsets[accountID] = offset
+	return offset, nil
+}
+
+func (s *stream) Publish(accountID identity.DID, message Message) (Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	offset, err := s.lastOffset(accountID)
+	if err != nil {
+		return Event{}, err
+	}
+
+	e := Event{Offset: offset + 1, AccountID: accountID[:], Message: message}
+	err = s.repo.Create(eventKey(accountID, e.Offset), &e)
+	if err != nil {
+		return e, err
+	}
+
+	s.offsets[accountID] = e.Offset
+	if e.Offset > eventRetention {
+		if err := s.repo.Delete(eventKey(accountID, e.Offset-eventRetention)); err != nil {
+			log.Warnf("failed to prune event %d of %s: %v", e.Offset-eventRetention, accountID.String(), err)
+		}
+	}
+
+	for ch := range s.subscribers[accountID] {
+		select {
+		case ch <- e:
+		default:
+			log.Warnf("disconnecting slow event subscriber of %s", accountID.String())
+			delete(s.subscribers[accountID], ch)
+			close(ch)
+		}
+	}
+
+	return e, nil
+}
+
+func (s *stream) Subscribe(accountID identity.DID, offset uint64) ([]Event, <-chan Event, func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	backlog, err := s.events(accountID, offset)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	if s.subscribers[accountID] == nil {
+		s.subscribers[accountID] = make(map[chan Event]struct{})
+	}
+	s.subscribers[accountID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subscribers[accountID][ch]; ok {
+			delete(s.subscribers[accountID], ch)
+			close(ch)
+		}
+	}
+
+	return backlog, ch, unsubscribe, nil
+}
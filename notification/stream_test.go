@@ -0,0 +1,87 @@
+// +build unit
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream_PublishSubscribe(t *testing.T) {
+	o := newTestOutbox(t, outboxConfig{})
+	did, other := randomDID(), randomDID()
+	for i := 0; i < 3; i++ {
+		e, err := o.Publish(did, jobMessage())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(i+1), e.Offset)
+	}
+
+	// offsets are per account
+	e, err := o.Publish(other, jobMessage())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), e.Offset)
+
+	// resume after offset 1
+	backlog, events, unsubscribe, err := o.Subscribe(did, 1)
+	assert.NoError(t, err)
+	assert.Len(t, backlog, 2)
+	assert.Equal(t, uint64(2), backlog[0].Offset)
+	assert.Equal(t, uint64(3), backlog[1].Offset)
+
+	// live events of the account only
+	_, err = o.Publish(other, jobMessage())
+	assert.NoError(t, err)
+	msg := jobMessage()
+	_, err = o.Publish(did, msg)
+	assert.NoError(t, err)
+	e = <-events
+	assert.Equal(t, uint64(4), e.Offset)
+	assert.Equal(t, *msg.Job, *e.Message.Job)
+	assert.Len(t, events, 0)
+
+	unsubscribe()
+	_, ok := <-events
+	assert.False(t, ok)
+	unsubscribe()
+
+	// offsets survive the restart of the node
+	s := newStream(o.repo)
+	e, err = s.Publish(did, jobMessage())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), e.Offset)
+}
+
+func TestStream_SlowSubscriber(t *testing.T) {
+	o := newTestOutbox(t, outboxConfig{})
+	did := randomDID()
+	_, events, unsubscribe, err := o.Subscribe(did, 0)
+	assert.NoError(t, err)
+	defer unsubscribe()
+	for i := 0; i < subscriberBuffer+1; i++ {
+		_, err := o.Publish(did, jobMessage())
+		assert.NoError(t, err)
+	}
+
+	var received int
+	for range events {
+		received++
+	}
+
+	assert.Equal(t, subscriberBuffer, received)
+}
+
+func TestStream_Retention(t *testing.T) {
+	o := newTestOutbox(t, outboxConfig{})
+	did := randomDID()
+	for i := 0; i < eventRetention+5; i++ {
+		_, err := o.Publish(did, jobMessage())
+		assert.NoError(t, err)
+	}
+
+	events, err := o.events(did, 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, eventRetention)
+	assert.Equal(t, uint64(6), events[0].Offset)
+	assert.Equal(t, uint64(eventRetention+5), events[len(events)-1].Offset)
+}
@@ -0,0 +1,221 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrSubscriptionNotFound is returned when the webhook subscription doesn't exist.
+	ErrSubscriptionNotFound = errors.Error("webhook subscription not found")
+
+	// ErrInvalidSubscription is returned when the webhook subscription is invalid.
+	ErrInvalidSubscription = errors.Error("invalid webhook subscription")
+
+	subscriptionPrefix = "notification_subscription_"
+)
+
+// Subscription is a webhook of an account subscribed to a subset of the event types.
+type Subscription struct {
+	ID        byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`
+	AccountID byteutils.HexBytes `json:"account_id" swaggertype:"primitive,string"`
+	URL       string             `json:"url"`
+
+	// EventTypes the webhook is subscribed to. Empty subscribes to the job and document events.
+	EventTypes []EventType `json:"event_types" enums:"job,document,anchor,nft,signature_requested,signature_given,document_anchored,document_committed,nft_minted,nft_transferred,collaborators_changed,oracle_pushed"`
+
+	// Schemes filters the document and document_committed events by the scheme of the document.
+	// Empty matches all the schemes.
+	Schemes []string `json:"schemes,omitempty"`
+
+	// JobDescriptions filters the job events by the description of the job. Empty matches all the jobs.
+	JobDescriptions []string `json:"job_descriptions,omitempty"`
+
+	Enabled bool `json:"enabled"`
+
+	// Secret signs the payloads delivered to the webhook. The node secret is used if empty.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at" swaggertype:"primitive,string"`
+}
+
+// JSON marshals Subscription to json bytes.
+func (s *Subscription) JSON() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromJSON loads json bytes to Subscription.
+func (s *Subscription) FromJSON(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// Type returns the type of Subscription.
+func (s *Subscription) Type() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// Validate checks the webhook URL and the event types of the subscription.
+func (s Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NewTypedError(ErrInvalidSubscription, errors.New("invalid url %q", s.URL))
+	}
+
+	for _, et := range s.EventTypes {
+		if !containsEventType(eventTypes, et) {
+			return errors.NewTypedError(ErrInvalidSubscription, errors.New("unknown event type %s", et))
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the subscription is enabled and the message passes its filters.
+func (s Subscription) Matches(message Message) bool {
+	if !s.Enabled {
+		return false
+	}
+
+	if len(s.EventTypes) == 0 && !message.EventType.isLegacy() {
+		return false
+	}
+
+	if len(s.EventTypes) > 0 && !containsEventType(s.EventTypes, message.EventType) {
+		return false
+	}
+
+	if scheme, ok := message.scheme(); ok && len(s.Schemes) > 0 {
+		return utils.ContainsString(s.Schemes, scheme)
+	}
+
+	if message.Job != nil && len(s.JobDescriptions) > 0 {
+		return utils.ContainsString(s.JobDescriptions, message.Job.Desc)
+	}
+
+	return true
+}
+
+func containsEventType(types []EventType, et EventType) bool {
+	for _, t := range types {
+		if t == et {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Subscriptions manages the webhook subscriptions of the accounts.
+type Subscriptions interface {
+	// CreateSubscription stores a new webhook subscription for the account.
+	CreateSubscription(accountID identity.DID, sub Subscription) (Subscription, error)
+
+	// GetSubscription returns the webhook subscription of the account.
+	GetSubscription(accountID identity.DID, subscriptionID []byte) (Subscription, error)
+
+	// ListSubscriptions returns the webhook subscriptions of the account, oldest first.
+	ListSubscriptions(accountID identity.DID) ([]Subscription, error)
+
+	// UpdateSubscription replaces the webhook subscription of the account.
+	// The secret is kept if sub has none, unless clearSecret is set.
+	UpdateSubscription(accountID identity.DID, sub Subscription, clearSecret bool) (Subscription, error)
+
+	// DeleteSubscription deletes the webhook subscription of the account.
+	DeleteSubscription(accountID identity.DID, subscriptionID []byte) error
+}
+
+func accountSubscriptionsPrefix(accountID []byte) string {
+	return subscriptionPrefix + hexutil.Encode(accountID) + "_"
+}
+
+func subscriptionKey(accountID, subscriptionID []byte) []byte {
+	return []byte(accountSubscriptionsPrefix(accountID) + hexutil.Encode(subscriptionID))
+}
+
+func (o *outbox) CreateSubscription(accountID identity.DID, sub Subscription) (Subscription, error) {
+	if err := sub.Validate(); err != nil {
+		return sub, err
+	}
+
+	sub.ID = utils.RandomSlice(32)
+	sub.AccountID = accountID[:]
+	sub.CreatedAt = time.Now().UTC()
+	return sub, o.repo.Create(subscriptionKey(accountID[:], sub.ID), &sub)
+}
+
+func (o *outbox) GetSubscription(accountID identity.DID, subscriptionID []byte) (Subscription, error) {
+	m, err := o.repo.Get(subscriptionKey(accountID[:], subscriptionID))
+	if err != nil {
+		return Subscription{}, ErrSubscriptionNotFound
+	}
+
+	sub, ok := m.(*Subscription)
+	if !ok {
+		return Subscription{}, ErrSubscriptionNotFound
+	}
+
+	return *sub, nil
+}
+
+func (o *outbox) ListSubscriptions(accountID identity.DID) ([]Subscription, error) {
+	return o.subscriptions(accountID[:])
+}
+
+func (o *outbox) subscriptions(accountID []byte) ([]Subscription, error) {
+	models, err := o.repo.GetAllByPrefix(accountSubscriptionsPrefix(accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	var subs []Subscription
+	for _, m := range models {
+		sub, ok := m.(*Subscription)
+		if !ok || !bytes.Equal(sub.AccountID, accountID) {
+			continue
+		}
+
+		subs = append(subs, *sub)
+	}
+
+	sort.Slice(subs, func(i, j int) bool {
+		return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+	})
+
+	return subs, nil
+}
+
+func (o *outbox) UpdateSubscription(accountID identity.DID, sub Subscription, clearSecret bool) (Subscription, error) {
+	old, err := o.GetSubscription(accountID, sub.ID)
+	if err != nil {
+		return sub, err
+	}
+
+	if err := sub.Validate(); err != nil {
+		return sub, err
+	}
+
+	if sub.Secret == "" && !clearSecret {
+		sub.Secret = old.Secret
+	}
+
+	sub.AccountID = old.AccountID
+	sub.CreatedAt = old.CreatedAt
+	return sub, o.repo.Update(subscriptionKey(accountID[:], sub.ID), &sub)
+}
+
+func (o *outbox) DeleteSubscription(accountID identity.DID, subscriptionID []byte) error {
+	if _, err := o.GetSubscription(accountID, subscriptionID); err != nil {
+		return err
+	}
+
+	return o.repo.Delete(subscriptionKey(accountID[:], subscriptionID))
+}
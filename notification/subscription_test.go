@@ -0,0 +1,167 @@
+// +build unit
+
+package notification
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscription_Validate(t *testing.T) {
+	for _, sub := range []Subscription{
+		{URL: ""},
+		{URL: "ftp://localhost"},
+		{URL: "http://"},
+		{URL: "http://localhost", EventTypes: []EventType{"unknown"}},
+	} {
+		err := sub.Validate()
+		assert.Error(t, err)
+		assert.True(t, errors.IsOfType(ErrInvalidSubscription, err))
+	}
+
+	assert.NoError(t, Subscription{URL: "https://localhost/webhook", EventTypes: []EventType{EventTypeJob}}.Validate())
+}
+
+func TestSubscription_Matches(t *testing.T) {
+	job := Message{EventType: EventTypeJob, Job: &JobMessage{Desc: "Mint NFT"}}
+	doc := Message{EventType: EventTypeDocument, Document: &DocumentMessage{Scheme: "generic"}}
+	committed := Message{EventType: EventTypeDocumentCommitted, DocumentCommitted: &DocumentCommittedMessage{Scheme: "entity"}}
+	for _, c := range []struct {
+		sub                 Subscription
+		job, doc, committed bool
+	}{
+		{Subscription{}, false, false, false},
+		// the event types other than job and document must be listed
+		{Subscription{Enabled: true}, true, true, false},
+		{Subscription{Enabled: true, EventTypes: []EventType{EventTypeJob}}, true, false, false},
+		{Subscription{Enabled: true, EventTypes: []EventType{EventTypeDocument}}, false, true, false},
+		{Subscription{Enabled: true, EventTypes: []EventType{EventTypeDocumentCommitted}}, false, false, true},
+		{Subscription{Enabled: true, Schemes: []string{"entity"}}, true, false, false},
+		{Subscription{Enabled: true, Schemes: []string{"entity", "generic"}}, true, true, false},
+		{Subscription{Enabled: true, EventTypes: []EventType{EventTypeDocument, EventTypeDocumentCommitted}, Schemes: []string{"entity"}}, false, false, true},
+		{Subscription{Enabled: true, JobDescriptions: []string{"Commit document"}}, false, true, false},
+		{Subscription{Enabled: true, JobDescriptions: []string{"Mint NFT"}}, true, true, false},
+	} {
+		assert.Equal(t, c.job, c.sub.Matches(job))
+		assert.Equal(t, c.doc, c.sub.Matches(doc))
+		assert.Equal(t, c.committed, c.sub.Matches(committed))
+	}
+}
+
+func TestOutbox_Subscriptions(t *testing.T) {
+	o := newTestOutbox(t, outboxConfig{maxAttempts: 1})
+	did, other := randomDID(), randomDID()
+
+	// invalid
+	_, err := o.CreateSubscription(did, Subscription{URL: "invalid"})
+	assert.True(t, errors.IsOfType(ErrInvalidSubscription, err))
+
+	sub, err := o.CreateSubscription(did, Subscription{URL: "http://localhost/1", Enabled: true, Secret: "secret"})
+	assert.NoError(t, err)
+	assert.Len(t, sub.ID, 32)
+	assert.Equal(t, did[:], []byte(sub.AccountID))
+	sub2, err := o.CreateSubscription(did, Subscription{URL: "http://localhost/2"})
+	assert.NoError(t, err)
+
+	got, err := o.GetSubscription(did, sub.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, sub.URL, got.URL)
+	assert.Equal(t, "secret", got.Secret)
+	_, err = o.GetSubscription(other, sub.ID)
+	assert.Equal(t, ErrSubscriptionNotFound, err)
+
+	subs, err := o.ListSubscriptions(did)
+	assert.NoError(t, err)
+	assert.Len(t, subs, 2)
+	assert.Equal(t, sub.ID, subs[0].ID)
+	assert.Equal(t, sub2.ID, subs[1].ID)
+	subs, err = o.ListSubscriptions(other)
+	assert.NoError(t, err)
+	assert.Len(t, subs, 0)
+
+	// update
+	_, err = o.UpdateSubscription(other, Subscription{ID: sub.ID, URL: "http://localhost/3"}, false)
+	assert.Equal(t, ErrSubscriptionNotFound, err)
+	_, err = o.UpdateSubscription(did, Subscription{ID: sub.ID, URL: "invalid"}, false)
+	assert.True(t, errors.IsOfType(ErrInvalidSubscription, err))
+	updated, err := o.UpdateSubscription(did, Subscription{ID: sub.ID, URL: "http://localhost/3", Enabled: false}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, sub.CreatedAt, updated.CreatedAt)
+	got, err = o.GetSubscription(did, sub.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost/3", got.URL)
+	assert.False(t, got.Enabled)
+	// the secret is kept unless replaced or cleared
+	assert.Equal(t, "secret", got.Secret)
+	_, err = o.UpdateSubscription(did, Subscription{ID: sub.ID, URL: "http://localhost/3", Secret: "new"}, false)
+	assert.NoError(t, err)
+	got, err = o.GetSubscription(did, sub.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", got.Secret)
+	_, err = o.UpdateSubscription(did, Subscription{ID: sub.ID, URL: "http://localhost/3"}, true)
+	assert.NoError(t, err)
+	got, err = o.GetSubscription(did, sub.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Secret)
+
+	// delete
+	assert.Equal(t, ErrSubscriptionNotFound, o.DeleteSubscription(other, sub.ID))
+	assert.NoError(t, o.DeleteSubscription(did, sub.ID))
+	_, err = o.GetSubscription(did, sub.ID)
+	assert.Equal(t, ErrSubscriptionNotFound, err)
+}
+
+func TestOutbox_SendSubscriptions(t *testing.T) {
+	var mu sync.Mutex
+	signatures := make(map[string]string)
+	payloads := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		signatures[r.URL.Path] = r.Header.Get(SignatureHeader)
+		payloads[r.URL.Path] = data
+	}))
+	defer server.Close()
+
+	o := newTestOutbox(t, outboxConfig{secret: "node", maxAttempts: 1})
+	did := randomDID()
+	_, err := o.CreateSubscription(did, Subscription{
+		URL: server.URL + "/jobs", EventTypes: []EventType{EventTypeJob}, Enabled: true, Secret: "jobs"})
+	assert.NoError(t, err)
+	_, err = o.CreateSubscription(did, Subscription{
+		URL: server.URL + "/documents", EventTypes: []EventType{EventTypeDocument}, Enabled: true})
+	assert.NoError(t, err)
+	_, err = o.CreateSubscription(did, Subscription{URL: server.URL + "/disabled"})
+	assert.NoError(t, err)
+	removed, err := o.CreateSubscription(did, Subscription{URL: server.URL + "/removed", Enabled: true})
+	assert.NoError(t, err)
+
+	// subscriptions matching the message and the account webhook
+	assert.NoError(t, o.Send(accountContext(t, did, server.URL+"/account"), jobMessage()))
+	models, err := o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 3)
+
+	// deliveries of removed subscriptions are dropped
+	assert.NoError(t, o.DeleteSubscription(did, removed.ID))
+	o.deliverDue()
+	assert.Len(t, signatures, 2)
+	assert.Contains(t, signatures, "/account")
+	assert.Contains(t, signatures, "/jobs")
+	assert.True(t, VerifySignature([]byte("node"), payloads["/account"], signatures["/account"]))
+	assert.True(t, VerifySignature([]byte("jobs"), payloads["/jobs"], signatures["/jobs"]))
+	models, err = o.repo.GetAllByPrefix(outboxPrefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+	failed, err := o.FailedDeliveries(did)
+	assert.NoError(t, err)
+	assert.Len(t, failed, 0)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -21,12 +22,15 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	dispatcher := ctx[jobs.BootstrappedDispatcher].(jobs.Dispatcher)
 	client := ctx[ethereum.BootstrappedEthereumClient].(ethereum.Client)
 	accountSrv := ctx[config.BootstrappedConfigStorage].(config.Service)
+	notifier, _ := ctx[notification.BootstrappedOutbox].(notification.Sender)
 	oracleSrv := newService(docService, idService, client, dispatcher)
 	ctx[BootstrappedOracleService] = oracleSrv
+	dispatcher.RegisterScheduledFunc(PushToOracleAction, pushToOracle(oracleSrv))
 	go dispatcher.RegisterRunner(oraclePushJob, &PushToOracleJob{
 		accountsSrv:     accountSrv,
 		identityService: idService,
 		ethClient:       client,
+		notifier:        notifier,
 	})
 	return nil
 }
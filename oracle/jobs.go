@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/gob"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/config"
@@ -12,6 +13,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/gocelery/v2"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -25,11 +27,13 @@ const oraclePushJob = "Push to Oracle"
 // PushToOracleJob pushes nft value to oracle
 // args are as follows
 // did, oracleAddr, tokenID, fingerprint, value
+// the pushed value is sent to notifier.
 type PushToOracleJob struct {
 	jobs.Base
 	accountsSrv     config.Service
 	identityService identity.Service
 	ethClient       ethereum.Client
+	notifier        notification.Sender
 }
 
 // New returns a new PushToOracleJob instance
@@ -38,6 +42,7 @@ func (p *PushToOracleJob) New() gocelery.Runner {
 		accountsSrv:     p.accountsSrv,
 		identityService: p.identityService,
 		ethClient:       p.ethClient,
+		notifier:        p.notifier,
 	}
 
 	np.Base = jobs.NewBase(np.getTasks())
@@ -63,11 +68,12 @@ func (p *PushToOracleJob) getTasks() map[string]jobs.Task {
 					return nil, fmt.Errorf("failed to send oracle txn: %w", err)
 				}
 
-				log.Infof("Sent nft details to oracle[%s] with txn[%s]", oracleAddr, txn.Hash())
+				p.Logger(overrides).Infof("sent the nft details to oracle %s in transaction %s", oracleAddr.Hex(), txn.Hash().Hex())
 				overrides["eth_txn"] = txn.Hash()
 				return nil, nil
 			},
-			Next: "wait_for_txn",
+			Next:  "wait_for_txn",
+			Retry: jobs.DefaultRetryPolicy(),
 		},
 
 		"wait_for_txn": {
@@ -78,13 +84,48 @@ func (p *PushToOracleJob) getTasks() map[string]jobs.Task {
 					return nil, err
 				}
 
-				log.Infof("Document value successfully pushed to Oracle with TX hash: %v\n", txn.Hex())
+				p.Logger(overrides).Infof("value pushed to the oracle in transaction %s", txn.Hex())
+				p.notifyPush(args, overrides)
 				return nil, nil
 			},
 		},
 	}
 }
 
+// notifyPush sends the value pushed to the oracle to the notifier, if any.
+func (p *PushToOracleJob) notifyPush(args []interface{}, overrides map[string]interface{}) {
+	if p.notifier == nil {
+		return
+	}
+
+	did := args[0].(identity.DID)
+	acc, err := p.accountsSrv.GetAccount(did[:])
+	if err != nil {
+		log.Errorf("failed to get account: %v", err)
+		return
+	}
+
+	var tokenID nft.TokenID
+	copy(tokenID[:], common.LeftPadBytes(args[2].(*big.Int).Bytes(), len(tokenID)))
+	fp, value := args[3].([32]byte), args[4].([32]byte)
+	txn := overrides["eth_txn"].(common.Hash)
+	err = p.notifier.Send(contextutil.WithAccount(context.Background(), acc), notification.Message{
+		EventType:  notification.EventTypeOraclePushed,
+		RecordedAt: time.Now().UTC(),
+		OraclePushed: &notification.OraclePushedMessage{
+			JobID:         jobs.JobIDFromOverrides(overrides),
+			OracleAddress: args[1].(common.Address).Hex(),
+			TokenID:       tokenID.String(),
+			Fingerprint:   fp[:],
+			Value:         value[:],
+			TxHash:        txn.Hex(),
+		},
+	})
+	if err != nil {
+		log.Errorf("failed to send oracle push: %v", err)
+	}
+}
+
 func initOraclePushJob(
 	dispatcher jobs.Dispatcher,
 	did identity.DID, oracleAddr common.Address,
@@ -2,6 +2,7 @@ package oracle
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
@@ -9,6 +10,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/gocelery/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	logging "github.com/ipfs/go-log"
 )
 
@@ -83,3 +87,30 @@ func (s *service) PushAttributeToOracle(ctx context.Context, docID []byte, req P
 		PushAttributeToOracleRequest: req,
 	}, nil
 }
+
+// PushToOracleAction is the scheduled action pushing the attribute of the document to the oracle, like a daily push
+// of the latest value. The params of the schedule are the push request along with the "document_id".
+const PushToOracleAction = "push_to_oracle"
+
+type pushToOracleParams struct {
+	DocumentID byteutils.HexBytes `json:"document_id"`
+	PushAttributeToOracleRequest
+}
+
+// pushToOracle returns the scheduled func pushing the attribute of the params to the oracle.
+// The value is read from the latest version of the document at the time of the push.
+func pushToOracle(srv Service) jobs.ScheduledFunc {
+	return func(ctx context.Context, params json.RawMessage) (gocelery.JobID, error) {
+		var p pushToOracleParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		resp, err := srv.PushAttributeToOracle(ctx, p.DocumentID, p.PushAttributeToOracleRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		return hexutil.Decode(resp.JobID)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/node"
 	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -37,6 +38,11 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 		cfg, nil, nil, documents.NewServiceRegistry(), ids, nil)
 	m[bootstrap.BootstrappedNFTService] = new(testingdocuments.MockRegistry)
 
+	// no outbox
+	err = b.Bootstrap(m)
+	assert.Error(t, err)
+
+	m[notification.BootstrappedOutbox] = new(notification.MockOutbox)
 	err = b.Bootstrap(m)
 	assert.Nil(t, err)
 
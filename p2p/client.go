@@ -10,6 +10,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	p2pcommon "github.com/centrifuge/go-centrifuge/p2p/common"
 	"github.com/centrifuge/go-centrifuge/version"
 	"github.com/golang/protobuf/proto"
@@ -18,6 +19,14 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 )
 
+// ErrPeerUnreachable is returned when the peer can't be found or fails to answer. It is transient.
+const ErrPeerUnreachable = errors.Error("peer unreachable")
+
+// unreachable returns err typed as ErrPeerUnreachable.
+func unreachable(err error) error {
+	return jobs.Transient(errors.NewTypedError(ErrPeerUnreachable, err))
+}
+
 func (s *peer) SendAnchoredDocument(ctx context.Context, receiverID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error) {
 	nc, err := s.config.GetConfig()
 	if err != nil {
@@ -65,7 +74,7 @@ func (s *peer) SendAnchoredDocument(ctx context.Context, receiverID identity.DID
 		envelope,
 		p2pcommon.ProtocolForDID(receiverID))
 	if err != nil {
-		return nil, err
+		return nil, unreachable(err)
 	}
 
 	recvEnvelope, err := p2pcommon.ResolveDataEnvelope(recv)
@@ -139,7 +148,7 @@ func (s *peer) GetDocumentRequest(ctx context.Context, requesterID identity.DID,
 		envelope,
 		p2pcommon.ProtocolForDID(requesterID))
 	if err != nil {
-		return nil, err
+		return nil, unreachable(err)
 	}
 
 	recvEnvelope, err := p2pcommon.ResolveDataEnvelope(recv)
@@ -197,7 +206,7 @@ func (s *peer) getPeerID(ctx context.Context, id identity.DID) (libp2pPeer.ID, e
 		defer canc()
 		pinfo, err := s.dht.FindPeer(c, peerID)
 		if err != nil {
-			return peerID, err
+			return peerID, unreachable(err)
 		}
 
 		// We have a peer ID and a targetAddr so we add it to the peer store
@@ -253,7 +262,7 @@ func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Docu
 		log.Infof("Requesting signature from %s\n", receiverPeer)
 		recv, err := s.mes.SendMessage(ctx, receiverPeer, envelope, p2pcommon.ProtocolForDID(collaborator))
 		if err != nil {
-			return nil, err
+			return nil, unreachable(err)
 		}
 		recvEnvelope, err := p2pcommon.ResolveDataEnvelope(recv)
 		if err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	p2pcommon "github.com/centrifuge/go-centrifuge/p2p/common"
 	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -57,6 +58,8 @@ func TestGetSignatureForDocument_fail_connect(t *testing.T) {
 	resp, err := testClient.getSignatureForDocument(ctx, model, did, did)
 	m.AssertExpectations(t)
 	assert.Error(t, err, "must fail")
+	assert.True(t, errors.IsOfType(ErrPeerUnreachable, err))
+	assert.True(t, jobs.IsTransient(err))
 	assert.Nil(t, resp, "must be nil")
 }
 
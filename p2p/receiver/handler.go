@@ -12,6 +12,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
 	p2pcommon "github.com/centrifuge/go-centrifuge/p2p/common"
 	"github.com/centrifuge/go-centrifuge/utils/timeutils"
 	"github.com/ethereum/go-ethereum/common"
@@ -30,6 +31,7 @@ type Handler struct {
 	docSrv             documents.Service
 	tokenRegistry      documents.TokenRegistry
 	srvDID             identity.Service
+	notifier           notification.Sender
 }
 
 // New returns an implementation of P2PServiceServer
@@ -38,13 +40,15 @@ func New(
 	handshakeValidator ValidatorGroup,
 	docSrv documents.Service,
 	tokenRegistry documents.TokenRegistry,
-	srvDID identity.Service) *Handler {
+	srvDID identity.Service,
+	notifier notification.Sender) *Handler {
 	return &Handler{
 		config:             config,
 		handshakeValidator: handshakeValidator,
 		docSrv:             docSrv,
 		tokenRegistry:      tokenRegistry,
 		srvDID:             srvDID,
+		notifier:           notifier,
 	}
 }
 
@@ -148,6 +152,22 @@ func (srv *Handler) RequestDocumentSignature(ctx context.Context, sigReq *p2ppb.
 		return nil, err
 	}
 
+	if srv.notifier != nil {
+		msg := notification.Message{
+			EventType:  notification.EventTypeSignatureRequested,
+			RecordedAt: time.Now().UTC(),
+			SignatureRequested: &notification.SignatureRequestedMessage{
+				DocumentID: model.ID(),
+				VersionID:  model.CurrentVersion(),
+				Requester:  collaborator[:],
+			},
+		}
+
+		if err := srv.notifier.Send(ctx, msg); err != nil {
+			log.Errorf("failed to send signature requested notification: %v", err)
+		}
+	}
+
 	return &p2ppb.SignatureResponse{Signatures: signatures}, nil
 }
 
@@ -3,6 +3,7 @@ package pending
 import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -26,6 +27,11 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("%s not found in the bootstrapper", storage.BootstrappedDB)
 	}
 	repo := NewRepository(ldb)
-	ctx[BootstrappedPendingDocumentService] = DefaultService(docSrv, repo)
+	srv := DefaultService(docSrv, repo)
+	ctx[BootstrappedPendingDocumentService] = srv
+	if dispatcher, ok := ctx[jobs.BootstrappedDispatcher].(jobs.Dispatcher); ok {
+		dispatcher.RegisterScheduledFunc(CommitDocumentAction, commitDocument(srv))
+	}
+
 	return nil
 }
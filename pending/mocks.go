@@ -122,3 +122,9 @@ func (m *MockService) DeleteAttribute(ctx context.Context, docID []byte, key doc
 	doc, _ := args.Get(0).(documents.Document)
 	return doc, args.Error(1)
 }
+
+func (m *MockService) List(ctx context.Context, filter documents.ListFilter) (documents.ListResult, error) {
+	args := m.Called(ctx, filter)
+	res, _ := args.Get(0).(documents.ListResult)
+	return res, args.Error(1)
+}
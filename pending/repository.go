@@ -28,6 +28,9 @@ type Repository interface {
 
 	// Delete deletes the data associated with account and ID.
 	Delete(accountID, id []byte) error
+
+	// List returns the pending documents, owned by accountID, that match the filter.
+	List(accountID []byte, filter documents.ListFilter) ([]documents.Document, error)
 }
 
 // NewRepository creates an instance of the pending document Repository
@@ -63,17 +66,51 @@ func (r *repo) Get(accountID, id []byte) (documents.Document, error) {
 // should error out if the document exists.
 func (r *repo) Create(accountID, id []byte, model documents.Document) error {
 	key := r.getKey(accountID, id)
-	return r.db.Create(key, model)
+	return storage.RunTransaction(r.db, func(tx storage.Transaction) error {
+		return tx.Create(key, model)
+	})
 }
 
 // Update strictly updates the model.
 // Will error out when the model doesn't exist in the DB.
 func (r *repo) Update(accountID, id []byte, model documents.Document) error {
 	key := r.getKey(accountID, id)
-	return r.db.Update(key, model)
+	return storage.RunTransaction(r.db, func(tx storage.Transaction) error {
+		return tx.Update(key, model)
+	})
 }
 
+// Delete deletes the data associated with account and ID.
 func (r *repo) Delete(accountID, id []byte) error {
 	key := r.getKey(accountID, id)
-	return r.db.Delete(key)
+	return storage.RunTransaction(r.db, func(tx storage.Transaction) error {
+		return tx.Delete(key)
+	})
+}
+
+// List returns the pending documents, owned by accountID, that match the filter.
+func (r *repo) List(accountID []byte, filter documents.ListFilter) ([]documents.Document, error) {
+	if !filter.HasStatus(documents.Pending) {
+		return nil, nil
+	}
+
+	prefix := DocPrefix + hexutil.Encode(accountID)
+	models, err := r.db.GetAllByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []documents.Document
+	for _, m := range models {
+		doc, ok := m.(documents.Document)
+		if !ok {
+			continue
+		}
+
+		if filter.Match(doc) {
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs, nil
 }
@@ -20,7 +20,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
-	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
@@ -41,7 +41,7 @@ func TestMain(m *testing.M) {
 	ibootstappers := []bootstrap.TestBootstrapper{
 		&testlogging.TestLoggingBootstrapper{},
 		&config.Bootstrapper{},
-		&leveldb.Bootstrapper{},
+		&backend.Bootstrapper{},
 		jobs.Bootstrapper{},
 		&configstore.Bootstrapper{},
 		&anchors.Bootstrapper{},
@@ -165,3 +165,31 @@ func TestLevelDBRepo_Get_Create_Update(t *testing.T) {
 		assert.Contains(t, err.Error(), "is not a model object")
 	}
 }
+
+
+func (m *doc) GetStatus() documents.Status {
+	return documents.Pending
+}
+
+func TestRepo_List(t *testing.T) {
+	r := getRepository(ctx)
+	r.(*repo).db.Register(new(doc))
+	acc := utils.RandomSlice(20)
+
+	docs, err := r.List(acc, documents.ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 0)
+
+	id := utils.RandomSlice(32)
+	assert.NoError(t, r.Create(acc, id, &doc{DocID: id}))
+	assert.NoError(t, r.Create(utils.RandomSlice(20), id, &doc{DocID: id}))
+	docs, err = r.List(acc, documents.ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, id, docs[0].ID())
+
+	// committed only
+	docs, err = r.List(acc, documents.ListFilter{Statuses: []documents.Status{documents.Committed}})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 0)
+}
@@ -3,12 +3,14 @@ package pending
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/centrifuge/gocelery/v2"
 )
@@ -66,6 +68,9 @@ type Service interface {
 
 	// DeleteTransitionRule deletes the transition rule associated with ruleID in th document.
 	DeleteTransitionRule(ctx context.Context, docID, ruleID []byte) error
+
+	// List returns a page of the pending and committed documents that match the filter.
+	List(ctx context.Context, filter documents.ListFilter) (documents.ListResult, error)
 }
 
 // service implements Service
@@ -443,3 +448,49 @@ func (s service) DeleteAttribute(ctx context.Context, docID []byte, key document
 
 	return doc, s.pendingRepo.Update(did[:], docID, doc)
 }
+
+// List returns a page of the pending and committed documents that match the filter.
+// Pending documents are listed from the pending repo and committed ones from the document service.
+func (s service) List(ctx context.Context, filter documents.ListFilter) (documents.ListResult, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return documents.ListResult{}, contextutil.ErrDIDMissingFromContext
+	}
+
+	docs, err := s.pendingRepo.List(did[:], filter)
+	if err != nil {
+		return documents.ListResult{}, err
+	}
+
+	if filter.HasStatus(documents.Committed) {
+		cdocs, err := s.docSrv.List(ctx, filter)
+		if err != nil {
+			return documents.ListResult{}, err
+		}
+
+		docs = append(docs, cdocs...)
+	}
+
+	return documents.Paginate(docs, filter), nil
+}
+
+// CommitDocumentAction is the scheduled action committing the pending document.
+// The params of the schedule are {"document_id": "0x..."}.
+const CommitDocumentAction = "commit_document"
+
+type commitDocumentParams struct {
+	DocumentID byteutils.HexBytes `json:"document_id"`
+}
+
+// commitDocument returns the scheduled func committing the pending document of the params.
+func commitDocument(srv Service) jobs.ScheduledFunc {
+	return func(ctx context.Context, params json.RawMessage) (gocelery.JobID, error) {
+		var p commitDocumentParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		_, jobID, err := srv.Commit(ctx, p.DocumentID)
+		return jobID, err
+	}
+}
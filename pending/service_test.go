@@ -47,6 +47,12 @@ func (m *mockRepo) Update(accID, id []byte, doc documents.Document) error {
 	return args.Error(0)
 }
 
+func (m *mockRepo) List(accID []byte, filter documents.ListFilter) ([]documents.Document, error) {
+	args := m.Called(accID, filter)
+	docs, _ := args.Get(0).([]documents.Document)
+	return docs, args.Error(1)
+}
+
 func TestService_Commit(t *testing.T) {
 	s := service{}
 
@@ -673,3 +679,59 @@ func TestService_DeleteAttribute(t *testing.T) {
 	_, err = s.DeleteAttribute(ctx, docID, key)
 	assert.NoError(t, err)
 }
+
+
+func TestService_List(t *testing.T) {
+	s := service{}
+
+	// missing did
+	_, err := s.List(context.Background(), documents.ListFilter{})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	pdoc := new(documents.MockModel)
+	pdoc.On("ID").Return(utils.RandomSlice(32))
+	pdoc.On("GetStatus").Return(documents.Pending)
+	cdoc := new(documents.MockModel)
+	cdoc.On("ID").Return(utils.RandomSlice(32))
+	cdoc.On("GetStatus").Return(documents.Committed)
+
+	// pending only
+	filter := documents.ListFilter{Statuses: []documents.Status{documents.Pending}}
+	repo := new(mockRepo)
+	repo.On("List", did[:], filter).Return([]documents.Document{pdoc}, nil).Once()
+	s.pendingRepo = repo
+	res, err := s.List(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []documents.Document{pdoc}, res.Documents)
+	assert.Empty(t, res.NextCursor)
+
+	// pending and committed
+	filter = documents.ListFilter{Limit: 1}
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("List", ctx, filter).Return([]documents.Document{cdoc}, nil).Once()
+	s.docSrv = docSrv
+	repo.On("List", did[:], filter).Return([]documents.Document{pdoc}, nil).Once()
+	res, err = s.List(ctx, filter)
+	assert.NoError(t, err)
+	assert.Len(t, res.Documents, 1)
+	assert.NotEmpty(t, res.NextCursor)
+	first := res.Documents[0]
+	filter.Cursor = res.NextCursor
+	docSrv.On("List", ctx, filter).Return([]documents.Document{cdoc}, nil).Once()
+	repo.On("List", did[:], filter).Return([]documents.Document{pdoc}, nil).Once()
+	res, err = s.List(ctx, filter)
+	assert.NoError(t, err)
+	assert.Len(t, res.Documents, 1)
+	assert.Empty(t, res.NextCursor)
+	assert.NotEqual(t, first, res.Documents[0])
+
+	// document service error
+	filter = documents.ListFilter{Schemes: []string{"generic"}}
+	repo.On("List", did[:], filter).Return(nil, nil).Once()
+	docSrv.On("List", ctx, filter).Return(nil, errors.New("failed")).Once()
+	_, err = s.List(ctx, filter)
+	assert.Error(t, err)
+	repo.AssertExpectations(t)
+}
@@ -25,7 +25,7 @@ func bindata_read(data []byte, name string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var _go_centrifuge_build_configs_default_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xdb\x6e\xdb\x30\xd2\xbe\xd7\x53\x0c\x9c\x9b\xf6\x47\x6b\x5b\xf2\x21\x8e\x80\xff\xc2\xb1\x13\x37\xcd\x61\x9d\xd8\x4d\xda\xde\x2c\x68\x6a\x24\xb1\x96\x48\x95\xa4\x7c\xc8\xd3\x2f\x86\x92\x9c\xa4\x69\xb7\xbb\x5d\xec\x02\x0b\x6c\x6e\x22\x90\x9c\x6f\x86\x33\xdf\x1c\xe8\x23\x98\x62\xcc\xca\xcc\x42\x84\x1b\xcc\x54\x91\xa3\xb4\x60\xd1\x58\x89\x16\x58\xc2\x84\x34\x16\xd6\x6a\xc3\xa4\xc7\x51\x5a\x2d\xe2\x32\xc1\x1b\xb4\x5b\xa5\xd7\x21\xc4\x99\x90\xd6\x73\x20\x42\x22\xd8\x14\x21\xaa\xf1\x64\x75\xc6\x80\x4d\x99\x85\xc9\x41\x16\x72\x26\xa4\x25\x5c\xaf\x39\x12\x7a\x00\x47\x70\xa5\x38\xcb\x9c\x6a\x21\x13\xe0\x4a\x5a\xcd\xb8\x05\x16\x45\x1a\x8d\x41\x03\x12\x31\x02\xab\x60\x85\x60\xd0\xc2\x56\xd8\x14\x50\x6e\x60\xc3\xb4\x60\xab\x0c\x4d\xdb\x83\x46\x9e\x20\x01\x44\x14\x42\xaf\xd7\x73\xdf\x68\x53\xd4\x58\xe6\xb5\xed\x17\x51\x08\xa3\xde\xa8\xda\x5b\x29\x65\x8d\xd5\xac\x98\x23\x6a\x53\xc9\xbe\x87\x56\x47\x14\xfd\x8e\x1f\x1c\xb7\xbb\xed\x6e\xdb\xef\x58\x5e\x74\x7a\xa3\xa0\x1b\x74\x44\x11\x9b\xce\x6d\xbe\xbc\xdd\xad\xb6\xeb\xf2\xeb\x97\x2f\xd3\xb8\x7c\x5c\xae\x76\x67\xe3\x3b\x5c\xde\x4c\xae\xd4\xe3\x7e\x3f\x18\x8c\x36\xb7\x32\xb9\xdf\xcc\xaf\xbf\x5d\x7d\x59\xb7\x7e\x03\xda\x6b\x40\xef\xe3\xe1\xd9\xcd\x30\x5f\x7f\x7f\xc0\x6f\x0f\x97\x0f\xc1\xf7\x79\xe9\x0f\x3f\x17\xd1\xac\xb7\xfe\xa8\xfc\x65\x2f\x4f\x59\x3a\x3f\x1d\x2c\x70\x20\xfd\x0a\xb4\x71\xd5\xb8\xf1\x54\x75\x01\xba\x3e\x4a\x2b\xec\xfe\x9c\x71\xab\xf4\x3e\x84\x56\xcb\x73\xae\xbe\x66\x42\xbe\x0a\x78\x13\x31\x78\x73\x49\xe1\x7e\xeb\x41\x15\xde\x0a\xed\x08\x6e\xca\x1c\xb5\xe0\x70\x31\x05\x15\xbb\x50\x3f\x0b\x6a\x2d\x7b\xf0\xba\x1f\xd4\x52\xa7\x8d\x6b\x21\x13\xc6\x92\xa4\x54\x11\xbe\x66\x45\xa1\xd5\x46\xb8\x0d\xe5\xb0\x9d\xea\x86\x88\xbf\x0d\x52\x6f\xd0\x0e\xfa\x41\x3b\xe8\x75\xdb\xbe\x3f\xfc\x31\x52\x7e\x30\xed\x5d\x2a\xf5\xb0\x58\xed\x56\x97\x93\xd5\xd7\xf4\xe4\xe3\xbd\x35\xb7\xfb\xfb\x59\xb4\x9c\x6b\xd6\xbf\x2b\x16\xe3\xbe\x5d\x6d\xcc\x90\x49\xdf\xff\xb6\x9d\x8d\x83\xc7\xd6\x2b\xfc\x5e\xbf\x7d\x1c\xb4\xfd\xe0\xf8\x57\xf0\xb7\x79\xc0\x17\xb9\x3e\x13\x6c\x71\x7d\xdf\x4f\x3e\x6d\x8e\x1f\x66\x69\x91\xdc\x6d\xd5\x68\xab\xce\x17\xe6\x43\xfa\x75\xb6\x9a\x89\x1e\x1b\x8f\x76\xad\xda\x3d\x67\x35\x2b\x0f\xce\xbf\x98\xc2\x7b\x70\x01\xf8\x15\x6b\xfb\x8d\x6b\xaf\x98\x0b\x5b\x84\x45\xa6\xf6\x18\xc1\x22\x67\xda\xc2\xa4\x66\x83\x81\x58\x69\xe7\xca\x44\x6c\x50\xbe\x70\xe5\x3f\xc1\x98\xee\xce\xef\x0d\x83\x33\x7e\x1a\x8f\x86\xc7\x27\x41\xbf\x77\x16\xf4\xe3\x71\xf7\x6c\xd2\x0f\x06\x51\x80\x7e\x77\xdc\x1d\x05\x41\x8f\x1f\x4f\x9f\x73\xcb\x58\x96\x50\x16\xbf\xa6\x14\xcb\x57\xa8\xff\x8c\x52\xfe\xbf\x48\x29\xa7\xfa\xb7\x94\xfa\xf7\x93\xea\x7f\xb4\xfa\x43\x5a\x51\x4b\x7a\x62\x45\x5e\xad\xfc\x19\x97\xba\xff\x48\x49\xf1\x4f\x46\x6d\x3f\x08\xda\xbe\xff\xcb\xe0\x8c\x93\xde\x19\x1f\x5b\xfd\xe5\x7e\xb2\xdb\x3e\x0e\xd7\x43\xb3\x3c\x11\x5f\x17\x77\x8f\xf6\xf1\x64\x7a\xbc\xff\xf4\x58\x9c\xce\xef\xce\xce\x1f\xf5\x27\x75\xff\xba\xa4\x10\xbb\x02\xbf\xed\xfb\xaf\x9a\x4b\x83\x7f\x39\xdb\x8a\xdd\x67\x94\xe5\xe7\xf1\xfd\xf7\xf5\xc7\xcb\x5c\x7e\x58\x8c\x3f\x4e\xbf\x3d\xc6\xc7\x38\xbb\x56\x43\xab\x95\x48\xbe\xee\xf2\xe3\xf1\xe0\xee\xef\x07\xbf\x76\xd7\xaf\xc2\xef\xff\x67\xa3\x3f\x3e\xef\x0f\x86\xdc\x1f\xf6\x46\x43\x36\xec\xc7\x51\xff\xbc\xbf\x1a\x9e\xb0\xd8\xef\xb1\xd1\x70\x1a\x77\x4f\x07\xc3\x60\xcc\xba\xdd\x96\x47\xd3\x05\xb3\x0c\x16\x56\x69\x96\xa0\x67\xaa\xff\xd5\xcc\x30\x67\x36\x75\x26\x65\xd4\xcc\xa6\xa7\x10\x8b\x0c\x3d\x80\x82\xd9\x34\x84\x8e\xcd\x8b\xce\xd3\xd4\xf2\xd7\x88\x59\xd6\x76\x27\xa3\x15\xe1\x4e\x94\x8c\x45\x52\x6a\x66\x85\x92\x07\x05\xdc\xad\x2e\xfe\x5c\x4d\x05\xf0\x4a\xdb\x98\x73\x55\x4a\x6b\x60\x8d\x7b\xa8\x6f\xe1\xb1\x7a\x91\xf4\xac\x71\x4f\xcb\x58\x23\x36\x5b\x24\x7b\x21\x2d\xea\x98\x71\x84\x2d\x45\xce\x45\x60\x3c\xbf\x00\x26\x23\x98\x07\x73\x58\xa0\xde\xa0\x76\xf5\x10\x25\x15\x3c\x8f\x4a\xe2\x07\x65\xac\x64\x39\x52\x3b\xae\xe7\x0d\xef\x08\xe6\x4a\xdb\x1a\x86\x20\x7e\x2e\x4a\x87\x42\x18\x75\x47\x01\xa9\xa7\xf4\x78\x6f\xd5\xfb\x02\x51\x03\x7f\xee\x35\xe3\x15\x41\x51\x39\x69\x51\x20\x17\xf1\x1e\xce\x76\x16\xb5\x64\x19\x5c\xcc\x9f\x59\x4b\xa0\xc0\x99\xa4\xe9\x4d\x23\xe3\x29\x46\xc0\x2c\x88\x18\x56\x98\x0a\x19\xc1\xcd\x78\x49\x30\x58\x4b\x5f\xcc\x43\xd8\xb6\x77\xed\x7d\xfb\xb1\x0a\x01\x59\x5d\x1a\x8c\x0e\x0c\xa4\x7b\x67\x6c\x8f\x9a\x02\xe1\xcc\x75\xf9\xe3\x4e\x2f\x45\x8e\xaa\x74\xd7\x94\xa0\x0a\x94\xf5\x48\x29\x91\x3b\xab\xa9\x25\xd0\x65\x8c\x07\xcd\x72\x2d\x12\x42\xab\xd7\x35\x2d\x87\x92\x0b\x29\xf2\x32\x87\x08\x33\xb6\x77\x7a\x71\x83\x7a\x0f\x45\x50\x80\x46\x53\x28\x69\x90\x90\xd8\x46\x89\x08\xac\xc8\x49\x0b\xb3\x96\xf1\xb5\x71\x00\x2c\xfa\x56\x1a\x0b\x2b\x46\x76\x2b\x09\xa9\x32\x96\x24\x55\xa9\x39\x1a\x78\xb3\x58\x4c\xdf\xc1\x64\xfe\xe9\x1d\x70\xa5\xd1\x40\xbb\xdd\x7e\x5b\xcf\xc2\x6a\x0d\x42\x42\xa6\x12\x97\x72\x21\xb4\xc8\x3e\xb2\xd5\x94\x39\x46\xb0\xda\xd3\xb5\xaa\x18\xb4\xc8\x8b\xbb\xff\x7f\xb3\x61\x59\x89\x77\xc8\x22\xf8\x3f\x08\xde\x82\x30\x90\xa1\x71\x6d\x51\x82\xdb\x83\x15\x66\x6a\xfb\x8e\xbc\x27\x81\xa7\x4c\x26\x78\xb8\xc7\xd4\xdd\xd1\x2a\xd8\x79\xf0\x72\x31\x84\xd6\xa0\xdb\xcd\x8d\x4b\xc5\xdb\x12\x4b\xfc\x81\x02\xce\x33\xcc\xec\x25\x4f\xb5\x92\xaa\x34\xd4\x79\x39\x1a\x23\x64\xe2\x7d\x27\x81\x8a\x20\xd5\x23\xc1\x54\x74\x28\x5d\x33\x56\x31\x50\x01\x42\x6d\x3a\xf5\xd5\x74\xdd\xc7\xb7\x22\xcb\x88\x2b\x2c\xcb\x14\x67\xb6\x62\x8b\xb1\x4c\xdb\xb2\xf0\x80\xe4\x1f\x2a\x41\x2a\xe6\x5d\x87\x7f\xae\x11\x0d\x94\x05\x79\x14\xf8\x9e\x67\x68\x2a\x02\x54\x2a\xc8\x21\x5b\x26\xdc\xeb\xa2\x8e\x25\x65\x17\xd4\xdb\x0f\x4c\x38\x0e\x5c\x2f\xaa\x62\x78\x04\xe3\x9c\xf2\xcf\x75\x13\xf2\x3d\x03\xcb\xcc\x9a\x50\x36\x2c\x13\x11\xc4\x5a\xe5\xee\x2e\x5c\xa3\x73\x84\x07\xd5\xce\xb9\x8b\x97\x1f\xa4\x2d\xcf\x55\x19\x94\x76\x92\xba\xa9\xc8\x65\x88\xe0\x2f\xfd\xe7\xde\x55\xee\x00\xb9\x89\xf2\xe4\xd3\xdd\x55\x08\x5b\x13\x76\x9e\xde\x09\xe1\xc9\x49\xbf\xef\xac\xba\xa1\x44\xb2\x9a\x49\xc3\x1c\x97\xa1\x50\x2a\x83\x9c\xed\x40\xa3\xd5\xa2\x1a\x77\x0c\xca\x88\x0c\x7e\x76\x4c\x6d\x5c\xa6\xe4\x6c\x77\x57\x9d\x0b\x21\xa8\x1d\xf7\x73\x48\x41\x35\x67\xc3\x32\x87\xbb\xaf\x3c\xc9\xc8\x74\x5e\x6a\xed\x1e\x0d\xcf\x24\x52\x66\x60\x85\x48\xaf\x0a\x8b\xdc\x62\xe4\xc1\x01\x80\xf4\x11\x8b\x82\x3a\xad\x9a\x17\x67\x26\x62\xac\x89\x69\x15\xe5\x76\xa5\x83\xab\x3c\x17\xd6\x85\x89\x49\x60\x92\xa7\x44\xaf\xfa\x25\xea\xfc\x8d\xd2\x72\xe7\xd0\xf7\xe0\xc3\x1e\x19\xdd\xab\x3a\x77\x25\x62\x34\x05\x93\x21\xb4\x46\xc7\xc3\x6e\xea\x38\x7b\xe8\x87\xbf\xf0\x7f\xd3\x0d\xeb\x32\x86\x19\x52\xa3\xdb\xa6\x82\xa7\x87\x4e\x09\x75\x35\x6e\x2c\xad\x47\x0c\x45\x7c\xae\xe7\xcc\x88\x12\xd6\xd9\x57\x1a\xab\xf2\x5a\x49\xd3\x2a\xea\x67\x71\xdd\x04\x6e\x5c\x55\x6e\x51\x4f\x6e\x1d\x1e\xbf\x55\x98\x2a\xe0\x83\x5e\x9e\x09\xf2\xb5\x2b\x9f\x6f\xb6\x94\xaf\xdf\x4b\xa1\x11\xb6\x06\x94\x06\x51\xf0\xfa\x45\x4c\x0f\x60\xfa\xe4\xcc\x92\xd9\x8e\xdb\x6f\x9f\xf3\x29\xb5\xb6\x08\x3b\x1d\xca\xa6\x8c\xea\x50\x78\x32\xe8\x0f\xaa\x32\xc7\x76\xae\xcc\x51\xaa\x6d\x31\x82\x84\xd1\x9d\x04\x77\x78\x45\x5d\xf9\x5e\x92\x49\x48\xd8\xa2\x70\xd2\x41\x17\x66\x5b\x14\x20\xd5\xb6\xa2\xd7\x8c\x99\x39\x49\x3b\x7e\x35\x7f\xee\xe8\x8c\x19\xc8\x44\x2e\xea\x31\x22\x12\x71\x8c\x8e\x49\x87\x08\x1d\x6a\x1a\xe5\x65\xc2\xcc\x95\x3b\xdd\x3c\xe6\x27\x94\x68\xe8\x12\xbe\xc6\xa4\xd5\x71\x14\x5d\xe2\x3e\x84\xde\xf3\xc5\x3b\xdc\xa8\x35\xba\xf5\xc1\xa0\x59\xae\x38\x32\x71\xfc\x0a\x61\xf4\xc3\xfa\x5c\x63\xb3\xe5\x3f\x41\xc9\xd8\x5e\xd3\x23\x18\x4e\x5e\xac\x2d\xc9\x19\x31\xea\x73\xad\xf2\x10\xfc\xc1\x61\x8f\x19\x83\x76\x51\xb5\xf1\xe1\x61\xb5\x28\x4d\xba\x54\x7f\xd1\x8c\x67\xd8\x40\xc1\xd1\xa1\xc8\x69\xcc\xd5\x86\x4a\x9c\x01\xa3\x94\xa4\xff\x2b\x2d\xa2\x04\xa9\xda\x50\x1a\x25\x9a\x55\x39\xf5\xd4\xda\xac\x72\xd5\xac\x0a\x8e\x7c\x22\xcc\xf3\x30\xd5\xd4\x88\xa2\xea\x87\x13\x06\xab\x4c\xf1\xb5\x9b\x1a\x2a\x86\x80\xd5\x22\x49\x50\x3b\x6c\x1a\xe0\x70\x67\x9b\x42\x58\x35\xc3\x61\xb7\xe9\x86\x3f\x53\xac\xa9\xdb\x28\x99\x3d\xeb\x46\xe6\x90\xab\x8d\x49\x4f\xd0\xd4\x9c\x5e\xc2\xfb\x83\x1a\xfd\xbf\xbf\xac\x2d\x53\x61\xc0\x60\x55\xb9\x0c\x4d\x55\x86\x02\x99\x97\x99\x15\x45\x26\xa8\xdb\xd1\xa0\xf5\x22\xbb\x9f\x52\x0d\x8d\x15\x79\xd3\x46\x12\x66\xae\x0f\x62\x21\xf8\xed\x2e\xd5\x31\x26\xf7\x10\xe1\xaa\x4c\x92\x7a\x9a\xa1\xf2\xe2\x28\x94\x28\x20\x40\xcf\xed\x56\x65\x0c\xa5\xab\x08\x6e\x85\xc6\x08\x92\xf1\x80\xbe\x42\x88\x59\x66\xd0\x9d\x2a\x0a\xad\xe2\x2a\x19\x1b\x60\x9a\xa6\x68\xb5\x39\xe6\x55\xd9\x51\xff\xa6\x56\x68\xe4\x75\x92\x58\x5d\xa2\xf7\xb7\x00\x00\x00\xff\xff\xda\xc2\x06\xd8\x40\x14\x00\x00")
+var _go_centrifuge_build_configs_default_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xed\x59\xd9\x72\xdb\x38\x16\x7d\xd7\x57\xa0\x9c\x97\x64\xca\x96\x25\x6a\xf1\x52\x35\x0f\x8a\xb7\x38\x5e\x46\xb1\x1c\xbb\x93\x97\x29\x88\x04\x25\x44\x24\xc1\x10\xa4\x16\x7f\x7d\x9f\x7b\x01\x6a\xc9\x32\xe9\xee\xa9\x99\xaa\xa9\x9a\xee\x07\xb9\xb1\x9c\xbb\x9d\xbb\x80\xfd\x4a\x9c\xab\x58\x56\x49\x29\x22\x35\x57\x89\xc9\x53\x95\x95\xa2\x54\xb6\xcc\x54\x29\xe4\x44\xea\xcc\x96\x62\x66\xe6\x32\x6b\x84\xd8\x2a\x74\x5c\x4d\xd4\xbd\x2a\x17\xa6\x98\x9d\x8a\x38\xd1\x59\xd9\x78\x45\x20\x3a\x53\xa2\x9c\x2a\xe0\x38\xbc\xcc\x9d\xb1\x58\x94\xa5\x38\x5b\xdf\x15\x29\x30\x4b\xc2\x6d\xd4\x47\x4e\x1b\x42\xbc\x12\xb7\x26\x94\x09\x8b\xd6\xd9\x44\x84\x06\x17\x64\x08\x1d\xa2\xa8\x50\xd6\x2a\x0b\x44\x15\x89\xd2\x88\xb1\x12\x16\xca\x2d\x74\x39\x15\x2a\x9b\x8b\xb9\x2c\xb4\x1c\x27\xca\x36\x81\xe3\xef\x13\xa4\x10\x3a\x3a\x15\x9d\x4e\x87\xff\x56\x50\xae\x50\x55\xea\x75\xbf\xc6\xd6\x71\xe7\xd8\xed\x8d\x8d\x29\x2d\xc4\xe5\x43\xa5\x0a\xeb\xee\x1e\x88\xbd\x43\x9d\x77\x0f\xdb\xc1\x51\xb3\x85\x7f\xdb\x87\x65\x98\x1f\x76\x8e\x83\x56\x80\xf5\xd8\x1e\x7e\x48\x1f\x3f\x2c\xc7\x8b\x59\xf5\xf9\xd3\xa7\xf3\xb8\x7a\x79\x1c\x2f\x2f\x06\x0f\xea\xf1\xfe\xec\xd6\xbc\xac\x56\xbd\xde\xf1\xfc\x43\x36\x79\x9a\x0f\xef\xbe\xdc\x7e\x9a\xed\xfd\x02\xb4\x53\x83\x3e\xc5\xfd\x8b\xfb\x7e\x3a\xfb\xfa\xac\xbe\x3c\xdf\x3c\x07\x5f\x87\x55\xbb\xff\x5b\x1e\x5d\x75\x66\xef\x4d\xfb\xb1\x93\x4e\xe5\x74\xf8\xb6\x37\x52\xbd\xac\xed\x40\x6b\x57\x0d\x6a\x4f\x39\x03\xc8\x7c\x78\x5d\x97\xab\x4b\x6c\x9a\x62\x75\x2a\xf6\xf6\x1a\xec\xea\x3b\xb8\xff\xbb\x80\xd7\x11\x13\xaf\x6f\x28\xdc\x6f\x70\x92\xc3\xeb\xd0\x5e\x89\xfb\x2a\x55\x85\x0e\xc5\xf5\xb9\x30\x31\x87\x7a\x2b\xa8\xfe\xee\xda\xeb\xed\xc0\xdf\x7a\x5b\xbb\x56\x24\x1a\x32\x70\x33\x33\x91\xfa\x9e\x15\x79\x61\xe6\x9a\x37\x0c\x63\xb3\xe8\x9a\x88\xbf\x0c\x52\xa7\xd7\x0c\xba\x41\x33\xe8\xc0\xa5\xed\xfe\xb7\x91\x6a\x07\xe7\x9d\x1b\x63\x9e\x47\xe3\xe5\xf8\xe6\x6c\xfc\x79\x7a\xf2\xfe\xa9\xb4\x1f\x56\x4f\x57\xd1\xe3\xb0\x90\xdd\x87\x7c\x34\xe8\x96\xe3\xb9\xed\xcb\xac\xdd\xfe\xb2\xb8\x1a\x04\x2f\x7b\xdf\xe1\x77\xba\xcd\xa3\xa0\x89\xc8\xfd\x0c\xfe\x43\x1a\x84\xa3\xb4\xb8\xd0\x72\x74\xf7\xd4\x9d\x7c\x9c\x1f\x3d\x5f\x4d\xf3\xc9\xc3\xc2\x1c\x2f\xcc\xe5\xc8\xbe\x9b\x7e\xbe\x1a\x5f\xe9\x8e\x1c\x1c\x2f\xf7\xbc\x7b\x2e\x3c\x2b\xd7\xce\x87\x77\x0f\x04\x07\xe0\x67\xac\xed\xd6\xae\xbd\x95\x1c\xb6\x48\xe5\x89\x59\x21\x35\x46\xa9\x2c\xe0\x53\xcf\x06\x2b\x62\x53\xb0\x2b\x27\x7a\xae\xb2\x1d\x57\xfe\x09\xc6\xb4\x96\xed\x4e\x3f\xb8\x08\xdf\xc6\xc7\xfd\xa3\x93\xa0\xdb\xb9\x08\xba\xf1\xa0\x75\x71\xd6\x0d\x7a\x51\xa0\xda\xad\x41\xeb\x38\x08\x3a\xe1\xd1\xf9\x36\xb7\x6c\x29\x27\x94\xc5\xdf\x53\x4a\xa6\x63\x55\xfc\x35\x4a\xb5\xff\x4d\x4a\xb1\xe8\x5f\x52\xea\x3f\x4f\xaa\xff\xd3\xea\x2f\xd2\x8a\x5a\xd2\x86\x15\xa9\x5b\xf9\x6b\x5c\x6a\xfd\x91\x92\xd2\x3e\x39\x46\x60\x10\x9c\xf6\x4f\x83\x33\x98\x74\x2e\xc2\x41\x59\x7c\x7a\x3a\x5b\x2e\x5e\xfa\xb3\xbe\x7d\x3c\xd1\x9f\x47\x0f\x2f\xe5\xcb\xc9\xf9\xd1\xea\xe3\x4b\xfe\x76\xf8\x70\x71\xf9\x52\x7c\x34\x4f\x7b\x3f\x2c\x59\x41\x1b\xf8\xed\x9f\xe1\xdf\x5c\x2d\xf4\xf2\x37\x95\x55\xbf\x0d\x9e\xbe\xce\xde\xdf\xa4\xd9\xbb\xd1\xe0\xfd\xf9\x97\x97\xf8\x48\x5d\xdd\x99\x7e\x59\x18\x3d\xf9\xbc\x4c\x8f\x06\xbd\x87\x7f\x1d\x7c\xef\xae\x9f\x85\xbf\xfd\xdf\x8d\xfe\xe0\xb2\xdb\xeb\x87\xed\x7e\xe7\xb8\x2f\xfb\xdd\x38\xea\x5e\x76\xc7\xfd\x13\x19\xb7\x3b\xf2\xb8\x7f\x1e\xb7\xde\xf6\xfa\xc1\x40\xb6\x5a\x88\x3e\xa6\x0b\x59\x4a\x31\xc2\x5d\x39\x51\x0d\xeb\x7e\xdd\xcc\x70\x81\x94\x8e\x22\xa8\x19\xe1\xc8\x58\x5a\x85\x81\x60\x42\x93\x88\xa7\x00\x2d\x0b\x99\x45\xa4\x5c\xac\x27\x55\x21\x4b\x6d\xa8\x3c\x31\x86\x3d\x15\x09\x35\xc1\x68\x2c\x60\xd5\xd8\x24\x65\x34\x06\xec\x58\x86\x33\x95\x45\xeb\x4d\x96\x34\x94\x98\x36\xc8\x78\x5e\x3c\x7f\x2b\x62\x9d\x28\xec\xe4\x58\x3f\x15\x87\x65\x9a\x1f\x6e\xe6\xa3\x7f\x92\xdc\x66\x7d\x1d\x16\x9c\xed\x88\xaf\x4d\x71\x4a\x8d\xb6\x0d\xfa\x73\x62\x1c\xc0\x77\xd2\x06\x61\x68\xaa\x0c\xc1\x9a\xa9\x55\x6d\x6b\x43\xfa\x45\x92\x83\x75\x5a\x56\x1e\xb1\xde\xa2\xbb\xd7\x59\xa9\x8a\x58\x86\x4a\x2c\x88\x23\xec\xc5\xc1\xf0\x9a\x9d\x38\x0c\x86\x62\xa4\x8a\x39\xaa\x28\x55\x5e\x95\x51\x69\x6d\x50\xf1\x7d\x67\xc0\x03\x99\x2a\x6a\xfc\x7e\xb2\x01\xd6\xd0\x80\x3a\x0e\x86\x20\x7e\x7c\x95\x0e\x61\x14\x43\xba\x93\x78\x4a\xc4\x83\xd2\x1c\xe4\xf8\xdd\x0d\x9a\x6d\xe4\x41\xee\x9c\x34\xca\x55\xa8\xe3\x95\xb8\x58\x42\xd7\x0c\x43\xe3\xf5\x70\x4b\x5b\x02\x15\xa1\xcc\x68\x4e\x2c\x94\x0c\xa7\xa0\x07\x1a\x83\x8e\xb1\x30\xd5\x30\xe3\x7e\xf0\x48\x30\xca\xdf\xbe\x1e\x9e\x8a\x45\x73\xd9\x5c\x35\x5f\x5c\x08\x48\xeb\xca\xe2\x56\xcd\x75\xb2\x3b\x91\x2b\x55\x50\x20\x58\x5d\xce\x54\x3e\xfd\xa8\x53\x65\x2a\x36\x33\x13\x26\x57\x99\x1f\x5e\x33\x15\xb2\xd6\xd4\x7c\xc8\x18\xdb\x10\xf5\xb2\xbf\x82\x3c\xe8\xb4\xec\x1e\xa3\xa4\x3a\xd3\x29\x32\x36\x52\x90\xc3\x72\x11\xcd\x62\x25\x60\x32\x6c\xb0\x39\x80\x14\x21\xc9\xb9\xd1\x98\x81\x75\x4a\x52\x64\x59\x82\xa9\x96\x01\x64\xf4\xa5\x42\xda\x52\x0a\x44\x02\x14\x9b\x22\x20\x74\xd3\x54\x45\x88\x0e\xf8\x7a\x34\x3a\xdf\x17\x67\xc3\x8f\xfb\x50\x02\xcb\xa2\xd9\x6c\xbe\xf1\x53\xb7\x99\x09\x74\xec\xc4\x4c\x38\xb9\xa1\x15\xe9\x47\xba\x5a\x54\xd4\x48\x8c\x57\x64\x96\x8b\xc1\x1e\x79\x71\xf9\xf7\xd7\x73\x99\x54\xea\x41\xc9\x48\xfc\x4d\x04\x6f\x84\xb6\xa0\xab\xe5\x06\x9c\x09\xde\x83\xab\x13\xb3\xd8\x27\xef\x65\x22\xc4\xf2\x44\xad\xed\x38\x67\x1b\x61\xcc\x12\x0a\xec\x2c\x42\x76\xaf\xd5\x4a\x2d\x27\xfd\x87\x4a\x55\xea\x1b\x0a\xb0\x67\xa4\x5d\x65\xe1\xb4\x30\x99\xa9\x2c\xf5\x78\xd8\x67\xe1\x8e\xc6\x57\xba\xe0\x08\xe2\x9e\x23\xd6\xd1\xa1\xe2\xb6\x8f\x82\x40\xa5\x0e\x81\x38\xf4\xa6\x15\x7e\x62\x58\xe8\x24\x21\xae\xc8\x24\xc1\x0b\xa4\x74\x6c\xc1\x00\x53\x94\x55\x0e\x34\xdc\x7f\x76\x17\xa9\x6d\xb4\x18\xff\xb2\x50\x40\xaf\x72\xf2\xa8\x08\x57\x21\xac\x77\x04\x70\x22\xc8\x21\x0b\xa9\xf9\x1d\xe3\x63\x49\xd9\x25\xfc\xf6\x33\xb6\xc8\xc7\x77\x23\x57\x76\x91\xb0\x29\xe5\x1f\x17\x2d\xf2\xbd\x14\xa5\xb4\x33\x42\x81\x33\x11\xef\xb8\x30\x29\xdb\x12\x82\xcf\xe4\x08\x5c\xe2\x9d\x4b\x8e\x57\x3b\x98\xb2\xc7\x9e\xd5\x78\x4a\xd1\xcc\x4c\xa9\x63\x1d\xfa\xac\xd9\xf9\x2f\x9f\x3f\x0a\x40\x9e\xe3\x88\x83\xd5\x93\x8c\xe1\x73\xb9\x4a\x8c\x8c\xac\x7b\x5a\xbd\xbb\x1b\x9c\x1d\x8c\xde\x0d\x82\x5e\xbf\x29\xae\x54\x46\x1c\x70\x7c\x60\x35\x94\x0b\x4d\x13\x25\xcb\xdf\x92\x05\x25\x5f\xc9\x78\x38\x08\xaa\xa8\x34\x2f\x57\x10\x69\x59\x20\xbf\x3f\x7c\xab\xf6\x21\x01\xdf\x35\x13\x1d\x5c\xa6\xc3\x16\x81\x80\xc7\xea\x44\xde\x68\x4e\xce\x48\xcd\xdc\x29\xec\x9e\x99\x32\x3a\x48\x14\xae\x15\x82\x23\xcf\x13\xc1\x72\xe0\x71\x7c\x8b\x27\x2a\x10\xdb\xb6\x50\x63\x5d\x70\x6a\x94\xc5\x6a\x5f\x44\xa6\xc2\xc3\x91\x33\xc6\x25\x1c\xaf\x33\x2f\xf1\xcb\xb5\x10\x8e\x26\x5e\x12\x29\xa9\x90\x23\x92\x67\x53\x1e\x71\xb9\x08\x61\xe0\xd8\xa1\x28\x3f\x92\xf9\x00\xb9\x9a\x4a\xd1\xc7\x87\x5b\xd4\x17\x7b\x7a\xb8\x79\xf4\x9d\x9e\x9c\x74\xbb\xce\x13\x54\xab\xd0\x35\x33\x2b\xb9\x5c\xa0\xbc\x98\x84\xec\x60\x05\xb4\x9b\x5d\x2d\x9a\x11\x71\x62\xeb\x18\x3c\x51\x38\x83\x1f\xdc\xb9\x53\x11\x78\x6e\xfe\x18\x52\x7b\x53\x9c\x61\x8e\xac\x92\x54\x0f\xab\xa2\xe0\x17\xe0\xd6\x8d\xa9\xa4\x38\x28\x7a\x22\x22\xcc\x08\x3a\x80\x6b\x00\x92\x47\x89\x1a\xf8\xca\x55\x7f\x3e\x48\x74\xac\x7c\xee\x43\x65\x50\xcb\xc9\x08\x4d\x9a\xea\x92\x33\x01\xb5\x41\x22\x71\x29\x83\xfd\x67\x05\xa6\x34\x84\x87\xec\xd0\x03\xd1\x16\x2b\x25\xc9\x2e\x77\xee\x16\x90\x36\x97\x19\xa4\x1d\x1f\xf5\x5b\x53\x27\xf0\x19\x25\xdc\x2c\xa8\x64\x2d\xa6\x3a\x9c\xba\xc9\x9e\xcf\x5b\x62\x14\x49\x71\xed\x8c\xf9\x18\x9a\x24\x61\x13\xfc\x14\xc0\xda\x10\x39\xe1\x43\x8c\x09\x63\x09\xe1\x02\x9d\xa0\x80\x42\x3a\x6c\xba\x6c\xf4\x68\xee\x7e\x7d\x83\x8f\xaf\xf8\x07\xcc\x6e\xad\xb5\x7c\x4b\x10\x4e\xa9\x2d\xb7\xdc\x21\x84\x9b\xca\xb3\xad\xe3\xaf\x74\xd8\x05\x1e\xe9\x17\xb5\xa9\x3b\xd7\xd9\x81\x2f\x78\x48\xb2\xb4\x4a\x38\x21\xd9\x7b\xfb\x2e\x9b\xc9\xad\x54\x96\xbd\x4c\x6e\x85\xa8\x66\x9e\x87\x2e\x24\x2a\xa3\x8f\x25\xd1\xae\xad\xdb\x76\xf2\xd5\x6f\xf0\xd9\x19\x09\x35\x15\xc6\x58\x83\x63\x8c\xc8\xf9\xbb\x8b\x3f\x6f\xfc\xdb\xce\x0b\x39\x15\xb1\x4c\xac\xaa\x07\xd3\xda\x46\xe7\x5b\xab\x64\xe2\xdc\x20\xc5\x18\xb5\x77\xe6\xd5\xd6\xf0\xd9\x02\x8b\x30\xd2\x18\xfe\xc5\xe2\xca\x5d\xa9\xc6\xb5\xf3\x5c\x0c\x84\xbb\x49\xe5\x94\xe6\x4a\x5b\xcf\xc0\x0f\x50\xbd\xf6\x82\xda\x88\x8d\xa5\xf6\xe9\x1e\x69\x50\x0b\x0e\xde\x07\xd3\xcb\x05\x91\xbd\xc5\x14\x71\x73\x30\x9d\xab\x0a\x45\x28\xa7\x90\xd3\xd8\x1a\xaa\x7f\x92\xf7\xf5\x48\x5d\x57\x58\x62\x9d\xf5\x14\xad\xf7\xd6\xcc\xf4\x19\xe2\xf5\x33\xd4\xaa\xfc\x63\x95\xdd\xc1\x79\x81\x76\x8e\xaa\xef\x84\xd4\x53\xa0\xff\xb6\xe6\xe7\xbb\x7b\x1e\xb8\xf6\x68\xb0\xdf\x5b\x7f\x41\x73\xe5\xc1\x1b\x5e\xcb\x0d\x13\x4d\x39\xce\x11\x7b\xbd\xa0\x56\xfc\xb5\xd2\x70\xe7\xc2\xd2\xe0\xab\xf3\xd0\x7f\x56\xa3\x98\xd1\x9f\xa1\xa3\x24\xb7\xad\x37\xdb\x75\x6c\x5a\x96\x39\x2a\x19\x35\xca\x84\x46\x8c\xd3\x93\x5e\xb7\xe7\x26\x18\xb9\xe4\x09\x86\xba\xe8\x02\x66\x4c\x24\xd9\xa4\x43\xc6\xcb\xfd\x50\xb3\x5b\xc4\x28\x85\x95\xe6\xdb\x41\x4b\x5c\xe1\x6f\x08\x5a\xb8\xb2\x76\x25\xed\x90\x6e\x73\x5d\xab\xff\xe1\xa3\xd8\x41\xb1\x01\x0b\xdc\x34\x10\xe9\x38\x56\x5c\xc1\xd6\x11\x5a\x8f\x2b\xd4\x72\xa1\xc7\x2d\x9f\xae\xbf\x08\x9e\x71\xf3\xe2\x9c\xf2\x98\xb4\x8a\x47\xcb\x8d\x42\x5d\xeb\x6c\x2f\x3e\xa8\xb9\x99\x29\x5e\xef\xf5\xea\x65\x97\x9c\x67\x9c\x2f\x98\x5b\xbf\x59\x1f\x16\xaa\xde\x6a\x6f\xa0\xb2\xb8\xbc\xa3\x2f\x69\xe2\x64\x67\xed\x91\x9c\x01\xed\x2f\xd1\xdf\x71\xbe\xb7\xde\x93\x78\x3e\x95\x23\x37\xa1\xf7\xd7\xab\x79\x65\xa7\x8f\xe6\x1f\x78\x63\x25\xaa\x86\x82\x43\xea\xf9\xa5\x50\xae\x41\x6e\xe5\xcd\xb8\xd0\x11\x26\x2f\xf4\x4e\x2a\xdf\x13\x6e\xe0\x3b\x53\x2b\x62\x43\x83\x8a\x0b\x4e\xb6\x21\xcc\x76\x98\x3c\x35\xf8\x99\x45\x23\xa8\xcf\x55\x4a\x16\xc7\x10\x9c\xd6\x93\x09\x2e\x46\x6e\xc6\x2d\x91\x70\xf5\x8c\xe3\xe6\x5c\xd8\xe0\xeb\xe2\x8f\x04\x17\x5c\xb1\xb2\x64\x6b\xd0\xb4\xeb\x1e\x51\xab\xb4\x81\xa6\xb9\x73\x17\xbe\xdd\xf3\xe8\xff\xfb\xed\xf4\x71\x8a\x60\x21\xf8\xdc\x31\x2d\x3d\x98\x2c\x05\x12\xb5\xb5\xd4\x39\xb2\xb8\x60\x5d\x77\xb3\x7b\x93\x6a\xf4\xed\x3b\xad\x27\x44\x2c\xdf\xad\xaf\x81\x5e\xcd\x5f\xf5\x10\x37\xf6\x11\x74\xfd\x62\xdf\x17\xf7\x97\x8f\x1c\x69\xc3\xac\x5b\xbf\xf1\xed\xe6\x03\xc1\xce\xb0\xfc\x47\xba\xc0\x39\x2a\x0f\x7f\x08\xd8\xc8\x43\xf7\xc9\xb9\x30\x6f\xf0\x7d\x09\x1b\x57\x3a\x89\x0e\xb9\xee\x9b\xa8\xc2\x9c\xbd\xf3\xa9\xc1\x02\x0b\xce\xe3\x43\x3c\x02\xcb\x6c\x05\xcd\xc6\xd5\x64\xe2\x9f\x61\x54\x3c\x39\x41\x26\x46\x90\xbb\x1a\xbc\xeb\x8a\xb4\xd3\xce\x9d\xa7\xf7\x0f\xdd\xc1\x06\xfe\xda\x28\xfc\x4a\xe4\xf0\x56\xec\x4a\x4d\x0d\x4c\xcf\x40\x5a\xad\x8f\x35\x5c\xee\xfb\xff\xed\x90\xc3\x3c\x5f\x02\xca\x02\x73\xe9\xef\x06\x7e\x73\x92\x63\x19\x00\x00")
 
 func go_centrifuge_build_configs_default_config_yaml() ([]byte, error) {
 	return bindata_read(
@@ -34,7 +34,7 @@ func go_centrifuge_build_configs_default_config_yaml() ([]byte, error) {
 	)
 }
 
-var _go_centrifuge_build_configs_testing_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x53\xc9\x6e\x1c\x37\x10\xbd\xf7\x57\x10\xcc\xc1\x97\x59\xb8\x6f\x37\x43\xf1\x12\x08\x11\xe2\x38\x80\x9c\x63\x91\xac\x96\x1a\xa3\x5e\xc2\x66\x4b\x1e\x1b\xfe\xf7\xa0\x67\x46\xb6\x6f\x51\x6e\x64\x75\xbd\xf7\x58\xd5\xef\x25\x1c\x6a\xe9\xda\xe5\x0e\x6f\xb0\x3e\x8d\xe5\x10\x48\xc5\xb9\x76\xc3\x5d\x83\xf5\x1e\x0b\x2e\x7d\x68\x08\x81\x94\xc6\x65\xa8\xf3\x7a\x26\xa4\x87\x6e\x08\xe4\x74\x24\xe4\x80\xc7\x40\x5e\x7d\xa5\x90\x73\xc1\x79\xa6\x81\x3a\x1f\x19\x38\xa3\x9d\x4c\x4a\x29\x05\xa9\xcd\x96\x47\x65\x24\xb2\x2c\x93\xd6\x80\x5c\x71\x01\x9a\x6e\x68\x2a\xc7\xa9\x8e\x34\x7c\xa5\xa9\x9b\xee\xb1\xd0\x40\x01\xe7\x2d\x17\x6e\x9b\x6a\x59\x1b\x4e\xe5\x8a\x9f\x2b\x0d\x34\x59\xeb\x5b\x27\xad\xcf\xd6\xb2\xec\x45\x6a\x13\xcf\x39\x2b\x70\xad\xe4\x59\x03\x83\x9c\x5c\x2b\x80\x45\x01\x5c\x31\x2e\x2d\xcb\xd2\x48\xd6\x4a\x97\x58\x72\xf0\x9d\x6f\x82\x02\xfd\xbc\xca\x76\x8f\x34\x50\x69\x12\x37\x0e\xad\x8c\xad\x77\xac\x45\xab\x23\xb3\xc2\xb6\xce\x33\xb0\x1c\x32\xfd\xb6\xa1\x87\xdc\xd2\x40\xe7\xd3\x83\xe9\xe9\xfa\x83\x24\x1f\x1e\x70\xa0\x41\x8a\x0d\x1d\x68\x10\x46\x70\xa5\x36\x74\xa2\x81\x6f\x68\xa1\xc1\x6d\xe8\x0c\x0f\xeb\x00\x19\x79\x44\x6e\x50\x26\xef\xb8\x57\x2a\x73\x4c\x20\xa2\x8b\xc2\xa2\x42\x83\x2c\xea\xd8\x46\x25\x23\x32\x69\x0d\xe8\xec\x9c\xf3\x2d\x18\xeb\x41\x38\x2e\xc4\xfa\x90\x1e\xd2\xba\x8a\xc4\x85\x8b\x8e\x6b\xad\x75\x04\x8e\x90\x6d\x02\xf4\xcc\x30\x74\x4e\x09\x68\x13\x38\xa9\x4d\x66\x46\x69\x1d\xb3\x07\x6d\xb5\x88\x60\xda\x94\x98\x17\xd8\xae\x4c\x5d\xa6\x81\x2a\x8d\xcc\x30\x30\xdb\x2c\x00\xb7\x4a\x46\xb7\xf5\x42\xb4\x5b\xa5\x9c\xf0\xca\xfb\x2c\x6d\xa6\x1b\xfa\x88\x65\xee\xc6\x75\xc8\x6f\xaf\x2e\x3f\x7e\x82\x79\x7e\x1a\x4b\x0e\xe4\xd5\x73\xe9\xe2\x81\x40\x5e\x6a\x81\xa6\xe9\x32\x0e\xb5\xab\xc7\xdf\x72\x20\x94\x7d\x7e\xb1\x77\x9a\x66\xb5\xee\xd5\xfd\x6a\xc5\x1f\x06\x3d\xfb\xb3\x3b\x73\x65\x25\xb5\x97\xc9\x72\xdd\xe6\x2c\x79\x32\x9c\x2b\x0e\x31\x33\x05\xde\xb7\xd9\x38\x21\x92\xd3\xda\x39\xad\x52\xca\x28\x3d\x68\xe3\x14\x5a\xd0\x26\x83\xb0\x99\x9e\xc8\x66\x4c\x05\x6b\x20\x74\xbf\x7f\xfd\xd0\x25\x3c\x57\xbf\x4f\x4a\xf5\xbb\xf2\xf4\x08\x6f\xde\xea\x2f\x9f\xa2\x30\x6f\xbf\xf8\x92\x3e\x4c\xbf\xde\x7e\xd4\xf6\xaa\xbe\xf9\xf3\xfd\x74\x83\xf7\x9f\xae\xfe\x48\x37\xe3\xfb\x77\xd7\x4b\xfd\xf0\x37\x6d\x9a\x5f\xc8\xeb\x4b\x9e\xd6\xf4\x90\xb9\x8e\x05\xee\xb0\xf9\x39\x64\x07\x3c\xae\x65\x0c\x64\x5f\xfb\x69\xff\xfc\xa9\x69\xfe\x59\x70\xc1\xb5\x63\x58\xfa\xdb\xb1\x1c\xb0\xcc\x81\x88\x86\x90\xa7\xd3\xe5\x16\xba\xfa\x57\xd7\xe3\xef\x1f\x03\xe1\x4d\xb3\xd2\xac\xcd\x93\x98\xce\xab\x99\x96\xf8\xd0\xa5\xeb\x35\xb3\xbb\xdd\x7e\xb7\xdb\xc7\xa5\x7b\xc8\xfb\x82\xf3\xb8\x94\x84\xf3\x7e\x12\xd3\x35\x1e\x77\xd3\x12\x77\x13\xf6\x67\x4c\xe9\x1e\xa1\xe2\x7f\x83\x0e\x2b\xf0\x04\x9a\xbb\xbb\xa1\x1b\xee\x5e\xa8\x79\xe9\xfe\xff\xba\x3f\x01\x9f\xb5\x1b\x18\xd2\xfd\x58\x2e\xe2\x53\xc1\x34\xf6\x7d\x57\x03\xa9\x65\xc1\xe6\xdf\x00\x00\x00\xff\xff\xdc\x3c\xc5\xc4\xef\x04\x00\x00")
+var _go_centrifuge_build_configs_testing_config_yaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\x95\x54\xc9\x8e\xdc\x36\x10\xbd\xeb\x2b\x08\xf9\xe0\x4b\xf7\xb4\xb8\x89\xa4\x6e\xc6\xc4\x4b\x60\x64\x60\xc7\x01\xc6\x39\x96\xc8\x62\x0f\x31\xad\x25\xa2\x34\x8b\x0d\xff\x7b\x4a\xdd\x3d\x63\xdf\x32\x11\x04\x48\x2c\xbe\xf7\x8a\xaf\x54\x25\x8f\xfd\x3c\xa5\xb8\xec\xf1\x0a\xe7\xfb\x61\xba\x6d\xd8\x8c\x79\x4e\xfd\xbe\xc0\xf9\x06\x27\x5c\xba\xa6\x60\x0c\xbc\x1f\x96\x7e\xce\xeb\x3b\x63\x1d\xa4\xbe\x61\xc7\x57\xc6\x6e\xf1\xb1\x61\xaf\xbf\x97\x10\xc2\x84\x39\x97\x4d\x69\x5d\x5b\x81\xad\xb5\x95\x5e\xd1\x05\x3e\x06\xc3\x5b\x55\x4b\xac\x82\xf4\x5a\x03\x72\xc5\x05\xe8\x72\x53\xfa\xe9\x71\x9c\x87\xb2\xf9\x5e\xfa\x34\x52\x3a\x62\x03\xe6\x2d\x17\x76\xeb\xe7\x69\x05\x1c\xc3\x33\x3e\xcc\xb4\xe5\x8d\x71\xd1\x4a\xe3\x82\x31\x55\x70\xc2\x47\xcf\x43\x08\x0a\x6c\x94\x3c\x68\xa8\x20\x78\x1b\x05\x54\xad\x00\xae\x2a\x2e\x09\x25\x6b\x59\x45\x69\x7d\xe5\x2d\x3c\xeb\x8d\x30\x41\x97\xd7\xb4\xe9\x8e\x74\x65\xed\x79\x6d\xd1\xc8\x36\x3a\x5b\x45\x34\xba\xad\x8c\x30\xd1\xba\x0a\x0c\x87\x50\xfe\xd8\x94\xb7\x21\x12\x32\x1f\x0f\x5c\x1e\x97\x3f\x45\xc2\xed\x01\xfb\xb2\x91\x62\x53\xd2\x43\xd4\x82\x2b\xb5\x29\xc7\xb2\xe1\x9b\x92\x2c\xd9\x4d\x99\xe1\xb0\x1a\x08\xc8\x5b\xe4\x35\x4a\xef\x2c\x77\x4a\x05\x8e\x1e\x44\x6b\x5b\x61\x50\x61\x8d\x55\xab\xdb\xd8\x2a\xd9\x62\x25\x4d\x0d\x3a\x58\x6b\x5d\x84\xda\x38\x10\x96\x0b\xb1\x1e\xa4\x03\xbf\x96\xc2\x53\x8d\x5a\xcb\x35\x5d\x2d\x70\x84\x60\x3c\xa0\xab\xea\x0a\xad\x55\x02\xa2\x07\x2b\x75\x1d\xaa\x5a\x11\x20\x38\xd0\x46\x8b\x16\xea\xe8\x7d\xe5\x04\xc6\x55\x29\x05\x12\x52\x1a\x89\x04\xf5\x36\x08\xc0\x2d\xa5\xb6\x5b\x27\x44\xdc\x2a\x65\x85\x53\xce\x05\x69\x02\xf9\xbd\xc3\x29\xa7\x61\x35\xf9\xe3\xf5\xf9\xc3\x8f\x90\x33\x75\x4c\xa0\xaf\xff\x14\x3a\xf7\x40\xc3\x5e\xda\x02\x45\x91\x02\x75\x60\x9a\x1f\x7f\x27\x9d\xb2\x7a\x78\x71\xef\x14\x85\x27\xe2\xe5\xcd\xda\x8a\x3f\x1b\xf4\xd4\x9f\xe9\xa4\x15\x94\xd4\x4e\x7a\xc3\x75\x0c\x41\x72\x5f\x73\xe2\x42\x1b\x2a\x05\xce\xc5\x50\x5b\x21\xbc\xd5\xda\x5a\xad\xbc\x0f\x28\xa9\x48\xb5\x55\x68\xe8\x11\x40\x90\xed\xa3\x58\x46\x3f\xe1\x4c\x82\xbb\xdd\x9b\x43\xf2\x78\x8a\x3e\x3b\x2d\xf5\xfb\xe9\xfe\x0e\xde\xbe\xd3\xdf\xbe\xb6\xa2\x7e\xf7\xcd\x4d\xfe\xf3\xf8\xdb\xf5\x17\x6d\x2e\xe7\xb7\x7f\x7e\x18\xaf\xf0\xe6\xeb\xe5\x27\x7f\x35\x7c\x78\xff\x71\x99\x3f\xff\xbd\xf2\x5f\x31\xe8\xfd\xcd\x30\xd1\x98\xb1\x94\x19\x3e\xe0\xe4\x53\xc6\xc0\x60\x4f\x7e\xf2\xcc\x68\xf4\x58\x4e\xdd\x72\x80\x99\xa2\x7e\x75\xc9\xf2\x40\x61\x38\xed\xad\x33\x9a\xd9\xb4\xf4\x6c\x88\xf1\x90\x7a\x24\xd1\x33\x7e\x98\x4e\x45\xc0\x1e\xda\x03\x52\x25\xe6\x69\xc1\xa2\x78\xc5\xde\x9c\x67\x78\x9d\x58\x96\x09\x07\x7b\x2c\x7e\x1d\x6c\x8a\xaf\x61\x6c\xd8\x6e\xee\xc6\xdd\xd3\x56\x51\xfc\xb3\xe0\x82\x2b\xa2\x5f\xba\x6b\xfa\x47\x50\x2f\x34\x4c\xd0\xfa\xfe\xb8\xb8\x86\x34\xff\x95\x3a\xfc\xe3\x4b\xc3\x78\x51\xac\x32\x2b\x78\x14\xe3\xe9\x24\xe3\xd2\x52\xe1\x3e\xae\xff\x89\x8b\x8b\x1d\xdd\xed\x92\x0e\x61\x47\xf5\x1b\x96\xc9\x63\xde\x11\x92\x76\x2f\x08\x77\x31\x62\x77\xe2\x4c\xe9\x8e\xbc\xff\x37\xe9\x76\x25\x1e\x49\x39\xed\x7b\x2a\xe8\x0b\x73\x9e\xd1\xff\x3f\xef\x2f\xc4\xa7\xdc\xc5\xf3\xd7\x3c\xfa\x9e\xd0\x0f\x5d\x97\xe6\x73\xe9\xff\x05\x79\xf9\x53\x27\x63\x05\x00\x00")
 
 func go_centrifuge_build_configs_testing_config_yaml() ([]byte, error) {
 	return bindata_read(
@@ -0,0 +1,78 @@
+package backend
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/boltdb"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("storage")
+
+const (
+	// LevelDB is the goleveldb backend. It is used when no backend is configured.
+	LevelDB = "leveldb"
+
+	// BoltDB is the bbolt backend.
+	BoltDB = "boltdb"
+
+	// ErrUnknownBackend must be used when the configured storage backend is not supported
+	ErrUnknownBackend = errors.Error("unknown storage backend")
+)
+
+// DB is an opened database. Repository and KV share the underlying database.
+type DB struct {
+	Repository storage.Repository
+	KV         storage.KV
+}
+
+// Close closes the database.
+func (db *DB) Close() error {
+	return db.Repository.Close()
+}
+
+// Encrypt returns the database with the Repository and the KV encrypting the values with the key derived from secret.
+// If secret is nil, the database is returned as is as long as it isn't encrypted.
+func (db *DB) Encrypt(secret *encryption.Secret) (*DB, error) {
+	if secret == nil {
+		if encryption.IsEncrypted(db.KV) {
+			return nil, encryption.ErrEncrypted
+		}
+
+		return db, nil
+	}
+
+	c, err := encryption.Open(db.KV, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{
+		Repository: encryption.NewRepository(db.Repository, c),
+		KV:         encryption.NewKV(db.KV, c),
+	}, nil
+}
+
+// Open opens the database at path with the backend.
+func Open(backend, path string) (*DB, error) {
+	switch backend {
+	case "", LevelDB:
+		db, err := leveldb.NewLevelDBStorage(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DB{Repository: leveldb.NewLevelDBRepository(db), KV: leveldb.NewLevelDBKV(db)}, nil
+	case BoltDB:
+		db, err := boltdb.NewBoltDBStorage(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &DB{Repository: boltdb.NewBoltDBRepository(db), KV: boltdb.NewBoltDBKV(db)}, nil
+	default:
+		return nil, errors.NewTypedError(ErrUnknownBackend, errors.New("%s", backend))
+	}
+}
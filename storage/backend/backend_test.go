@@ -0,0 +1,32 @@
+// +build unit
+
+package backend
+
+import (
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpen(t *testing.T) {
+	for _, b := range []string{"", LevelDB, BoltDB} {
+		path := leveldb.GetRandomTestStoragePath()
+		db, err := Open(b, path)
+		assert.NoError(t, err)
+		assert.NotNil(t, db.Repository)
+		assert.NotNil(t, db.KV)
+		assert.NoError(t, db.KV.Set([]byte("key"), []byte("value")))
+		v, err := db.KV.Get([]byte("key"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value"), v)
+		assert.NoError(t, db.Close())
+		assert.NoError(t, os.RemoveAll(path))
+	}
+
+	_, err := Open("unknown", leveldb.GetRandomTestStoragePath())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrUnknownBackend, err))
+}
@@ -0,0 +1,76 @@
+package backend
+
+import (
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+)
+
+const (
+	// BootstrappedRawDBs is a key mapped to the KVs, without encryption, of the databases at boot.
+	// The KVs are mapped by the database name.
+	BootstrappedRawDBs = "BootstrappedRawDBs"
+
+	// MainDB is the name of the database holding the documents and the jobs
+	MainDB = "main"
+
+	// ConfigDB is the name of the database holding the node and the account configs
+	ConfigDB = "config"
+)
+
+// Config holds configuration data for storage package
+type Config interface {
+	GetStorageBackend() string
+	GetStoragePath() string
+	GetConfigStoragePath() string
+}
+
+// Bootstrapper implements bootstrapper.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap opens the databases with the configured backend.
+// The databases are encrypted with the encryption.Secret in the context, if any.
+func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
+	if _, ok := context[bootstrap.BootstrappedConfig]; !ok {
+		return errors.New("config not initialised")
+	}
+	cfg := context[bootstrap.BootstrappedConfig].(Config)
+	secret, _ := context[encryption.BootstrappedSecret].(*encryption.Secret)
+
+	configDB, rawConfigDB, err := openEncrypted(cfg.GetStorageBackend(), cfg.GetConfigStoragePath(), secret)
+	if err != nil {
+		return errors.New("failed to init config db: %v", err)
+	}
+	context[storage.BootstrappedConfigDB] = configDB.Repository
+
+	db, rawDB, err := openEncrypted(cfg.GetStorageBackend(), cfg.GetStoragePath(), secret)
+	if err != nil {
+		return errors.New("failed to init db: %v", err)
+	}
+	context[storage.BootstrappedDB] = db.Repository
+	context[storage.BootstrappedKV] = db.KV
+	context[BootstrappedRawDBs] = map[string]storage.KV{MainDB: rawDB.KV, ConfigDB: rawConfigDB.KV}
+	return nil
+}
+
+// openEncrypted opens the database and encrypts it with secret.
+// The database without encryption is returned along with the encrypted one.
+// The database is closed if the secret can't be used.
+func openEncrypted(backend, path string, secret *encryption.Secret) (edb, db *DB, err error) {
+	db, err = Open(backend, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	edb, err = db.Encrypt(secret)
+	if err != nil {
+		if cerr := db.Close(); cerr != nil {
+			log.Warnf("failed to close db: %v", cerr)
+		}
+
+		return nil, nil, err
+	}
+
+	return edb, db, nil
+}
@@ -0,0 +1,63 @@
+// +build unit
+
+package backend
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockConfig struct {
+	backend, path, configPath string
+}
+
+func (c mockConfig) GetStorageBackend() string {
+	return c.backend
+}
+
+func (c mockConfig) GetStoragePath() string {
+	return c.path
+}
+
+func (c mockConfig) GetConfigStoragePath() string {
+	return c.configPath
+}
+
+func TestBootstrapper_Bootstrap(t *testing.T) {
+	err := (&Bootstrapper{}).Bootstrap(map[string]interface{}{})
+	assert.Error(t, err, "Should throw an error because of empty context")
+}
+
+func TestBootstrapper_Bootstrap_Encrypted(t *testing.T) {
+	cfg := mockConfig{backend: BoltDB, path: t.TempDir(), configPath: t.TempDir()}
+	secret, err := encryption.NewPassphraseSecret("passphrase")
+	assert.NoError(t, err)
+	closeDBs := func(ctx map[string]interface{}) {
+		assert.NoError(t, ctx[storage.BootstrappedDB].(storage.Repository).Close())
+		assert.NoError(t, ctx[storage.BootstrappedConfigDB].(storage.Repository).Close())
+	}
+
+	// new dbs are encrypted
+	ctx := map[string]interface{}{
+		bootstrap.BootstrappedConfig:  cfg,
+		encryption.BootstrappedSecret: secret,
+	}
+	assert.NoError(t, (&Bootstrapper{}).Bootstrap(ctx))
+	assert.NoError(t, ctx[storage.BootstrappedKV].(storage.KV).Set([]byte("key"), []byte("value")))
+	closeDBs(ctx)
+
+	// encrypted dbs can't be opened without the secret
+	ctx = map[string]interface{}{bootstrap.BootstrappedConfig: cfg}
+	assert.Error(t, (&Bootstrapper{}).Bootstrap(ctx))
+
+	ctx[encryption.BootstrappedSecret] = secret
+	assert.NoError(t, (&Bootstrapper{}).Bootstrap(ctx))
+	v, err := ctx[storage.BootstrappedKV].(storage.KV).Get([]byte("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), v)
+	closeDBs(ctx)
+}
@@ -1,46 +1,47 @@
 // +build integration unit
 
-package leveldb
+package backend
 
 import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/storage"
-	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 )
 
-var db *leveldb.DB
-var configdb *leveldb.DB
+var db *DB
+var configdb *DB
 
 func (*Bootstrapper) TestBootstrap(context map[string]interface{}) (err error) {
 	cfg := context[bootstrap.BootstrappedConfig].(config.Configuration)
 
-	crs := GetRandomTestStoragePath()
+	crs := leveldb.GetRandomTestStoragePath()
 	cfg.Set("configStorage.path", crs)
 	log.Info("Set configStorage.path to:", cfg.GetConfigStoragePath())
-	configdb, err = NewLevelDBStorage(cfg.GetConfigStoragePath())
+	configdb, err = Open(cfg.GetStorageBackend(), cfg.GetConfigStoragePath())
 	if err != nil {
-		return errors.New("failed to init config level db: %v", err)
+		return errors.New("failed to init config db: %v", err)
 	}
-	context[storage.BootstrappedConfigDB] = NewLevelDBRepository(configdb)
+	context[storage.BootstrappedConfigDB] = configdb.Repository
 
-	rs := GetRandomTestStoragePath()
+	rs := leveldb.GetRandomTestStoragePath()
 	cfg.Set("storage.Path", rs)
 	log.Info("Set storage.Path to:", cfg.GetStoragePath())
-	db, err = NewLevelDBStorage(cfg.GetStoragePath())
+	db, err = Open(cfg.GetStorageBackend(), cfg.GetStoragePath())
 	if err != nil {
-		return errors.New("failed to init level db: %v", err)
+		return errors.New("failed to init db: %v", err)
 	}
-	log.Infof("Setting levelDb at: %s", cfg.GetStoragePath())
-	context[storage.BootstrappedDB] = NewLevelDBRepository(db)
-	context[BootstrappedLevelDB] = db
+	log.Infof("Setting %s db at: %s", cfg.GetStorageBackend(), cfg.GetStoragePath())
+	context[storage.BootstrappedDB] = db.Repository
+	context[storage.BootstrappedKV] = db.KV
+	context[BootstrappedRawDBs] = map[string]storage.KV{MainDB: db.KV, ConfigDB: configdb.KV}
 	return nil
 }
 
 func (b *Bootstrapper) TestTearDown() error {
 	var err error
-	dbs := []*leveldb.DB{db, configdb}
+	dbs := []*DB{db, configdb}
 	for _, idb := range dbs {
 		if ierr := idb.Close(); ierr != nil {
 			if err == nil {
@@ -0,0 +1,445 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/migration"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/centrifuge/go-centrifuge/storage/encryption"
+	"github.com/centrifuge/go-centrifuge/version"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("backup")
+
+const (
+	// Version is the version of the archive format.
+	Version = 1
+
+	// ErrInvalidArchive must be used when the archive is malformed or doesn't match its manifest
+	ErrInvalidArchive = errors.Error("invalid backup archive")
+
+	// ErrIncompatibleArchive must be used when the archive can't be restored by the node
+	ErrIncompatibleArchive = errors.Error("backup archive is incompatible with the node")
+
+	// ErrDBInUse must be used when restoring over a database opened by a running node
+	ErrDBInUse = errors.Error("database is in use, stop the node before restoring")
+
+	manifestFile = "manifest.json"
+	dbFileSuffix = ".db"
+
+	// maxRecordLength bounds the length of the keys and the values read from an archive.
+	maxRecordLength = 1 << 30
+)
+
+// DB describes a database in the archive.
+type DB struct {
+	Name      string `json:"name"`
+	Entries   int    `json:"entries"`
+	Checksum  string `json:"checksum"`
+	Encrypted bool   `json:"encrypted"`
+}
+
+// Manifest describes the archive. It is the first file of the archive.
+type Manifest struct {
+	Version     int       `json:"version"`
+	NodeVersion string    `json:"node_version"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Migrations are the migrations recorded in the main database.
+	Migrations []string `json:"migrations"`
+	DBs        []DB     `json:"dbs"`
+}
+
+// Write writes a snapshot of each of the dbs, mapped by name, to w.
+// The archive is a gzipped tar holding the manifest and a file per db with the key value pairs of the db.
+// Each db is read from a consistent snapshot, so the dbs can be backed up while the node is running.
+func Write(w io.Writer, dbs map[string]storage.KV) (*Manifest, error) {
+	dir, err := ioutil.TempDir("", "centrifuge-backup")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	m := &Manifest{
+		Version:     Version,
+		NodeVersion: version.GetVersion().String(),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	var names []string
+	for name := range dbs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		db, migrations, err := dump(dbs[name], filepath.Join(dir, name+dbFileSuffix))
+		if err != nil {
+			return nil, errors.New("failed to snapshot %s db: %v", name, err)
+		}
+
+		db.Name = name
+		m.DBs = append(m.DBs, db)
+		if name == backend.MainDB {
+			m.Migrations = migrations
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = tw.WriteHeader(&tar.Header{Name: manifestFile, Mode: 0600, Size: int64(len(data)), ModTime: m.CreatedAt})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = tw.Write(data); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		err = addFile(tw, filepath.Join(dir, name+dbFileSuffix), m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return m, gz.Close()
+}
+
+// dump writes the key value pairs of kv to the file at path.
+// Returns the migrations recorded in kv along with the description of the db.
+func dump(kv storage.KV, path string) (db DB, migrations []string, err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return db, nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	bw := bufio.NewWriter(io.MultiWriter(f, h))
+	ierr := kv.Iterate(nil, func(key, value []byte) {
+		if err != nil {
+			return
+		}
+
+		if id, ok := migration.IDFromKey(key); ok {
+			migrations = append(migrations, id)
+		}
+
+		db.Entries++
+		err = writeRecord(bw, key, value)
+	})
+	if err != nil {
+		return db, nil, err
+	}
+
+	// a failed scan leaves a truncated dump behind
+	if ierr != nil {
+		return db, nil, ierr
+	}
+
+	if err = bw.Flush(); err != nil {
+		return db, nil, err
+	}
+
+	db.Checksum = hex.EncodeToString(h.Sum(nil))
+	db.Encrypted = encryption.IsEncrypted(kv)
+	return db, migrations, nil
+}
+
+func addFile(tw *tar.Writer, path string, modTime time.Time) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(&tar.Header{Name: filepath.Base(path), Mode: 0600, Size: info.Size(), ModTime: modTime})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// writeRecord writes the length prefixed key and value.
+func writeRecord(w io.Writer, key, value []byte) error {
+	for _, b := range [][]byte{key, value} {
+		var l [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(l[:], uint64(len(b)))
+		if _, err := w.Write(l[:n]); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readRecord reads the length prefixed key and value. Returns io.EOF when there are no more records.
+func readRecord(r *bufio.Reader) (key, value []byte, err error) {
+	var kv [2][]byte
+	for i := range kv {
+		l, err := binary.ReadUvarint(r)
+		if err != nil {
+			if i == 0 && err == io.EOF {
+				return nil, nil, io.EOF
+			}
+
+			return nil, nil, errors.NewTypedError(ErrInvalidArchive, err)
+		}
+
+		if l > maxRecordLength {
+			return nil, nil, errors.NewTypedError(ErrInvalidArchive, errors.New("record of %d bytes", l))
+		}
+
+		kv[i] = make([]byte, l)
+		if _, err = io.ReadFull(r, kv[i]); err != nil {
+			return nil, nil, errors.NewTypedError(ErrInvalidArchive, err)
+		}
+	}
+
+	return kv[0], kv[1], nil
+}
+
+// Read reads the archive from r and extracts the db files to dir.
+// The checksums of the db files are verified against the manifest.
+func Read(r io.Reader, dir string) (*Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestFile {
+		return nil, errors.NewTypedError(ErrInvalidArchive, errors.New("manifest missing"))
+	}
+
+	m := new(Manifest)
+	if err = json.NewDecoder(tr).Decode(m); err != nil {
+		return nil, errors.NewTypedError(ErrInvalidArchive, err)
+	}
+
+	if m.Version != Version {
+		return nil, errors.NewTypedError(ErrIncompatibleArchive, errors.New("unsupported archive version %d", m.Version))
+	}
+
+	for _, db := range m.DBs {
+		if db.Name == "" || db.Name != filepath.Base(db.Name) || db.Name == ".." {
+			return nil, errors.NewTypedError(ErrInvalidArchive, errors.New("invalid db name %s", db.Name))
+		}
+	}
+
+	checksums := make(map[string]string)
+	for {
+		hdr, err = tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidArchive, err)
+		}
+
+		db, ok := m.db(hdr.Name)
+		if !ok || checksums[db.Name] != "" {
+			return nil, errors.NewTypedError(ErrInvalidArchive, errors.New("unexpected file %s", hdr.Name))
+		}
+
+		checksums[db.Name], err = extract(tr, filepath.Join(dir, hdr.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, db := range m.DBs {
+		if checksums[db.Name] != db.Checksum {
+			return nil, errors.NewTypedError(ErrInvalidArchive, errors.New("checksum mismatch for %s db", db.Name))
+		}
+	}
+
+	return m, nil
+}
+
+// db returns the db stored in the file.
+func (m *Manifest) db(file string) (DB, bool) {
+	for _, db := range m.DBs {
+		if db.Name+dbFileSuffix == file {
+			return db, true
+		}
+	}
+
+	return DB{}, false
+}
+
+// extract writes r to the file at path and returns the checksum of the content.
+func extract(r io.Reader, path string) (string, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(f, h), r); err != nil {
+		return "", errors.NewTypedError(ErrInvalidArchive, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Restore restores the dbs of the archive read from r at paths, mapped by db name, with the storage backend.
+// The archive is validated against its checksums and against the migrations known to the node before any db is replaced.
+// The replaced dbs are kept next to the restored ones.
+func Restore(r io.Reader, backendName string, paths map[string]string) (*Manifest, error) {
+	dir, err := ioutil.TempDir("", "centrifuge-restore")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	m, err := Read(r, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.DBs) != len(paths) {
+		return nil, errors.NewTypedError(ErrIncompatibleArchive, errors.New("archive holds %d dbs, expected %d", len(m.DBs), len(paths)))
+	}
+
+	for _, db := range m.DBs {
+		if _, ok := paths[db.Name]; !ok {
+			return nil, errors.NewTypedError(ErrIncompatibleArchive, errors.New("unexpected %s db", db.Name))
+		}
+	}
+
+	err = migration.ValidateIDs(m.Migrations)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrIncompatibleArchive, err)
+	}
+
+	for _, db := range m.DBs {
+		if err = ensureNotInUse(backendName, paths[db.Name]); err != nil {
+			return nil, err
+		}
+	}
+
+	// load the dbs next to the current ones so that a failure leaves the current dbs untouched
+	for _, db := range m.DBs {
+		err = load(filepath.Join(dir, db.Name+dbFileSuffix), backendName, restorePath(paths[db.Name]))
+		if err != nil {
+			return nil, errors.New("failed to restore %s db: %v", db.Name, err)
+		}
+	}
+
+	suffix := ".pre-restore-" + time.Now().UTC().Format("20060102150405")
+	for _, db := range m.DBs {
+		path := paths[db.Name]
+		if _, err = os.Stat(path); err == nil {
+			if err = os.Rename(path, path+suffix); err != nil {
+				return nil, err
+			}
+
+			log.Infof("Moved the current %s db to %s", db.Name, path+suffix)
+		}
+
+		if err = os.Rename(restorePath(path), path); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func restorePath(path string) string {
+	return filepath.Clean(path) + ".restore"
+}
+
+// ensureNotInUse errors out if the db at path is opened by a running node.
+func ensureNotInUse(backendName, path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	db, err := backend.Open(backendName, path)
+	if err != nil {
+		return errors.NewTypedError(ErrDBInUse, err)
+	}
+
+	return db.Close()
+}
+
+// load loads the key value pairs in the file into a new db at path.
+func load(file, backendName, path string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = os.RemoveAll(path); err != nil {
+		return err
+	}
+
+	db, err := backend.Open(backendName, path)
+	if err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(f)
+	for {
+		key, value, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+
+		if err == nil {
+			err = db.KV.Set(key, value)
+		}
+
+		if err != nil {
+			if cerr := db.Close(); cerr != nil {
+				log.Warnf("failed to close db: %v", cerr)
+			}
+
+			return err
+		}
+	}
+
+	return db.Close()
+}
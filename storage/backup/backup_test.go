@@ -0,0 +1,193 @@
+// +build unit
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func openDB(t *testing.T, backendName, path string) *backend.DB {
+	db, err := backend.Open(backendName, path)
+	assert.NoError(t, err)
+	return db
+}
+
+func content(t *testing.T, db *backend.DB) map[string]string {
+	c := make(map[string]string)
+	assert.NoError(t, db.KV.Iterate(nil, func(key, value []byte) {
+		c[string(key)] = string(value)
+	}))
+	return c
+}
+
+// writeArchive writes an archive with the manifest and the files.
+func writeArchive(t *testing.T, m *Manifest, files map[string][]byte) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	data, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: manifestFile, Mode: 0600, Size: int64(len(data))}))
+	_, err = tw.Write(data)
+	assert.NoError(t, err)
+	for name, data := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data))}))
+		_, err = tw.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	return buf
+}
+
+func TestWriteRestore(t *testing.T) {
+	for _, backendName := range []string{backend.LevelDB, backend.BoltDB} {
+		t.Run(backendName, func(t *testing.T) {
+			dir := t.TempDir()
+			paths := map[string]string{
+				backend.MainDB:   filepath.Join(dir, "main"),
+				backend.ConfigDB: filepath.Join(dir, "config"),
+			}
+
+			opened := make(map[string]*backend.DB)
+			dbs := make(map[string]storage.KV)
+			expected := make(map[string]map[string]string)
+			for name, path := range paths {
+				db := openDB(t, backendName, path)
+				assert.NoError(t, db.KV.Set([]byte(name+"_key"), []byte(name+"_value")))
+				assert.NoError(t, db.KV.Set([]byte("empty"), nil))
+				opened[name] = db
+				dbs[name] = db.KV
+			}
+			assert.NoError(t, dbs[backend.MainDB].Set([]byte("migration_01KeysToHex"), []byte("{}")))
+			for name, db := range opened {
+				expected[name] = content(t, db)
+			}
+
+			buf := new(bytes.Buffer)
+			m, err := Write(buf, dbs)
+			assert.NoError(t, err)
+			assert.Equal(t, Version, m.Version)
+			assert.Equal(t, []string{"01KeysToHex"}, m.Migrations)
+			assert.Len(t, m.DBs, 2)
+			assert.Equal(t, backend.ConfigDB, m.DBs[0].Name)
+			assert.Equal(t, 2, m.DBs[0].Entries)
+			assert.False(t, m.DBs[0].Encrypted)
+
+			// restore over modified dbs
+			for name, db := range opened {
+				assert.NoError(t, db.KV.Set([]byte("new"), []byte("value")))
+				assert.NoError(t, db.KV.Delete([]byte(name+"_key")))
+				assert.NoError(t, db.Close())
+			}
+
+			archive := buf.Bytes()
+			rm, err := Restore(bytes.NewReader(archive), backendName, paths)
+			assert.NoError(t, err)
+			assert.Equal(t, m.DBs, rm.DBs)
+			for name, path := range paths {
+				db := openDB(t, backendName, path)
+				assert.Equal(t, expected[name], content(t, db))
+				assert.NoError(t, db.Close())
+
+				// the replaced db is kept
+				replaced, err := filepath.Glob(path + ".pre-restore-*")
+				assert.NoError(t, err)
+				assert.Len(t, replaced, 1)
+			}
+
+			// restore into an empty dir
+			dir = t.TempDir()
+			for name := range paths {
+				paths[name] = filepath.Join(dir, name)
+			}
+			_, err = Restore(bytes.NewReader(archive), backendName, paths)
+			assert.NoError(t, err)
+			db := openDB(t, backendName, paths[backend.ConfigDB])
+			assert.Equal(t, expected[backend.ConfigDB], content(t, db))
+			assert.NoError(t, db.Close())
+		})
+	}
+}
+
+func TestRestore_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	paths := map[string]string{backend.MainDB: filepath.Join(dir, "main")}
+	restore := func(m *Manifest, files map[string][]byte) error {
+		_, err := Restore(writeArchive(t, m, files), backend.LevelDB, paths)
+		return err
+	}
+
+	// not an archive
+	_, err := Restore(bytes.NewReader([]byte("backup")), backend.LevelDB, paths)
+	assert.True(t, errors.IsOfType(ErrInvalidArchive, err))
+
+	// unsupported version
+	err = restore(&Manifest{Version: Version + 1}, nil)
+	assert.True(t, errors.IsOfType(ErrIncompatibleArchive, err))
+
+	// invalid db name
+	err = restore(&Manifest{Version: Version, DBs: []DB{{Name: "../main"}}}, nil)
+	assert.True(t, errors.IsOfType(ErrInvalidArchive, err))
+
+	// checksum mismatch
+	var rec bytes.Buffer
+	assert.NoError(t, writeRecord(&rec, []byte("key"), []byte("value")))
+	files := map[string][]byte{backend.MainDB + dbFileSuffix: rec.Bytes()}
+	err = restore(&Manifest{Version: Version, DBs: []DB{{Name: backend.MainDB, Checksum: "checksum"}}}, files)
+	assert.True(t, errors.IsOfType(ErrInvalidArchive, err))
+
+	// unexpected file
+	err = restore(&Manifest{Version: Version}, files)
+	assert.True(t, errors.IsOfType(ErrInvalidArchive, err))
+
+	// unknown db
+	var m *Manifest
+	buf := new(bytes.Buffer)
+	db := openDB(t, backend.LevelDB, filepath.Join(dir, "other"))
+	m, err = Write(buf, map[string]storage.KV{"other": db.KV})
+	assert.NoError(t, err)
+	_, err = Restore(buf, backend.LevelDB, paths)
+	assert.True(t, errors.IsOfType(ErrIncompatibleArchive, err))
+
+	// unknown migration
+	assert.NoError(t, db.KV.Set([]byte("migration_99Unknown.go"), []byte("{}")))
+	buf.Reset()
+	m, err = Write(buf, map[string]storage.KV{backend.MainDB: db.KV})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"99Unknown.go"}, m.Migrations)
+	assert.NoError(t, db.Close())
+	_, err = Restore(buf, backend.LevelDB, paths)
+	assert.True(t, errors.IsOfType(ErrIncompatibleArchive, err))
+	_, err = os.Stat(paths[backend.MainDB])
+	assert.True(t, os.IsNotExist(err))
+}
+
+// failingKV fails the scan after the first key.
+type failingKV struct {
+	storage.KV
+}
+
+func (f failingKV) Iterate(prefix []byte, fn func(key, value []byte)) error {
+	fn([]byte("key"), []byte("value"))
+	return errors.New("scan failed")
+}
+
+func TestWrite_IterateError(t *testing.T) {
+	buf := new(bytes.Buffer)
+	m, err := Write(buf, map[string]storage.KV{backend.MainDB: failingKV{}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "scan failed")
+	assert.Nil(t, m)
+}
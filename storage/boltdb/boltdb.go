@@ -0,0 +1,176 @@
+package boltdb
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	logging "github.com/ipfs/go-log"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// ErrKeyNotFound must be used when the key is not found in the db
+	ErrKeyNotFound = errors.Error("key not found")
+
+	// dbFile is the name of the db file in the storage path.
+	dbFile = "centrifuge.db"
+
+	// openTimeout is the time to wait for the file lock held by another process.
+	openTimeout = 5 * time.Second
+)
+
+var (
+	log = logging.Logger("storage")
+
+	// bucket holds all the keys.
+	bucket = []byte("centrifuge")
+)
+
+// NewBoltDBStorage opens the BoltDB in the path.
+// Like the levelDB path, the path is a directory that holds the db file.
+func NewBoltDBStorage(path string) (*bolt.DB, error) {
+	err := os.MkdirAll(path, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(filepath.Join(path, dbFile), 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// boltDBRepo implements Repository using BoltDB as storage layer
+type boltDBRepo struct {
+	db    *bolt.DB
+	types *storage.ModelTypes
+}
+
+// NewBoltDBRepository returns BoltDB implementation of Repository
+func NewBoltDBRepository(db *bolt.DB) storage.Repository {
+	return &boltDBRepo{
+		db:    db,
+		types: storage.NewModelTypes(),
+	}
+}
+
+// Register registers the model so that the DB can return the model without knowing the type
+func (b *boltDBRepo) Register(model storage.Model) {
+	b.types.Register(model)
+}
+
+// get returns a copy of the value of the key and true if the key exists.
+func (b *boltDBRepo) get(key []byte) (data []byte, found bool, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucket).Get(key)
+		if v == nil {
+			return nil
+		}
+
+		found = true
+		data = append([]byte{}, v...)
+		return nil
+	})
+
+	return data, found, err
+}
+
+// Exists checks whether the key exists in db
+func (b *boltDBRepo) Exists(key []byte) bool {
+	_, found, err := b.get(key)
+	return err == nil && found
+}
+
+// Get retrieves model by key, otherwise returns error
+func (b *boltDBRepo) Get(key []byte) (storage.Model, error) {
+	data, found, err := b.get(key)
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
+	}
+
+	if !found {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, ErrKeyNotFound)
+	}
+
+	return b.types.DecodeModel(data)
+}
+
+// GetAllByPrefix returns all models which keys match the provided prefix
+// If an error is found parsing one of the matched models, logs warning and continues
+func (b *boltDBRepo) GetAllByPrefix(prefix string) ([]storage.Model, error) {
+	var values [][]byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		iterate(tx, []byte(prefix), func(_, v []byte) {
+			values = append(values, append([]byte{}, v...))
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var models []storage.Model
+	for _, data := range values {
+		model, err := b.types.DecodeModel(data)
+		if err != nil {
+			log.Warnf("Error parsing model: %v", err)
+			continue
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+// Create creates a model indexed by the key provided
+// errors out if key already exists
+func (b *boltDBRepo) Create(key []byte, model storage.Model) error {
+	return storage.RunTransaction(b, func(tx storage.Transaction) error {
+		return tx.Create(key, model)
+	})
+}
+
+// Update updates a model indexed by the key provided
+// errors out if key doesn't exists
+func (b *boltDBRepo) Update(key []byte, model storage.Model) error {
+	return storage.RunTransaction(b, func(tx storage.Transaction) error {
+		return tx.Update(key, model)
+	})
+}
+
+// Delete deletes a model, along with its index entries, by the key provided
+func (b *boltDBRepo) Delete(key []byte) error {
+	batch := b.NewBatch()
+	batch.Delete(key)
+	return batch.Write()
+}
+
+// Close closes the database
+func (b *boltDBRepo) Close() error {
+	return b.db.Close()
+}
+
+// iterate calls f for each key with the prefix.
+// key and value are only valid while the transaction is open.
+func iterate(tx *bolt.Tx, prefix []byte, f func(key, value []byte)) {
+	c := tx.Bucket(bucket).Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		f(k, v)
+	}
+}
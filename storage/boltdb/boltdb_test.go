@@ -0,0 +1,39 @@
+// +build unit
+
+package boltdb
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoltDB_Conformance(t *testing.T) {
+	storage.RunConformanceTests(t, func(t *testing.T) (storage.Repository, storage.KV) {
+		db, err := NewBoltDBStorage(t.TempDir())
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, db.Close())
+		})
+
+		return NewBoltDBRepository(db), NewBoltDBKV(db)
+	})
+}
+
+func TestNewBoltDBStorage(t *testing.T) {
+	path := t.TempDir()
+	db, err := NewBoltDBStorage(path)
+	assert.NoError(t, err)
+
+	// reopen keeps the data
+	repo := NewBoltDBRepository(db)
+	assert.NoError(t, NewBoltDBKV(db).Set([]byte("key"), []byte("value")))
+	assert.NoError(t, repo.Close())
+	db, err = NewBoltDBStorage(path)
+	assert.NoError(t, err)
+	defer db.Close()
+	v, err := NewBoltDBKV(db).Get([]byte("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), v)
+}
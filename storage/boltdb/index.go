@@ -0,0 +1,94 @@
+package boltdb
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	bolt "go.etcd.io/bbolt"
+)
+
+// writeIndexes replaces the index entries of the key with entries.
+func writeIndexes(b *bolt.Bucket, key []byte, entries [][]byte) error {
+	ikey := storage.IndexedKeysKey(key)
+	var old [][]byte
+	if data := b.Get(ikey); data != nil {
+		var err error
+		old, err = storage.DecodeIndexEntries(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, e := range old {
+		if err := b.Delete(e); err != nil {
+			return errors.NewTypedError(storage.ErrRepositoryIndex, err)
+		}
+	}
+
+	if len(entries) == 0 {
+		if len(old) == 0 {
+			return nil
+		}
+
+		return b.Delete(ikey)
+	}
+
+	data, err := storage.EncodeIndexEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if err := b.Put(e, key); err != nil {
+			return errors.NewTypedError(storage.ErrRepositoryIndex, err)
+		}
+	}
+
+	return b.Put(ikey, data)
+}
+
+// GetKeysByIndex returns the keys of the models with the indexed value equal to value.
+func (b *boltDBRepo) GetKeysByIndex(index string, value []byte) ([][]byte, error) {
+	p := storage.IndexValuePrefix(index, value, true)
+	return b.getIndexedKeys(p, p, nil)
+}
+
+// GetKeysByIndexRange returns the keys of the models with the indexed value in the range [start, end).
+// Keys are ordered by the indexed value. A nil start or end leaves the range unbounded on that side.
+func (b *boltDBRepo) GetKeysByIndexRange(index string, start, end []byte) ([][]byte, error) {
+	p := storage.IndexNamePrefix(index)
+	from := p
+	if start != nil {
+		from = storage.IndexValuePrefix(index, start, false)
+	}
+
+	var limit []byte
+	if end != nil {
+		limit = storage.IndexValuePrefix(index, end, true)
+	}
+
+	return b.getIndexedKeys(p, from, limit)
+}
+
+// getIndexedKeys returns the values of the entries with the prefix, from the entry from until limit, excluded.
+func (b *boltDBRepo) getIndexedKeys(prefix, from, limit []byte) ([][]byte, error) {
+	var keys [][]byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		for k, v := c.Seek(from); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if limit != nil && bytes.Compare(k, limit) >= 0 {
+				break
+			}
+
+			keys = append(keys, append([]byte{}, v...))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrRepositoryIndex, err)
+	}
+
+	return keys, nil
+}
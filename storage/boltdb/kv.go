@@ -0,0 +1,67 @@
+package boltdb
+
+import (
+	"github.com/centrifuge/go-centrifuge/storage"
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltDBKV implements storage.KV using BoltDB as storage layer
+type boltDBKV struct {
+	db *bolt.DB
+}
+
+// NewBoltDBKV returns BoltDB implementation of KV
+func NewBoltDBKV(db *bolt.DB) storage.KV {
+	return boltDBKV{db: db}
+}
+
+// Get returns the value of the key.
+func (b boltDBKV) Get(key []byte) (value []byte, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucket).Get(key)
+		if v == nil {
+			return ErrKeyNotFound
+		}
+
+		value = append([]byte{}, v...)
+		return nil
+	})
+
+	return value, err
+}
+
+// Set sets the value of the key.
+func (b boltDBKV) Set(key, value []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put(key, value)
+	})
+}
+
+// Delete deletes the key.
+func (b boltDBKV) Delete(key []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete(key)
+	})
+}
+
+// Iterate calls f for each key with the prefix.
+// The keys are copied before calling f since BoltDB doesn't allow writes while a read transaction is open.
+func (b boltDBKV) Iterate(prefix []byte, f func(key, value []byte)) error {
+	var keys, values [][]byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		iterate(tx, prefix, func(k, v []byte) {
+			keys = append(keys, append([]byte{}, k...))
+			values = append(values, append([]byte{}, v...))
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range keys {
+		f(keys[i], values[i])
+	}
+
+	return nil
+}
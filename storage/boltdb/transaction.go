@@ -0,0 +1,188 @@
+package boltdb
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	bolt "go.etcd.io/bbolt"
+)
+
+// apply applies the writes, along with their index entries, to the bucket.
+func apply(b *bolt.Bucket, w *storage.Writes) error {
+	return w.Range(func(key []byte, wr *storage.Write) error {
+		err := writeIndexes(b, key, wr.Entries)
+		if err != nil {
+			return err
+		}
+
+		if wr.Deleted {
+			err = b.Delete(key)
+		} else {
+			err = b.Put(key, wr.Data)
+		}
+
+		if err != nil {
+			return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+		}
+
+		return nil
+	})
+}
+
+// boltDBBatch implements storage.Batch.
+type boltDBBatch struct {
+	db     *bolt.DB
+	writes storage.Writes
+}
+
+// NewBatch returns an empty Batch.
+func (b *boltDBRepo) NewBatch() storage.Batch {
+	return &boltDBBatch{db: b.db, writes: storage.NewWrites()}
+}
+
+// Put sets the model at the key, replacing the existing model if any.
+func (b *boltDBBatch) Put(key []byte, model storage.Model) error {
+	return b.writes.Put(key, model)
+}
+
+// Delete deletes the key.
+func (b *boltDBBatch) Delete(key []byte) {
+	b.writes.Delete(key)
+}
+
+// Len returns the number of keys written by the batch.
+func (b *boltDBBatch) Len() int {
+	return b.writes.Len()
+}
+
+// Write applies all the writes of the batch atomically.
+func (b *boltDBBatch) Write() error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return apply(tx.Bucket(bucket), &b.writes)
+	})
+}
+
+// read is the value of a key as seen by the transaction.
+type read struct {
+	data  []byte
+	found bool
+}
+
+// boltDBTransaction implements storage.Transaction.
+// Each read is served by a short read transaction and recorded, and the commit validates all the reads
+// in the write transaction. BoltDB doesn't allow a goroutine to hold a read transaction while writing,
+// so the reads are not served from a single snapshot.
+type boltDBTransaction struct {
+	b      *boltDBRepo
+	reads  map[string]read
+	writes storage.Writes
+	done   bool
+}
+
+// NewTransaction starts a Transaction.
+func (b *boltDBRepo) NewTransaction() (storage.Transaction, error) {
+	return &boltDBTransaction{
+		b:      b,
+		reads:  make(map[string]read),
+		writes: storage.NewWrites(),
+	}, nil
+}
+
+// read returns the value of the key, looking up the pending writes first.
+func (t *boltDBTransaction) read(key []byte) (read, error) {
+	if t.done {
+		return read{}, storage.ErrTransactionDone
+	}
+
+	if wr, ok := t.writes.Get(key); ok {
+		return read{data: wr.Data, found: !wr.Deleted}, nil
+	}
+
+	if r, ok := t.reads[string(key)]; ok {
+		return r, nil
+	}
+
+	data, found, err := t.b.get(key)
+	if err != nil {
+		return read{}, err
+	}
+
+	r := read{data: data, found: found}
+	t.reads[string(key)] = r
+	return r, nil
+}
+
+// Exists checks whether the key exists.
+func (t *boltDBTransaction) Exists(key []byte) bool {
+	r, err := t.read(key)
+	return err == nil && r.found
+}
+
+// Get retrieves the model by the key.
+func (t *boltDBTransaction) Get(key []byte) (storage.Model, error) {
+	r, err := t.read(key)
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
+	}
+
+	if !r.found {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, ErrKeyNotFound)
+	}
+
+	return t.b.types.DecodeModel(r.data)
+}
+
+// Create creates the model at the key. errors out if key already exists.
+func (t *boltDBTransaction) Create(key []byte, model storage.Model) error {
+	if t.Exists(key) {
+		return storage.ErrRepositoryModelCreateKeyExists
+	}
+
+	return t.writes.Put(key, model)
+}
+
+// Update updates the model at the key. errors out if key doesn't exist.
+func (t *boltDBTransaction) Update(key []byte, model storage.Model) error {
+	if !t.Exists(key) {
+		return storage.ErrRepositoryModelUpdateKeyNotFound
+	}
+
+	return t.writes.Put(key, model)
+}
+
+// Delete deletes the key.
+func (t *boltDBTransaction) Delete(key []byte) error {
+	if t.done {
+		return storage.ErrTransactionDone
+	}
+
+	t.writes.Delete(key)
+	return nil
+}
+
+// Commit applies the writes atomically.
+// Returns ErrTransactionConflict if any key read by the transaction was modified since it was read.
+func (t *boltDBTransaction) Commit() error {
+	if t.done {
+		return storage.ErrTransactionDone
+	}
+	defer t.Discard()
+
+	return t.b.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		for k, r := range t.reads {
+			data := b.Get([]byte(k))
+			if r.found != (data != nil) || !bytes.Equal(r.data, data) {
+				return storage.ErrTransactionConflict
+			}
+		}
+
+		return apply(b, &t.writes)
+	})
+}
+
+// Discard drops the writes.
+func (t *boltDBTransaction) Discard() {
+	t.done = true
+}
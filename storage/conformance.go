@@ -0,0 +1,298 @@
+// +build integration unit
+
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type conformanceDoc struct {
+	ID    []byte `json:"id"`
+	Value string `json:"value"`
+	Owner []byte `json:"owner"`
+	Order []byte `json:"order"`
+}
+
+func (m *conformanceDoc) JSON() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *conformanceDoc) FromJSON(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (m *conformanceDoc) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
+
+func (m *conformanceDoc) Indexes() map[string][]byte {
+	return map[string][]byte{"owner": m.Owner, "order": m.Order}
+}
+
+// RunConformanceTests runs the tests that every Repository and KV implementation must pass.
+// newDB must return an empty Repository and a KV sharing the same db.
+func RunConformanceTests(t *testing.T, newDB func(t *testing.T) (Repository, KV)) {
+	tests := map[string]func(t *testing.T, repo Repository, kv KV){
+		"Repository":          testRepository,
+		"GetKeysByIndex":      testGetKeysByIndex,
+		"GetKeysByIndexRange": testGetKeysByIndexRange,
+		"Batch":               testBatch,
+		"Transaction":         testTransaction,
+		"TransactionConflict": testTransactionConflict,
+		"KV":                  testKV,
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			repo, kv := newDB(t)
+			repo.Register(new(conformanceDoc))
+			test(t, repo, kv)
+		})
+	}
+}
+
+func testRepository(t *testing.T, repo Repository, _ KV) {
+	key := []byte("doc_1")
+	assert.False(t, repo.Exists(key))
+	_, err := repo.Get(key)
+	assert.True(t, errors.IsOfType(ErrModelRepositoryNotFound, err))
+	err = repo.Update(key, &conformanceDoc{})
+	assert.True(t, errors.IsOfType(ErrRepositoryModelUpdateKeyNotFound, err))
+
+	d := &conformanceDoc{ID: []byte{1}, Value: "1"}
+	assert.NoError(t, repo.Create(key, d))
+	assert.True(t, repo.Exists(key))
+	err = repo.Create(key, d)
+	assert.True(t, errors.IsOfType(ErrRepositoryModelCreateKeyExists, err))
+	m, err := repo.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, d, m)
+
+	d.Value = "2"
+	assert.NoError(t, repo.Update(key, d))
+	m, err = repo.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, d, m)
+
+	assert.NoError(t, repo.Create([]byte("doc_2"), &conformanceDoc{Value: "3"}))
+	assert.NoError(t, repo.Create([]byte("dod_1"), &conformanceDoc{Value: "4"}))
+	models, err := repo.GetAllByPrefix("doc_")
+	assert.NoError(t, err)
+	assert.Len(t, models, 2)
+	assert.Equal(t, "2", models[0].(*conformanceDoc).Value)
+	assert.Equal(t, "3", models[1].(*conformanceDoc).Value)
+
+	assert.NoError(t, repo.Delete(key))
+	assert.False(t, repo.Exists(key))
+	assert.NoError(t, repo.Delete(key))
+}
+
+func testGetKeysByIndex(t *testing.T, repo Repository, _ KV) {
+	keys, err := repo.GetKeysByIndex("owner", []byte{1})
+	assert.NoError(t, err)
+	assert.Len(t, keys, 0)
+
+	assert.NoError(t, repo.Create([]byte("key_1"), &conformanceDoc{ID: []byte{1}, Owner: []byte{1}, Order: []byte{1}}))
+	assert.NoError(t, repo.Create([]byte("key_2"), &conformanceDoc{ID: []byte{2}, Owner: []byte{1}, Order: []byte{2}}))
+	assert.NoError(t, repo.Create([]byte("key_3"), &conformanceDoc{ID: []byte{3}, Owner: []byte{1, 1}, Order: []byte{3}}))
+
+	// exact value should not match values it is a prefix of
+	keys, err = repo.GetKeysByIndex("owner", []byte{1})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("key_1"), []byte("key_2")}, keys)
+
+	// update removes the stale entries
+	assert.NoError(t, repo.Update([]byte("key_2"), &conformanceDoc{ID: []byte{2}, Owner: []byte{2}, Order: []byte{2}}))
+	keys, err = repo.GetKeysByIndex("owner", []byte{1})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("key_1")}, keys)
+	keys, err = repo.GetKeysByIndex("owner", []byte{2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("key_2")}, keys)
+
+	// delete removes the entries
+	assert.NoError(t, repo.Delete([]byte("key_1")))
+	keys, err = repo.GetKeysByIndex("owner", []byte{1})
+	assert.NoError(t, err)
+	assert.Len(t, keys, 0)
+	assert.False(t, repo.Exists([]byte("indexed_keys_key_1")))
+}
+
+func testGetKeysByIndexRange(t *testing.T, repo Repository, _ KV) {
+	for i := byte(1); i <= 5; i++ {
+		key := []byte{'k', i}
+		assert.NoError(t, repo.Create(key, &conformanceDoc{ID: []byte{i}, Owner: []byte{1}, Order: []byte{0, i}}))
+	}
+
+	keys, err := repo.GetKeysByIndexRange("order", []byte{0, 2}, []byte{0, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{'k', 2}, {'k', 3}}, keys)
+
+	keys, err = repo.GetKeysByIndexRange("order", nil, []byte{0, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{'k', 1}, {'k', 2}}, keys)
+
+	keys, err = repo.GetKeysByIndexRange("order", []byte{0, 4}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{'k', 4}, {'k', 5}}, keys)
+
+	// other indexes are not part of the range
+	keys, err = repo.GetKeysByIndexRange("order", nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 5)
+}
+
+func testBatch(t *testing.T, repo Repository, _ KV) {
+	assert.NoError(t, repo.Create([]byte("key_1"), &conformanceDoc{ID: []byte{1}, Owner: []byte{1}}))
+
+	b := repo.NewBatch()
+	assert.NoError(t, b.Put([]byte("key_2"), &conformanceDoc{ID: []byte{2}, Owner: []byte{1}}))
+	assert.NoError(t, b.Put([]byte("key_3"), &conformanceDoc{ID: []byte{3}, Owner: []byte{1}}))
+	b.Delete([]byte("key_1"))
+	assert.Equal(t, 3, b.Len())
+
+	// nothing is written before Write
+	assert.False(t, repo.Exists([]byte("key_2")))
+	assert.NoError(t, b.Write())
+	assert.False(t, repo.Exists([]byte("key_1")))
+	assert.True(t, repo.Exists([]byte("key_2")))
+	assert.True(t, repo.Exists([]byte("key_3")))
+	keys, err := repo.GetKeysByIndex("owner", []byte{1})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("key_2"), []byte("key_3")}, keys)
+
+	// last write to a key wins
+	b = repo.NewBatch()
+	assert.NoError(t, b.Put([]byte("key_4"), &conformanceDoc{ID: []byte{4}, Owner: []byte{1}}))
+	assert.NoError(t, b.Put([]byte("key_4"), &conformanceDoc{ID: []byte{4}, Owner: []byte{2}}))
+	assert.Equal(t, 1, b.Len())
+	assert.NoError(t, b.Write())
+	keys, err = repo.GetKeysByIndex("owner", []byte{2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("key_4")}, keys)
+}
+
+func testTransaction(t *testing.T, repo Repository, _ KV) {
+	assert.NoError(t, repo.Create([]byte("key_1"), &conformanceDoc{Value: "1"}))
+
+	// reads see the writes of the transaction
+	tx, err := repo.NewTransaction()
+	assert.NoError(t, err)
+	assert.True(t, tx.Exists([]byte("key_1")))
+	err = tx.Create([]byte("key_1"), &conformanceDoc{})
+	assert.True(t, errors.IsOfType(ErrRepositoryModelCreateKeyExists, err))
+	assert.NoError(t, tx.Create([]byte("key_2"), &conformanceDoc{Value: "2"}))
+	m, err := tx.Get([]byte("key_2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "2", m.(*conformanceDoc).Value)
+	assert.NoError(t, tx.Delete([]byte("key_1")))
+	assert.False(t, tx.Exists([]byte("key_1")))
+	err = tx.Update([]byte("key_1"), &conformanceDoc{})
+	assert.True(t, errors.IsOfType(ErrRepositoryModelUpdateKeyNotFound, err))
+
+	// nothing is written before Commit
+	assert.False(t, repo.Exists([]byte("key_2")))
+	assert.NoError(t, tx.Commit())
+	assert.False(t, repo.Exists([]byte("key_1")))
+	assert.True(t, repo.Exists([]byte("key_2")))
+	assert.True(t, errors.IsOfType(ErrTransactionDone, tx.Commit()))
+	_, err = tx.Get([]byte("key_2"))
+	assert.Error(t, err)
+
+	// discarded
+	tx, err = repo.NewTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Create([]byte("key_3"), &conformanceDoc{}))
+	tx.Discard()
+	assert.True(t, errors.IsOfType(ErrTransactionDone, tx.Commit()))
+	assert.False(t, repo.Exists([]byte("key_3")))
+}
+
+func testTransactionConflict(t *testing.T, repo Repository, _ KV) {
+	assert.NoError(t, repo.Create([]byte("key_1"), &conformanceDoc{Value: "1"}))
+
+	// key read by the transaction is updated
+	tx, err := repo.NewTransaction()
+	assert.NoError(t, err)
+	m, err := tx.Get([]byte("key_1"))
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Update([]byte("key_1"), &conformanceDoc{Value: m.(*conformanceDoc).Value + "1"}))
+	assert.NoError(t, repo.Update([]byte("key_1"), &conformanceDoc{Value: "2"}))
+	err = tx.Commit()
+	assert.True(t, errors.IsOfType(ErrTransactionConflict, err))
+	m, err = repo.Get([]byte("key_1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "2", m.(*conformanceDoc).Value)
+
+	// missing key read by the transaction is created
+	tx, err = repo.NewTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Create([]byte("key_2"), &conformanceDoc{Value: "1"}))
+	assert.NoError(t, repo.Create([]byte("key_2"), &conformanceDoc{Value: "2"}))
+	assert.True(t, errors.IsOfType(ErrTransactionConflict, tx.Commit()))
+
+	// keys written without a read don't conflict
+	tx, err = repo.NewTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Delete([]byte("key_2")))
+	assert.NoError(t, repo.Update([]byte("key_2"), &conformanceDoc{Value: "3"}))
+	assert.NoError(t, tx.Commit())
+	assert.False(t, repo.Exists([]byte("key_2")))
+
+	// RunTransaction retries on conflict
+	var runs int
+	err = RunTransaction(repo, func(tx Transaction) error {
+		runs++
+		m, err := tx.Get([]byte("key_1"))
+		if err != nil {
+			return err
+		}
+
+		if runs == 1 {
+			assert.NoError(t, repo.Update([]byte("key_1"), &conformanceDoc{Value: "3"}))
+		}
+
+		return tx.Update([]byte("key_1"), &conformanceDoc{Value: m.(*conformanceDoc).Value + "1"})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, runs)
+	m, err = repo.Get([]byte("key_1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "31", m.(*conformanceDoc).Value)
+}
+
+func testKV(t *testing.T, repo Repository, kv KV) {
+	_, err := kv.Get([]byte("raw_1"))
+	assert.Error(t, err)
+
+	assert.NoError(t, kv.Set([]byte("raw_1"), []byte("1")))
+	assert.NoError(t, kv.Set([]byte("raw_2"), []byte("2")))
+	assert.NoError(t, kv.Set([]byte("raw_3"), []byte("3")))
+	assert.NoError(t, kv.Set([]byte("rax_1"), []byte("4")))
+	v, err := kv.Get([]byte("raw_1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), v)
+
+	// iterate in key order and modify the store from f
+	var keys, values []string
+	assert.NoError(t, kv.Iterate([]byte("raw_"), func(key, value []byte) {
+		keys = append(keys, string(key))
+		values = append(values, string(value))
+		assert.NoError(t, kv.Delete(key))
+	}))
+	assert.Equal(t, []string{"raw_1", "raw_2", "raw_3"}, keys)
+	assert.Equal(t, []string{"1", "2", "3"}, values)
+	_, err = kv.Get([]byte("raw_1"))
+	assert.Error(t, err)
+
+	// repository shares the db
+	assert.NoError(t, repo.Create([]byte("doc_1"), &conformanceDoc{Value: "1"}))
+	_, err = kv.Get([]byte("doc_1"))
+	assert.NoError(t, err)
+}
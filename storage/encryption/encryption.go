@@ -0,0 +1,320 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	logging "github.com/ipfs/go-log"
+	"golang.org/x/crypto/scrypt"
+)
+
+var log = logging.Logger("storage")
+
+const (
+	// BootstrappedSecret is a key mapped to the Secret the databases are encrypted with at boot
+	BootstrappedSecret = "BootstrappedSecret"
+
+	// ErrInvalidKey must be used when the secret doesn't match the key the database is encrypted with
+	ErrInvalidKey = errors.Error("invalid encryption key")
+
+	// ErrInvalidSecret must be used when the passphrase or the key file can't be used
+	ErrInvalidSecret = errors.Error("invalid encryption secret")
+
+	// ErrNotEncrypted must be used when a secret is supplied for a database holding plaintext data
+	ErrNotEncrypted = errors.Error("database is not encrypted, run the migrations with the secret to encrypt it")
+
+	// ErrEncrypted must be used when an encrypted database is opened without a secret
+	ErrEncrypted = errors.Error("database is encrypted, a passphrase or a key file is required")
+
+	// ErrDecryption must be used when a value can't be decrypted
+	ErrDecryption = errors.Error("failed to decrypt the value")
+)
+
+const (
+	version = 1
+
+	// keyLength is the length of the master and the data keys.
+	keyLength = 32
+
+	// metaKey is the plaintext entry holding the parameters of the master key.
+	metaKey = "encryption_meta"
+
+	kdfNone   = "none"
+	kdfScrypt = "scrypt"
+
+	// scrypt parameters recommended for interactive logins.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// checkValue is sealed with the master key so that an invalid key is detected when the database is opened.
+var checkValue = []byte("centrifuge")
+
+// Secret is the passphrase or the key the master key of a database is derived from.
+type Secret struct {
+	passphrase []byte
+	key        []byte
+}
+
+// NewPassphraseSecret returns a Secret deriving the master keys from the passphrase.
+func NewPassphraseSecret(passphrase string) (*Secret, error) {
+	if passphrase == "" {
+		return nil, errors.NewTypedError(ErrInvalidSecret, errors.New("empty passphrase"))
+	}
+
+	return &Secret{passphrase: []byte(passphrase)}, nil
+}
+
+// NewKeySecret returns a Secret using the key as the master key.
+// The key must be 32 bytes long, raw or hex encoded.
+func NewKeySecret(key []byte) (*Secret, error) {
+	if hk := bytes.TrimSpace(key); len(hk) == hex.EncodedLen(keyLength) {
+		d := make([]byte, keyLength)
+		if _, err := hex.Decode(d, hk); err == nil {
+			key = d
+		}
+	}
+
+	if len(key) != keyLength {
+		return nil, errors.NewTypedError(ErrInvalidSecret, errors.New("key must be %d bytes long", keyLength))
+	}
+
+	return &Secret{key: key}, nil
+}
+
+// LoadSecret returns the Secret from the passphrase or the key file.
+// Returns nil if neither is supplied.
+func LoadSecret(passphrase, keyFile string) (*Secret, error) {
+	switch {
+	case passphrase != "" && keyFile != "":
+		return nil, errors.NewTypedError(ErrInvalidSecret, errors.New("both passphrase and key file supplied"))
+	case passphrase != "":
+		return NewPassphraseSecret(passphrase)
+	case keyFile != "":
+		key, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidSecret, err)
+		}
+
+		return NewKeySecret(key)
+	default:
+		return nil, nil
+	}
+}
+
+// meta holds the parameters of the master key of a database.
+type meta struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt,omitempty"`
+	Check   []byte `json:"check"`
+}
+
+// Cipher encrypts the values of a database with envelope encryption.
+// Each value is encrypted with a random data key, which is stored encrypted with the master key next to the value.
+type Cipher struct {
+	master cipher.AEAD
+}
+
+// IsEncrypted returns true if the database behind kv is encrypted.
+func IsEncrypted(kv storage.KV) bool {
+	_, err := kv.Get([]byte(metaKey))
+	return err == nil
+}
+
+// Open returns the Cipher of the database behind kv.
+// An empty database is initialised for encryption with secret.
+// Errors out if the secret doesn't match or the database holds plaintext data.
+func Open(kv storage.KV, secret *Secret) (*Cipher, error) {
+	data, err := kv.Get([]byte(metaKey))
+	if err != nil {
+		empty, err := isEmpty(kv)
+		if err != nil {
+			return nil, err
+		}
+
+		if !empty {
+			return nil, ErrNotEncrypted
+		}
+
+		return initialise(kv, secret)
+	}
+
+	m := new(meta)
+	err = json.Unmarshal(data, m)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidKey, errors.New("failed to unmarshal meta: %v", err))
+	}
+
+	c, err := newCipher(secret, m)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := c.decrypt(m.Check)
+	if err != nil || !bytes.Equal(check, checkValue) {
+		return nil, ErrInvalidKey
+	}
+
+	return c, nil
+}
+
+// initialise stores the parameters of the master key derived from secret.
+func initialise(kv storage.KV, secret *Secret) (*Cipher, error) {
+	m := &meta{Version: version, KDF: kdfNone}
+	if secret.key == nil {
+		m.KDF = kdfScrypt
+		m.Salt = make([]byte, keyLength)
+		if _, err := io.ReadFull(rand.Reader, m.Salt); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := newCipher(secret, m)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Check, err = c.encrypt(checkValue)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, kv.Set([]byte(metaKey), data)
+}
+
+// isEmpty returns true if kv holds no keys.
+func isEmpty(kv storage.KV) (bool, error) {
+	empty := true
+	err := kv.Iterate(nil, func(_, _ []byte) {
+		empty = false
+	})
+
+	return empty, err
+}
+
+func newCipher(secret *Secret, m *meta) (*Cipher, error) {
+	if m.Version != version {
+		return nil, errors.NewTypedError(ErrInvalidKey, errors.New("unsupported version %d", m.Version))
+	}
+
+	var key []byte
+	switch {
+	case m.KDF == kdfNone && secret.key != nil:
+		key = secret.key
+	case m.KDF == kdfScrypt && secret.passphrase != nil:
+		var err error
+		key, err = scrypt.Key(secret.passphrase, m.Salt, scryptN, scryptR, scryptP, keyLength)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidKey, err)
+		}
+	default:
+		return nil, errors.NewTypedError(ErrInvalidKey, errors.New("database is encrypted with a %s key", m.KDF))
+	}
+
+	master, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{master: master}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(b)
+}
+
+// seal encrypts data with aead. The nonce is prepended to the cipher text.
+func seal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// unseal decrypts the data sealed with aead.
+func unseal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrDecryption
+	}
+
+	res, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+
+	return res, nil
+}
+
+// envelope is an encrypted value along with its encrypted data key.
+type envelope struct {
+	Key  []byte `json:"key"`
+	Data []byte `json:"data"`
+}
+
+// encrypt returns the json encoded envelope of data.
+func (c *Cipher) encrypt(data []byte) ([]byte, error) {
+	key := make([]byte, keyLength)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	env := new(envelope)
+	env.Data, err = seal(aead, data)
+	if err != nil {
+		return nil, err
+	}
+
+	env.Key, err = seal(c.master, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(env)
+}
+
+// decrypt returns the data of the json encoded envelope.
+func (c *Cipher) decrypt(data []byte) ([]byte, error) {
+	env := new(envelope)
+	if err := json.Unmarshal(data, env); err != nil {
+		return nil, ErrDecryption
+	}
+
+	key, err := unseal(c.master, env.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+
+	return unseal(aead, env.Data)
+}
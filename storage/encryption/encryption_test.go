@@ -0,0 +1,194 @@
+// +build unit
+
+package encryption
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/boltdb"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type doc struct {
+	Secret string `json:"secret"`
+}
+
+func (d *doc) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *doc) FromJSON(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+func (d *doc) Type() reflect.Type {
+	return reflect.TypeOf(d)
+}
+
+func newLevelDB(t *testing.T) (storage.Repository, storage.KV) {
+	db, err := leveldb.NewLevelDBStorage(t.TempDir())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, db.Close())
+	})
+
+	return leveldb.NewLevelDBRepository(db), leveldb.NewLevelDBKV(db)
+}
+
+func newKeySecret(t *testing.T) *Secret {
+	secret, err := NewKeySecret(utils.RandomSlice(keyLength))
+	assert.NoError(t, err)
+	return secret
+}
+
+func TestEncryption_Conformance(t *testing.T) {
+	encrypted := func(repo storage.Repository, kv storage.KV) (storage.Repository, storage.KV) {
+		c, err := Open(kv, newKeySecret(t))
+		assert.NoError(t, err)
+		return NewRepository(repo, c), NewKV(kv, c)
+	}
+
+	t.Run("LevelDB", func(t *testing.T) {
+		storage.RunConformanceTests(t, func(t *testing.T) (storage.Repository, storage.KV) {
+			return encrypted(newLevelDB(t))
+		})
+	})
+
+	t.Run("BoltDB", func(t *testing.T) {
+		storage.RunConformanceTests(t, func(t *testing.T) (storage.Repository, storage.KV) {
+			db, err := boltdb.NewBoltDBStorage(t.TempDir())
+			assert.NoError(t, err)
+			t.Cleanup(func() {
+				assert.NoError(t, db.Close())
+			})
+
+			return encrypted(boltdb.NewBoltDBRepository(db), boltdb.NewBoltDBKV(db))
+		})
+	})
+}
+
+func TestOpen(t *testing.T) {
+	repo, kv := newLevelDB(t)
+	assert.False(t, IsEncrypted(kv))
+	secret, err := NewPassphraseSecret("passphrase")
+	assert.NoError(t, err)
+	c, err := Open(kv, secret)
+	assert.NoError(t, err)
+	assert.True(t, IsEncrypted(kv))
+
+	// values are stored encrypted
+	erepo := NewRepository(repo, c)
+	erepo.Register(new(doc))
+	assert.NoError(t, erepo.Create([]byte("doc"), &doc{Secret: "plaintext"}))
+	assert.NoError(t, NewKV(kv, c).Set([]byte("job"), []byte("plaintext")))
+	assert.NoError(t, kv.Iterate(nil, func(key, value []byte) {
+		assert.NotContains(t, string(value), "plaintext")
+	}))
+
+	// reopen with the same passphrase
+	c, err = Open(kv, secret)
+	assert.NoError(t, err)
+	erepo = NewRepository(repo, c)
+	erepo.Register(new(doc))
+	m, err := erepo.Get([]byte("doc"))
+	assert.NoError(t, err)
+	assert.Equal(t, &doc{Secret: "plaintext"}, m)
+
+	// wrong passphrase
+	wrong, err := NewPassphraseSecret("wrong")
+	assert.NoError(t, err)
+	_, err = Open(kv, wrong)
+	assert.True(t, errors.IsOfType(ErrInvalidKey, err))
+
+	// key instead of passphrase
+	_, err = Open(kv, newKeySecret(t))
+	assert.True(t, errors.IsOfType(ErrInvalidKey, err))
+
+	// plaintext db with data
+	_, kv = newLevelDB(t)
+	assert.NoError(t, kv.Set([]byte("key"), []byte("value")))
+	_, err = Open(kv, secret)
+	assert.True(t, errors.IsOfType(ErrNotEncrypted, err))
+}
+
+func TestEncrypt(t *testing.T) {
+	repo, kv := newLevelDB(t)
+	repo.Register(new(doc))
+	assert.NoError(t, repo.Create([]byte("doc"), &doc{Secret: "plaintext"}))
+	assert.NoError(t, kv.Set([]byte("job"), []byte("plaintext")))
+	assert.NoError(t, kv.Set([]byte("migration_00"), []byte("{}")))
+
+	secret := newKeySecret(t)
+	assert.NoError(t, Encrypt(kv, secret))
+	v, err := kv.Get([]byte("migration_00"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("{}"), v)
+	assert.NoError(t, kv.Iterate(nil, func(key, value []byte) {
+		assert.NotContains(t, string(value), "plaintext")
+	}))
+
+	// encrypting again is a no-op
+	assert.NoError(t, Encrypt(kv, secret))
+
+	c, err := Open(kv, secret)
+	assert.NoError(t, err)
+	erepo := NewRepository(repo, c)
+	erepo.Register(new(doc))
+	m, err := erepo.Get([]byte("doc"))
+	assert.NoError(t, err)
+	assert.Equal(t, &doc{Secret: "plaintext"}, m)
+	v, err = NewKV(kv, c).Get([]byte("job"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("plaintext"), v)
+
+	// wrong key
+	assert.Error(t, Encrypt(kv, newKeySecret(t)))
+}
+
+func TestLoadSecret(t *testing.T) {
+	// nothing supplied
+	secret, err := LoadSecret("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, secret)
+
+	// passphrase
+	secret, err = LoadSecret("passphrase", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("passphrase"), secret.passphrase)
+
+	// hex encoded key file
+	key := utils.RandomSlice(keyLength)
+	keyFile := filepath.Join(t.TempDir(), "db.key")
+	assert.NoError(t, ioutil.WriteFile(keyFile, []byte(hex.EncodeToString(key)+"\n"), 0600))
+	secret, err = LoadSecret("", keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, key, secret.key)
+
+	// raw key file
+	assert.NoError(t, ioutil.WriteFile(keyFile, key, 0600))
+	secret, err = LoadSecret("", keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, key, secret.key)
+
+	// invalid key
+	assert.NoError(t, ioutil.WriteFile(keyFile, key[1:], 0600))
+	_, err = LoadSecret("", keyFile)
+	assert.True(t, errors.IsOfType(ErrInvalidSecret, err))
+
+	// missing key file
+	_, err = LoadSecret("", keyFile+"_missing")
+	assert.True(t, errors.IsOfType(ErrInvalidSecret, err))
+
+	// both supplied
+	_, err = LoadSecret("passphrase", keyFile)
+	assert.True(t, errors.IsOfType(ErrInvalidSecret, err))
+}
@@ -0,0 +1,159 @@
+package encryption
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// kvPrefix marks the values encrypted by the KV.
+var kvPrefix = []byte("\x00enc1")
+
+// plaintextPrefixes are the prefixes of the keys that are never encrypted.
+// They hold the keys of the secondary indexes, the migration records and the encryption parameters.
+var plaintextPrefixes = [][]byte{
+	[]byte("index_"),
+	[]byte("indexed_keys_"),
+	[]byte("migration_"),
+	[]byte(metaKey),
+}
+
+// kv implements storage.KV by encrypting the values stored in the underlying KV.
+type kv struct {
+	kv     storage.KV
+	cipher *Cipher
+}
+
+// NewKV returns a KV encrypting the values stored in store with c.
+// Values not encrypted by a KV, such as the models of a Repository sharing the db, are returned as stored.
+func NewKV(store storage.KV, c *Cipher) storage.KV {
+	return kv{kv: store, cipher: c}
+}
+
+func (k kv) open(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, kvPrefix) {
+		return data, nil
+	}
+
+	return k.cipher.decrypt(data[len(kvPrefix):])
+}
+
+// Get returns the decrypted value of the key.
+func (k kv) Get(key []byte) ([]byte, error) {
+	data, err := k.kv.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.open(data)
+}
+
+// Set encrypts the value and sets it at the key.
+func (k kv) Set(key, value []byte) error {
+	data, err := k.cipher.encrypt(value)
+	if err != nil {
+		return err
+	}
+
+	return k.kv.Set(key, append(append([]byte{}, kvPrefix...), data...))
+}
+
+// Delete deletes the key.
+func (k kv) Delete(key []byte) error {
+	return k.kv.Delete(key)
+}
+
+// Iterate calls f with the decrypted value for each key with the prefix.
+// Errors out on the first value failing to decrypt.
+func (k kv) Iterate(prefix []byte, f func(key, value []byte)) error {
+	var derr error
+	err := k.kv.Iterate(prefix, func(key, data []byte) {
+		if derr != nil {
+			return
+		}
+
+		v, err := k.open(data)
+		if err != nil {
+			derr = errors.New("failed to decrypt value of %s: %v", string(key), err)
+			return
+		}
+
+		f(key, v)
+	})
+	if err != nil {
+		return err
+	}
+
+	return derr
+}
+
+// Encrypt encrypts the plaintext values of the database behind store with secret.
+// Models stored by a Repository are sealed as if they were written by the Repository returned by NewRepository,
+// the other values as if they were written by the KV returned by NewKV.
+// Values encrypted already are skipped, so an interrupted run can be resumed.
+func Encrypt(store storage.KV, secret *Secret) (err error) {
+	var c *Cipher
+	if IsEncrypted(store) {
+		c, err = Open(store, secret)
+	} else {
+		c, err = initialise(store, secret)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ekv := NewKV(store, c)
+	var count int
+	ierr := store.Iterate(nil, func(key, data []byte) {
+		if err != nil || isPlaintextKey(key) || bytes.HasPrefix(data, kvPrefix) {
+			return
+		}
+
+		tp, terr := storage.ModelType(data)
+		if terr != nil || tp == "" {
+			err = ekv.Set(key, data)
+			count++
+			return
+		}
+
+		if tp == sealedType {
+			return
+		}
+
+		var env []byte
+		env, err = c.encrypt(data)
+		if err != nil {
+			return
+		}
+
+		data, err = storage.EncodeModel(&sealedModel{envelope: env})
+		if err != nil {
+			return
+		}
+
+		err = store.Set(key, data)
+		count++
+	})
+	if err != nil {
+		return err
+	}
+
+	if ierr != nil {
+		return ierr
+	}
+
+	log.Infof("Encrypted %d values", count)
+	return nil
+}
+
+func isPlaintextKey(key []byte) bool {
+	for _, p := range plaintextPrefixes {
+		if bytes.HasPrefix(key, p) {
+			return true
+		}
+	}
+
+	return false
+}
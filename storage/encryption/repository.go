@@ -0,0 +1,218 @@
+package encryption
+
+import (
+	"reflect"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// sealedModel is how the models are stored in the underlying repository.
+// Index values are handed to the underlying repository but are not persisted in the model.
+type sealedModel struct {
+	envelope []byte
+	indexes  map[string][]byte
+}
+
+// JSON returns the json encoded envelope.
+func (s *sealedModel) JSON() ([]byte, error) {
+	return s.envelope, nil
+}
+
+// FromJSON loads the json encoded envelope.
+func (s *sealedModel) FromJSON(data []byte) error {
+	s.envelope = append([]byte{}, data...)
+	return nil
+}
+
+// Type returns the type of sealedModel.
+func (s *sealedModel) Type() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// Indexes returns the indexes of the plaintext model.
+func (s *sealedModel) Indexes() map[string][]byte {
+	return s.indexes
+}
+
+// sealedType is the type name of sealedModel in the underlying repository.
+var sealedType = reflect.TypeOf(sealedModel{}).String()
+
+// repository implements storage.Repository by encrypting the models stored in the underlying repository.
+// Keys and secondary index entries are stored in plaintext.
+type repository struct {
+	storage.Repository
+	cipher *Cipher
+	types  *storage.ModelTypes
+}
+
+// NewRepository returns a Repository encrypting the models stored in repo with c.
+func NewRepository(repo storage.Repository, c *Cipher) storage.Repository {
+	repo.Register(new(sealedModel))
+	return &repository{
+		Repository: repo,
+		cipher:     c,
+		types:      storage.NewModelTypes(),
+	}
+}
+
+// Register registers the model so that the repository can return the model without knowing the type
+func (r *repository) Register(model storage.Model) {
+	r.types.Register(model)
+}
+
+// seal returns the encrypted model.
+func (r *repository) seal(model storage.Model) (storage.Model, error) {
+	data, err := storage.EncodeModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := r.cipher.encrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &sealedModel{envelope: env}
+	if im, ok := model.(storage.IndexedModel); ok {
+		s.indexes = im.Indexes()
+	}
+
+	return s, nil
+}
+
+// open returns the plaintext model of the encrypted model.
+func (r *repository) open(model storage.Model) (storage.Model, error) {
+	s, ok := model.(*sealedModel)
+	if !ok {
+		return nil, errors.NewTypedError(ErrDecryption, errors.New("value is not encrypted"))
+	}
+
+	data, err := r.cipher.decrypt(s.envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.types.DecodeModel(data)
+}
+
+// Get retrieves model by key, otherwise returns error
+func (r *repository) Get(key []byte) (storage.Model, error) {
+	m, err := r.Repository.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.open(m)
+}
+
+// GetAllByPrefix returns all models which keys match the provided prefix
+// If an error is found decrypting one of the matched models, logs warning and continues
+func (r *repository) GetAllByPrefix(prefix string) ([]storage.Model, error) {
+	sealed, err := r.Repository.GetAllByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []storage.Model
+	for _, s := range sealed {
+		m, err := r.open(s)
+		if err != nil {
+			log.Warnf("Error decrypting model: %v", err)
+			continue
+		}
+
+		models = append(models, m)
+	}
+
+	return models, nil
+}
+
+// Create encrypts and stores the model at the key
+// errors out if key already exists
+func (r *repository) Create(key []byte, model storage.Model) error {
+	s, err := r.seal(model)
+	if err != nil {
+		return err
+	}
+
+	return r.Repository.Create(key, s)
+}
+
+// Update encrypts and stores the model at the key
+// errors out if key doesn't exists
+func (r *repository) Update(key []byte, model storage.Model) error {
+	s, err := r.seal(model)
+	if err != nil {
+		return err
+	}
+
+	return r.Repository.Update(key, s)
+}
+
+// NewBatch returns an empty Batch encrypting the models.
+func (r *repository) NewBatch() storage.Batch {
+	return batch{Batch: r.Repository.NewBatch(), repo: r}
+}
+
+// NewTransaction starts a Transaction encrypting the models.
+func (r *repository) NewTransaction() (storage.Transaction, error) {
+	tx, err := r.Repository.NewTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction{Transaction: tx, repo: r}, nil
+}
+
+// batch implements storage.Batch by encrypting the models put in the underlying batch.
+type batch struct {
+	storage.Batch
+	repo *repository
+}
+
+// Put encrypts the model and sets it at the key.
+func (b batch) Put(key []byte, model storage.Model) error {
+	s, err := b.repo.seal(model)
+	if err != nil {
+		return err
+	}
+
+	return b.Batch.Put(key, s)
+}
+
+// transaction implements storage.Transaction by encrypting the models of the underlying transaction.
+type transaction struct {
+	storage.Transaction
+	repo *repository
+}
+
+// Get returns the decrypted model at the key.
+func (t transaction) Get(key []byte) (storage.Model, error) {
+	m, err := t.Transaction.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.repo.open(m)
+}
+
+// Create encrypts the model and creates it at the key.
+func (t transaction) Create(key []byte, model storage.Model) error {
+	s, err := t.repo.seal(model)
+	if err != nil {
+		return err
+	}
+
+	return t.Transaction.Create(key, s)
+}
+
+// Update encrypts the model and updates it at the key.
+func (t transaction) Update(key []byte, model storage.Model) error {
+	s, err := t.repo.seal(model)
+	if err != nil {
+		return err
+	}
+
+	return t.Transaction.Update(key, s)
+}
@@ -20,4 +20,13 @@ const (
 
 	// ErrModelTypeNotRegistered must be used when model hasn't been registered in db
 	ErrModelTypeNotRegistered = errors.Error("type not registered")
+
+	// ErrRepositoryIndex must be used when db repository fails to maintain or read a secondary index
+	ErrRepositoryIndex = errors.Error("db repository index error")
+
+	// ErrTransactionConflict must be used when a transaction read a key that was modified before the commit
+	ErrTransactionConflict = errors.Error("db transaction conflict")
+
+	// ErrTransactionDone must be used when a committed or discarded transaction is used
+	ErrTransactionDone = errors.Error("db transaction already committed or discarded")
 )
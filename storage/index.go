@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+const (
+	// indexPrefix is the prefix of the secondary index entries.
+	// An entry is stored as index_<name>/<hex(value)>/<key> and maps to the key of the model.
+	indexPrefix = "index_"
+
+	// indexedKeysPrefix is the prefix of the entries holding the index entries of a model.
+	// This lets the backends drop stale entries without decoding the old model.
+	indexedKeysPrefix = "indexed_keys_"
+
+	// indexSeparator sorts before the hex characters so that entries are ordered by the indexed value.
+	indexSeparator = '/'
+)
+
+// IndexNamePrefix returns the prefix of all the entries of the index.
+func IndexNamePrefix(name string) []byte {
+	return append([]byte(indexPrefix+name), indexSeparator)
+}
+
+// IndexValuePrefix returns the prefix of the entries with the indexed value.
+// If closed is true, the separator is appended so that only the exact value matches.
+func IndexValuePrefix(name string, value []byte, closed bool) []byte {
+	p := append(IndexNamePrefix(name), hex.EncodeToString(value)...)
+	if closed {
+		p = append(p, indexSeparator)
+	}
+
+	return p
+}
+
+// IndexEntries returns the index entries of the model stored at key.
+func IndexEntries(key []byte, model Model) [][]byte {
+	im, ok := model.(IndexedModel)
+	if !ok {
+		return nil
+	}
+
+	var entries [][]byte
+	for name, val := range im.Indexes() {
+		if len(val) == 0 {
+			continue
+		}
+
+		entries = append(entries, append(IndexValuePrefix(name, val, true), key...))
+	}
+
+	return entries
+}
+
+// IndexedKeysKey returns the key of the entry holding the index entries of the model stored at key.
+func IndexedKeysKey(key []byte) []byte {
+	return append([]byte(indexedKeysPrefix), key...)
+}
+
+// EncodeIndexEntries returns the value of the entry holding the index entries.
+func EncodeIndexEntries(entries [][]byte) ([]byte, error) {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrRepositoryIndex, err)
+	}
+
+	return data, nil
+}
+
+// DecodeIndexEntries returns the index entries held by the value returned by EncodeIndexEntries.
+func DecodeIndexEntries(data []byte) ([][]byte, error) {
+	var entries [][]byte
+	err := json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrRepositoryIndex, err)
+	}
+
+	return entries, nil
+}
@@ -1,14 +0,0 @@
-// +build unit
-
-package leveldb
-
-import (
-	"testing"
-
-	"github.com/stretchr/testify/assert"
-)
-
-func TestBootstrapper_Bootstrap(t *testing.T) {
-	err := (&Bootstrapper{}).Bootstrap(map[string]interface{}{})
-	assert.Error(t, err, "Should throw an error because of empty context")
-}
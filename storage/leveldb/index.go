@@ -0,0 +1,92 @@
+package leveldb
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// indexedKeys returns the index entries currently stored for the key.
+func (l *levelDBRepo) indexedKeys(key []byte) ([][]byte, error) {
+	data, err := l.db.Get(storage.IndexedKeysKey(key), nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, nil
+		}
+
+		return nil, errors.NewTypedError(storage.ErrRepositoryIndex, err)
+	}
+
+	return storage.DecodeIndexEntries(data)
+}
+
+// writeIndexes adds the operations replacing the index entries of the key with entries to the batch.
+func (l *levelDBRepo) writeIndexes(batch *leveldb.Batch, key []byte, entries [][]byte) error {
+	old, err := l.indexedKeys(key)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range old {
+		batch.Delete(e)
+	}
+
+	ikey := storage.IndexedKeysKey(key)
+	if len(entries) == 0 {
+		if len(old) > 0 {
+			batch.Delete(ikey)
+		}
+
+		return nil
+	}
+
+	data, err := storage.EncodeIndexEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		batch.Put(e, key)
+	}
+
+	batch.Put(ikey, data)
+	return nil
+}
+
+// GetKeysByIndex returns the keys of the models with the indexed value equal to value.
+func (l *levelDBRepo) GetKeysByIndex(index string, value []byte) ([][]byte, error) {
+	return l.getIndexedKeys(util.BytesPrefix(storage.IndexValuePrefix(index, value, true)))
+}
+
+// GetKeysByIndexRange returns the keys of the models with the indexed value in the range [start, end).
+// Keys are ordered by the indexed value. A nil start or end leaves the range unbounded on that side.
+func (l *levelDBRepo) GetKeysByIndexRange(index string, start, end []byte) ([][]byte, error) {
+	rng := util.BytesPrefix(storage.IndexNamePrefix(index))
+	if start != nil {
+		rng.Start = storage.IndexValuePrefix(index, start, false)
+	}
+
+	if end != nil {
+		rng.Limit = storage.IndexValuePrefix(index, end, true)
+	}
+
+	return l.getIndexedKeys(rng)
+}
+
+func (l *levelDBRepo) getIndexedKeys(rng *util.Range) ([][]byte, error) {
+	var keys [][]byte
+	iter := l.db.NewIterator(rng, nil)
+	for iter.Next() {
+		key := make([]byte, len(iter.Value()))
+		copy(key, iter.Value())
+		keys = append(keys, key)
+	}
+
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, errors.NewTypedError(storage.ErrRepositoryIndex, err)
+	}
+
+	return keys, nil
+}
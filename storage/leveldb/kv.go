@@ -0,0 +1,44 @@
+package leveldb
+
+import (
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// levelDBKV implements storage.KV using LevelDB as storage layer
+type levelDBKV struct {
+	db *leveldb.DB
+}
+
+// NewLevelDBKV returns levelDb implementation of KV
+func NewLevelDBKV(db *leveldb.DB) storage.KV {
+	return levelDBKV{db: db}
+}
+
+// Get returns the value of the key.
+func (l levelDBKV) Get(key []byte) ([]byte, error) {
+	return l.db.Get(key, nil)
+}
+
+// Set sets the value of the key.
+func (l levelDBKV) Set(key, value []byte) error {
+	return l.db.Put(key, value, nil)
+}
+
+// Delete deletes the key.
+func (l levelDBKV) Delete(key []byte) error {
+	return l.db.Delete(key, nil)
+}
+
+// Iterate calls f for each key with the prefix.
+// The iterator reads from an implicit snapshot, so f can modify the store.
+func (l levelDBKV) Iterate(prefix []byte, f func(key, value []byte)) error {
+	iter := l.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		f(iter.Key(), iter.Value())
+	}
+
+	return iter.Error()
+}
@@ -44,6 +44,18 @@ func getRandomRepository() (storage.Repository, string, error) {
 	return NewLevelDBRepository(db), randomPath, nil
 }
 
+func TestLevelDB_Conformance(t *testing.T) {
+	storage.RunConformanceTests(t, func(t *testing.T) (storage.Repository, storage.KV) {
+		db, err := NewLevelDBStorage(t.TempDir())
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, db.Close())
+		})
+
+		return NewLevelDBRepository(db), NewLevelDBKV(db)
+	})
+}
+
 func TestNewLevelDBRepository(t *testing.T) {
 	path := GetRandomTestStoragePath()
 	db, err := NewLevelDBStorage(path)
@@ -57,11 +69,16 @@ func TestNewLevelDBRepository(t *testing.T) {
 func TestLevelDBRepo_Register(t *testing.T) {
 	repo, _, err := getRandomRepository()
 	assert.Nil(t, err)
-	assert.Len(t, repo.(*levelDBRepo).models, 0, "should be empty")
+	id := utils.RandomSlice(32)
 	d := &doc{SomeString: "Hello, Repo!"}
+	assert.Nil(t, repo.Create(id, d))
+	_, err = repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelTypeNotRegistered, err))
+
 	repo.Register(d)
-	assert.Len(t, repo.(*levelDBRepo).models, 1, "should be not empty")
-	assert.Contains(t, repo.(*levelDBRepo).models, "leveldb.doc")
+	m, err := repo.Get(id)
+	assert.Nil(t, err)
+	assert.Equal(t, d, m)
 }
 
 func TestLevelDBRepo_Exists(t *testing.T) {
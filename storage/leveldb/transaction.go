@@ -0,0 +1,213 @@
+package leveldb
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// write applies the writes, along with their index entries, in a single leveldb batch.
+// Caller must hold wmu.
+func (l *levelDBRepo) write(w *storage.Writes) error {
+	batch := new(leveldb.Batch)
+	err := w.Range(func(key []byte, wr *storage.Write) error {
+		err := l.writeIndexes(batch, key, wr.Entries)
+		if err != nil {
+			return err
+		}
+
+		if wr.Deleted {
+			batch.Delete(key)
+			return nil
+		}
+
+		batch.Put(key, wr.Data)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = l.db.Write(batch, nil)
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	return nil
+}
+
+// levelDBBatch implements storage.Batch.
+type levelDBBatch struct {
+	l      *levelDBRepo
+	writes storage.Writes
+}
+
+// NewBatch returns an empty Batch.
+func (l *levelDBRepo) NewBatch() storage.Batch {
+	return &levelDBBatch{l: l, writes: storage.NewWrites()}
+}
+
+// Put sets the model at the key, replacing the existing model if any.
+func (b *levelDBBatch) Put(key []byte, model storage.Model) error {
+	return b.writes.Put(key, model)
+}
+
+// Delete deletes the key.
+func (b *levelDBBatch) Delete(key []byte) {
+	b.writes.Delete(key)
+}
+
+// Len returns the number of keys written by the batch.
+func (b *levelDBBatch) Len() int {
+	return b.writes.Len()
+}
+
+// Write applies all the writes of the batch atomically.
+func (b *levelDBBatch) Write() error {
+	b.l.wmu.Lock()
+	defer b.l.wmu.Unlock()
+	return b.l.write(&b.writes)
+}
+
+// read is the value of a key as seen by the transaction.
+type read struct {
+	data  []byte
+	found bool
+}
+
+// levelDBTransaction implements storage.Transaction.
+// Reads are served from a snapshot and recorded so that the commit can detect the keys modified in the meantime.
+type levelDBTransaction struct {
+	l      *levelDBRepo
+	snap   *leveldb.Snapshot
+	reads  map[string]read
+	writes storage.Writes
+	done   bool
+}
+
+// NewTransaction starts a Transaction.
+func (l *levelDBRepo) NewTransaction() (storage.Transaction, error) {
+	snap, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, errors.New("failed to get db snapshot: %v", err)
+	}
+
+	return &levelDBTransaction{
+		l:      l,
+		snap:   snap,
+		reads:  make(map[string]read),
+		writes: storage.NewWrites(),
+	}, nil
+}
+
+// read returns the value of the key, looking up the pending writes first.
+func (t *levelDBTransaction) read(key []byte) (read, error) {
+	if t.done {
+		return read{}, storage.ErrTransactionDone
+	}
+
+	if wr, ok := t.writes.Get(key); ok {
+		return read{data: wr.Data, found: !wr.Deleted}, nil
+	}
+
+	if r, ok := t.reads[string(key)]; ok {
+		return r, nil
+	}
+
+	data, err := t.snap.Get(key, nil)
+	if err != nil && err != leveldb.ErrNotFound {
+		return read{}, err
+	}
+
+	r := read{data: data, found: err == nil}
+	t.reads[string(key)] = r
+	return r, nil
+}
+
+// Exists checks whether the key exists.
+func (t *levelDBTransaction) Exists(key []byte) bool {
+	r, err := t.read(key)
+	return err == nil && r.found
+}
+
+// Get retrieves the model by the key.
+func (t *levelDBTransaction) Get(key []byte) (storage.Model, error) {
+	r, err := t.read(key)
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
+	}
+
+	if !r.found {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, leveldb.ErrNotFound)
+	}
+
+	return t.l.types.DecodeModel(r.data)
+}
+
+// Create creates the model at the key. errors out if key already exists.
+func (t *levelDBTransaction) Create(key []byte, model storage.Model) error {
+	if t.Exists(key) {
+		return storage.ErrRepositoryModelCreateKeyExists
+	}
+
+	return t.writes.Put(key, model)
+}
+
+// Update updates the model at the key. errors out if key doesn't exist.
+func (t *levelDBTransaction) Update(key []byte, model storage.Model) error {
+	if !t.Exists(key) {
+		return storage.ErrRepositoryModelUpdateKeyNotFound
+	}
+
+	return t.writes.Put(key, model)
+}
+
+// Delete deletes the key.
+func (t *levelDBTransaction) Delete(key []byte) error {
+	if t.done {
+		return storage.ErrTransactionDone
+	}
+
+	t.writes.Delete(key)
+	return nil
+}
+
+// Commit applies the writes atomically.
+// Returns ErrTransactionConflict if any key read by the transaction was modified since it was read.
+func (t *levelDBTransaction) Commit() error {
+	if t.done {
+		return storage.ErrTransactionDone
+	}
+	defer t.Discard()
+
+	t.l.wmu.Lock()
+	defer t.l.wmu.Unlock()
+	for k, r := range t.reads {
+		data, err := t.l.db.Get([]byte(k), nil)
+		if err != nil && err != leveldb.ErrNotFound {
+			return err
+		}
+
+		if r.found != (err == nil) || !bytes.Equal(r.data, data) {
+			return storage.ErrTransactionConflict
+		}
+	}
+
+	if t.writes.Len() == 0 {
+		return nil
+	}
+
+	return t.l.write(&t.writes)
+}
+
+// Discard drops the writes and releases the snapshot.
+func (t *levelDBTransaction) Discard() {
+	if t.done {
+		return
+	}
+
+	t.done = true
+	t.snap.Release()
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// value is the db representation of a model, shared by the repository backends.
+type value struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// EncodeModel returns the db representation of the model.
+func EncodeModel(model Model) ([]byte, error) {
+	data, err := model.JSON()
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to marshall model: %v", err))
+	}
+
+	data, err = json.Marshal(value{
+		Type: getTypeIndirect(model.Type()).String(),
+		Data: json.RawMessage(data),
+	})
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to marshall value: %v", err))
+	}
+
+	return data, nil
+}
+
+// ModelType returns the type name of the model in the db representation returned by EncodeModel.
+func ModelType(data []byte) (string, error) {
+	v := new(value)
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return "", errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to unmarshal to value: %v", err))
+	}
+
+	return v.Type, nil
+}
+
+// ModelTypes holds the registered model types so that the repositories can return the models without knowing their type.
+type ModelTypes struct {
+	types map[string]reflect.Type
+	mu    sync.RWMutex // to protect the types
+}
+
+// NewModelTypes returns an empty ModelTypes.
+func NewModelTypes() *ModelTypes {
+	return &ModelTypes{types: make(map[string]reflect.Type)}
+}
+
+// Register registers the type of the model.
+func (m *ModelTypes) Register(model Model) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tp := getTypeIndirect(model.Type())
+	m.types[tp.String()] = tp
+}
+
+// DecodeModel returns the model of the db representation returned by EncodeModel.
+func (m *ModelTypes) DecodeModel(data []byte) (Model, error) {
+	v := new(value)
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to unmarshal to value: %v", err))
+	}
+
+	m.mu.RLock()
+	tp, ok := m.types[v.Type]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewTypedError(ErrModelTypeNotRegistered, errors.New("%s", v.Type))
+	}
+
+	nm := reflect.New(tp).Interface().(Model)
+	err = nm.FromJSON([]byte(v.Data))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to unmarshal to model: %v", err))
+	}
+
+	return nm, nil
+}
+
+// getTypeIndirect returns the type of the model without pointers.
+func getTypeIndirect(tp reflect.Type) reflect.Type {
+	if tp.Kind() == reflect.Ptr {
+		return getTypeIndirect(tp.Elem())
+	}
+
+	return tp
+}
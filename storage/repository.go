@@ -2,6 +2,8 @@ package storage
 
 import (
 	"reflect"
+
+	"github.com/centrifuge/go-centrifuge/errors"
 )
 
 const (
@@ -9,6 +11,8 @@ const (
 	BootstrappedDB string = "BootstrappedDB"
 	// BootstrappedConfigDB is a key mapped to DB for configs at boot
 	BootstrappedConfigDB string = "BootstrappedConfigDB"
+	// BootstrappedKV is a key mapped to the KV sharing the DB at boot
+	BootstrappedKV string = "BootstrappedKV"
 )
 
 // Model is an interface to abstract away storage model specificness
@@ -23,6 +27,53 @@ type Model interface {
 	FromJSON(json []byte) error
 }
 
+// IndexedModel is a Model that declares secondary indexes.
+// Index entries are maintained by the Repository when the model is created, updated or deleted.
+type IndexedModel interface {
+	Model
+
+	// Indexes returns the values of the indexed fields mapped by the index name.
+	// Empty values are not indexed.
+	Indexes() map[string][]byte
+}
+
+// Store defines the key operations shared by the Repository and the Transaction.
+type Store interface {
+	Exists(key []byte) bool
+	Get(key []byte) (Model, error)
+	Create(key []byte, model Model) error
+	Update(key []byte, model Model) error
+	Delete(key []byte) error
+}
+
+// Batch holds writes that are applied atomically.
+type Batch interface {
+	// Put sets the model at the key, replacing the existing model if any.
+	Put(key []byte, model Model) error
+
+	// Delete deletes the key.
+	Delete(key []byte)
+
+	// Len returns the number of keys written by the batch.
+	Len() int
+
+	// Write applies all the writes of the batch atomically.
+	Write() error
+}
+
+// Transaction reads from a consistent snapshot of the db and buffers the writes until Commit.
+// Reads see the writes made earlier in the transaction.
+type Transaction interface {
+	Store
+
+	// Commit applies the writes atomically.
+	// Returns ErrTransactionConflict if any key read by the transaction was modified since it was read.
+	Commit() error
+
+	// Discard drops the writes and releases the transaction. Discard after Commit is a no-op.
+	Discard()
+}
+
 // Repository defines the required methods for standard storage repository.
 type Repository interface {
 	Register(model Model)
@@ -33,4 +84,67 @@ type Repository interface {
 	Update(key []byte, model Model) error
 	Delete(key []byte) error
 	Close() error
+
+	// NewBatch returns an empty Batch.
+	NewBatch() Batch
+
+	// NewTransaction starts a Transaction.
+	NewTransaction() (Transaction, error)
+
+	// GetKeysByIndex returns the keys of the models with the indexed value equal to value.
+	GetKeysByIndex(index string, value []byte) ([][]byte, error)
+
+	// GetKeysByIndexRange returns the keys of the models with the indexed value in the range [start, end).
+	// Keys are ordered by the indexed value. A nil start or end leaves the range unbounded on that side.
+	GetKeysByIndexRange(index string, start, end []byte) ([][]byte, error)
+}
+
+// KV is a store of raw bytes, used by the components that encode their own values.
+// KV and Repository can share the underlying db as long as their keys don't collide.
+type KV interface {
+	// Get returns the value of the key. Errors out if the key doesn't exist.
+	Get(key []byte) ([]byte, error)
+
+	// Set sets the value of the key.
+	Set(key, value []byte) error
+
+	// Delete deletes the key.
+	Delete(key []byte) error
+
+	// Iterate calls f, in key order, for each key with the prefix.
+	// f is called on a snapshot of the keys, so it can modify the store.
+	// key and value are only valid during the call.
+	// Returns the error the scan failed with, in which case f may not have been called for all the keys.
+	Iterate(prefix []byte, f func(key, value []byte)) error
+}
+
+// maxTransactionRetries is the number of times RunTransaction retries a conflicting transaction.
+const maxTransactionRetries = 5
+
+// RunTransaction runs fn in a transaction and commits it if fn succeeds.
+// The transaction is retried, with a fresh snapshot, when the commit conflicts.
+func RunTransaction(repo Repository, fn func(tx Transaction) error) (err error) {
+	for i := 0; i <= maxTransactionRetries; i++ {
+		err = runTransaction(repo, fn)
+		if err == nil || !errors.IsOfType(ErrTransactionConflict, err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func runTransaction(repo Repository, fn func(tx Transaction) error) error {
+	tx, err := repo.NewTransaction()
+	if err != nil {
+		return err
+	}
+	defer tx.Discard()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,68 @@
+package storage
+
+// Write is a pending write of a key.
+type Write struct {
+	Data    []byte
+	Entries [][]byte // index entries of the model
+	Deleted bool
+}
+
+// Writes holds the pending writes of a batch or a transaction in the order the keys were first written.
+type Writes struct {
+	keys [][]byte
+	m    map[string]*Write
+}
+
+// NewWrites returns an empty Writes.
+func NewWrites() Writes {
+	return Writes{m: make(map[string]*Write)}
+}
+
+func (w *Writes) set(key []byte, wr *Write) {
+	k := string(key)
+	if _, ok := w.m[k]; !ok {
+		w.keys = append(w.keys, []byte(k))
+	}
+
+	w.m[k] = wr
+}
+
+// Put encodes the model, along with its index entries, and sets it at the key.
+func (w *Writes) Put(key []byte, model Model) error {
+	data, err := EncodeModel(model)
+	if err != nil {
+		return err
+	}
+
+	w.set(key, &Write{Data: data, Entries: IndexEntries(key, model)})
+	return nil
+}
+
+// Delete deletes the key.
+func (w *Writes) Delete(key []byte) {
+	w.set(key, &Write{Deleted: true})
+}
+
+// Get returns the pending write of the key and true if the key was written.
+func (w *Writes) Get(key []byte) (*Write, bool) {
+	wr, ok := w.m[string(key)]
+	return wr, ok
+}
+
+// Len returns the number of keys written.
+func (w *Writes) Len() int {
+	return len(w.keys)
+}
+
+// Range calls f for each written key in the order the keys were first written.
+// Range stops at the first error returned by f.
+func (w *Writes) Range(f func(key []byte, wr *Write) error) error {
+	for _, key := range w.keys {
+		err := f(key, w.m[string(key)])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
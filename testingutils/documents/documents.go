@@ -71,6 +71,36 @@ func (m *MockService) Derive(ctx context.Context, payload documents.UpdatePayloa
 	return model, args.Error(1)
 }
 
+func (m *MockService) List(ctx context.Context, filter documents.ListFilter) ([]documents.Document, error) {
+	args := m.Called(ctx, filter)
+	docs, _ := args.Get(0).([]documents.Document)
+	return docs, args.Error(1)
+}
+
+func (m *MockService) Export(ctx context.Context, documentID []byte) (*documents.Bundle, error) {
+	args := m.Called(ctx, documentID)
+	b, _ := args.Get(0).(*documents.Bundle)
+	return b, args.Error(1)
+}
+
+func (m *MockService) Import(ctx context.Context, bundle *documents.Bundle) (documents.Document, error) {
+	args := m.Called(ctx, bundle)
+	doc, _ := args.Get(0).(documents.Document)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) Versions(ctx context.Context, documentID []byte) ([]documents.VersionInfo, error) {
+	args := m.Called(ctx, documentID)
+	versions, _ := args.Get(0).([]documents.VersionInfo)
+	return versions, args.Error(1)
+}
+
+func (m *MockService) Diff(ctx context.Context, documentID, from, to []byte) (*documents.Diff, error) {
+	args := m.Called(ctx, documentID, from, to)
+	d, _ := args.Get(0).(*documents.Diff)
+	return d, args.Error(1)
+}
+
 type MockModel struct {
 	documents.Document
 	mock.Mock
@@ -241,12 +241,18 @@ func SetupSmartContractAddresses(cfg config.Configuration, sca *config.SmartCont
 // BuildIntegrationTestingContext sets up configuration for integration tests
 func BuildIntegrationTestingContext() map[string]interface{} {
 	projDir := GetProjectDir()
+	cfg := LoadTestConfig()
 	StartPOAGeth()
-	StartCentChain()
+	// the centchain simulator is bootstrapped in process, there is no chain to bridge to
+	simulated := cfg.IsCentChainSimulatorEnabled()
+	if !simulated {
+		StartCentChain()
+	}
 	RunSmartContractMigrations() // Running migrations so bridge addresses are generated before running bridge
-	StartBridge()
+	if !simulated {
+		StartBridge()
+	}
 	addresses := GetSmartContractAddresses()
-	cfg := LoadTestConfig()
 	cfg.Set("keys.p2p.publicKey", fmt.Sprintf("%s/build/resources/p2pKey.pub.pem", projDir))
 	cfg.Set("keys.p2p.privateKey", fmt.Sprintf("%s/build/resources/p2pKey.key.pem", projDir))
 	cfg.Set("keys.signing.publicKey", fmt.Sprintf("%s/build/resources/signingKey.pub.pem", projDir))
@@ -3,19 +3,29 @@ package utils
 import (
 	"crypto/tls"
 	"net"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
 	"gopkg.in/resty.v1"
 )
 
+// postRequestTimeout is the timeout of the post requests, so that an unresponsive receiver doesn't block the caller.
+const postRequestTimeout = time.Minute
+
 // SendPOSTRequest sends post with data to given URL.
 func SendPOSTRequest(url string, contentType string, payload []byte) (statusCode int, err error) {
+	return SendPOSTRequestWithHeaders(url, map[string]string{"Content-Type": contentType}, payload)
+}
+
+// SendPOSTRequestWithHeaders sends the payload with the headers to the url.
+func SendPOSTRequestWithHeaders(url string, headers map[string]string, payload []byte) (statusCode int, err error) {
 	c := resty.New()
 	cfg := &tls.Config{InsecureSkipVerify: true} // Temporary until we have defined a cert truststore
 	c.SetTLSClientConfig(cfg)
+	c.SetTimeout(postRequestTimeout)
 
 	resp, err := c.R().
-		SetHeader("Content-Type", contentType).
+		SetHeaders(headers).
 		SetBody(payload).
 		Post(url)
 